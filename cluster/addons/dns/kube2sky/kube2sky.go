@@ -148,9 +148,15 @@ func getSkyMsg(ip string, port int) *skymsg.Service {
 }
 
 func (ks *kube2sky) generateRecordsForHeadlessService(subdomain string, e *kapi.Endpoints, svc *kapi.Service) error {
+	// Not-ready pods are only published if the service asks for them.
+	tolerateUnready, _ := kapi.ToleratesUnreadyEndpoints(svc.Annotations)
 	for idx := range e.Subsets {
-		for subIdx := range e.Subsets[idx].Addresses {
-			b, err := json.Marshal(getSkyMsg(e.Subsets[idx].Addresses[subIdx].IP, 0))
+		addresses := e.Subsets[idx].Addresses
+		if tolerateUnready {
+			addresses = append(append([]kapi.EndpointAddress{}, addresses...), e.Subsets[idx].NotReadyAddresses...)
+		}
+		for subIdx := range addresses {
+			b, err := json.Marshal(getSkyMsg(addresses[subIdx].IP, 0))
 			if err != nil {
 				return err
 			}
@@ -204,7 +210,7 @@ func (ks *kube2sky) addDNSUsingEndpoints(subdomain string, e *kapi.Endpoints) er
 	if err != nil {
 		return err
 	}
-	if svc == nil || kapi.IsServiceIPSet(svc) {
+	if svc == nil || kapi.IsServiceIPSet(svc) || svc.Spec.Type == kapi.ServiceTypeExternalName {
 		// No headless service found corresponding to endpoints object.
 		return nil
 	}
@@ -249,6 +255,20 @@ func (ks *kube2sky) generateRecordsForPortalService(subdomain string, service *k
 	return nil
 }
 
+// generateRecordsForExternalNameService writes a single record pointing at the
+// service's external name, which SkyDNS serves as a CNAME.
+func (ks *kube2sky) generateRecordsForExternalNameService(subdomain string, service *kapi.Service) error {
+	b, err := json.Marshal(getSkyMsg(service.Spec.ExternalName, 0))
+	if err != nil {
+		return err
+	}
+	recordValue := string(b)
+	recordKey := buildDNSNameString(subdomain, getHash(recordValue))
+
+	glog.V(2).Infof("Setting DNS record: %v -> %q, with recordKey: %v\n", subdomain, recordValue, recordKey)
+	return ks.writeSkyRecord(recordKey, recordValue)
+}
+
 func buildPortSegmentString(portName string, portProtocol kapi.Protocol) string {
 	if portName == "" {
 		// we don't create a random name
@@ -276,6 +296,10 @@ func (ks *kube2sky) generateSRVRecord(subdomain, portSegment, recordName, cName
 }
 
 func (ks *kube2sky) addDNS(subdomain string, service *kapi.Service) error {
+	// ExternalName services have neither ports nor a ClusterIP
+	if service.Spec.Type == kapi.ServiceTypeExternalName {
+		return ks.generateRecordsForExternalNameService(subdomain, service)
+	}
 	if len(service.Spec.Ports) == 0 {
 		glog.Fatalf("unexpected service with no ports: %v", service)
 	}
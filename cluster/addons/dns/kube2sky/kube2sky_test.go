@@ -228,6 +228,33 @@ func TestHeadlessService(t *testing.T) {
 	assert.Empty(t, ec.writes)
 }
 
+func TestHeadlessServiceWithNotReadyAddresses(t *testing.T) {
+	const (
+		testService   = "testservice"
+		testNamespace = "default"
+	)
+	ec := &fakeEtcdClient{make(map[string]string)}
+	k2s := newKube2Sky(ec)
+	service := newHeadlessService(testNamespace, testService)
+	assert.NoError(t, k2s.servicesStore.Add(&service))
+	subset := newSubsetWithOnePort("", 80, "10.0.0.1")
+	subset.NotReadyAddresses = []kapi.EndpointAddress{{IP: "10.0.0.2"}}
+	endpoints := newEndpoints(service, subset)
+	assert.NoError(t, k2s.endpointsStore.Add(&endpoints))
+
+	// Only the ready address is published by default.
+	k2s.newService(&service)
+	assert.Equal(t, 1, len(ec.writes))
+	k2s.removeService(&service)
+	assert.Empty(t, ec.writes)
+
+	// Both are published if the service tolerates unready endpoints.
+	service.Annotations = map[string]string{kapi.TolerateUnreadyEndpointsAnnotation: "true"}
+	assert.NoError(t, k2s.servicesStore.Update(&service))
+	k2s.newService(&service)
+	assert.Equal(t, 2, len(ec.writes))
+}
+
 func TestHeadlessServiceWithNamedPorts(t *testing.T) {
 	const (
 		testService   = "testservice"
@@ -324,6 +351,28 @@ func TestAddSinglePortService(t *testing.T) {
 	assertDnsServiceEntryInEtcd(t, ec, testService, testNamespace, expectedValue)
 }
 
+func TestAddExternalNameService(t *testing.T) {
+	const (
+		testService   = "testservice"
+		testNamespace = "default"
+	)
+	ec := &fakeEtcdClient{make(map[string]string)}
+	k2s := newKube2Sky(ec)
+	service := kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:      testService,
+			Namespace: testNamespace,
+		},
+		Spec: kapi.ServiceSpec{
+			Type:         kapi.ServiceTypeExternalName,
+			ExternalName: "db.example.com",
+		},
+	}
+	k2s.newService(&service)
+	assertDnsServiceEntryInEtcd(t, ec, testService, testNamespace, &hostPort{Host: "db.example.com"})
+	assert.Len(t, ec.writes, 1)
+}
+
 func TestUpdateSinglePortService(t *testing.T) {
 	const (
 		testService   = "testservice"
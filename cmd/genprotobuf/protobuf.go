@@ -0,0 +1,97 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	_ "k8s.io/kubernetes/pkg/api/v1"
+	pkg_runtime "k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/golang/glog"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/tools/imports"
+)
+
+const pkgBase = "k8s.io/kubernetes/pkg"
+
+var (
+	functionDest = flag.StringP("funcDest", "f", "-", "Output for protobuf functions; '-' means stdout")
+	groupVersion = flag.StringP("version", "v", "api/v1", "groupPath/version for protobuf functions.")
+)
+
+func main() {
+	runtime.GOMAXPROCS(runtime.NumCPU())
+	flag.Parse()
+
+	var funcOut io.Writer
+	if *functionDest == "-" {
+		funcOut = os.Stdout
+	} else {
+		file, err := os.Create(*functionDest)
+		if err != nil {
+			glog.Fatalf("Couldn't open %v: %v", *functionDest, err)
+		}
+		defer file.Close()
+		funcOut = file
+	}
+
+	group, version := path.Split(*groupVersion)
+	group = strings.TrimRight(group, "/")
+	if len(version) == 0 {
+		glog.Fatalf("protobuf functions are only generated for versioned packages, got %q", *groupVersion)
+	}
+
+	versionPath := path.Join(pkgBase, group, version)
+	generator := pkg_runtime.NewProtobufGenerator(versionPath)
+	for _, knownType := range api.Scheme.KnownTypes(version) {
+		if knownType.PkgPath() != versionPath {
+			continue
+		}
+		if err := generator.AddType(knownType); err != nil {
+			glog.Fatalf("error while generating protobuf functions for %v: %v", knownType, err)
+		}
+	}
+
+	functions := new(bytes.Buffer)
+	if err := generator.WriteProtobufFunctions(functions); err != nil {
+		glog.Fatalf("error while writing protobuf functions: %v", err)
+	}
+	data := new(bytes.Buffer)
+	if _, err := data.WriteString(fmt.Sprintf("package %s\n", version)); err != nil {
+		glog.Fatalf("error writing package line: %v", err)
+	}
+	if err := generator.WriteImports(data); err != nil {
+		glog.Fatalf("error while writing imports: %v", err)
+	}
+	data.Write(functions.Bytes())
+
+	b, err := imports.Process("", data.Bytes(), nil)
+	if err != nil {
+		glog.Fatalf("error while update imports: %v", err)
+	}
+	if _, err := funcOut.Write(b); err != nil {
+		glog.Fatalf("error while writing out the resulting file: %v", err)
+	}
+}
@@ -160,6 +160,7 @@ func startComponents(firstManifestURL, secondManifestURL, apiVersion string) (st
 		EnableCoreControllers: true,
 		EnableLogsSupport:     false,
 		EnableProfiling:       true,
+		EnableWatchCache:      true,
 		APIPrefix:             "/api",
 		ExpAPIPrefix:          "/experimental",
 		Authorizer:            apiserver.NewAlwaysAllowAuthorizer(),
@@ -0,0 +1,143 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package app does all of the work necessary to configure and run a
+// Kubernetes app process.
+package app
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/cache"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
+	kdns "k8s.io/kubernetes/pkg/dns"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/util"
+
+	"github.com/golang/glog"
+	"github.com/miekg/dns"
+	"github.com/spf13/pflag"
+)
+
+// DNSServer configures and runs a Kubernetes cluster DNS server
+type DNSServer struct {
+	BindAddress        net.IP
+	Port               int
+	HealthzPort        int
+	HealthzBindAddress net.IP
+	Master             string
+	Kubeconfig         string
+	Domain             string
+	Nameservers        []string
+	ResolvConf         string
+	ResyncPeriod       time.Duration
+}
+
+// NewDNSServer creates a new DNSServer object with default parameters
+func NewDNSServer() *DNSServer {
+	return &DNSServer{
+		BindAddress:        net.ParseIP("0.0.0.0"),
+		Port:               53,
+		HealthzPort:        8081,
+		HealthzBindAddress: net.ParseIP("127.0.0.1"),
+		Domain:             "cluster.local",
+		ResolvConf:         "/etc/resolv.conf",
+		ResyncPeriod:       30 * time.Minute,
+	}
+}
+
+// AddFlags adds flags for a specific DNSServer to the specified FlagSet
+func (s *DNSServer) AddFlags(fs *pflag.FlagSet) {
+	fs.IPVar(&s.BindAddress, "bind-address", s.BindAddress, "The IP address to serve DNS on (set to 0.0.0.0 for all interfaces)")
+	fs.IntVar(&s.Port, "port", s.Port, "The port to serve DNS on, over both UDP and TCP")
+	fs.IntVar(&s.HealthzPort, "healthz-port", s.HealthzPort, "The port to bind the health check server. Use 0 to disable.")
+	fs.IPVar(&s.HealthzBindAddress, "healthz-bind-address", s.HealthzBindAddress, "The IP address for the health check server to serve on, defaulting to 127.0.0.1 (set to 0.0.0.0 for all interfaces)")
+	fs.StringVar(&s.Master, "master", s.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	fs.StringVar(&s.Kubeconfig, "kubeconfig", s.Kubeconfig, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+	fs.StringVar(&s.Domain, "domain", s.Domain, "The cluster domain to serve the names of services under")
+	fs.StringSliceVar(&s.Nameservers, "nameservers", s.Nameservers, "Comma separated list of host:port of the nameservers to forward queries for names outside of the cluster domain to. Defaults to the nameservers in --resolv-conf.")
+	fs.StringVar(&s.ResolvConf, "resolv-conf", s.ResolvConf, "Resolver configuration file to take the upstream nameservers from if --nameservers is not given. Empty means not to forward queries.")
+	fs.DurationVar(&s.ResyncPeriod, "resync-period", s.ResyncPeriod, "How often to relist all services and endpoints (e.g. '5s', '1m', '2h22m').")
+}
+
+// Run runs the specified DNSServer.  This should never exit.
+func (s *DNSServer) Run(_ []string) error {
+	nameservers := s.Nameservers
+	if len(nameservers) == 0 && len(s.ResolvConf) > 0 {
+		config, err := dns.ClientConfigFromFile(s.ResolvConf)
+		if err != nil {
+			return err
+		}
+		for _, server := range config.Servers {
+			nameservers = append(nameservers, net.JoinHostPort(server, config.Port))
+		}
+	}
+	glog.Infof("Forwarding queries outside of %s to %v", s.Domain, nameservers)
+
+	// define api config source
+	if s.Kubeconfig == "" && s.Master == "" {
+		glog.Warningf("Neither --kubeconfig nor --master was specified.  Using default API client.  This might not work.")
+	}
+
+	// This creates a client, first loading any specified kubeconfig
+	// file, and then overriding the Master flag, if non-empty.
+	kubeconfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: s.Kubeconfig},
+		&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: s.Master}}).ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := client.New(kubeconfig)
+	if err != nil {
+		glog.Fatalf("Invalid API configuration: %v", err)
+	}
+
+	kd := kdns.NewKubeDNS(
+		cache.NewListWatchFromClient(client, "services", api.NamespaceAll, fields.Everything()),
+		cache.NewListWatchFromClient(client, "endpoints", api.NamespaceAll, fields.Everything()),
+		s.Domain,
+		nameservers,
+		s.ResyncPeriod,
+	)
+	kd.Run(util.NeverStop)
+
+	if s.HealthzPort > 0 {
+		go util.Until(func() {
+			err := http.ListenAndServe(s.HealthzBindAddress.String()+":"+strconv.Itoa(s.HealthzPort), nil)
+			if err != nil {
+				glog.Errorf("Starting health server failed: %v", err)
+			}
+		}, 5*time.Second, util.NeverStop)
+	}
+
+	addr := net.JoinHostPort(s.BindAddress.String(), strconv.Itoa(s.Port))
+	errs := make(chan error, 2)
+	for _, network := range []string{"udp", "tcp"} {
+		server := &dns.Server{Addr: addr, Net: network, Handler: kd}
+		go func() {
+			errs <- server.ListenAndServe()
+		}()
+	}
+	glog.Infof("Serving DNS for %s on %s", s.Domain, addr)
+	return <-errs
+}
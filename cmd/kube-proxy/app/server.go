@@ -28,18 +28,23 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/cache"
 	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
 	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
 	"k8s.io/kubernetes/pkg/client/unversioned/record"
+	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/kubelet/qos"
+	"k8s.io/kubernetes/pkg/networkpolicy"
 	"k8s.io/kubernetes/pkg/proxy"
 	"k8s.io/kubernetes/pkg/proxy/config"
 	"k8s.io/kubernetes/pkg/proxy/iptables"
+	ipvsproxy "k8s.io/kubernetes/pkg/proxy/ipvs"
 	"k8s.io/kubernetes/pkg/proxy/userspace"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/exec"
 	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	utilipvs "k8s.io/kubernetes/pkg/util/ipvs"
 	nodeutil "k8s.io/kubernetes/pkg/util/node"
 	"k8s.io/kubernetes/pkg/util/oom"
 
@@ -60,10 +65,15 @@ type ProxyServer struct {
 	Recorder            record.EventRecorder
 	HostnameOverride    string
 	ForceUserspaceProxy bool
+	UseIPVSProxy        bool
+	IPVSScheduler       string
+	UserspaceAlgorithm  string
 	SyncPeriod          time.Duration
 	nodeRef             *api.ObjectReference // Reference to this node.
 	MasqueradeAll       bool
+	ClusterCIDR         string
 	CleanupAndExit      bool
+	NetworkPolicy       bool
 }
 
 // NewProxyServer creates a new ProxyServer object with default parameters
@@ -75,6 +85,8 @@ func NewProxyServer() *ProxyServer {
 		OOMScoreAdj:        qos.KubeProxyOomScoreAdj,
 		ResourceContainer:  "/kube-proxy",
 		SyncPeriod:         5 * time.Second,
+		IPVSScheduler:      string(utilipvs.SchedulerRoundRobin),
+		UserspaceAlgorithm: "rr",
 	}
 }
 
@@ -90,9 +102,14 @@ func (s *ProxyServer) AddFlags(fs *pflag.FlagSet) {
 	fs.Var(&s.PortRange, "proxy-port-range", "Range of host ports (beginPort-endPort, inclusive) that may be consumed in order to proxy service traffic. If unspecified (0-0) then ports will be randomly chosen.")
 	fs.StringVar(&s.HostnameOverride, "hostname-override", s.HostnameOverride, "If non-empty, will use this string as identification instead of the actual hostname.")
 	fs.BoolVar(&s.ForceUserspaceProxy, "legacy-userspace-proxy", true, "Use the legacy userspace proxy (instead of the pure iptables proxy).")
+	fs.BoolVar(&s.UseIPVSProxy, "ipvs-proxy", s.UseIPVSProxy, "Use the IPVS proxy (instead of the userspace or pure iptables proxies). Requires the ip_vs kernel module.")
+	fs.StringVar(&s.IPVSScheduler, "ipvs-scheduler", s.IPVSScheduler, "The IPVS scheduler for services without session affinity, one of 'rr' (round-robin) or 'lc' (least-connection). Services with ClientIP affinity always use 'sh' (source-hash).")
+	fs.StringVar(&s.UserspaceAlgorithm, "userspace-lb-algorithm", s.UserspaceAlgorithm, "The load balancing algorithm of the legacy userspace proxy, one of 'rr' (round-robin), 'lc' (least-connections) or 'wrr' (weighted round-robin, using the endpoint weights annotation). Services with ClientIP affinity remain sticky with every algorithm.")
 	fs.DurationVar(&s.SyncPeriod, "iptables-sync-period", 5*time.Second, "How often iptables rules are refreshed (e.g. '5s', '1m', '2h22m').  Must be greater than 0.")
 	fs.BoolVar(&s.MasqueradeAll, "masquerade-all", false, "If using the pure iptables proxy, SNAT everything")
+	fs.StringVar(&s.ClusterCIDR, "cluster-cidr", s.ClusterCIDR, "The CIDR range of pods in the cluster. If using the IPVS proxy, traffic to services from outside this range is SNATed; if empty, all of it is.")
 	fs.BoolVar(&s.CleanupAndExit, "cleanup-iptables", false, "If true cleanup iptables rules and exit.")
+	fs.BoolVar(&s.NetworkPolicy, "enforce-network-policy", false, "If true, enforce the experimental network policies selecting the pods of this node with iptables.")
 }
 
 // Run runs the specified ProxyServer.  This should never exit (unless CleanupAndExit is set).
@@ -108,6 +125,10 @@ func (s *ProxyServer) Run(_ []string) error {
 		ipt := utiliptables.New(execer, protocol)
 		encounteredError := userspace.CleanupLeftovers(ipt)
 		encounteredError = iptables.CleanupLeftovers(ipt) || encounteredError
+		encounteredError = networkpolicy.CleanupLeftovers(ipt) || encounteredError
+		if ipvs, err := utilipvs.New(ipvsproxy.DummyDevice); err == nil {
+			encounteredError = ipvsproxy.CleanupLeftovers(ipvs, ipt) || encounteredError
+		}
 		if encounteredError {
 			return errors.New("Encountered an error while tearing down rules.")
 		}
@@ -141,6 +162,14 @@ func (s *ProxyServer) Run(_ []string) error {
 		return err
 	}
 
+	var expClient *client.ExperimentalClient
+	if s.NetworkPolicy {
+		expClient, err = client.NewExperimental(kubeconfig)
+		if err != nil {
+			glog.Fatalf("Invalid API configuration: %v", err)
+		}
+	}
+
 	client, err := client.New(kubeconfig)
 	if err != nil {
 		glog.Fatalf("Invalid API configuration: %v", err)
@@ -173,12 +202,36 @@ func (s *ProxyServer) Run(_ []string) error {
 	if err != nil {
 		glog.Errorf("Can't determine whether to use iptables or userspace, using userspace proxier: %v", err)
 	}
-	if !s.ForceUserspaceProxy && shouldUseIptables {
+	if s.UseIPVSProxy {
+		glog.V(2).Info("Using IPVS Proxier.")
+
+		ipvs, err := utilipvs.New(ipvsproxy.DummyDevice)
+		if err != nil {
+			glog.Fatalf("Unable to use IPVS: %v", err)
+		}
+		nodeIPs, err := ipvsproxy.NodeIPs()
+		if err != nil {
+			glog.Fatalf("Unable to list the addresses of this node: %v", err)
+		}
+		execer := exec.New()
+		ipt := utiliptables.New(execer, protocol)
+		proxierIPVS, err := ipvsproxy.NewProxier(ipvs, ipt, execer, s.SyncPeriod, utilipvs.Scheduler(s.IPVSScheduler), nodeIPs, s.ClusterCIDR)
+		if err != nil {
+			glog.Fatalf("Unable to create proxier: %v", err)
+		}
+		proxier = proxierIPVS
+		endpointsHandler = proxierIPVS
+		// Remove artifacts that might still exist from the other Proxiers.
+		glog.V(2).Info("Tearing down userspace and pure-iptables proxy rules. Errors here are acceptable.")
+		userspace.CleanupLeftovers(ipt)
+		iptables.CleanupLeftovers(ipt)
+
+	} else if !s.ForceUserspaceProxy && shouldUseIptables {
 		glog.V(2).Info("Using iptables Proxier.")
 
 		execer := exec.New()
 		ipt := utiliptables.New(execer, protocol)
-		proxierIptables, err := iptables.NewProxier(ipt, execer, s.SyncPeriod, s.MasqueradeAll)
+		proxierIptables, err := iptables.NewProxier(ipt, execer, s.SyncPeriod, s.MasqueradeAll, Hostname)
 		if err != nil {
 			glog.Fatalf("Unable to create proxier: %v", err)
 		}
@@ -187,12 +240,26 @@ func (s *ProxyServer) Run(_ []string) error {
 		// No turning back. Remove artifacts that might still exist from the userspace Proxier.
 		glog.V(2).Info("Tearing down userspace rules. Errors here are acceptable.")
 		userspace.CleanupLeftovers(ipt)
+		cleanupIPVS(ipt)
 
 	} else {
 		glog.V(2).Info("Using userspace Proxier.")
 		// This is a proxy.LoadBalancer which NewProxier needs but has methods we don't need for
 		// our config.EndpointsConfigHandler.
-		loadBalancer := userspace.NewLoadBalancerRR()
+		var loadBalancer interface {
+			userspace.LoadBalancer
+			config.EndpointsConfigHandler
+		}
+		switch s.UserspaceAlgorithm {
+		case "rr":
+			loadBalancer = userspace.NewLoadBalancerRR()
+		case "lc":
+			loadBalancer = userspace.NewLoadBalancerLeastConn()
+		case "wrr":
+			loadBalancer = userspace.NewLoadBalancerWeighted()
+		default:
+			glog.Fatalf("Unknown userspace load balancing algorithm %q", s.UserspaceAlgorithm)
+		}
 		// set EndpointsConfigHandler to our loadBalancer
 		endpointsHandler = loadBalancer
 
@@ -206,6 +273,7 @@ func (s *ProxyServer) Run(_ []string) error {
 		// Remove artifacts from the pure-iptables Proxier.
 		glog.V(2).Info("Tearing down pure-iptables proxy rules. Errors here are acceptable.")
 		iptables.CleanupLeftovers(ipt)
+		cleanupIPVS(ipt)
 	}
 
 	// Wire proxier to handle changes to services
@@ -224,6 +292,27 @@ func (s *ProxyServer) Run(_ []string) error {
 		endpointsConfig.Channel("api"),
 	)
 
+	if s.NetworkPolicy {
+		glog.V(2).Info("Enforcing network policies.")
+		ipt := utiliptables.New(exec.New(), protocol)
+		if err := networkpolicy.EnsureBridgeNetfilter(ipt); err != nil {
+			glog.Fatalf("Unable to enforce network policies: %v", err)
+		}
+		enforcer := networkpolicy.NewEnforcer(
+			cache.NewListWatchFromClient(expClient, "networkpolicies", api.NamespaceAll, fields.Everything()),
+			cache.NewListWatchFromClient(client, "pods", api.NamespaceAll, fields.Everything()),
+			cache.NewListWatchFromClient(client, "namespaces", api.NamespaceAll, fields.Everything()),
+			Hostname,
+			ipt,
+			s.SyncPeriod,
+		)
+		go enforcer.Run(util.NeverStop)
+	} else {
+		// Remove artifacts from a previous run enforcing network policies.
+		glog.V(2).Info("Tearing down network policy rules. Errors here are acceptable.")
+		networkpolicy.CleanupLeftovers(utiliptables.New(exec.New(), protocol))
+	}
+
 	if s.HealthzPort > 0 {
 		go util.Until(func() {
 			err := http.ListenAndServe(s.HealthzBindAddress.String()+":"+strconv.Itoa(s.HealthzPort), nil)
@@ -238,6 +327,17 @@ func (s *ProxyServer) Run(_ []string) error {
 	return nil
 }
 
+// cleanupIPVS removes artifacts from the IPVS Proxier, if the kernel
+// supports IPVS at all.
+func cleanupIPVS(ipt utiliptables.Interface) {
+	ipvs, err := utilipvs.New(ipvsproxy.DummyDevice)
+	if err != nil {
+		return
+	}
+	glog.V(2).Info("Tearing down IPVS proxy virtual servers. Errors here are acceptable.")
+	ipvsproxy.CleanupLeftovers(ipvs, ipt)
+}
+
 func (s *ProxyServer) birthCry() {
 	s.Recorder.Eventf(s.nodeRef, "Starting", "Starting kube-proxy.")
 }
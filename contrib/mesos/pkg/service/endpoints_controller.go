@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/client/unversioned/cache"
 	kservice "k8s.io/kubernetes/pkg/controller/endpoint"
 	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -278,7 +279,13 @@ func (e *endpointController) syncService(key string) {
 	}
 
 	glog.V(5).Infof("About to update endpoints for service %q", key)
-	pods, err := e.podStore.Pods(service.Namespace).List(labels.Set(service.Spec.Selector).AsSelector())
+	selector, err := expapi.LabelSelectorAsSelector(expapi.SetAsLabelSelector(labels.Set(service.Spec.Selector)))
+	if err != nil {
+		// Don't retry, the selector passed validation and will not change.
+		glog.Errorf("Invalid selector of service %q: %v", key, err)
+		return
+	}
+	pods, err := e.podStore.Pods(service.Namespace).List(selector)
 	if err != nil {
 		// Since we're getting stuff from a local cache, it is
 		// basically impossible to get this error.
@@ -307,21 +314,22 @@ func (e *endpointController) syncService(key string) {
 				glog.V(4).Infof("Failed to find a host IP for pod %s/%s", pod.Namespace, pod.Name)
 				continue
 			}
-			if !api.IsPodReady(pod) {
-				glog.V(5).Infof("Pod is out of service: %v/%v", pod.Namespace, pod.Name)
-				continue
-			}
 
 			// HACK(jdef): use HostIP instead of pod.CurrentState.PodIP for generic mesos compat
 			epp := api.EndpointPort{Name: portName, Port: portNum, Protocol: portProto}
-			epa := api.EndpointAddress{IP: pod.Status.HostIP, TargetRef: &api.ObjectReference{
+			epa := api.EndpointAddress{IP: pod.Status.HostIP, NodeName: pod.Spec.NodeName, TargetRef: &api.ObjectReference{
 				Kind:            "Pod",
 				Namespace:       pod.ObjectMeta.Namespace,
 				Name:            pod.ObjectMeta.Name,
 				UID:             pod.ObjectMeta.UID,
 				ResourceVersion: pod.ObjectMeta.ResourceVersion,
 			}}
-			subsets = append(subsets, api.EndpointSubset{Addresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			if api.IsPodReady(pod) {
+				subsets = append(subsets, api.EndpointSubset{Addresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			} else {
+				glog.V(5).Infof("Pod is out of service: %v/%v", pod.Namespace, pod.Name)
+				subsets = append(subsets, api.EndpointSubset{NotReadyAddresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			}
 			containerPortAnnotations[fmt.Sprintf(meta.ContainerPortKeyFormat, portProto, pod.Status.HostIP, portNum)] = strconv.Itoa(containerPort)
 		}
 	}
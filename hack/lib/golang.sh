@@ -34,6 +34,7 @@ kube::golang::server_targets() {
     cmd/kubelet
     cmd/hyperkube
     cmd/linkcheck
+    cmd/kube-dns
     plugin/cmd/kube-scheduler
   )
   if [ -n "${KUBERNETES_CONTRIB:-}" ]; then
@@ -68,6 +69,7 @@ kube::golang::test_targets() {
     cmd/genbashcomp
     cmd/genconversion
     cmd/gendeepcopy
+    cmd/genprotobuf
     cmd/genswaggertypedocs
     examples/k8petstore/web-server
     github.com/onsi/ginkgo/ginkgo
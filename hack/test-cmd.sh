@@ -252,6 +252,7 @@ runTests() {
   kube::test::get_object_assert pods "{{range.items}}{{$id_field}}:{{end}}" 'valid-pod:'
   # Command
   kubectl delete pod valid-pod "${kube_flags[@]}" --grace-period=0
+  kubectl wait --for=delete pods/valid-pod --timeout=30s "${kube_flags[@]}"
   # Post-condition: no POD is running
   kube::test::get_object_assert pods "{{range.items}}{{$id_field}}:{{end}}" ''
 
@@ -682,9 +683,22 @@ __EOF__
 
   ### Perform a rolling update with --image
   # Command
-  kubectl rolling-update frontend --image=kubernetes/pause --update-period=10ns --poll-interval=10ms "${kube_flags[@]}"
+  kubectl rolling-update frontend --image=kubernetes/pause --update-period=10ns --poll-interval=10ms --record "${kube_flags[@]}"
   # Post-condition: current image IS kubernetes/pause
   kube::test::get_object_assert 'rc frontend' '{{range \$c:=$rc_container_image_field}} {{\$c.image}} {{end}}' ' +kubernetes/pause +'
+  # Post-condition: the replaced controller is kept as revision 1 of frontend
+  kube::test::get_object_assert rc "{{range.items}}{{$id_field}}:{{end}}" 'frontend:frontend-rev1:'
+  kubectl rollout history rc frontend "${kube_flags[@]}"
+
+  ### Roll back to the previous revision
+  # Command
+  kubectl rollout undo rc frontend --update-period=10ns --poll-interval=10ms "${kube_flags[@]}"
+  # Post-condition: current image IS the original image, and both replaced revisions are kept
+  kube::test::get_object_assert 'rc frontend' '{{range \$c:=$rc_container_image_field}} {{\$c.image}} {{end}}' ' +gcr.io/google_containers/example-guestbook-php-redis:v3 +'
+  kube::test::get_object_assert rc "{{range.items}}{{$id_field}}:{{end}}" 'frontend:frontend-rev1:frontend-rev2:'
+  kubectl rollout status rc frontend "${kube_flags[@]}"
+  # Clean up the rollout history
+  kubectl delete rc frontend-rev1 frontend-rev2 "${kube_flags[@]}"
 
   ### Delete replication controller with id
   # Pre-condition: frontend replication controller is running
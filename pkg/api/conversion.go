@@ -17,6 +17,8 @@ limitations under the License.
 package api
 
 import (
+	"time"
+
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/conversion"
 	"k8s.io/kubernetes/pkg/fields"
@@ -51,6 +53,19 @@ func init() {
 			*out = *in
 			return nil
 		},
+		func(in *[]string, out **util.Time, s conversion.Scope) error {
+			if len(*in) == 0 {
+				*out = nil
+				return nil
+			}
+			t, err := time.Parse(time.RFC3339, (*in)[0])
+			if err != nil {
+				return err
+			}
+			ut := util.NewTime(t)
+			*out = &ut
+			return nil
+		},
 		func(in *string, out *labels.Selector, s conversion.Scope) error {
 			selector, err := labels.Parse(*in)
 			if err != nil {
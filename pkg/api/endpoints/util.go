@@ -31,38 +31,27 @@ import (
 // RepackSubsets takes a slice of EndpointSubset objects, expands it to the full
 // representation, and then repacks that into the canonical layout.  This
 // ensures that code which operates on these objects can rely on the common
-// form for things like comparison.  The result is a newly allocated slice.
+// form for things like comparison.  An address listed as both ready and not
+// ready for the same port is kept as ready.  The result is a newly allocated
+// slice.
 func RepackSubsets(subsets []api.EndpointSubset) []api.EndpointSubset {
 	// First map each unique port definition to the sets of hosts that
 	// offer it.  The sets of hosts must be de-duped, using IP+UID as the key.
-	type addressKey struct {
-		ip  string
-		uid types.UID
-	}
 	allAddrs := map[addressKey]*api.EndpointAddress{}
 	portsToAddrs := map[api.EndpointPort]addressSet{}
 	for i := range subsets {
 		for j := range subsets[i].Ports {
 			epp := &subsets[i].Ports[j]
+			if _, found := portsToAddrs[*epp]; !found {
+				portsToAddrs[*epp] = addressSet{}
+			}
 			for k := range subsets[i].Addresses {
 				epa := &subsets[i].Addresses[k]
-				ak := addressKey{ip: epa.IP}
-				if epa.TargetRef != nil {
-					ak.uid = epa.TargetRef.UID
-				}
-				// Accumulate the address.
-				if allAddrs[ak] == nil {
-					// Make a copy so we don't write to the
-					// input args of this function.
-					p := &api.EndpointAddress{}
-					*p = *epa
-					allAddrs[ak] = p
-				}
-				// Remember that this port maps to this address.
-				if _, found := portsToAddrs[*epp]; !found {
-					portsToAddrs[*epp] = addressSet{}
-				}
-				portsToAddrs[*epp].Insert(allAddrs[ak])
+				portsToAddrs[*epp].Insert(mapAddress(allAddrs, epa), true)
+			}
+			for k := range subsets[i].NotReadyAddresses {
+				epa := &subsets[i].NotReadyAddresses[k]
+				portsToAddrs[*epp].Insert(mapAddress(allAddrs, epa), false)
 			}
 		}
 	}
@@ -75,6 +64,9 @@ func RepackSubsets(subsets []api.EndpointSubset) []api.EndpointSubset {
 	addrSets := map[keyString]addressSet{}
 	addrSetsToPorts := map[keyString][]api.EndpointPort{}
 	for epp, addrs := range portsToAddrs {
+		if len(addrs) == 0 {
+			continue
+		}
 		key := keyString(hashAddresses(addrs))
 		addrSets[key] = addrs
 		addrSetsToPorts[key] = append(addrSetsToPorts[key], epp)
@@ -83,21 +75,55 @@ func RepackSubsets(subsets []api.EndpointSubset) []api.EndpointSubset {
 	// Next, build the N-to-M association the API wants.
 	final := []api.EndpointSubset{}
 	for key, ports := range addrSetsToPorts {
-		addrs := []api.EndpointAddress{}
-		for k := range addrSets[key] {
-			addrs = append(addrs, *k)
+		var readyAddrs, notReadyAddrs []api.EndpointAddress
+		for k, ready := range addrSets[key] {
+			if ready {
+				readyAddrs = append(readyAddrs, *k)
+			} else {
+				notReadyAddrs = append(notReadyAddrs, *k)
+			}
 		}
-		final = append(final, api.EndpointSubset{Addresses: addrs, Ports: ports})
+		final = append(final, api.EndpointSubset{Addresses: readyAddrs, NotReadyAddresses: notReadyAddrs, Ports: ports})
 	}
 
 	// Finally, sort it.
 	return SortSubsets(final)
 }
 
-type addressSet map[*api.EndpointAddress]struct{}
+// mapAddress returns the canonical copy of epa, de-duped using IP+UID as the
+// key.
+func mapAddress(allAddrs map[addressKey]*api.EndpointAddress, epa *api.EndpointAddress) *api.EndpointAddress {
+	ak := addressKey{ip: epa.IP}
+	if epa.TargetRef != nil {
+		ak.uid = epa.TargetRef.UID
+	}
+	if allAddrs[ak] == nil {
+		// Make a copy so we don't write to the
+		// input args of RepackSubsets.
+		p := &api.EndpointAddress{}
+		*p = *epa
+		allAddrs[ak] = p
+	}
+	return allAddrs[ak]
+}
+
+type addressKey struct {
+	ip  string
+	uid types.UID
+}
+
+// addressSet maps each address to whether it is ready.
+type addressSet map[*api.EndpointAddress]bool
 
-func (set addressSet) Insert(addr *api.EndpointAddress) {
-	set[addr] = struct{}{}
+// Insert adds addr to the set. An address that is listed as both ready and
+// not ready is considered ready.
+func (set addressSet) Insert(addr *api.EndpointAddress, ready bool) {
+	set[addr] = set[addr] || ready
+}
+
+type addrReady struct {
+	Addr  *api.EndpointAddress
+	Ready bool
 }
 
 func hashAddresses(addrs addressSet) string {
@@ -110,8 +136,12 @@ func hashAddresses(addrs addressSet) string {
 		slice = append(slice, k)
 	}
 	sort.Sort(addrPtrsByIpAndUID(slice))
+	items := make([]addrReady, 0, len(slice))
+	for _, k := range slice {
+		items = append(items, addrReady{k, addrs[k]})
+	}
 	hasher := md5.New()
-	util.DeepHashObject(hasher, slice)
+	util.DeepHashObject(hasher, items)
 	return hex.EncodeToString(hasher.Sum(nil)[0:])
 }
 
@@ -143,6 +173,7 @@ func SortSubsets(subsets []api.EndpointSubset) []api.EndpointSubset {
 	for i := range subsets {
 		ss := &subsets[i]
 		sort.Sort(addrsByIpAndUID(ss.Addresses))
+		sort.Sort(addrsByIpAndUID(ss.NotReadyAddresses))
 		sort.Sort(portsByHash(ss.Ports))
 	}
 	sort.Sort(subsetsByHash(subsets))
@@ -20,6 +20,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/conversion"
@@ -107,9 +108,48 @@ func IsServiceIPSet(service *Service) bool {
 
 // this function aims to check if the service's cluster IP is requested or not
 func IsServiceIPRequested(service *Service) bool {
+	// ExternalName services don't get a cluster IP
+	if service.Spec.Type == ServiceTypeExternalName {
+		return false
+	}
 	return service.Spec.ClusterIP == ""
 }
 
+// ExternalTrafficIsLocal returns true if external traffic of the service is
+// only sent to endpoints on the node receiving it.
+func ExternalTrafficIsLocal(service *Service) bool {
+	if service.Spec.Type != ServiceTypeNodePort && service.Spec.Type != ServiceTypeLoadBalancer {
+		return false
+	}
+	return service.Spec.ExternalTrafficPolicy == ServiceExternalTrafficPolicyTypeLocal
+}
+
+// NeedsHealthCheck returns true if nodes answer health checks for the service
+// on its HealthCheckNodePort.
+func NeedsHealthCheck(service *Service) bool {
+	return service.Spec.Type == ServiceTypeLoadBalancer && ExternalTrafficIsLocal(service)
+}
+
+// TolerateUnreadyEndpointsAnnotation is set to "true" on a service to have
+// the NotReadyAddresses of its endpoints published in DNS along with the ready
+// ones, so that peers can find each other before they become ready. Proxies
+// never route to not-ready addresses.
+const TolerateUnreadyEndpointsAnnotation = "service.alpha.kubernetes.io/tolerate-unready-endpoints"
+
+// ToleratesUnreadyEndpoints returns true if the given service annotations ask
+// for not-ready endpoint addresses to be published in DNS.
+func ToleratesUnreadyEndpoints(annotations map[string]string) (bool, error) {
+	value, found := annotations[TolerateUnreadyEndpointsAnnotation]
+	if !found {
+		return false, nil
+	}
+	tolerate, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q", TolerateUnreadyEndpointsAnnotation, value)
+	}
+	return tolerate, nil
+}
+
 var standardFinalizers = util.NewStringSet(
 	string(FinalizerKubernetes))
 
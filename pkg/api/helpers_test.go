@@ -142,3 +142,33 @@ func TestAddToNodeAddresses(t *testing.T) {
 		}
 	}
 }
+
+func TestToleratesUnreadyEndpoints(t *testing.T) {
+	testCases := []struct {
+		annotations map[string]string
+		expected    bool
+		expectErr   bool
+	}{
+		{annotations: nil, expected: false},
+		{annotations: map[string]string{"foo": "bar"}, expected: false},
+		{annotations: map[string]string{TolerateUnreadyEndpointsAnnotation: "true"}, expected: true},
+		{annotations: map[string]string{TolerateUnreadyEndpointsAnnotation: "false"}, expected: false},
+		{annotations: map[string]string{TolerateUnreadyEndpointsAnnotation: "yes"}, expectErr: true},
+	}
+
+	for i, tc := range testCases {
+		tolerate, err := ToleratesUnreadyEndpoints(tc.annotations)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("case[%d]: expected an error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case[%d]: unexpected error: %v", i, err)
+		}
+		if tolerate != tc.expected {
+			t.Errorf("case[%d]: expected %v, got %v", i, tc.expected, tolerate)
+		}
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	apitesting "k8s.io/kubernetes/pkg/api/testing"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 	"k8s.io/kubernetes/pkg/util"
 
 	_ "k8s.io/kubernetes/pkg/expapi"
@@ -153,6 +154,45 @@ func TestRoundTripTypes(t *testing.T) {
 	}
 }
 
+func TestProtobufRoundTripTypes(t *testing.T) {
+	codec := protobuf.NewCodec(api.Scheme, "v1")
+	for kind := range api.Scheme.KnownTypes("") {
+		if nonRoundTrippableTypes.Has(kind) {
+			continue
+		}
+		// Only pkg/api/v1 has generated protobuf methods.
+		external, err := api.Scheme.New("v1", kind)
+		if err != nil {
+			continue
+		}
+		if _, ok := external.(protobuf.Marshaler); !ok {
+			continue
+		}
+		for i := 0; i < *fuzzIters; i++ {
+			item, err := api.Scheme.New("", kind)
+			if err != nil {
+				t.Fatalf("Couldn't make a %v? %v", kind, err)
+			}
+			roundTrip(t, codec, fuzzInternalObject(t, "v1", item, rand.Int63()))
+		}
+	}
+}
+
+func TestProtobufDecodesJSON(t *testing.T) {
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "bar"}}
+	data, err := testapi.Codec().Encode(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := protobuf.NewCodec(api.Scheme, "v1").Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out, ok := obj.(*api.Pod); !ok || out.Name != pod.Name || out.Namespace != pod.Namespace {
+		t.Errorf("unexpected object: %#v", obj)
+	}
+}
+
 func TestEncode_Ptr(t *testing.T) {
 	grace := int64(30)
 	pod := &api.Pod{
@@ -216,6 +256,16 @@ func BenchmarkEncodeJSON(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeProtobuf(b *testing.B) {
+	pod := api.Pod{}
+	apiObjectFuzzer := apitesting.FuzzerFor(nil, "", rand.NewSource(benchmarkSeed))
+	apiObjectFuzzer.Fuzz(&pod)
+	codec := protobuf.NewCodec(api.Scheme, "v1")
+	for i := 0; i < b.N; i++ {
+		codec.Encode(&pod)
+	}
+}
+
 func BenchmarkDecode(b *testing.B) {
 	pod := api.Pod{}
 	apiObjectFuzzer := apitesting.FuzzerFor(nil, "", rand.NewSource(benchmarkSeed))
@@ -237,6 +287,17 @@ func BenchmarkDecodeInto(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeProtobuf(b *testing.B) {
+	pod := api.Pod{}
+	apiObjectFuzzer := apitesting.FuzzerFor(nil, "", rand.NewSource(benchmarkSeed))
+	apiObjectFuzzer.Fuzz(&pod)
+	codec := protobuf.NewCodec(api.Scheme, "v1")
+	data, _ := codec.Encode(&pod)
+	for i := 0; i < b.N; i++ {
+		codec.Decode(data)
+	}
+}
+
 // BenchmarkDecodeJSON provides a baseline for regular JSON decode performance
 func BenchmarkDecodeJSON(b *testing.B) {
 	pod := api.Pod{}
@@ -235,7 +235,11 @@ func FuzzerFor(t *testing.T, version string, src rand.Source) *fuzz.Fuzzer {
 			*p = types[c.Rand.Intn(len(types))]
 		},
 		func(p *api.ServiceType, c fuzz.Continue) {
-			types := []api.ServiceType{api.ServiceTypeClusterIP, api.ServiceTypeNodePort, api.ServiceTypeLoadBalancer}
+			types := []api.ServiceType{api.ServiceTypeClusterIP, api.ServiceTypeNodePort, api.ServiceTypeLoadBalancer, api.ServiceTypeExternalName}
+			*p = types[c.Rand.Intn(len(types))]
+		},
+		func(p *api.ServiceExternalTrafficPolicyType, c fuzz.Continue) {
+			types := []api.ServiceExternalTrafficPolicyType{api.ServiceExternalTrafficPolicyTypeCluster, api.ServiceExternalTrafficPolicyTypeLocal}
 			*p = types[c.Rand.Intn(len(types))]
 		},
 		func(ct *api.Container, c fuzz.Continue) {
@@ -306,6 +310,10 @@ func FuzzerFor(t *testing.T, version string, src rand.Source) *fuzz.Fuzzer {
 		},
 		func(ss *api.ServiceSpec, c fuzz.Continue) {
 			c.FuzzNoCustom(ss) // fuzz self without calling this function again
+			if ss.SessionAffinity == api.ServiceAffinityClientIP && ss.SessionAffinityTimeoutSeconds == 0 {
+				// Round-tripping would default it.
+				ss.SessionAffinityTimeoutSeconds = api.DefaultSessionAffinityTimeoutSeconds
+			}
 			if len(ss.Ports) == 0 {
 				// There must be at least 1 port.
 				ss.Ports = append(ss.Ports, api.ServicePort{})
@@ -324,6 +332,17 @@ func FuzzerFor(t *testing.T, version string, src rand.Source) *fuzz.Fuzzer {
 			c.FuzzNoCustom(n)
 			n.Spec.ExternalID = "external"
 		},
+		func(p *expapi.NetworkPolicyPort, c fuzz.Continue) {
+			protocols := []api.Protocol{api.ProtocolTCP, api.ProtocolUDP}
+			p.Protocol = &protocols[c.Rand.Intn(len(protocols))]
+			if c.RandBool() {
+				port := util.NewIntOrStringFromInt(1 + c.Rand.Intn(65535))
+				p.Port = &port
+			} else {
+				port := util.NewIntOrStringFromString(c.RandString())
+				p.Port = &port
+			}
+		},
 		func(s *expapi.APIVersion, c fuzz.Continue) {
 			// We can't use c.RandString() here because it may generate empty
 			// string, which will cause tests failure.
@@ -1083,6 +1083,15 @@ const (
 	ServiceAffinityNone ServiceAffinity = "None"
 )
 
+const (
+	// DefaultSessionAffinityTimeoutSeconds is the session timeout of ClientIP
+	// affinity when none is specified, three hours.
+	DefaultSessionAffinityTimeoutSeconds = 10800
+	// MaxSessionAffinityTimeoutSeconds is the longest allowed session timeout,
+	// one day.
+	MaxSessionAffinityTimeoutSeconds = 86400
+)
+
 // Service Type string describes ingress methods for a service
 type ServiceType string
 
@@ -1099,6 +1108,27 @@ const (
 	// external load balancer (if the cloud provider supports it), in addition
 	// to 'NodePort' type.
 	ServiceTypeLoadBalancer ServiceType = "LoadBalancer"
+
+	// ServiceTypeExternalName means a service consists of only a reference to
+	// an external name that cluster DNS returns as a CNAME record, with no
+	// cluster IP and no proxying.
+	ServiceTypeExternalName ServiceType = "ExternalName"
+)
+
+// ServiceExternalTrafficPolicyType describes where traffic arriving at the node
+// ports, external IPs and load-balancer ingress points of a service is sent.
+type ServiceExternalTrafficPolicyType string
+
+const (
+	// ServiceExternalTrafficPolicyTypeCluster balances external traffic across
+	// the endpoints of the whole cluster.  The traffic is masqueraded, which
+	// hides the client IP from the endpoints.
+	ServiceExternalTrafficPolicyTypeCluster ServiceExternalTrafficPolicyType = "Cluster"
+
+	// ServiceExternalTrafficPolicyTypeLocal only sends external traffic to
+	// endpoints on the node that received it, and preserves the client IP.
+	// Nodes without such endpoints drop the traffic.
+	ServiceExternalTrafficPolicyTypeLocal ServiceExternalTrafficPolicyType = "Local"
 )
 
 // ServiceStatus represents the current status of a service
@@ -1144,7 +1174,7 @@ type ServiceSpec struct {
 	// None can be specified for headless services when proxying is not required
 	ClusterIP string `json:"clusterIP,omitempty"`
 
-	// Type determines how the service will be exposed.  Valid options: ClusterIP, NodePort, LoadBalancer
+	// Type determines how the service will be exposed.  Valid options: ClusterIP, NodePort, LoadBalancer, ExternalName
 	Type ServiceType `json:"type,omitempty"`
 
 	// ExternalIPs are used by external load balancers, or can be set by
@@ -1153,6 +1183,23 @@ type ServiceSpec struct {
 
 	// Required: Supports "ClientIP" and "None".  Used to maintain session affinity.
 	SessionAffinity ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// ExternalTrafficPolicy is "Cluster" or "Local".  Only NodePort and
+	// LoadBalancer services may use "Local".
+	ExternalTrafficPolicy ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+
+	// HealthCheckNodePort is the port on every node that answers health checks
+	// for a LoadBalancer service with the "Local" ExternalTrafficPolicy.
+	// Default is to auto-allocate a port if the service needs one.
+	HealthCheckNodePort int `json:"healthCheckNodePort,omitempty"`
+
+	// SessionAffinityTimeoutSeconds is how long a ClientIP session lasts
+	// without traffic.  Zero means the proxy's default.
+	SessionAffinityTimeoutSeconds int `json:"sessionAffinityTimeoutSeconds,omitempty"`
+
+	// ExternalName is the external DNS name cluster DNS returns as a CNAME
+	// for services of type ExternalName.
+	ExternalName string `json:"externalName,omitempty"`
 }
 
 type ServicePort struct {
@@ -1240,6 +1287,8 @@ type Endpoints struct {
 
 // EndpointSubset is a group of addresses with a common set of ports.  The
 // expanded set of endpoints is the Cartesian product of Addresses x Ports.
+// Addresses that are not yet ready to serve are kept in NotReadyAddresses,
+// which are never load-balanced to.
 // For example, given:
 //   {
 //     Addresses: [{"ip": "10.10.1.1"}, {"ip": "10.10.2.2"}],
@@ -1249,8 +1298,9 @@ type Endpoints struct {
 //     a: [ 10.10.1.1:8675, 10.10.2.2:8675 ],
 //     b: [ 10.10.1.1:309, 10.10.2.2:309 ]
 type EndpointSubset struct {
-	Addresses []EndpointAddress
-	Ports     []EndpointPort
+	Addresses         []EndpointAddress
+	NotReadyAddresses []EndpointAddress
+	Ports             []EndpointPort
 }
 
 // EndpointAddress is a tuple that describes single IP address.
@@ -1261,6 +1311,9 @@ type EndpointAddress struct {
 
 	// Optional: The kubernetes object related to the entry point.
 	TargetRef *ObjectReference
+
+	// Optional: The node hosting this endpoint.
+	NodeName string
 }
 
 // EndpointPort is a tuple that describes a single port.
@@ -1509,6 +1562,8 @@ type ListOptions struct {
 	Watch bool
 	// The resource version to watch (no effect on list yet)
 	ResourceVersion string
+	// If true, the watch may include BOOKMARK events that only carry a resource version
+	AllowWatchBookmarks bool
 }
 
 // PodLogOptions is the query options for a Pod's logs REST call
@@ -1523,6 +1578,26 @@ type PodLogOptions struct {
 
 	// If true, return previous terminated container logs
 	Previous bool
+	// A relative time in seconds before the current time from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceSeconds *int64
+	// An RFC3339 timestamp from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceTime *util.Time
+	// If true, add an RFC3339 or RFC3339Nano timestamp at the beginning of every line
+	// of log output.
+	Timestamps bool
+	// If set, the number of lines from the end of the logs to show. If not specified,
+	// logs are shown from the creation of the container or sinceSeconds or sinceTime
+	TailLines *int64
+	// If set, the number of bytes to read from the server before terminating the
+	// log output. This may not display a complete final line of logging, and may return
+	// slightly more or slightly less than the specified limit.
+	LimitBytes *int64
 }
 
 // PodAttachOptions is the query options to a Pod's remote attach call
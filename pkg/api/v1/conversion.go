@@ -19,9 +19,11 @@ package v1
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/conversion"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 func addConversionFuncs() {
@@ -41,47 +43,14 @@ func addConversionFuncs() {
 	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Pod",
 		func(label, value string) (string, string, error) {
 			switch label {
-			case "metadata.name",
-				"metadata.namespace",
-				"status.phase",
-				"status.podIP",
-				"spec.nodeName":
-				return label, value, nil
-				// This is for backwards compatibility with old v1 clients which send spec.host
+			// This is for backwards compatibility with old v1 clients which send spec.host
 			case "spec.host":
 				return "spec.nodeName", value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
 			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Node",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
-				return label, value, nil
-			case "spec.unschedulable":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "ReplicationController",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name",
-				"status.replicas":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
+			if !podFieldLabels.Has(label) {
+				return "", "", fmt.Errorf("field label %q is not supported for pods; supported fields are: %s", label, strings.Join(podFieldLabels.List(), ", "))
 			}
+			return label, value, nil
 		})
 	if err != nil {
 		// If one of the conversion functions is malformed, detect it immediately.
@@ -90,78 +59,53 @@ func addConversionFuncs() {
 	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Event",
 		func(label, value string) (string, string, error) {
 			switch label {
-			case "involvedObject.kind",
-				"involvedObject.namespace",
-				"involvedObject.name",
-				"involvedObject.uid",
-				"involvedObject.apiVersion",
-				"involvedObject.resourceVersion",
-				"involvedObject.fieldPath",
-				"reason",
-				"source":
-				return label, value, nil
+			// This is for backwards compatibility with clients which select on
+			// the reporting component as "source".
+			case "source":
+				return "source.component", value, nil
 			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Namespace",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "status.phase":
 				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
 			}
 		})
 	if err != nil {
 		// If one of the conversion functions is malformed, detect it immediately.
 		panic(err)
 	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Secret",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "type":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "ServiceAccount",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
-	}
-	err = api.Scheme.AddFieldLabelConversionFunc("v1", "Endpoints",
-		func(label, value string) (string, string, error) {
-			switch label {
-			case "metadata.name":
-				return label, value, nil
-			default:
-				return "", "", fmt.Errorf("field label not supported: %s", label)
-			}
-		})
-	if err != nil {
-		// If one of the conversion functions is malformed, detect it immediately.
-		panic(err)
+	// The field labels of these kinds are the same in v1 and the internal
+	// version. Their registries reject the fields which are not selectable.
+	for _, kind := range []string{
+		"Endpoints",
+		"LimitRange",
+		"Namespace",
+		"Node",
+		"PersistentVolume",
+		"PersistentVolumeClaim",
+		"PodTemplate",
+		"ReplicationController",
+		"ResourceQuota",
+		"Secret",
+		"Service",
+		"ServiceAccount",
+	} {
+		err = api.Scheme.AddFieldLabelConversionFunc("v1", kind, SameFieldLabel)
+		if err != nil {
+			// If one of the conversion functions is malformed, detect it immediately.
+			panic(err)
+		}
 	}
 }
 
+// podFieldLabels are the field labels of pods, in v1 and the internal version,
+// which can be used in field selectors and in the field paths of the downward
+// API. They have to include the SelectableFields of the pod registry.
+var podFieldLabels = util.NewStringSet("metadata.name", "metadata.namespace", "spec.nodeName", "status.phase", "status.podIP")
+
+// SameFieldLabel is the field label conversion function of kinds whose field
+// labels are the same in both versions.
+func SameFieldLabel(label, value string) (string, string, error) {
+	return label, value, nil
+}
+
 func convert_api_ReplicationControllerSpec_To_v1_ReplicationControllerSpec(in *api.ReplicationControllerSpec, out *ReplicationControllerSpec, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*api.ReplicationControllerSpec))(in)
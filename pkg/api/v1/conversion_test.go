@@ -23,6 +23,38 @@ import (
 	versioned "k8s.io/kubernetes/pkg/api/v1"
 )
 
+func TestFieldLabelConversion(t *testing.T) {
+	tests := []struct {
+		kind, label   string
+		expectedLabel string
+		expectErr     bool
+	}{
+		{kind: "Pod", label: "spec.nodeName", expectedLabel: "spec.nodeName"},
+		{kind: "Pod", label: "spec.host", expectedLabel: "spec.nodeName"},
+		{kind: "Pod", label: "spec.whoops", expectErr: true},
+		{kind: "Event", label: "source", expectedLabel: "source.component"},
+		{kind: "Service", label: "spec.type", expectedLabel: "spec.type"},
+		// Kinds without a conversion function do not support field selectors.
+		{kind: "Binding", label: "metadata.name", expectErr: true},
+	}
+	for _, test := range tests {
+		label, value, err := api.Scheme.ConvertFieldLabel("v1", test.kind, test.label, "foo")
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s %s: expected an error", test.kind, test.label)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %v", test.kind, test.label, err)
+			continue
+		}
+		if label != test.expectedLabel || value != "foo" {
+			t.Errorf("%s %s: expected %s=foo, got %s=%s", test.kind, test.label, test.expectedLabel, label, value)
+		}
+	}
+}
+
 func TestNodeConversion(t *testing.T) {
 	obj, err := versioned.Codec.Decode([]byte(`{"kind":"Minion","apiVersion":"v1"}`))
 	if err != nil {
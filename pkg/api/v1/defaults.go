@@ -75,9 +75,15 @@ func addDefaultingFuncs() {
 			if obj.SessionAffinity == "" {
 				obj.SessionAffinity = ServiceAffinityNone
 			}
+			if obj.SessionAffinity == ServiceAffinityClientIP && obj.SessionAffinityTimeoutSeconds == 0 {
+				obj.SessionAffinityTimeoutSeconds = DefaultSessionAffinityTimeoutSeconds
+			}
 			if obj.Type == "" {
 				obj.Type = ServiceTypeClusterIP
 			}
+			if obj.ExternalTrafficPolicy == "" && (obj.Type == ServiceTypeNodePort || obj.Type == ServiceTypeLoadBalancer) {
+				obj.ExternalTrafficPolicy = ServiceExternalTrafficPolicyTypeCluster
+			}
 			for i := range obj.Ports {
 				sp := &obj.Ports[i]
 				if sp.Protocol == "" {
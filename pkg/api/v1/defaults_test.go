@@ -239,6 +239,41 @@ func TestSetDefaultService(t *testing.T) {
 	if svc2.Spec.Type != versioned.ServiceTypeClusterIP {
 		t.Errorf("Expected default type:%s, got: %s", versioned.ServiceTypeClusterIP, svc2.Spec.Type)
 	}
+	if svc2.Spec.ExternalTrafficPolicy != "" {
+		t.Errorf("Expected no external traffic policy, got: %s", svc2.Spec.ExternalTrafficPolicy)
+	}
+}
+
+func TestSetDefaultServiceExternalTrafficPolicy(t *testing.T) {
+	svc := &versioned.Service{Spec: versioned.ServiceSpec{Type: versioned.ServiceTypeNodePort}}
+	obj2 := roundTrip(t, runtime.Object(svc))
+	svc2 := obj2.(*versioned.Service)
+	if svc2.Spec.ExternalTrafficPolicy != versioned.ServiceExternalTrafficPolicyTypeCluster {
+		t.Errorf("Expected default external traffic policy:%s, got: %s", versioned.ServiceExternalTrafficPolicyTypeCluster, svc2.Spec.ExternalTrafficPolicy)
+	}
+
+	svc = &versioned.Service{Spec: versioned.ServiceSpec{Type: versioned.ServiceTypeLoadBalancer, ExternalTrafficPolicy: versioned.ServiceExternalTrafficPolicyTypeLocal}}
+	obj2 = roundTrip(t, runtime.Object(svc))
+	svc2 = obj2.(*versioned.Service)
+	if svc2.Spec.ExternalTrafficPolicy != versioned.ServiceExternalTrafficPolicyTypeLocal {
+		t.Errorf("Expected external traffic policy:%s, got: %s", versioned.ServiceExternalTrafficPolicyTypeLocal, svc2.Spec.ExternalTrafficPolicy)
+	}
+}
+
+func TestSetDefaultServiceSessionAffinityTimeout(t *testing.T) {
+	svc := &versioned.Service{Spec: versioned.ServiceSpec{SessionAffinity: versioned.ServiceAffinityClientIP}}
+	obj2 := roundTrip(t, runtime.Object(svc))
+	svc2 := obj2.(*versioned.Service)
+	if svc2.Spec.SessionAffinityTimeoutSeconds != versioned.DefaultSessionAffinityTimeoutSeconds {
+		t.Errorf("Expected default session affinity timeout:%d, got: %d", versioned.DefaultSessionAffinityTimeoutSeconds, svc2.Spec.SessionAffinityTimeoutSeconds)
+	}
+
+	svc = &versioned.Service{}
+	obj2 = roundTrip(t, runtime.Object(svc))
+	svc2 = obj2.(*versioned.Service)
+	if svc2.Spec.SessionAffinityTimeoutSeconds != 0 {
+		t.Errorf("Expected no session affinity timeout without affinity, got: %d", svc2.Spec.SessionAffinityTimeoutSeconds)
+	}
 }
 
 func TestSetDefaultSecret(t *testing.T) {
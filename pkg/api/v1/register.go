@@ -20,11 +20,16 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/registered"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 )
 
 // Codec encodes internal objects to the v1 scheme
 var Codec = runtime.CodecFor(api.Scheme, "v1")
 
+// ProtobufCodec encodes internal objects to the v1 scheme in the protobuf
+// wire format
+var ProtobufCodec = protobuf.NewCodec(api.Scheme, "v1")
+
 func init() {
 	// Check if v1 is in the list of supported API versions.
 	if !registered.IsRegisteredAPIVersion("v1") {
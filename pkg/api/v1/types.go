@@ -1376,6 +1376,10 @@ const (
 	ServiceAffinityNone ServiceAffinity = "None"
 )
 
+// DefaultSessionAffinityTimeoutSeconds is the session timeout of ClientIP
+// affinity when none is specified, three hours.
+const DefaultSessionAffinityTimeoutSeconds = 10800
+
 // Service Type string describes ingress methods for a service
 type ServiceType string
 
@@ -1392,6 +1396,27 @@ const (
 	// external load balancer (if the cloud provider supports it), in addition
 	// to 'NodePort' type.
 	ServiceTypeLoadBalancer ServiceType = "LoadBalancer"
+
+	// ServiceTypeExternalName means a service consists of only a reference to
+	// an external name that cluster DNS returns as a CNAME record, with no
+	// cluster IP and no proxying.
+	ServiceTypeExternalName ServiceType = "ExternalName"
+)
+
+// ServiceExternalTrafficPolicyType describes where traffic arriving at the node
+// ports, external IPs and load-balancer ingress points of a service is sent.
+type ServiceExternalTrafficPolicyType string
+
+const (
+	// ServiceExternalTrafficPolicyTypeCluster balances external traffic across
+	// the endpoints of the whole cluster.  The traffic is masqueraded, which
+	// hides the client IP from the endpoints.
+	ServiceExternalTrafficPolicyTypeCluster ServiceExternalTrafficPolicyType = "Cluster"
+
+	// ServiceExternalTrafficPolicyTypeLocal only sends external traffic to
+	// endpoints on the node that received it, and preserves the client IP.
+	// Nodes without such endpoints drop the traffic.
+	ServiceExternalTrafficPolicyTypeLocal ServiceExternalTrafficPolicyType = "Local"
 )
 
 // ServiceStatus represents the current status of a service.
@@ -1441,7 +1466,7 @@ type ServiceSpec struct {
 	// More info: http://releases.k8s.io/HEAD/docs/user-guide/services.md#virtual-ips-and-service-proxies
 	ClusterIP string `json:"clusterIP,omitempty"`
 
-	// Type of exposed service. Must be ClusterIP, NodePort, or LoadBalancer.
+	// Type of exposed service. Must be ClusterIP, NodePort, LoadBalancer, or ExternalName.
 	// Defaults to ClusterIP.
 	// More info: http://releases.k8s.io/HEAD/docs/user-guide/services.md#external-services
 	Type ServiceType `json:"type,omitempty"`
@@ -1457,6 +1482,34 @@ type ServiceSpec struct {
 	// Defaults to None.
 	// More info: http://releases.k8s.io/HEAD/docs/user-guide/services.md#virtual-ips-and-service-proxies
 	SessionAffinity ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// ExternalTrafficPolicy denotes whether traffic arriving at node ports,
+	// external IPs and load-balancer ingress points is balanced across the
+	// endpoints of the whole cluster ("Cluster"), or only sent to endpoints on
+	// the receiving node ("Local"). "Local" preserves the client IP and avoids
+	// a second hop, at the risk of imbalanced traffic. Nodes without local
+	// endpoints fail the health checks of the load-balancer.
+	// Must be Cluster or Local; only NodePort and LoadBalancer services may be Local.
+	// Defaults to Cluster for NodePort and LoadBalancer services.
+	ExternalTrafficPolicy ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+
+	// HealthCheckNodePort is the port on every node that answers HTTP health
+	// checks of the load-balancer, with success only on nodes that have local
+	// endpoints. Only LoadBalancer services with the Local external traffic
+	// policy have one.
+	// Default is to auto-allocate a port.
+	HealthCheckNodePort int `json:"healthCheckNodePort,omitempty"`
+
+	// SessionAffinityTimeoutSeconds is how long, in seconds, a ClientIP
+	// session is kept without traffic from the client.
+	// Must be between 1 and 86400 (one day), and only set with ClientIP affinity.
+	// Defaults to 10800 (three hours) for ClientIP affinity.
+	SessionAffinityTimeoutSeconds int `json:"sessionAffinityTimeoutSeconds,omitempty"`
+
+	// ExternalName is the external reference, a DNS name, that cluster DNS
+	// returns as a CNAME record for this service. No proxying is involved.
+	// Required for, and only allowed with, services of type ExternalName.
+	ExternalName string `json:"externalName,omitempty"`
 }
 
 // ServicePort conatins information on service's port.
@@ -1599,6 +1652,10 @@ type EndpointSubset struct {
 	Addresses []EndpointAddress `json:"addresses,omitempty"`
 	// Port numbers available on the related IP addresses.
 	Ports []EndpointPort `json:"ports,omitempty"`
+	// IP addresses which offer the related ports but are not currently marked as ready
+	// because they have not yet finished starting, have recently failed a readiness check,
+	// or have recently failed a liveness check.
+	NotReadyAddresses []EndpointAddress `json:"notReadyAddresses,omitempty"`
 }
 
 // EndpointAddress is a tuple that describes single IP address.
@@ -1611,6 +1668,10 @@ type EndpointAddress struct {
 
 	// Reference to object providing the endpoint.
 	TargetRef *ObjectReference `json:"targetRef,omitempty"`
+
+	// Optional: Node hosting this endpoint. This can be used to determine
+	// endpoints local to a node.
+	NodeName string `json:"nodeName,omitempty"`
 }
 
 // EndpointPort is a tuple that describes a single port.
@@ -1893,6 +1954,9 @@ type ListOptions struct {
 	// When specified with a watch call, shows changes that occur after that particular version of a resource.
 	// Defaults to changes from the beginning of history.
 	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// When specified with a watch call, allows the server to send BOOKMARK events that carry
+	// only the current resourceVersion, so clients can resume watching without relisting.
+	AllowWatchBookmarks bool `json:"allowWatchBookmarks,omitempty"`
 }
 
 // PodLogOptions is the query options for a Pod's logs REST call.
@@ -1909,6 +1973,26 @@ type PodLogOptions struct {
 	// Return previous terminated container logs.
 	// Defaults to false.
 	Previous bool `json:"previous,omitempty"`
+	// A relative time in seconds before the current time from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceSeconds *int64 `json:"sinceSeconds,omitempty"`
+	// An RFC3339 timestamp from which to show logs. If this value
+	// precedes the time a pod was started, only logs since the pod start will be returned.
+	// If this value is in the future, no logs will be returned.
+	// Only one of sinceSeconds or sinceTime may be specified.
+	SinceTime *util.Time `json:"sinceTime,omitempty"`
+	// If true, add an RFC3339 or RFC3339Nano timestamp at the beginning of every line
+	// of log output. Defaults to false.
+	Timestamps bool `json:"timestamps,omitempty"`
+	// If set, the number of lines from the end of the logs to show. If not specified,
+	// logs are shown from the creation of the container or sinceSeconds or sinceTime
+	TailLines *int64 `json:"tailLines,omitempty"`
+	// If set, the number of bytes to read from the server before terminating the
+	// log output. This may not display a complete final line of logging, and may return
+	// slightly more or slightly less than the specified limit.
+	LimitBytes *int64 `json:"limitBytes,omitempty"`
 }
 
 // PodAttachOptions is the query options to a Pod's remote attach call.
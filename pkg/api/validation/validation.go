@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/capabilities"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
 	errs "k8s.io/kubernetes/pkg/util/fielderrors"
 
 	"github.com/golang/glog"
@@ -967,11 +968,22 @@ func validateImagePullSecrets(imagePullSecrets []api.LocalObjectReference) errs.
 func ValidatePod(pod *api.Pod) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMeta(&pod.ObjectMeta, true, ValidatePodName).Prefix("metadata")...)
+	allErrs = append(allErrs, validatePodBandwidthAnnotations(pod.Annotations).Prefix("metadata")...)
 	allErrs = append(allErrs, ValidatePodSpec(&pod.Spec).Prefix("spec")...)
 
 	return allErrs
 }
 
+// validatePodBandwidthAnnotations tests that the bandwidth limits of a pod are
+// reasonable quantities.
+func validatePodBandwidthAnnotations(annotations map[string]string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if _, _, err := bandwidth.ExtractPodBandwidthResources(annotations); err != nil {
+		allErrs = append(allErrs, errs.NewFieldInvalid("annotations", annotations, err.Error()))
+	}
+	return allErrs
+}
+
 // ValidatePodSpec tests that the specified PodSpec has valid data.
 // This includes checking formatting and uniqueness.  It also canonicalizes the
 // structure by setting default values and implementing any backwards-compatibility
@@ -1007,6 +1019,7 @@ func ValidatePodUpdate(newPod, oldPod *api.Pod) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 
 	allErrs = append(allErrs, ValidateObjectMetaUpdate(&newPod.ObjectMeta, &oldPod.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, validatePodBandwidthAnnotations(newPod.Annotations).Prefix("metadata")...)
 
 	if len(newPod.Spec.Containers) != len(oldPod.Spec.Containers) {
 		//TODO: Pinpoint the specific container that causes the invalid error after we have strategic merge diff
@@ -1067,15 +1080,34 @@ func ValidatePodTemplateUpdate(newPod, oldPod *api.PodTemplate) errs.ValidationE
 
 var supportedSessionAffinityType = util.NewStringSet(string(api.ServiceAffinityClientIP), string(api.ServiceAffinityNone))
 var supportedServiceType = util.NewStringSet(string(api.ServiceTypeClusterIP), string(api.ServiceTypeNodePort),
-	string(api.ServiceTypeLoadBalancer))
+	string(api.ServiceTypeLoadBalancer), string(api.ServiceTypeExternalName))
+var supportedExternalTrafficPolicyType = util.NewStringSet(string(api.ServiceExternalTrafficPolicyTypeCluster), string(api.ServiceExternalTrafficPolicyTypeLocal))
 
 // ValidateService tests if required fields in the service are set.
 func ValidateService(service *api.Service) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMeta(&service.ObjectMeta, true, ValidateServiceName).Prefix("metadata")...)
+	if _, err := api.ToleratesUnreadyEndpoints(service.Annotations); err != nil {
+		allErrs = append(allErrs, errs.NewFieldInvalid("metadata.annotations", service.Annotations, err.Error()))
+	}
 
-	if len(service.Spec.Ports) == 0 {
-		allErrs = append(allErrs, errs.NewFieldRequired("spec.ports"))
+	if service.Spec.Type == api.ServiceTypeExternalName {
+		// Nothing is proxied to an external name, so ports are optional.
+		if service.Spec.ExternalName == "" {
+			allErrs = append(allErrs, errs.NewFieldRequired("spec.externalName"))
+		} else if !util.IsDNS1123Subdomain(service.Spec.ExternalName) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.externalName", service.Spec.ExternalName, DNSSubdomainErrorMsg))
+		}
+		if service.Spec.ClusterIP != "" {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, "must be empty for services of type ExternalName"))
+		}
+	} else {
+		if len(service.Spec.Ports) == 0 {
+			allErrs = append(allErrs, errs.NewFieldRequired("spec.ports"))
+		}
+		if service.Spec.ExternalName != "" {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.externalName", service.Spec.ExternalName, "may only be set for services of type ExternalName"))
+		}
 	}
 	if service.Spec.Type == api.ServiceTypeLoadBalancer {
 		for ix := range service.Spec.Ports {
@@ -1099,6 +1131,13 @@ func ValidateService(service *api.Service) errs.ValidationErrorList {
 	} else if !supportedSessionAffinityType.Has(string(service.Spec.SessionAffinity)) {
 		allErrs = append(allErrs, errs.NewFieldValueNotSupported("spec.sessionAffinity", service.Spec.SessionAffinity, supportedSessionAffinityType.List()))
 	}
+	if timeout := service.Spec.SessionAffinityTimeoutSeconds; timeout != 0 {
+		if service.Spec.SessionAffinity != api.ServiceAffinityClientIP {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.sessionAffinityTimeoutSeconds", timeout, "may only be set with ClientIP session affinity"))
+		} else if timeout < 0 || timeout > api.MaxSessionAffinityTimeoutSeconds {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.sessionAffinityTimeoutSeconds", timeout, fmt.Sprintf("must be between 1 and %d", api.MaxSessionAffinityTimeoutSeconds)))
+		}
+	}
 
 	if api.IsServiceIPSet(service) {
 		if ip := net.ParseIP(service.Spec.ClusterIP); ip == nil {
@@ -1107,7 +1146,7 @@ func ValidateService(service *api.Service) errs.ValidationErrorList {
 	}
 
 	for _, ip := range service.Spec.ExternalIPs {
-		if ip == "0.0.0.0" {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.IsUnspecified() {
 			allErrs = append(allErrs, errs.NewFieldInvalid("spec.externalIPs", ip, "is not an IP address"))
 		}
 		allErrs = append(allErrs, validateIpIsNotLinkLocalOrLoopback(ip, "spec.externalIPs")...)
@@ -1127,10 +1166,10 @@ func ValidateService(service *api.Service) errs.ValidationErrorList {
 		}
 	}
 
-	if service.Spec.Type == api.ServiceTypeClusterIP {
+	if service.Spec.Type == api.ServiceTypeClusterIP || service.Spec.Type == api.ServiceTypeExternalName {
 		for i := range service.Spec.Ports {
 			if service.Spec.Ports[i].NodePort != 0 {
-				allErrs = append(allErrs, errs.NewFieldInvalid(fmt.Sprintf("spec.ports[%d].nodePort", i), service.Spec.Ports[i].NodePort, "cannot specify a node port with services of type ClusterIP"))
+				allErrs = append(allErrs, errs.NewFieldInvalid(fmt.Sprintf("spec.ports[%d].nodePort", i), service.Spec.Ports[i].NodePort, fmt.Sprintf("cannot specify a node port with services of type %s", service.Spec.Type)))
 			}
 		}
 	}
@@ -1152,6 +1191,28 @@ func ValidateService(service *api.Service) errs.ValidationErrorList {
 		nodePorts[key] = true
 	}
 
+	if service.Spec.ExternalTrafficPolicy != "" {
+		if !supportedExternalTrafficPolicyType.Has(string(service.Spec.ExternalTrafficPolicy)) {
+			allErrs = append(allErrs, errs.NewFieldValueNotSupported("spec.externalTrafficPolicy", service.Spec.ExternalTrafficPolicy, supportedExternalTrafficPolicyType.List()))
+		} else if service.Spec.ExternalTrafficPolicy == api.ServiceExternalTrafficPolicyTypeLocal && !api.ExternalTrafficIsLocal(service) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.externalTrafficPolicy", service.Spec.ExternalTrafficPolicy, "may only be Local for services of type NodePort or LoadBalancer"))
+		}
+	}
+
+	if port := service.Spec.HealthCheckNodePort; port != 0 {
+		if !api.NeedsHealthCheck(service) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.healthCheckNodePort", port, "may only be set for LoadBalancer services with the Local external traffic policy"))
+		} else if !util.IsValidPortNum(port) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("spec.healthCheckNodePort", port, portRangeErrorMsg))
+		} else {
+			for i := range service.Spec.Ports {
+				if service.Spec.Ports[i].NodePort == port {
+					allErrs = append(allErrs, errs.NewFieldInvalid("spec.healthCheckNodePort", port, fmt.Sprintf("is already the node port of spec.ports[%d]", i)))
+				}
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -1195,7 +1256,8 @@ func ValidateServiceUpdate(oldService, service *api.Service) errs.ValidationErro
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMetaUpdate(&service.ObjectMeta, &oldService.ObjectMeta).Prefix("metadata")...)
 
-	if api.IsServiceIPSet(oldService) && service.Spec.ClusterIP != oldService.Spec.ClusterIP {
+	// The cluster IP is released when the service is changed to type ExternalName.
+	if api.IsServiceIPSet(oldService) && service.Spec.ClusterIP != oldService.Spec.ClusterIP && service.Spec.Type != api.ServiceTypeExternalName {
 		allErrs = append(allErrs, errs.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, "field is immutable"))
 	}
 
@@ -1715,7 +1777,7 @@ func validateEndpointSubsets(subsets []api.EndpointSubset) errs.ValidationErrorL
 
 		ssErrs := errs.ValidationErrorList{}
 
-		if len(ss.Addresses) == 0 {
+		if len(ss.Addresses) == 0 && len(ss.NotReadyAddresses) == 0 {
 			ssErrs = append(ssErrs, errs.NewFieldRequired("addresses"))
 		}
 		if len(ss.Ports) == 0 {
@@ -1724,6 +1786,9 @@ func validateEndpointSubsets(subsets []api.EndpointSubset) errs.ValidationErrorL
 		for addr := range ss.Addresses {
 			ssErrs = append(ssErrs, validateEndpointAddress(&ss.Addresses[addr]).PrefixIndex(addr).Prefix("addresses")...)
 		}
+		for addr := range ss.NotReadyAddresses {
+			ssErrs = append(ssErrs, validateEndpointAddress(&ss.NotReadyAddresses[addr]).PrefixIndex(addr).Prefix("notReadyAddresses")...)
+		}
 		for port := range ss.Ports {
 			ssErrs = append(ssErrs, validateEndpointPort(&ss.Ports[port], len(ss.Ports) > 1).PrefixIndex(port).Prefix("ports")...)
 		}
@@ -1736,11 +1801,16 @@ func validateEndpointSubsets(subsets []api.EndpointSubset) errs.ValidationErrorL
 
 func validateEndpointAddress(address *api.EndpointAddress) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
-	if !util.IsValidIPv4(address.IP) {
-		allErrs = append(allErrs, errs.NewFieldInvalid("ip", address.IP, "invalid IPv4 address"))
+	if net.ParseIP(address.IP) == nil {
+		allErrs = append(allErrs, errs.NewFieldInvalid("ip", address.IP, "invalid IP address"))
 		return allErrs
 	}
-	return validateIpIsNotLinkLocalOrLoopback(address.IP, "ip")
+	if len(address.NodeName) > 0 {
+		if ok, msg := ValidateNodeName(address.NodeName, false); !ok {
+			allErrs = append(allErrs, errs.NewFieldInvalid("nodeName", address.NodeName, msg))
+		}
+	}
+	return append(allErrs, validateIpIsNotLinkLocalOrLoopback(address.IP, "ip")...)
 }
 
 func validateIpIsNotLinkLocalOrLoopback(ipAddress, fieldName string) errs.ValidationErrorList {
@@ -1753,13 +1823,13 @@ func validateIpIsNotLinkLocalOrLoopback(ipAddress, fieldName string) errs.Valida
 		return allErrs
 	}
 	if ip.IsLoopback() {
-		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the loopback range (127.0.0.0/8)"))
+		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the loopback range (127.0.0.0/8, ::1/128)"))
 	}
 	if ip.IsLinkLocalUnicast() {
-		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the link-local range (169.254.0.0/16)"))
+		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the link-local range (169.254.0.0/16, fe80::/10)"))
 	}
 	if ip.IsLinkLocalMulticast() {
-		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the link-local multicast range (224.0.0.0/24)"))
+		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName, ipAddress, "may not be in the link-local multicast range (224.0.0.0/24, ff02::/16)"))
 	}
 	return allErrs
 }
@@ -1814,6 +1884,27 @@ func ValidateSecurityContext(sc *api.SecurityContext) errs.ValidationErrorList {
 	return allErrs
 }
 
+// ValidatePodLogOptions tests if required fields in the pod log options are set.
+func ValidatePodLogOptions(opts *api.PodLogOptions) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if opts.TailLines != nil && *opts.TailLines < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("tailLines", *opts.TailLines, isNegativeErrorMsg))
+	}
+	if opts.LimitBytes != nil && *opts.LimitBytes < 1 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("limitBytes", *opts.LimitBytes, "must be a positive integer or nil"))
+	}
+	switch {
+	case opts.SinceSeconds != nil && opts.SinceTime != nil:
+		allErrs = append(allErrs, errs.NewFieldInvalid("sinceSeconds", *opts.SinceSeconds, "only one of sinceTime or sinceSeconds can be provided"))
+		allErrs = append(allErrs, errs.NewFieldInvalid("sinceTime", *opts.SinceTime, "only one of sinceTime or sinceSeconds can be provided"))
+	case opts.SinceSeconds != nil:
+		if *opts.SinceSeconds < 1 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("sinceSeconds", *opts.SinceSeconds, "must be a positive integer"))
+		}
+	}
+	return allErrs
+}
+
 func ValidateThirdPartyResourceUpdate(old, update *api.ThirdPartyResource) errs.ValidationErrorList {
 	return ValidateThirdPartyResource(update)
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/capabilities"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
 	utilerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/fielderrors"
 	errors "k8s.io/kubernetes/pkg/util/fielderrors"
@@ -1260,6 +1261,21 @@ func TestValidatePod(t *testing.T) {
 				NodeName: "foobar",
 			},
 		},
+		{ // Bandwidth limits.
+			ObjectMeta: api.ObjectMeta{
+				Name:      "123",
+				Namespace: "ns",
+				Annotations: map[string]string{
+					bandwidth.IngressBandwidthAnnotation: "10M",
+					bandwidth.EgressBandwidthAnnotation:  "1G",
+				},
+			},
+			Spec: api.PodSpec{
+				Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+				RestartPolicy: api.RestartPolicyAlways,
+				DNSPolicy:     api.DNSClusterFirst,
+			},
+		},
 	}
 	for _, pod := range successCases {
 		if errs := ValidatePod(&pod); len(errs) != 0 {
@@ -1290,6 +1306,30 @@ func TestValidatePod(t *testing.T) {
 				Containers: []api.Container{{}},
 			},
 		},
+		"bad ingress bandwidth": {
+			ObjectMeta: api.ObjectMeta{
+				Name:        "abc",
+				Namespace:   "ns",
+				Annotations: map[string]string{bandwidth.IngressBandwidthAnnotation: "fast"},
+			},
+			Spec: api.PodSpec{
+				RestartPolicy: api.RestartPolicyAlways,
+				DNSPolicy:     api.DNSClusterFirst,
+				Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			},
+		},
+		"unreasonable egress bandwidth": {
+			ObjectMeta: api.ObjectMeta{
+				Name:        "abc",
+				Namespace:   "ns",
+				Annotations: map[string]string{bandwidth.EgressBandwidthAnnotation: "10"},
+			},
+			Spec: api.PodSpec{
+				RestartPolicy: api.RestartPolicyAlways,
+				DNSPolicy:     api.DNSClusterFirst,
+				Containers:    []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			},
+		},
 		"bad label": {
 			ObjectMeta: api.ObjectMeta{
 				Name:      "abc",
@@ -1755,6 +1795,13 @@ func TestValidateService(t *testing.T) {
 			},
 			numErrs: 1,
 		},
+		{
+			name: "invalid IPv6 publicIPs",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ExternalIPs = []string{"::"}
+			},
+			numErrs: 1,
+		},
 		{
 			name: "invalid publicIPs host",
 			tweakSvc: func(s *api.Service) {
@@ -1815,6 +1862,93 @@ func TestValidateService(t *testing.T) {
 			},
 			numErrs: 0,
 		},
+		{
+			name: "valid tolerate unready endpoints annotation",
+			tweakSvc: func(s *api.Service) {
+				s.Annotations = map[string]string{api.TolerateUnreadyEndpointsAnnotation: "true"}
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid tolerate unready endpoints annotation",
+			tweakSvc: func(s *api.Service) {
+				s.Annotations = map[string]string{api.TolerateUnreadyEndpointsAnnotation: "sometimes"}
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid type - external name",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+				s.Spec.Selector = nil
+				s.Spec.ExternalName = "db.example.com"
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid type - external name without ports",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+				s.Spec.Selector = nil
+				s.Spec.Ports = nil
+				s.Spec.ExternalName = "db.example.com"
+			},
+			numErrs: 0,
+		},
+		{
+			name: "missing external name",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid external name",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+				s.Spec.ExternalName = "-db_.example.com"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid cluster ip with external name",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+				s.Spec.ExternalName = "db.example.com"
+				s.Spec.ClusterIP = "1.2.3.4"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid node port with external name",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeExternalName
+				s.Spec.ExternalName = "db.example.com"
+				s.Spec.Ports[0].NodePort = 30123
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid external name for type ClusterIP",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ExternalName = "db.example.com"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid IPv6 cluster ip",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ClusterIP = "fd00:10::1"
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid IPv6 publicIPs",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ExternalIPs = []string{"2001:db8::1", "1.2.3.4"}
+			},
+			numErrs: 0,
+		},
 		{
 			name: "valid cluster ip - empty",
 			tweakSvc: func(s *api.Service) {
@@ -1967,6 +2101,113 @@ func TestValidateService(t *testing.T) {
 			},
 			numErrs: 1,
 		},
+		{
+			name: "valid type=NodePort with Local external traffic policy",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid type=ClusterIP with Cluster external traffic policy",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeCluster
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid type=ClusterIP with Local external traffic policy",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid external traffic policy",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.ExternalTrafficPolicy = "Nearby"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid type=LoadBalancer with health check node port",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeLoadBalancer
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+				s.Spec.HealthCheckNodePort = 31000
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid health check node port with Cluster external traffic policy",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeLoadBalancer
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeCluster
+				s.Spec.HealthCheckNodePort = 31000
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid health check node port for type=NodePort",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+				s.Spec.HealthCheckNodePort = 31000
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid health check node port out of range",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeLoadBalancer
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+				s.Spec.HealthCheckNodePort = 65536
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid health check node port used by a service port",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeLoadBalancer
+				s.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeLocal
+				s.Spec.Ports[0].NodePort = 31000
+				s.Spec.HealthCheckNodePort = 31000
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid session affinity timeout",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.SessionAffinity = api.ServiceAffinityClientIP
+				s.Spec.SessionAffinityTimeoutSeconds = 600
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid session affinity timeout without ClientIP affinity",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.SessionAffinityTimeoutSeconds = 600
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid negative session affinity timeout",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.SessionAffinity = api.ServiceAffinityClientIP
+				s.Spec.SessionAffinityTimeoutSeconds = -1
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid session affinity timeout over a day",
+			tweakSvc: func(s *api.Service) {
+				s.Spec.SessionAffinity = api.ServiceAffinityClientIP
+				s.Spec.SessionAffinityTimeoutSeconds = api.MaxSessionAffinityTimeoutSeconds + 1
+			},
+			numErrs: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2705,6 +2946,24 @@ func TestValidateServiceUpdate(t *testing.T) {
 			},
 			numErrs: 1,
 		},
+		{
+			name: "change to type ExternalName",
+			tweakSvc: func(oldSvc, newSvc *api.Service) {
+				oldSvc.Spec.ClusterIP = "1.2.3.4"
+				newSvc.Spec.ClusterIP = ""
+				newSvc.Spec.Type = api.ServiceTypeExternalName
+				newSvc.Spec.ExternalName = "db.example.com"
+			},
+			numErrs: 0,
+		},
+		{
+			name: "change from type ExternalName",
+			tweakSvc: func(oldSvc, newSvc *api.Service) {
+				oldSvc.Spec.Type = api.ServiceTypeExternalName
+				oldSvc.Spec.ExternalName = "db.example.com"
+			},
+			numErrs: 0,
+		},
 		{
 			name: "remove cluster IP",
 			tweakSvc: func(oldSvc, newSvc *api.Service) {
@@ -3469,6 +3728,42 @@ func TestValidateEndpoints(t *testing.T) {
 				},
 			},
 		},
+		"node name": {
+			ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+			Subsets: []api.EndpointSubset{
+				{
+					Addresses: []api.EndpointAddress{{IP: "10.10.1.1", NodeName: "node-1.example.com"}},
+					Ports:     []api.EndpointPort{{Port: 8675, Protocol: "TCP"}},
+				},
+			},
+		},
+		"IPv6 addresses": {
+			ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+			Subsets: []api.EndpointSubset{
+				{
+					Addresses: []api.EndpointAddress{{IP: "2001:0db8:85a3:0042:1000:8a2e:0370:7334"}, {IP: "fd00::1"}, {IP: "10.10.1.1"}},
+					Ports:     []api.EndpointPort{{Port: 8675, Protocol: "TCP"}},
+				},
+			},
+		},		"not ready addresses": {
+			ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+			Subsets: []api.EndpointSubset{
+				{
+					Addresses:         []api.EndpointAddress{{IP: "10.10.1.1"}},
+					NotReadyAddresses: []api.EndpointAddress{{IP: "10.10.2.2"}},
+					Ports:             []api.EndpointPort{{Port: 8675, Protocol: "TCP"}},
+				},
+			},
+		},
+		"only not ready addresses": {
+			ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+			Subsets: []api.EndpointSubset{
+				{
+					NotReadyAddresses: []api.EndpointAddress{{IP: "10.10.2.2"}},
+					Ports:             []api.EndpointPort{{Port: 8675, Protocol: "TCP"}},
+				},
+			},
+		},
 	}
 
 	for k, v := range successCases {
@@ -3511,6 +3806,19 @@ func TestValidateEndpoints(t *testing.T) {
 			},
 			errorType: "FieldValueRequired",
 		},
+		"invalid node name": {
+			endpoints: api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+				Subsets: []api.EndpointSubset{
+					{
+						Addresses: []api.EndpointAddress{{IP: "10.10.1.1", NodeName: "Node_1"}},
+						Ports:     []api.EndpointPort{{Port: 8675, Protocol: "TCP"}},
+					},
+				},
+			},
+			errorType:   "FieldValueInvalid",
+			errorDetail: DNSSubdomainErrorMsg,
+		},
 		"empty ports": {
 			endpoints: api.Endpoints{
 				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
@@ -3527,13 +3835,39 @@ func TestValidateEndpoints(t *testing.T) {
 				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
 				Subsets: []api.EndpointSubset{
 					{
-						Addresses: []api.EndpointAddress{{IP: "2001:0db8:85a3:0042:1000:8a2e:0370:7334"}},
+						Addresses: []api.EndpointAddress{{IP: "2001:0db8:85a3:0042:1000:8a2e:0370:7334:1"}},
 						Ports:     []api.EndpointPort{{Name: "a", Port: 93, Protocol: "TCP"}},
 					},
 				},
 			},
 			errorType:   "FieldValueInvalid",
-			errorDetail: "invalid IPv4 address",
+			errorDetail: "invalid IP address",
+		},
+		"invalid not ready IP": {
+			endpoints: api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+				Subsets: []api.EndpointSubset{
+					{
+						NotReadyAddresses: []api.EndpointAddress{{IP: "[2001:0db8:85a3:0042:1000:8a2e:0370:7334]"}},
+						Ports:             []api.EndpointPort{{Name: "a", Port: 93, Protocol: "TCP"}},
+					},
+				},
+			},
+			errorType:   "FieldValueInvalid",
+			errorDetail: "invalid IP address",
+		},
+		"not ready loopback address": {
+			endpoints: api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+				Subsets: []api.EndpointSubset{
+					{
+						NotReadyAddresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
+						Ports:             []api.EndpointPort{{Name: "a", Port: 93, Protocol: "TCP"}},
+					},
+				},
+			},
+			errorType:   "FieldValueInvalid",
+			errorDetail: "loopback",
 		},
 		"Multiple ports, one without name": {
 			endpoints: api.Endpoints{
@@ -3583,7 +3917,7 @@ func TestValidateEndpoints(t *testing.T) {
 				},
 			},
 			errorType:   "FieldValueInvalid",
-			errorDetail: "invalid IPv4 address",
+			errorDetail: "invalid IP address",
 		},
 		"Port missing number": {
 			endpoints: api.Endpoints{
@@ -3649,6 +3983,32 @@ func TestValidateEndpoints(t *testing.T) {
 			errorType:   "FieldValueInvalid",
 			errorDetail: "link-local multicast",
 		},
+		"IPv6 address is loopback": {
+			endpoints: api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+				Subsets: []api.EndpointSubset{
+					{
+						Addresses: []api.EndpointAddress{{IP: "::1"}},
+						Ports:     []api.EndpointPort{{Name: "p", Port: 93, Protocol: "TCP"}},
+					},
+				},
+			},
+			errorType:   "FieldValueInvalid",
+			errorDetail: "loopback",
+		},
+		"IPv6 address is link-local": {
+			endpoints: api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "mysvc", Namespace: "namespace"},
+				Subsets: []api.EndpointSubset{
+					{
+						Addresses: []api.EndpointAddress{{IP: "fe80::1"}},
+						Ports:     []api.EndpointPort{{Name: "p", Port: 93, Protocol: "TCP"}},
+					},
+				},
+			},
+			errorType:   "FieldValueInvalid",
+			errorDetail: "link-local",
+		},
 	}
 
 	for k, v := range errorCases {
@@ -3743,3 +4103,42 @@ func fakeValidSecurityContext(priv bool) *api.SecurityContext {
 		Privileged: &priv,
 	}
 }
+
+func TestValidatePodLogOptions(t *testing.T) {
+	var (
+		positiveLine             = int64(8)
+		negativeLine             = int64(-8)
+		limitBytesGreaterThan1   = int64(12)
+		limitBytesLessThan1      = int64(0)
+		sinceSecondsGreaterThan1 = int64(10)
+		sinceSecondsLessThan1    = int64(0)
+		timestamp                = util.Now()
+	)
+
+	successCases := []api.PodLogOptions{
+		{},
+		{Follow: true, Previous: true, Timestamps: true},
+		{TailLines: &positiveLine},
+		{LimitBytes: &limitBytesGreaterThan1},
+		{SinceSeconds: &sinceSecondsGreaterThan1},
+		{SinceTime: &timestamp},
+		{TailLines: &positiveLine, LimitBytes: &limitBytesGreaterThan1, SinceSeconds: &sinceSecondsGreaterThan1},
+	}
+	for i := range successCases {
+		if errs := ValidatePodLogOptions(&successCases[i]); len(errs) != 0 {
+			t.Errorf("%d: unexpected error: %v", i, errs)
+		}
+	}
+
+	errorCases := map[string]api.PodLogOptions{
+		"negative tailLines":         {TailLines: &negativeLine},
+		"limitBytes less than 1":     {LimitBytes: &limitBytesLessThan1},
+		"sinceSeconds less than 1":   {SinceSeconds: &sinceSecondsLessThan1},
+		"sinceSeconds and sinceTime": {SinceSeconds: &sinceSecondsGreaterThan1, SinceTime: &timestamp},
+	}
+	for k, v := range errorCases {
+		if errs := ValidatePodLogOptions(&v); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+}
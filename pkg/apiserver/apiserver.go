@@ -38,6 +38,7 @@ import (
 	"k8s.io/kubernetes/pkg/apiserver/metrics"
 	"k8s.io/kubernetes/pkg/healthz"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/flushwriter"
@@ -87,7 +88,11 @@ type APIGroupVersion struct {
 
 	Mapper meta.RESTMapper
 
-	Codec     runtime.Codec
+	Codec runtime.Codec
+	// ProtobufCodec, if set, is used in place of Codec for clients that send or
+	// accept the protobuf media type. JSON remains the default.
+	ProtobufCodec runtime.Codec
+
 	Typer     runtime.ObjectTyper
 	Creater   runtime.ObjectCreater
 	Convertor runtime.ObjectConvertor
@@ -257,14 +262,16 @@ func isPrettyPrint(req *http.Request) bool {
 	return false
 }
 
-// writeJSON renders an object as JSON to the response.
+// writeJSON renders an object as JSON to the response. If codec is a protobuf
+// codec negotiated with the client, the object is rendered as protobuf instead.
 func writeJSON(statusCode int, codec runtime.Codec, object runtime.Object, w http.ResponseWriter, pretty bool) {
 	output, err := codec.Encode(object)
 	if err != nil {
 		errorJSONFatal(err, codec, w)
 		return
 	}
-	if pretty {
+	contentType := protobuf.MediaTypeFor(codec)
+	if pretty && contentType == "application/json" {
 		// PR #2243: Pretty-print JSON by default.
 		formatted := &bytes.Buffer{}
 		err = json.Indent(formatted, output, "", "  ")
@@ -274,7 +281,7 @@ func writeJSON(statusCode int, codec runtime.Codec, object runtime.Object, w htt
 		}
 		output = formatted.Bytes()
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(statusCode)
 	w.Write(output)
 }
@@ -297,7 +304,7 @@ func errorJSONFatal(err error, codec runtime.Codec, w http.ResponseWriter) int {
 		fmt.Fprintf(w, "%s: %s", status.Reason, status.Message)
 		return status.Code
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", protobuf.MediaTypeFor(codec))
 	w.WriteHeader(status.Code)
 	w.Write(output)
 	return status.Code
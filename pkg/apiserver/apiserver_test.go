@@ -94,11 +94,12 @@ func newMapper() *meta.DefaultRESTMapper {
 func addTestTypes() {
 	type ListOptions struct {
 		runtime.Object
-		api.TypeMeta    `json:",inline"`
-		LabelSelector   string `json:"labels,omitempty"`
-		FieldSelector   string `json:"fields,omitempty"`
-		Watch           bool   `json:"watch,omitempty"`
-		ResourceVersion string `json:"resourceVersion,omitempty"`
+		api.TypeMeta        `json:",inline"`
+		LabelSelector       string `json:"labels,omitempty"`
+		FieldSelector       string `json:"fields,omitempty"`
+		Watch               bool   `json:"watch,omitempty"`
+		ResourceVersion     string `json:"resourceVersion,omitempty"`
+		AllowWatchBookmarks bool   `json:"allowWatchBookmarks,omitempty"`
 	}
 	api.Scheme.AddKnownTypes(testVersion, &Simple{}, &SimpleList{}, &api.Status{}, &ListOptions{}, &api.DeleteOptions{}, &SimpleGetOptions{}, &SimpleRoot{})
 	api.Scheme.AddKnownTypes(testVersion, &api.Pod{})
@@ -107,11 +108,12 @@ func addTestTypes() {
 func addNewTestTypes() {
 	type ListOptions struct {
 		runtime.Object
-		api.TypeMeta    `json:",inline"`
-		LabelSelector   string `json:"labelSelector,omitempty"`
-		FieldSelector   string `json:"fieldSelector,omitempty"`
-		Watch           bool   `json:"watch,omitempty"`
-		ResourceVersion string `json:"resourceVersion,omitempty"`
+		api.TypeMeta        `json:",inline"`
+		LabelSelector       string `json:"labelSelector,omitempty"`
+		FieldSelector       string `json:"fieldSelector,omitempty"`
+		Watch               bool   `json:"watch,omitempty"`
+		ResourceVersion     string `json:"resourceVersion,omitempty"`
+		AllowWatchBookmarks bool   `json:"allowWatchBookmarks,omitempty"`
 	}
 	api.Scheme.AddKnownTypes(newVersion, &Simple{}, &SimpleList{}, &api.Status{}, &ListOptions{}, &api.DeleteOptions{}, &SimpleGetOptions{}, &SimpleRoot{})
 }
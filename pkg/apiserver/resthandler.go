@@ -18,9 +18,12 @@ package apiserver
 
 import (
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	gpath "path"
+	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/kubernetes/pkg/admission"
@@ -29,7 +32,9 @@ import (
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 	"k8s.io/kubernetes/pkg/util/strategicpatch"
+	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/emicklei/go-restful"
 	"github.com/evanphx/json-patch"
@@ -74,6 +79,62 @@ type RequestScope struct {
 
 	// The version of apiserver resources to use
 	ServerAPIVersion string
+
+	// ProtobufCodec, if set, serves clients that ask for the protobuf media type.
+	ProtobufCodec runtime.Codec
+}
+
+// responseCodec returns the codec used to write the response to req. Protobuf is
+// only used when the client lists it in the Accept header.
+func (scope *RequestScope) responseCodec(req *http.Request) runtime.Codec {
+	if scope.ProtobufCodec != nil && acceptsProtobuf(req.Header.Get("Accept")) {
+		return scope.ProtobufCodec
+	}
+	return scope.Codec
+}
+
+// requestCodec returns the codec used to read the body of req.
+func (scope *RequestScope) requestCodec(req *http.Request) runtime.Codec {
+	if scope.ProtobufCodec != nil && protobuf.IsProtobuf(req.Header.Get("Content-Type")) {
+		return scope.ProtobufCodec
+	}
+	return scope.Codec
+}
+
+// acceptsProtobuf returns true if the client prefers the protobuf media type
+// over JSON according to the provided Accept header. Media ranges are weighted
+// by their q parameter, and a range listed earlier wins over one of equal
+// weight. JSON is matched by application/json, application/* and */*.
+func acceptsProtobuf(accept string) bool {
+	protobufQ, jsonQ := 0.0, 0.0
+	protobufFirst := false
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		switch mediaType {
+		case protobuf.ContentType:
+			if q > protobufQ {
+				protobufQ = q
+				protobufFirst = q > jsonQ
+			}
+		case "application/json", "application/*", "*/*":
+			if q > jsonQ {
+				jsonQ = q
+			}
+		}
+	}
+	if protobufQ == 0 {
+		return false
+	}
+	return protobufQ > jsonQ || (protobufQ == jsonQ && protobufFirst)
 }
 
 // getterFunc performs a get request with the given context and object name. The request
@@ -85,9 +146,10 @@ type getterFunc func(ctx api.Context, name string, req *restful.Request) (runtim
 func getResourceHandler(scope RequestScope, getter getterFunc) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 		namespace, name, err := scope.Namer.Name(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		ctx := scope.ContextFunc(req)
@@ -95,14 +157,14 @@ func getResourceHandler(scope RequestScope, getter getterFunc) restful.RouteFunc
 
 		result, err := getter(ctx, name, req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		if err := setSelfLink(result, req, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
-		write(http.StatusOK, scope.APIVersion, scope.Codec, result, w, req.Request)
+		write(http.StatusOK, scope.APIVersion, codec, result, w, req.Request)
 	}
 }
 
@@ -146,16 +208,17 @@ func getRequestOptions(req *restful.Request, scope RequestScope, kind string, su
 func ConnectResource(connecter rest.Connecter, scope RequestScope, admit admission.Interface, connectOptionsKind, restPath string, subpath bool, subpathKey string) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 		namespace, name, err := scope.Namer.Name(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		ctx := scope.ContextFunc(req)
 		ctx = api.WithNamespace(ctx, namespace)
 		opts, err := getRequestOptions(req, scope, connectOptionsKind, subpath, subpathKey)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		if admit.Handles(admission.Connect) {
@@ -168,19 +231,19 @@ func ConnectResource(connecter rest.Connecter, scope RequestScope, admit admissi
 
 			err = admit.Admit(admission.NewAttributesRecord(connectRequest, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Connect, userInfo))
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 		}
 		handler, err := connecter.Connect(ctx, name, opts)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		handler.ServeHTTP(w, req.Request)
 		err = handler.RequestError()
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 	}
@@ -190,10 +253,11 @@ func ConnectResource(connecter rest.Connecter, scope RequestScope, admit admissi
 func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch bool, minRequestTimeout time.Duration) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 
 		namespace, err := scope.Namer.Namespace(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -210,7 +274,7 @@ func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch
 
 		out, err := queryToObject(req.Request.URL.Query(), scope, "ListOptions")
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		opts := *out.(*api.ListOptions)
@@ -223,7 +287,7 @@ func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch
 		if opts.FieldSelector, err = opts.FieldSelector.Transform(fn); err != nil {
 			// TODO: allow bad request to set field causes based on query parameters
 			err = errors.NewBadRequest(err.Error())
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -240,7 +304,7 @@ func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch
 				// single object.
 				errorJSON(
 					errors.NewBadRequest("both a name and a field selector provided; please provide one or the other."),
-					scope.Codec,
+					codec,
 					w,
 				)
 				return
@@ -251,29 +315,33 @@ func ListResource(r rest.Lister, rw rest.Watcher, scope RequestScope, forceWatch
 		if (opts.Watch || forceWatch) && rw != nil {
 			watcher, err := rw.Watch(ctx, opts.LabelSelector, opts.FieldSelector, opts.ResourceVersion)
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
+			if !opts.AllowWatchBookmarks {
+				watcher = watch.Filter(watcher, dropBookmarks)
+			}
 			serveWatch(watcher, scope, w, req, minRequestTimeout)
 			return
 		}
 
 		result, err := r.List(ctx, opts.LabelSelector, opts.FieldSelector)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		if err := setListSelfLink(result, req, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
-		write(http.StatusOK, scope.APIVersion, scope.Codec, result, w, req.Request)
+		write(http.StatusOK, scope.APIVersion, codec, result, w, req.Request)
 	}
 }
 
 func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.ObjectTyper, admit admission.Interface, includeName bool) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 
 		// TODO: we either want to remove timeout or document it (if we document, move timeout out of this function and declare it in api_installer)
 		timeout := parseTimeout(req.Request.URL.Query().Get("timeout"))
@@ -288,7 +356,7 @@ func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.Object
 			namespace, err = scope.Namer.Namespace(req)
 		}
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -297,14 +365,14 @@ func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.Object
 
 		body, err := readBody(req.Request)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		obj := r.New()
-		if err := scope.Codec.DecodeIntoWithSpecifiedVersionKind(body, obj, scope.APIVersion, scope.Kind); err != nil {
+		if err := scope.requestCodec(req.Request).DecodeIntoWithSpecifiedVersionKind(body, obj, scope.APIVersion, scope.Kind); err != nil {
 			err = transformDecodeError(typer, err, obj, body)
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -313,7 +381,7 @@ func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.Object
 
 			err = admit.Admit(admission.NewAttributesRecord(obj, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Create, userInfo))
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 		}
@@ -326,16 +394,16 @@ func createHandler(r rest.NamedCreater, scope RequestScope, typer runtime.Object
 			return out, err
 		})
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		if err := setSelfLink(result, req, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
-		write(http.StatusCreated, scope.APIVersion, scope.Codec, result, w, req.Request)
+		write(http.StatusCreated, scope.APIVersion, codec, result, w, req.Request)
 	}
 }
 
@@ -362,6 +430,7 @@ func (c *namedCreaterAdapter) Create(ctx api.Context, name string, obj runtime.O
 func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper, admit admission.Interface, converter runtime.ObjectConvertor) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 
 		// TODO: we either want to remove timeout or document it (if we
 		// document, move timeout out of this function and declare it in
@@ -370,7 +439,7 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 
 		namespace, name, err := scope.Namer.Name(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -384,46 +453,46 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 
 			err = admit.Admit(admission.NewAttributesRecord(obj, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Update, userInfo))
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 		}
 
 		versionedObj, err := converter.ConvertToVersion(obj, scope.APIVersion)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		original, err := r.Get(ctx, name)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		originalObjJS, err := scope.Codec.Encode(original)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		patchJS, err := readBody(req.Request)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		contentType := req.HeaderParameter("Content-Type")
 		patchedObjJS, err := getPatchedJS(contentType, originalObjJS, patchJS, versionedObj)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		if err := scope.Codec.DecodeInto(patchedObjJS, obj); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		if err := checkName(obj, name, namespace, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -433,16 +502,16 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			return obj, err
 		})
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		if err := setSelfLink(result, req, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
-		write(http.StatusOK, scope.APIVersion, scope.Codec, result, w, req.Request)
+		write(http.StatusOK, scope.APIVersion, codec, result, w, req.Request)
 	}
 }
 
@@ -450,13 +519,14 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectTyper, admit admission.Interface) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 
 		// TODO: we either want to remove timeout or document it (if we document, move timeout out of this function and declare it in api_installer)
 		timeout := parseTimeout(req.Request.URL.Query().Get("timeout"))
 
 		namespace, name, err := scope.Namer.Name(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		ctx := scope.ContextFunc(req)
@@ -464,19 +534,19 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 
 		body, err := readBody(req.Request)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		obj := r.New()
-		if err := scope.Codec.DecodeIntoWithSpecifiedVersionKind(body, obj, scope.APIVersion, scope.Kind); err != nil {
+		if err := scope.requestCodec(req.Request).DecodeIntoWithSpecifiedVersionKind(body, obj, scope.APIVersion, scope.Kind); err != nil {
 			err = transformDecodeError(typer, err, obj, body)
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		if err := checkName(obj, name, namespace, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -485,7 +555,7 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 
 			err = admit.Admit(admission.NewAttributesRecord(obj, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Update, userInfo))
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 		}
@@ -497,12 +567,12 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 			return obj, err
 		})
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
 		if err := setSelfLink(result, req, scope.Namer); err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -510,7 +580,7 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 		if wasCreated {
 			status = http.StatusCreated
 		}
-		writeJSON(status, scope.Codec, result, w, isPrettyPrint(req.Request))
+		writeJSON(status, codec, result, w, isPrettyPrint(req.Request))
 	}
 }
 
@@ -518,13 +588,14 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope, admit admission.Interface) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
 		w := res.ResponseWriter
+		codec := scope.responseCodec(req.Request)
 
 		// TODO: we either want to remove timeout or document it (if we document, move timeout out of this function and declare it in api_installer)
 		timeout := parseTimeout(req.Request.URL.Query().Get("timeout"))
 
 		namespace, name, err := scope.Namer.Name(req)
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 		ctx := scope.ContextFunc(req)
@@ -534,12 +605,12 @@ func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope,
 		if checkBody {
 			body, err := readBody(req.Request)
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 			if len(body) > 0 {
-				if err := scope.Codec.DecodeInto(body, options); err != nil {
-					errorJSON(err, scope.Codec, w)
+				if err := scope.requestCodec(req.Request).DecodeInto(body, options); err != nil {
+					errorJSON(err, codec, w)
 					return
 				}
 			}
@@ -550,7 +621,7 @@ func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope,
 
 			err = admit.Admit(admission.NewAttributesRecord(nil, scope.Kind, namespace, name, scope.Resource, scope.Subresource, admission.Delete, userInfo))
 			if err != nil {
-				errorJSON(err, scope.Codec, w)
+				errorJSON(err, codec, w)
 				return
 			}
 		}
@@ -559,7 +630,7 @@ func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope,
 			return r.Delete(ctx, name, options)
 		})
 		if err != nil {
-			errorJSON(err, scope.Codec, w)
+			errorJSON(err, codec, w)
 			return
 		}
 
@@ -578,12 +649,12 @@ func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope,
 			// when a non-status response is returned, set the self link
 			if _, ok := result.(*api.Status); !ok {
 				if err := setSelfLink(result, req, scope.Namer); err != nil {
-					errorJSON(err, scope.Codec, w)
+					errorJSON(err, codec, w)
 					return
 				}
 			}
 		}
-		write(http.StatusOK, scope.APIVersion, scope.Codec, result, w, req.Request)
+		write(http.StatusOK, scope.APIVersion, codec, result, w, req.Request)
 	}
 }
 
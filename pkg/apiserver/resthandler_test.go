@@ -0,0 +1,50 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+)
+
+func TestAcceptsProtobuf(t *testing.T) {
+	cases := map[string]bool{
+		"":                                    false,
+		"application/json":                    false,
+		"application/vnd.kubernetes.protobuf": true,
+		"application/vnd.kubernetes.protobuf; charset=utf-8":        true,
+		"application/vnd.kubernetes.protobuf;q=0":                   false,
+		"application/vnd.kubernetes.protobuf;q=0, application/json": false,
+		"application/vnd.kubernetes.protobuf;q=0.0, */*":            false,
+		"*/*":           false,
+		"application/*": false,
+		"application/vnd.kubernetes.protobuf, */*":                               true,
+		"*/*, application/vnd.kubernetes.protobuf":                               false,
+		"*/*;q=0.1, application/vnd.kubernetes.protobuf":                         true,
+		"application/json, application/vnd.kubernetes.protobuf":                  false,
+		"application/vnd.kubernetes.protobuf, application/json":                  true,
+		"application/json;q=0.9, application/vnd.kubernetes.protobuf":            true,
+		"application/json, application/vnd.kubernetes.protobuf;q=0.9":            false,
+		"application/vnd.kubernetes.protobuf;q=0.5, application/json;q=0.5":      true,
+		"application/vnd.kubernetes.protobuf;q=abc, application/json":            false,
+		"text/html, application/vnd.kubernetes.protobuf;q=0.2, text/plain;q=0.9": true,
+	}
+	for accept, expected := range cases {
+		if actual := acceptsProtobuf(accept); actual != expected {
+			t.Errorf("%q: expected %t, got %t", accept, expected, actual)
+		}
+	}
+}
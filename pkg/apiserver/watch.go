@@ -26,8 +26,10 @@ import (
 
 	"k8s.io/kubernetes/pkg/httplog"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 	"k8s.io/kubernetes/pkg/watch"
 	watchjson "k8s.io/kubernetes/pkg/watch/json"
+	watchprotobuf "k8s.io/kubernetes/pkg/watch/protobuf"
 
 	"github.com/emicklei/go-restful"
 	"github.com/golang/glog"
@@ -65,6 +67,12 @@ func (w *realTimeoutFactory) TimeoutCh() (<-chan time.Time, func() bool) {
 	return t.C, t.Stop
 }
 
+// dropBookmarks is a watch.FilterFunc that hides bookmark events from clients
+// which did not ask for them.
+func dropBookmarks(in watch.Event) (watch.Event, bool) {
+	return in, in.Type != watch.Bookmark
+}
+
 // serveWatch handles serving requests to the server
 func serveWatch(watcher watch.Interface, scope RequestScope, w http.ResponseWriter, req *restful.Request, minRequestTimeout time.Duration) {
 	var timeout time.Duration
@@ -72,7 +80,12 @@ func serveWatch(watcher watch.Interface, scope RequestScope, w http.ResponseWrit
 		// Each watch gets a random timeout between minRequestTimeout and 2*minRequestTimeout to avoid thundering herds.
 		timeout = time.Duration(float64(minRequestTimeout) * (rand.Float64() + 1.0))
 	}
-	watchServer := &WatchServer{watcher, scope.Codec, func(obj runtime.Object) {
+	// Websocket clients always receive JSON messages.
+	codec := scope.Codec
+	if !isWebsocketRequest(req.Request) {
+		codec = scope.responseCodec(req.Request)
+	}
+	watchServer := &WatchServer{watcher, codec, func(obj runtime.Object) {
 		if err := setSelfLink(obj, req, scope.Namer); err != nil {
 			glog.V(5).Infof("Failed to set self link for object %v: %v", reflect.TypeOf(obj), err)
 		}
@@ -128,8 +141,14 @@ func (w *WatchServer) HandleWS(ws *websocket.Conn) {
 	}
 }
 
+// watchEncoder writes watch events to a response.
+type watchEncoder interface {
+	Encode(event *watch.Event) error
+}
+
 // ServeHTTP serves a series of JSON encoded events via straight HTTP with
-// Transfer-Encoding: chunked.
+// Transfer-Encoding: chunked. If the codec is a protobuf codec, the events are
+// length prefixed protobuf messages instead.
 func (self *WatchServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	loggedW := httplog.LogOf(req, w)
 	w = httplog.Unlogged(w)
@@ -149,10 +168,16 @@ func (self *WatchServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		http.NotFound(w, req)
 		return
 	}
+	var encoder watchEncoder
+	if protobuf.IsProtobuf(protobuf.MediaTypeFor(self.codec)) {
+		w.Header().Set("Content-Type", protobuf.StreamContentType)
+		encoder = watchprotobuf.NewEncoder(w, self.codec)
+	} else {
+		encoder = watchjson.NewEncoder(w, self.codec)
+	}
 	w.Header().Set("Transfer-Encoding", "chunked")
 	w.WriteHeader(http.StatusOK)
 	flusher.Flush()
-	encoder := watchjson.NewEncoder(w, self.codec)
 	for {
 		select {
 		case <-cn.CloseNotify():
@@ -164,6 +164,54 @@ func TestWatchHTTP(t *testing.T) {
 	}
 }
 
+func TestWatchHTTPBookmarks(t *testing.T) {
+	simpleStorage := &SimpleRESTStorage{}
+	handler := handle(map[string]rest.Storage{"simples": simpleStorage})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	table := []struct {
+		rawQuery string
+		expected []watch.EventType
+	}{
+		{"", []watch.EventType{watch.Added}},
+		{"allowWatchBookmarks=true", []watch.EventType{watch.Bookmark, watch.Added}},
+	}
+	for _, item := range table {
+		dest, _ := url.Parse(server.URL)
+		dest.Path = "/api/version/watch/simples"
+		dest.RawQuery = item.rawQuery
+
+		response, err := http.Get(dest.String())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", item.rawQuery, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("%s: unexpected response %#v", item.rawQuery, response)
+		}
+		decoder := json.NewDecoder(response.Body)
+
+		simpleStorage.fakeWatch.Action(watch.Bookmark, &Simple{ObjectMeta: api.ObjectMeta{ResourceVersion: "10"}})
+		simpleStorage.fakeWatch.Add(&Simple{ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "11"}})
+		simpleStorage.fakeWatch.Stop()
+
+		for _, expected := range item.expected {
+			var got watchJSON
+			if err := decoder.Decode(&got); err != nil {
+				t.Fatalf("%s: unexpected error: %v", item.rawQuery, err)
+			}
+			if got.Type != expected {
+				t.Errorf("%s: expected %v, got %v", item.rawQuery, expected, got.Type)
+			}
+		}
+		var got watchJSON
+		if err := decoder.Decode(&got); err == nil {
+			t.Errorf("%s: unexpected event %v", item.rawQuery, got.Type)
+		}
+		response.Body.Close()
+	}
+}
+
 func TestWatchParamParsing(t *testing.T) {
 	simpleStorage := &SimpleRESTStorage{}
 	handler := handle(map[string]rest.Storage{
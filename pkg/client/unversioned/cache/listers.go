@@ -264,7 +264,11 @@ func (s *StoreToDaemonLister) GetPodDaemons(pod *api.Pod) (daemons []expapi.Daem
 		if daemonController.Namespace != pod.Namespace {
 			continue
 		}
-		selector = labels.Set(daemonController.Spec.Selector).AsSelector()
+		selector, err = expapi.LabelSelectorAsSelector(daemonController.Spec.Selector)
+		if err != nil {
+			// this should not happen if the daemon passed validation
+			return nil, err
+		}
 
 		// If a daemonController with a nil or empty selector creeps in, it should match nothing, not everything.
 		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
@@ -193,7 +193,7 @@ func TestStoreToDaemonLister(t *testing.T) {
 				{
 					ObjectMeta: api.ObjectMeta{Name: "basic", Namespace: "ns"},
 					Spec: expapi.DaemonSpec{
-						Selector: map[string]string{"foo": "baz"},
+						Selector: &expapi.LabelSelector{MatchLabels: map[string]string{"foo": "baz"}},
 					},
 				},
 			},
@@ -232,13 +232,13 @@ func TestStoreToDaemonLister(t *testing.T) {
 				{
 					ObjectMeta: api.ObjectMeta{Name: "foo"},
 					Spec: expapi.DaemonSpec{
-						Selector: map[string]string{"foo": "bar"},
+						Selector: &expapi.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
 					},
 				},
 				{
 					ObjectMeta: api.ObjectMeta{Name: "bar", Namespace: "ns"},
 					Spec: expapi.DaemonSpec{
-						Selector: map[string]string{"foo": "bar"},
+						Selector: &expapi.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
 					},
 				},
 			},
@@ -254,6 +254,42 @@ func TestStoreToDaemonLister(t *testing.T) {
 			},
 			outDCNames: util.NewStringSet("bar"),
 		},
+		// Matching set-based selectors
+		{
+			inDCs: []*expapi.Daemon{
+				{
+					ObjectMeta: api.ObjectMeta{Name: "in", Namespace: "set"},
+					Spec: expapi.DaemonSpec{
+						Selector: &expapi.LabelSelector{
+							MatchExpressions: []expapi.LabelSelectorRequirement{
+								{Key: "foo", Operator: expapi.LabelSelectorOpIn, Values: []string{"bar", "baz"}},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: api.ObjectMeta{Name: "notin", Namespace: "set"},
+					Spec: expapi.DaemonSpec{
+						Selector: &expapi.LabelSelector{
+							MatchExpressions: []expapi.LabelSelectorRequirement{
+								{Key: "foo", Operator: expapi.LabelSelectorOpNotIn, Values: []string{"bar"}},
+							},
+						},
+					},
+				},
+			},
+			list: func() ([]expapi.Daemon, error) {
+				pod := &api.Pod{
+					ObjectMeta: api.ObjectMeta{
+						Name:      "pod1",
+						Labels:    map[string]string{"foo": "bar"},
+						Namespace: "set",
+					},
+				}
+				return lister.GetPodDaemons(pod)
+			},
+			outDCNames: util.NewStringSet("in"),
+		},
 	}
 	for _, c := range testCases {
 		for _, r := range c.inDCs {
@@ -58,7 +58,9 @@ func NewListWatchFromClient(c Getter, resource string, namespace string, fieldSe
 			Namespace(namespace).
 			Resource(resource).
 			FieldsSelectorParam(fieldSelector).
-			Param("resourceVersion", resourceVersion).Watch()
+			Param("resourceVersion", resourceVersion).
+			Param("allowWatchBookmarks", "true").
+			Watch()
 	}
 	return &ListWatch{ListFunc: listFunc, WatchFunc: watchFunc}
 }
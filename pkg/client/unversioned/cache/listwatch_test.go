@@ -116,7 +116,7 @@ func TestListWatchesCanWatch(t *testing.T) {
 		{
 			location: buildLocation(
 				testapi.ResourcePathWithPrefix("watch", "minions", api.NamespaceAll, ""),
-				buildQueryValues(url.Values{"resourceVersion": []string{""}})),
+				buildQueryValues(url.Values{"resourceVersion": []string{""}, "allowWatchBookmarks": []string{"true"}})),
 			rv:            "",
 			resource:      "minions",
 			namespace:     api.NamespaceAll,
@@ -125,7 +125,7 @@ func TestListWatchesCanWatch(t *testing.T) {
 		{
 			location: buildLocation(
 				testapi.ResourcePathWithPrefix("watch", "minions", api.NamespaceAll, ""),
-				buildQueryValues(url.Values{"resourceVersion": []string{"42"}})),
+				buildQueryValues(url.Values{"resourceVersion": []string{"42"}, "allowWatchBookmarks": []string{"true"}})),
 			rv:            "42",
 			resource:      "minions",
 			namespace:     api.NamespaceAll,
@@ -135,7 +135,7 @@ func TestListWatchesCanWatch(t *testing.T) {
 		{
 			location: buildLocation(
 				testapi.ResourcePathWithPrefix("watch", "pods", api.NamespaceAll, ""),
-				buildQueryValues(url.Values{fieldSelectorQueryParamName: []string{"spec.host="}, "resourceVersion": []string{"0"}})),
+				buildQueryValues(url.Values{fieldSelectorQueryParamName: []string{"spec.host="}, "resourceVersion": []string{"0"}, "allowWatchBookmarks": []string{"true"}})),
 			rv:            "0",
 			resource:      "pods",
 			namespace:     api.NamespaceAll,
@@ -145,7 +145,7 @@ func TestListWatchesCanWatch(t *testing.T) {
 		{
 			location: buildLocation(
 				testapi.ResourcePathWithPrefix("watch", "pods", "foo", ""),
-				buildQueryValues(url.Values{fieldSelectorQueryParamName: []string{"spec.host="}, "resourceVersion": []string{"0"}})),
+				buildQueryValues(url.Values{fieldSelectorQueryParamName: []string{"spec.host="}, "resourceVersion": []string{"0"}, "allowWatchBookmarks": []string{"true"}})),
 			rv:            "0",
 			resource:      "pods",
 			namespace:     "foo",
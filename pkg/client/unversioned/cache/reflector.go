@@ -285,6 +285,9 @@ loop:
 				// state", which is passed in event.Object? If so, may need
 				// to change this.
 				r.store.Delete(event.Object)
+			case watch.Bookmark:
+				// Bookmarks carry no changes, they only let us resume the
+				// watch from a more recent resource version.
 			default:
 				util.HandleError(fmt.Errorf("%s: unable to understand watch event %#v", r.name, event))
 			}
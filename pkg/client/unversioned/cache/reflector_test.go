@@ -19,6 +19,7 @@ package cache
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
@@ -188,6 +189,32 @@ func TestReflector_watchHandler(t *testing.T) {
 	}
 }
 
+func TestReflector_watchHandlerBookmark(t *testing.T) {
+	s := NewStore(MetaNamespaceKeyFunc)
+	g := NewReflector(&testLW{}, &api.Pod{}, s, 0)
+	fw := watch.NewFake()
+	go func() {
+		fw.Add(&api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "10"}})
+		fw.Action(watch.Bookmark, &api.Pod{ObjectMeta: api.ObjectMeta{ResourceVersion: "20"}})
+		fw.Stop()
+	}()
+	var resumeRV string
+	err := g.watchHandler(fw, &resumeRV, neverExitWatch, util.NeverStop)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if e, a := []string{"foo"}, s.ListKeys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	// The bookmark should advance the resume point without touching the store.
+	if e, a := "20", resumeRV; e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := "20", g.LastSyncResourceVersion(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
 func TestReflector_watchHandlerTimeout(t *testing.T) {
 	s := NewStore(MetaNamespaceKeyFunc)
 	g := NewReflector(&testLW{}, &api.Pod{}, s, 0)
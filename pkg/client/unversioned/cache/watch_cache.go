@@ -275,6 +275,12 @@ func (w *WatchCache) GetAllEventsSinceThreadUnsafe(resourceVersion uint64) ([]Wa
 	return result, nil
 }
 
+// GetResourceVersionThreadUnsafe returns the resource version up to which
+// the WatchCache is propagated. The caller is responsible for locking.
+func (w *WatchCache) GetResourceVersionThreadUnsafe() uint64 {
+	return w.resourceVersion
+}
+
 func (w *WatchCache) GetAllEventsSince(resourceVersion uint64) ([]WatchCacheEvent, error) {
 	w.RLock()
 	defer w.RUnlock()
@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/emicklei/go-restful/swagger"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/version"
 )
@@ -46,6 +47,7 @@ type Interface interface {
 	PersistentVolumesInterface
 	PersistentVolumeClaimsNamespacer
 	ComponentStatusesInterface
+	SwaggerSchemaInterface
 }
 
 func (c *Client) ReplicationControllers(namespace string) ReplicationControllerInterface {
@@ -152,6 +154,33 @@ func (c *Client) ServerAPIVersions() (*api.APIVersions, error) {
 	return &v, nil
 }
 
+// SwaggerSchemaInterface has a method to retrieve the swagger schema.
+type SwaggerSchemaInterface interface {
+	SwaggerSchema(version string) (*swagger.ApiDeclaration, error)
+}
+
+// SwaggerSchema retrieves and parses the swagger API schema the server supports.
+func (c *Client) SwaggerSchema(version string) (*swagger.ApiDeclaration, error) {
+	return getSwaggerSchema(c.RESTClient, "api", version)
+}
+
+// getSwaggerSchema fetches the swagger API declaration published for the given
+// API group and version, defaulting to the client's own version.
+func getSwaggerSchema(c *RESTClient, group, version string) (*swagger.ApiDeclaration, error) {
+	if len(version) == 0 {
+		version = c.APIVersion()
+	}
+	body, err := c.Get().AbsPath("/swaggerapi", group, version).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	var schema swagger.ApiDeclaration
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, fmt.Errorf("got '%s': %v", string(body), err)
+	}
+	return &schema, nil
+}
+
 type ComponentValidatorInterface interface {
 	ValidateComponents() (*api.ComponentStatusList, error)
 }
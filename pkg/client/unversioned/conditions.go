@@ -38,6 +38,12 @@ func ControllerHasDesiredReplicas(c Interface, controller *api.ReplicationContro
 		// or, after this check has passed, a modification causes the rc manager to create more pods.
 		// This will not be an issue once we've implemented graceful delete for rcs, but till then
 		// concurrent stop operations on the same rc might have unintended side effects.
-		return ctrl.Status.ObservedGeneration >= desiredGeneration && ctrl.Status.Replicas == ctrl.Spec.Replicas, nil
+		return ControllerStatusHasDesiredReplicas(ctrl, desiredGeneration), nil
 	}
 }
+
+// ControllerStatusHasDesiredReplicas returns true iff the status of controller reflects at least
+// desiredGeneration of its spec and the replica count in the status equals the one in the spec.
+func ControllerStatusHasDesiredReplicas(controller *api.ReplicationController, desiredGeneration int64) bool {
+	return controller.Status.ObservedGeneration >= desiredGeneration && controller.Status.Replicas == controller.Spec.Replicas
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/emicklei/go-restful/swagger"
 	"k8s.io/kubernetes/pkg/api"
 	explatest "k8s.io/kubernetes/pkg/expapi/latest"
 	"k8s.io/kubernetes/pkg/version"
@@ -32,9 +33,11 @@ import (
 // incompatible ways at any time.
 type ExperimentalInterface interface {
 	VersionInterface
+	SwaggerSchemaInterface
 	HorizontalPodAutoscalersNamespacer
 	ScaleNamespacer
 	DaemonsNamespacer
+	NetworkPoliciesNamespacer
 }
 
 // ExperimentalClient is used to interact with experimental Kubernetes features.
@@ -73,6 +76,12 @@ func (c *ExperimentalClient) ServerAPIVersions() (*api.APIVersions, error) {
 	return &v, nil
 }
 
+// SwaggerSchema retrieves and parses the swagger API schema of the experimental
+// API group.
+func (c *ExperimentalClient) SwaggerSchema(version string) (*swagger.ApiDeclaration, error) {
+	return getSwaggerSchema(c.RESTClient, "experimental", version)
+}
+
 func (c *ExperimentalClient) HorizontalPodAutoscalers(namespace string) HorizontalPodAutoscalerInterface {
 	return newHorizontalPodAutoscalers(c, namespace)
 }
@@ -85,6 +94,10 @@ func (c *ExperimentalClient) Daemons(namespace string) DaemonInterface {
 	return newDaemons(c, namespace)
 }
 
+func (c *ExperimentalClient) NetworkPolicies(namespace string) NetworkPolicyInterface {
+	return newNetworkPolicies(c, namespace)
+}
+
 // NewExperimental creates a new ExperimentalClient for the given config. This client
 // provides access to experimental Kubernetes features.
 // Experimental features are not supported and may be changed or removed in
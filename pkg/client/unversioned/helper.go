@@ -52,7 +52,8 @@ type Config struct {
 	Version string
 	// Codec specifies the encoding and decoding behavior for runtime.Objects passed
 	// to a RESTClient or Client. Required when initializing a RESTClient, optional
-	// when initializing a Client.
+	// when initializing a Client. Set to v1.ProtobufCodec to request the protobuf
+	// wire format from servers that support it.
 	Codec runtime.Codec
 
 	// Server requires Basic authentication
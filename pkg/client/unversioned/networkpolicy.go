@@ -0,0 +1,95 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unversioned
+
+import (
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// NetworkPoliciesNamespacer has methods to work with NetworkPolicy resources in a namespace
+type NetworkPoliciesNamespacer interface {
+	NetworkPolicies(namespace string) NetworkPolicyInterface
+}
+
+type NetworkPolicyInterface interface {
+	List(selector labels.Selector) (*expapi.NetworkPolicyList, error)
+	Get(name string) (*expapi.NetworkPolicy, error)
+	Create(policy *expapi.NetworkPolicy) (*expapi.NetworkPolicy, error)
+	Update(policy *expapi.NetworkPolicy) (*expapi.NetworkPolicy, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// networkPolicies implements NetworkPoliciesNamespacer interface
+type networkPolicies struct {
+	r  *ExperimentalClient
+	ns string
+}
+
+func newNetworkPolicies(c *ExperimentalClient, namespace string) *networkPolicies {
+	return &networkPolicies{c, namespace}
+}
+
+// Ensure statically that networkPolicies implements NetworkPolicyInterface.
+var _ NetworkPolicyInterface = &networkPolicies{}
+
+func (c *networkPolicies) List(selector labels.Selector) (result *expapi.NetworkPolicyList, err error) {
+	result = &expapi.NetworkPolicyList{}
+	err = c.r.Get().Namespace(c.ns).Resource("networkpolicies").LabelsSelectorParam(selector).Do().Into(result)
+	return
+}
+
+// Get returns information about a particular network policy.
+func (c *networkPolicies) Get(name string) (result *expapi.NetworkPolicy, err error) {
+	result = &expapi.NetworkPolicy{}
+	err = c.r.Get().Namespace(c.ns).Resource("networkpolicies").Name(name).Do().Into(result)
+	return
+}
+
+// Create creates a new network policy.
+func (c *networkPolicies) Create(policy *expapi.NetworkPolicy) (result *expapi.NetworkPolicy, err error) {
+	result = &expapi.NetworkPolicy{}
+	err = c.r.Post().Namespace(c.ns).Resource("networkpolicies").Body(policy).Do().Into(result)
+	return
+}
+
+// Update updates an existing network policy.
+func (c *networkPolicies) Update(policy *expapi.NetworkPolicy) (result *expapi.NetworkPolicy, err error) {
+	result = &expapi.NetworkPolicy{}
+	err = c.r.Put().Namespace(c.ns).Resource("networkpolicies").Name(policy.Name).Body(policy).Do().Into(result)
+	return
+}
+
+// Delete deletes an existing network policy.
+func (c *networkPolicies) Delete(name string) error {
+	return c.r.Delete().Namespace(c.ns).Resource("networkpolicies").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested network policies.
+func (c *networkPolicies) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.r.Get().
+		Prefix("watch").
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(label).
+		FieldsSelectorParam(field).
+		Watch()
+}
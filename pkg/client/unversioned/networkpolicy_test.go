@@ -0,0 +1,160 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unversioned
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/expapi/testapi"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+func getNetworkPolicyResourceName() string {
+	return "networkpolicies"
+}
+
+func TestListNetworkPolicies(t *testing.T) {
+	ns := api.NamespaceAll
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath(getNetworkPolicyResourceName(), ns, ""),
+		},
+		Response: Response{StatusCode: 200,
+			Body: &expapi.NetworkPolicyList{
+				Items: []expapi.NetworkPolicy{
+					{
+						ObjectMeta: api.ObjectMeta{
+							Name: "foo",
+							Labels: map[string]string{
+								"foo":  "bar",
+								"name": "baz",
+							},
+						},
+						Spec: expapi.NetworkPolicySpec{
+							PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "baz"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	receivedPolicyList, err := c.Setup().NetworkPolicies(ns).List(labels.Everything())
+	c.Validate(t, receivedPolicyList, err)
+
+}
+
+func TestGetNetworkPolicy(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request: testRequest{Method: "GET", Path: testapi.ResourcePath(getNetworkPolicyResourceName(), ns, "foo"), Query: buildQueryValues(nil)},
+		Response: Response{
+			StatusCode: 200,
+			Body: &expapi.NetworkPolicy{
+				ObjectMeta: api.ObjectMeta{
+					Name: "foo",
+					Labels: map[string]string{
+						"foo":  "bar",
+						"name": "baz",
+					},
+				},
+				Spec: expapi.NetworkPolicySpec{
+					PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "baz"}},
+				},
+			},
+		},
+	}
+	receivedPolicy, err := c.Setup().NetworkPolicies(ns).Get("foo")
+	c.Validate(t, receivedPolicy, err)
+}
+
+func TestGetNetworkPolicyWithNoName(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{Error: true}
+	receivedPolicy, err := c.Setup().NetworkPolicies(ns).Get("")
+	if (err != nil) && (err.Error() != nameRequiredError) {
+		t.Errorf("Expected error: %v, but got %v", nameRequiredError, err)
+	}
+
+	c.Validate(t, receivedPolicy, err)
+}
+
+func TestUpdateNetworkPolicy(t *testing.T) {
+	ns := api.NamespaceDefault
+	requestPolicy := &expapi.NetworkPolicy{
+		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+	}
+	c := &testClient{
+		Request: testRequest{Method: "PUT", Path: testapi.ResourcePath(getNetworkPolicyResourceName(), ns, "foo"), Query: buildQueryValues(nil)},
+		Response: Response{
+			StatusCode: 200,
+			Body: &expapi.NetworkPolicy{
+				ObjectMeta: api.ObjectMeta{
+					Name: "foo",
+					Labels: map[string]string{
+						"foo":  "bar",
+						"name": "baz",
+					},
+				},
+				Spec: expapi.NetworkPolicySpec{
+					PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "baz"}},
+				},
+			},
+		},
+	}
+	receivedPolicy, err := c.Setup().NetworkPolicies(ns).Update(requestPolicy)
+	c.Validate(t, receivedPolicy, err)
+}
+
+func TestDeleteNetworkPolicy(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath(getNetworkPolicyResourceName(), ns, "foo"), Query: buildQueryValues(nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().NetworkPolicies(ns).Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestCreateNetworkPolicy(t *testing.T) {
+	ns := api.NamespaceDefault
+	requestPolicy := &expapi.NetworkPolicy{
+		ObjectMeta: api.ObjectMeta{Name: "foo"},
+	}
+	c := &testClient{
+		Request: testRequest{Method: "POST", Path: testapi.ResourcePath(getNetworkPolicyResourceName(), ns, ""), Body: requestPolicy, Query: buildQueryValues(nil)},
+		Response: Response{
+			StatusCode: 200,
+			Body: &expapi.NetworkPolicy{
+				ObjectMeta: api.ObjectMeta{
+					Name: "foo",
+					Labels: map[string]string{
+						"foo":  "bar",
+						"name": "baz",
+					},
+				},
+				Spec: expapi.NetworkPolicySpec{
+					PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "baz"}},
+				},
+			},
+		},
+	}
+	receivedPolicy, err := c.Setup().NetworkPolicies(ns).Create(requestPolicy)
+	c.Validate(t, receivedPolicy, err)
+}
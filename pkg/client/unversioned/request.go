@@ -37,10 +37,12 @@ import (
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/httpstream"
 	"k8s.io/kubernetes/pkg/watch"
 	watchjson "k8s.io/kubernetes/pkg/watch/json"
+	watchprotobuf "k8s.io/kubernetes/pkg/watch/protobuf"
 )
 
 // specialParams lists parameters that are handled specially and which users of Request
@@ -109,7 +111,7 @@ type Request struct {
 func NewRequest(client HTTPClient, verb string, baseURL *url.URL, apiVersion string,
 	codec runtime.Codec) *Request {
 	metrics.Register()
-	return &Request{
+	r := &Request{
 		client:     client,
 		verb:       verb,
 		baseURL:    baseURL,
@@ -117,6 +119,12 @@ func NewRequest(client HTTPClient, verb string, baseURL *url.URL, apiVersion str
 		apiVersion: apiVersion,
 		codec:      codec,
 	}
+	if protobuf.MediaTypeFor(codec) == protobuf.ContentType {
+		// Servers that do not offer protobuf fall back to JSON, which the
+		// protobuf codec can also decode.
+		r.SetHeader("Accept", protobuf.ContentType+", application/json")
+	}
+	return r
 }
 
 // Prefix adds segments to the relative beginning to the request path. These
@@ -270,6 +278,7 @@ const (
 	PodHost           = "spec.nodeName"
 	PodStatus         = "status.phase"
 	SecretType        = "type"
+	ServiceType       = "spec.type"
 
 	EventReason                  = "reason"
 	EventSource                  = "source"
@@ -332,6 +341,10 @@ var fieldMappings = versionToResourceToFieldMapping{
 		"secrets": clientFieldNameToAPIVersionFieldName{
 			SecretType: "type",
 		},
+		"services": clientFieldNameToAPIVersionFieldName{
+			ObjectNameField: "metadata.name",
+			ServiceType:     "spec.type",
+		},
 		"serviceAccounts": clientFieldNameToAPIVersionFieldName{
 			ObjectNameField: "metadata.name",
 		},
@@ -466,6 +479,9 @@ func (r *Request) Body(obj interface{}) *Request {
 		}
 		glog.V(8).Infof("Request Body: %s", string(data))
 		r.body = bytes.NewBuffer(data)
+		if mediaType := protobuf.MediaTypeFor(r.codec); mediaType == protobuf.ContentType {
+			r.SetHeader("Content-Type", mediaType)
+		}
 	default:
 		r.err = fmt.Errorf("unknown type used for body: %+v", obj)
 	}
@@ -540,6 +556,9 @@ func (r *Request) Watch() (watch.Interface, error) {
 	if err != nil {
 		return nil, err
 	}
+	for key, values := range r.headers {
+		req.Header[key] = values
+	}
 	client := r.client
 	if client == nil {
 		client = http.DefaultClient
@@ -559,6 +578,9 @@ func (r *Request) Watch() (watch.Interface, error) {
 		}
 		return nil, fmt.Errorf("for request '%+v', got status: %v", url, resp.StatusCode)
 	}
+	if protobuf.IsProtobuf(resp.Header.Get("Content-Type")) {
+		return watch.NewStreamWatcher(watchprotobuf.NewDecoder(resp.Body, r.codec)), nil
+	}
 	return watch.NewStreamWatcher(watchjson.NewDecoder(resp.Body, r.codec)), nil
 }
 
@@ -0,0 +1,77 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testclient
+
+import (
+	kClientLib "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// FakeNetworkPolicies implements NetworkPolicyInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the method you want to test easier.
+type FakeNetworkPolicies struct {
+	Fake      *Fake
+	Namespace string
+}
+
+// Ensure statically that FakeNetworkPolicies implements NetworkPolicyInterface.
+var _ kClientLib.NetworkPolicyInterface = &FakeNetworkPolicies{}
+
+func (c *FakeNetworkPolicies) Get(name string) (*expapi.NetworkPolicy, error) {
+	obj, err := c.Fake.Invokes(NewGetAction("networkpolicies", c.Namespace, name), &expapi.NetworkPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*expapi.NetworkPolicy), err
+}
+
+func (c *FakeNetworkPolicies) List(label labels.Selector) (*expapi.NetworkPolicyList, error) {
+	obj, err := c.Fake.Invokes(NewListAction("networkpolicies", c.Namespace, label, nil), &expapi.NetworkPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*expapi.NetworkPolicyList), err
+}
+
+func (c *FakeNetworkPolicies) Create(policy *expapi.NetworkPolicy) (*expapi.NetworkPolicy, error) {
+	obj, err := c.Fake.Invokes(NewCreateAction("networkpolicies", c.Namespace, policy), &expapi.NetworkPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*expapi.NetworkPolicy), err
+}
+
+func (c *FakeNetworkPolicies) Update(policy *expapi.NetworkPolicy) (*expapi.NetworkPolicy, error) {
+	obj, err := c.Fake.Invokes(NewUpdateAction("networkpolicies", c.Namespace, policy), &expapi.NetworkPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*expapi.NetworkPolicy), err
+}
+
+func (c *FakeNetworkPolicies) Delete(name string) error {
+	_, err := c.Fake.Invokes(NewDeleteAction("networkpolicies", c.Namespace, name), &expapi.NetworkPolicy{})
+	return err
+}
+
+func (c *FakeNetworkPolicies) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	c.Fake.Invokes(NewWatchAction("networkpolicies", c.Namespace, label, field, resourceVersion), nil)
+	return c.Fake.Watch, nil
+}
@@ -19,6 +19,7 @@ package testclient
 import (
 	"sync"
 
+	"github.com/emicklei/go-restful/swagger"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/latest"
 	"k8s.io/kubernetes/pkg/api/registered"
@@ -118,6 +119,10 @@ func (c *Fake) Daemons(namespace string) client.DaemonInterface {
 	return &FakeDaemons{Fake: c, Namespace: namespace}
 }
 
+func (c *Fake) NetworkPolicies(namespace string) client.NetworkPolicyInterface {
+	return &FakeNetworkPolicies{Fake: c, Namespace: namespace}
+}
+
 func (c *Fake) Nodes() client.NodeInterface {
 	return &FakeNodes{Fake: c}
 }
@@ -181,6 +186,15 @@ func (c *Fake) ServerAPIVersions() (*api.APIVersions, error) {
 	return &api.APIVersions{Versions: registered.RegisteredVersions}, nil
 }
 
+func (c *Fake) SwaggerSchema(version string) (*swagger.ApiDeclaration, error) {
+	action := ActionImpl{}
+	action.Verb = "get"
+	action.Resource = "swaggerschema"
+
+	c.Invokes(action, nil)
+	return &swagger.ApiDeclaration{}, nil
+}
+
 func (c *Fake) ComponentStatuses() client.ComponentStatusInterface {
 	return &FakeComponentStatuses{Fake: c}
 }
@@ -195,8 +195,9 @@ func (s *AWSCloud) ensureLoadBalancer(region, name string, listeners []*elb.List
 	return loadBalancer, nil
 }
 
-// Makes sure that the health check for an ELB matches the configured listeners
-func (s *AWSCloud) ensureLoadBalancerHealthCheck(region string, loadBalancer *elb.LoadBalancerDescription, listeners []*elb.Listener) error {
+// Makes sure that the health check for an ELB matches the configured listeners,
+// or checks the health check node port if there is one
+func (s *AWSCloud) ensureLoadBalancerHealthCheck(region string, loadBalancer *elb.LoadBalancerDescription, listeners []*elb.Listener, healthCheckNodePort int) error {
 	elbClient, err := s.getELBClient(region)
 	if err != nil {
 		return err
@@ -210,14 +211,19 @@ func (s *AWSCloud) ensureLoadBalancerHealthCheck(region string, loadBalancer *el
 	expectedTimeout := int64(5)
 	expectedInterval := int64(30)
 
-	// We only a TCP health-check on the first port
+	// We only a TCP health-check on the first port, unless the service asks
+	// for an HTTP health-check on its health check node port
 	expectedTarget := ""
-	for _, listener := range listeners {
-		if listener.InstancePort == nil {
-			continue
+	if healthCheckNodePort != 0 {
+		expectedTarget = "HTTP:" + strconv.Itoa(healthCheckNodePort) + "/"
+	} else {
+		for _, listener := range listeners {
+			if listener.InstancePort == nil {
+				continue
+			}
+			expectedTarget = "TCP:" + strconv.FormatInt(*listener.InstancePort, 10)
+			break
 		}
-		expectedTarget = "TCP:" + strconv.FormatInt(*listener.InstancePort, 10)
-		break
 	}
 
 	if expectedTarget == "" {
@@ -36,6 +36,8 @@ type FakeBalancer struct {
 	ExternalIP net.IP
 	Ports      []*api.ServicePort
 	Hosts      []string
+	// HealthCheckNodePort is the node port the balancer health checks, if any.
+	HealthCheckNodePort int
 }
 
 type FakeUpdateBalancerCall struct {
@@ -125,12 +127,12 @@ func (f *FakeCloud) GetTCPLoadBalancer(name, region string) (*api.LoadBalancerSt
 
 // EnsureTCPLoadBalancer is a test-spy implementation of TCPLoadBalancer.EnsureTCPLoadBalancer.
 // It adds an entry "create" into the internal method call record.
-func (f *FakeCloud) EnsureTCPLoadBalancer(name, region string, externalIP net.IP, ports []*api.ServicePort, hosts []string, affinityType api.ServiceAffinity) (*api.LoadBalancerStatus, error) {
+func (f *FakeCloud) EnsureTCPLoadBalancer(name, region string, externalIP net.IP, ports []*api.ServicePort, hosts []string, affinityType api.ServiceAffinity, healthCheckNodePort int) (*api.LoadBalancerStatus, error) {
 	f.addCall("create")
 	if f.Balancers == nil {
 		f.Balancers = make(map[string]FakeBalancer)
 	}
-	f.Balancers[name] = FakeBalancer{name, region, externalIP, ports, hosts}
+	f.Balancers[name] = FakeBalancer{name, region, externalIP, ports, hosts, healthCheckNodePort}
 
 	status := &api.LoadBalancerStatus{}
 	status.Ingress = []api.LoadBalancerIngress{{IP: f.ExternalIP.String()}}
@@ -242,7 +242,7 @@ const (
 	GCEAffinityTypeClientIPProto GCEAffinityType = "CLIENT_IP_PROTO"
 )
 
-func (gce *GCECloud) makeTargetPool(name, region string, hosts []string, affinityType GCEAffinityType) error {
+func (gce *GCECloud) makeTargetPool(name, region string, hosts []string, affinityType GCEAffinityType, healthCheckURL string) error {
 	var instances []string
 	for _, host := range hosts {
 		instances = append(instances, makeHostURL(gce.projectID, gce.zone, host))
@@ -252,6 +252,9 @@ func (gce *GCECloud) makeTargetPool(name, region string, hosts []string, affinit
 		Instances:       instances,
 		SessionAffinity: string(affinityType),
 	}
+	if healthCheckURL != "" {
+		pool.HealthChecks = []string{healthCheckURL}
+	}
 	op, err := gce.service.TargetPools.Insert(gce.projectID, region, pool).Do()
 	if err != nil {
 		return err
@@ -262,6 +265,31 @@ func (gce *GCECloud) makeTargetPool(name, region string, hosts []string, affinit
 	return nil
 }
 
+// makeHTTPHealthCheck creates an HTTP health check of the given node port and
+// returns its URL.
+func (gce *GCECloud) makeHTTPHealthCheck(name string, port int) (string, error) {
+	hc := &compute.HttpHealthCheck{
+		Name:        name,
+		Description: "KubernetesAutoGenerated_HealthCheckNodePort",
+		Port:        int64(port),
+		RequestPath: "/",
+	}
+	op, err := gce.service.HttpHealthChecks.Insert(gce.projectID, hc).Do()
+	if err != nil && !isHTTPErrorCode(err, http.StatusConflict) {
+		return "", err
+	}
+	if op != nil {
+		if err = gce.waitForGlobalOp(op); err != nil && !isHTTPErrorCode(err, http.StatusConflict) {
+			return "", err
+		}
+	}
+	hc, err = gce.service.HttpHealthChecks.Get(gce.projectID, name).Do()
+	if err != nil {
+		return "", err
+	}
+	return hc.SelfLink, nil
+}
+
 func (gce *GCECloud) targetPoolURL(name, region string) string {
 	return fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/targetPools/%s", gce.projectID, region, name)
 }
@@ -351,7 +379,7 @@ func makeFirewallName(name string) string {
 // EnsureTCPLoadBalancer is an implementation of TCPLoadBalancer.EnsureTCPLoadBalancer.
 // TODO(a-robinson): Don't just ignore specified IP addresses. Check if they're
 // owned by the project and available to be used, and use them if they are.
-func (gce *GCECloud) EnsureTCPLoadBalancer(name, region string, externalIP net.IP, ports []*api.ServicePort, hosts []string, affinityType api.ServiceAffinity) (*api.LoadBalancerStatus, error) {
+func (gce *GCECloud) EnsureTCPLoadBalancer(name, region string, externalIP net.IP, ports []*api.ServicePort, hosts []string, affinityType api.ServiceAffinity, healthCheckNodePort int) (*api.LoadBalancerStatus, error) {
 	if len(hosts) == 0 {
 		return nil, fmt.Errorf("Cannot EnsureTCPLoadBalancer() with no hosts")
 	}
@@ -371,7 +399,15 @@ func (gce *GCECloud) EnsureTCPLoadBalancer(name, region string, externalIP net.I
 		}
 	}
 
-	err = gce.makeTargetPool(name, region, hosts, translateAffinityType(affinityType))
+	healthCheckURL := ""
+	if healthCheckNodePort != 0 {
+		healthCheckURL, err = gce.makeHTTPHealthCheck(name, healthCheckNodePort)
+		if err != nil {
+			return nil, fmt.Errorf("error creating health check for GCE load balancer: %v", err)
+		}
+	}
+
+	err = gce.makeTargetPool(name, region, hosts, translateAffinityType(affinityType), healthCheckURL)
 	if err != nil {
 		if !isHTTPErrorCode(err, http.StatusConflict) {
 			return nil, err
@@ -417,6 +453,9 @@ func (gce *GCECloud) EnsureTCPLoadBalancer(name, region string, externalIP net.I
 	for ix := range ports {
 		allowedPorts[ix] = strconv.Itoa(ports[ix].Port)
 	}
+	if healthCheckNodePort != 0 {
+		allowedPorts = append(allowedPorts, strconv.Itoa(healthCheckNodePort))
+	}
 
 	hostTag := gce.computeHostTag(hosts[0])
 
@@ -545,6 +584,19 @@ func (gce *GCECloud) EnsureTCPLoadBalancerDeleted(name, region string) error {
 	if err != nil {
 		glog.Warningf("Failed waiting for Target Pool %s to be deleted: got error %s.", name, err.Error())
 	}
+	// The health check only exists for services with a health check node port.
+	op, err = gce.service.HttpHealthChecks.Delete(gce.projectID, name).Do()
+	if err != nil && isHTTPErrorCode(err, http.StatusNotFound) {
+		glog.V(2).Infof("Health check %s doesn't exist, moving on to deleting firewall.", name)
+	} else if err != nil {
+		glog.Warningf("Failed to delete health check %s, got error %v", name, err)
+		return err
+	} else {
+		if err = gce.waitForGlobalOp(op); err != nil {
+			glog.Warningf("Failed waiting for health check %s to be deleted.  Got error: %v", name, err)
+			return err
+		}
+	}
 	fwName := makeFirewallName(name)
 	op, err = gce.service.Firewalls.Delete(gce.projectID, fwName).Do()
 	if err != nil && isHTTPErrorCode(err, http.StatusNotFound) {
@@ -29,6 +29,7 @@ import (
 	client "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/client/unversioned/cache"
 	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -286,7 +287,13 @@ func (e *EndpointController) syncService(key string) {
 	}
 
 	glog.V(5).Infof("About to update endpoints for service %q", key)
-	pods, err := e.podStore.Pods(service.Namespace).List(labels.Set(service.Spec.Selector).AsSelector())
+	selector, err := expapi.LabelSelectorAsSelector(expapi.SetAsLabelSelector(labels.Set(service.Spec.Selector)))
+	if err != nil {
+		// Don't retry, the selector passed validation and will not change.
+		glog.Errorf("Invalid selector of service %q: %v", key, err)
+		return
+	}
+	pods, err := e.podStore.Pods(service.Namespace).List(selector)
 	if err != nil {
 		// Since we're getting stuff from a local cache, it is
 		// basically impossible to get this error.
@@ -318,11 +325,6 @@ func (e *EndpointController) syncService(key string) {
 				continue
 			}
 
-			if !api.IsPodReady(pod) {
-				glog.V(5).Infof("Pod is out of service: %v/%v", pod.Namespace, pod.Name)
-				continue
-			}
-
 			epp := api.EndpointPort{Name: portName, Port: portNum, Protocol: portProto}
 			epa := api.EndpointAddress{IP: pod.Status.PodIP, TargetRef: &api.ObjectReference{
 				Kind:            "Pod",
@@ -330,8 +332,13 @@ func (e *EndpointController) syncService(key string) {
 				Name:            pod.ObjectMeta.Name,
 				UID:             pod.ObjectMeta.UID,
 				ResourceVersion: pod.ObjectMeta.ResourceVersion,
-			}}
-			subsets = append(subsets, api.EndpointSubset{Addresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			}, NodeName: pod.Spec.NodeName}
+			if api.IsPodReady(pod) {
+				subsets = append(subsets, api.EndpointSubset{Addresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			} else {
+				glog.V(5).Infof("Pod is out of service: %v/%v", pod.Namespace, pod.Name)
+				subsets = append(subsets, api.EndpointSubset{NotReadyAddresses: []api.EndpointAddress{epa}, Ports: []api.EndpointPort{epp}})
+			}
 		}
 	}
 	subsets = endpoints.RepackSubsets(subsets)
@@ -383,7 +383,7 @@ func (s *ServiceController) createExternalLoadBalancer(service *api.Service) err
 			// TODO: Make this actually work for multiple IPs by using different
 			// names for each. For now, we'll just create the first and break.
 			status, err := s.balancer.EnsureTCPLoadBalancer(name, s.zone.Region, net.ParseIP(publicIP),
-				ports, hostsFromNodeList(&nodes), service.Spec.SessionAffinity)
+				ports, hostsFromNodeList(&nodes), service.Spec.SessionAffinity, service.Spec.HealthCheckNodePort)
 			if err != nil {
 				return err
 			} else {
@@ -393,7 +393,7 @@ func (s *ServiceController) createExternalLoadBalancer(service *api.Service) err
 		}
 	} else {
 		status, err := s.balancer.EnsureTCPLoadBalancer(name, s.zone.Region, nil,
-			ports, hostsFromNodeList(&nodes), service.Spec.SessionAffinity)
+			ports, hostsFromNodeList(&nodes), service.Spec.SessionAffinity, service.Spec.HealthCheckNodePort)
 		if err != nil {
 			return err
 		} else {
@@ -477,6 +477,10 @@ func needsUpdate(oldService *api.Service, newService *api.Service) bool {
 	if !portsEqualForLB(oldService, newService) || oldService.Spec.SessionAffinity != newService.Spec.SessionAffinity {
 		return true
 	}
+	if oldService.Spec.ExternalTrafficPolicy != newService.Spec.ExternalTrafficPolicy ||
+		oldService.Spec.HealthCheckNodePort != newService.Spec.HealthCheckNodePort {
+		return true
+	}
 	if len(oldService.Spec.ExternalIPs) != len(newService.Spec.ExternalIPs) {
 		return true
 	}
@@ -85,6 +85,25 @@ func TestCreateExternalLoadBalancer(t *testing.T) {
 			expectErr:           false,
 			expectCreateAttempt: true,
 		},
+		{
+			service: &api.Service{
+				ObjectMeta: api.ObjectMeta{
+					Name:      "local-traffic-service",
+					Namespace: "default",
+				},
+				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{
+						Port:     80,
+						Protocol: api.ProtocolTCP,
+					}},
+					Type:                  api.ServiceTypeLoadBalancer,
+					ExternalTrafficPolicy: api.ServiceExternalTrafficPolicyTypeLocal,
+					HealthCheckNodePort:   30000,
+				},
+			},
+			expectErr:           false,
+			expectCreateAttempt: true,
+		},
 	}
 
 	for _, item := range table {
@@ -124,7 +143,8 @@ func TestCreateExternalLoadBalancer(t *testing.T) {
 				t.Errorf("expected one load balancer to be created, got none")
 			} else if balancer.Name != controller.loadBalancerName(item.service) ||
 				balancer.Region != region ||
-				balancer.Ports[0].Port != item.service.Spec.Ports[0].Port {
+				balancer.Ports[0].Port != item.service.Spec.Ports[0].Port ||
+				balancer.HealthCheckNodePort != item.service.Spec.HealthCheckNodePort {
 				t.Errorf("created load balancer has incorrect parameters: %v", balancer)
 			}
 			actionFound := false
@@ -0,0 +1,519 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/miekg/dns"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/unversioned/cache"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+const (
+	// A subdomain added to the cluster domain for all services.
+	serviceSubdomain = "svc"
+	// The time to live of the records served, in seconds.
+	recordTTL = 30
+	// How long to wait for an upstream nameserver to answer.
+	forwardTimeout = 2 * time.Second
+
+	// The names of the indexes of services by cluster IP and of endpoints
+	// by the IPs of their addresses, used to answer PTR queries.
+	clusterIPIndex = "clusterIP"
+	addressIndex   = "address"
+)
+
+// KubeDNS is a dns.Handler which serves the records of the services in the
+// cluster domain and forwards other queries to upstream nameservers.
+type KubeDNS struct {
+	// The cluster domain, lower case and fully qualified.
+	domain string
+	// The nameservers to forward queries outside of the cluster domain to,
+	// as host:port.
+	nameservers []string
+
+	// Caches of all the services and endpoints, kept up to date by the
+	// informers which fill them.
+	services            cache.Indexer
+	servicesController  *framework.Controller
+	endpoints           cache.Indexer
+	endpointsController *framework.Controller
+}
+
+// NewKubeDNS returns a KubeDNS serving the services and endpoints listed
+// and watched through servicesLW and endpointsLW under domain. It has to be
+// started with Run.
+func NewKubeDNS(servicesLW, endpointsLW cache.ListerWatcher, domain string, nameservers []string, resyncPeriod time.Duration) *KubeDNS {
+	kd := &KubeDNS{
+		domain:      dns.Fqdn(strings.ToLower(domain)),
+		nameservers: nameservers,
+	}
+	kd.services, kd.servicesController = framework.NewIndexerInformer(
+		servicesLW,
+		&api.Service{},
+		resyncPeriod,
+		framework.ResourceEventHandlerFuncs{},
+		cache.Indexers{clusterIPIndex: serviceClusterIPIndexFunc},
+	)
+	kd.endpoints, kd.endpointsController = framework.NewIndexerInformer(
+		endpointsLW,
+		&api.Endpoints{},
+		resyncPeriod,
+		framework.ResourceEventHandlerFuncs{},
+		cache.Indexers{addressIndex: endpointsAddressIndexFunc},
+	)
+	return kd
+}
+
+// Run starts the informers filling the caches of services and endpoints
+// until stopCh is closed.
+func (kd *KubeDNS) Run(stopCh <-chan struct{}) {
+	go kd.servicesController.Run(stopCh)
+	go kd.endpointsController.Run(stopCh)
+}
+
+// HasSynced returns true once the initial lists of services and endpoints
+// have been loaded.
+func (kd *KubeDNS) HasSynced() bool {
+	return kd.servicesController.HasSynced() && kd.endpointsController.HasSynced()
+}
+
+func serviceClusterIPIndexFunc(obj interface{}) ([]string, error) {
+	service, ok := obj.(*api.Service)
+	if !ok {
+		return nil, fmt.Errorf("expected a service, got %#v", obj)
+	}
+	if !api.IsServiceIPSet(service) {
+		return []string{}, nil
+	}
+	return []string{service.Spec.ClusterIP}, nil
+}
+
+func endpointsAddressIndexFunc(obj interface{}) ([]string, error) {
+	endpoints, ok := obj.(*api.Endpoints)
+	if !ok {
+		return nil, fmt.Errorf("expected endpoints, got %#v", obj)
+	}
+	ips := util.NewStringSet()
+	for i := range endpoints.Subsets {
+		for _, address := range endpoints.Subsets[i].Addresses {
+			ips.Insert(address.IP)
+		}
+		// whether these are published depends on the service, see ptrRecords
+		for _, address := range endpoints.Subsets[i].NotReadyAddresses {
+			ips.Insert(address.IP)
+		}
+	}
+	return ips.List(), nil
+}
+
+// ServeDNS implements dns.Handler.
+func (kd *KubeDNS) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	if len(req.Question) != 1 {
+		m := new(dns.Msg)
+		m.SetRcodeFormatError(req)
+		w.WriteMsg(m)
+		return
+	}
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+
+	switch {
+	case dns.IsSubDomain(kd.domain, name):
+		if !kd.HasSynced() {
+			// Rather than denying that names exist, let the client retry.
+			m := new(dns.Msg)
+			m.SetRcode(req, dns.RcodeServerFailure)
+			w.WriteMsg(m)
+			return
+		}
+		answer, extra, exists := kd.records(name, q.Qtype)
+		kd.reply(w, req, answer, extra, exists)
+	case q.Qtype == dns.TypePTR && (strings.HasSuffix(name, ".in-addr.arpa.") || strings.HasSuffix(name, ".ip6.arpa.")):
+		// Only the addresses in the cluster are known, leave the rest of the
+		// reverse zone to the upstream nameservers.
+		if answer := kd.ptrRecords(name); len(answer) > 0 {
+			kd.reply(w, req, answer, nil, true)
+			return
+		}
+		kd.forward(w, req)
+	default:
+		kd.forward(w, req)
+	}
+}
+
+// reply answers req authoritatively. exists is false if the name asked for
+// does not exist at all, as opposed to having no records of the type asked
+// for.
+func (kd *KubeDNS) reply(w dns.ResponseWriter, req *dns.Msg, answer, extra []dns.RR, exists bool) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	m.Authoritative = true
+	m.RecursionAvailable = len(kd.nameservers) > 0
+	m.Answer = answer
+	m.Extra = extra
+	if !exists {
+		m.Rcode = dns.RcodeNameError
+	}
+	if len(answer) == 0 {
+		// For negative caching, see RFC 2308.
+		m.Ns = []dns.RR{kd.soa()}
+	}
+	if err := w.WriteMsg(m); err != nil {
+		glog.V(2).Infof("Failed to reply to %s: %v", w.RemoteAddr(), err)
+	}
+}
+
+// forward passes req on to the upstream nameservers in turn until one of
+// them answers, and passes the answer back.
+func (kd *KubeDNS) forward(w dns.ResponseWriter, req *dns.Msg) {
+	network := "udp"
+	if _, ok := w.RemoteAddr().(*net.TCPAddr); ok {
+		network = "tcp"
+	}
+	client := &dns.Client{
+		Net:          network,
+		DialTimeout:  forwardTimeout,
+		ReadTimeout:  forwardTimeout,
+		WriteTimeout: forwardTimeout,
+	}
+	for _, nameserver := range kd.nameservers {
+		resp, _, err := client.Exchange(req, nameserver)
+		if err != nil {
+			glog.V(2).Infof("Failed to forward query for %q to %s: %v", req.Question[0].Name, nameserver, err)
+			continue
+		}
+		if err := w.WriteMsg(resp); err != nil {
+			glog.V(2).Infof("Failed to reply to %s: %v", w.RemoteAddr(), err)
+		}
+		return
+	}
+	m := new(dns.Msg)
+	m.SetRcode(req, dns.RcodeServerFailure)
+	w.WriteMsg(m)
+}
+
+// records returns the answer and additional records for the name in the
+// cluster domain, and whether name exists.
+func (kd *KubeDNS) records(name string, qtype uint16) (answer, extra []dns.RR, exists bool) {
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, kd.domain))
+	n := len(labels)
+	if n < 3 {
+		// The cluster domain, the service subdomain and the namespaces
+		// below it exist, but have no records of their own.
+		return nil, nil, n == 0 || labels[n-1] == serviceSubdomain
+	}
+	if labels[n-1] != serviceSubdomain {
+		return nil, nil, false
+	}
+	service, ok := kd.getService(labels[n-2], labels[n-3])
+	if !ok {
+		return nil, nil, false
+	}
+	serviceName := strings.Join(labels[n-3:], ".") + "." + kd.domain
+
+	if service.Spec.Type == api.ServiceTypeExternalName {
+		// The name of the service is an alias of the external name for all
+		// types, and has no names below it.
+		if n > 3 {
+			return nil, nil, false
+		}
+		return []dns.RR{kd.cname(serviceName, dns.Fqdn(service.Spec.ExternalName))}, nil, true
+	}
+
+	switch prefix := labels[:n-3]; {
+	case len(prefix) == 0:
+		if api.IsServiceIPSet(service) {
+			if matchesAddress(qtype, service.Spec.ClusterIP) {
+				answer = append(answer, kd.address(serviceName, service.Spec.ClusterIP))
+			}
+			return answer, nil, true
+		}
+		ips := util.NewStringSet()
+		for _, address := range kd.addresses(service) {
+			if matchesAddress(qtype, address.IP) && !ips.Has(address.IP) {
+				ips.Insert(address.IP)
+				answer = append(answer, kd.address(serviceName, address.IP))
+			}
+		}
+		return answer, nil, true
+
+	case len(prefix) == 1:
+		if api.IsServiceIPSet(service) {
+			return nil, nil, false
+		}
+		for _, address := range kd.addresses(service) {
+			if addressLabel(address.IP) == prefix[0] {
+				if !matchesAddress(qtype, address.IP) {
+					return nil, nil, true
+				}
+				return []dns.RR{kd.address(name, address.IP)}, nil, true
+			}
+		}
+		return nil, nil, false
+
+	case len(prefix) == 2 && strings.HasPrefix(prefix[0], "_") && strings.HasPrefix(prefix[1], "_"):
+		answer, extra = kd.srvRecords(name, serviceName, service, prefix[0][1:], prefix[1][1:])
+		if len(answer) == 0 {
+			return nil, nil, false
+		}
+		if qtype != dns.TypeSRV && qtype != dns.TypeANY {
+			return nil, nil, true
+		}
+		return answer, extra, true
+	}
+	return nil, nil, false
+}
+
+// srvRecords returns the SRV records for the port of service with the given
+// name and protocol, and the address records of their targets.
+func (kd *KubeDNS) srvRecords(name, serviceName string, service *api.Service, portName, protocol string) (answer, extra []dns.RR) {
+	if api.IsServiceIPSet(service) {
+		for _, port := range service.Spec.Ports {
+			if port.Name == portName && strings.ToLower(string(port.Protocol)) == protocol {
+				answer = append(answer, kd.srv(name, serviceName, port.Port))
+				extra = append(extra, kd.address(serviceName, service.Spec.ClusterIP))
+			}
+		}
+		return answer, extra
+	}
+
+	endpoints, ok := kd.getEndpoints(service.Namespace, service.Name)
+	if !ok {
+		return nil, nil
+	}
+	for i := range endpoints.Subsets {
+		subset := &endpoints.Subsets[i]
+		for _, port := range subset.Ports {
+			if port.Name != portName || strings.ToLower(string(port.Protocol)) != protocol {
+				continue
+			}
+			for _, address := range publishedAddresses(service, subset) {
+				target := addressLabel(address.IP) + "." + serviceName
+				answer = append(answer, kd.srv(name, target, port.Port))
+				extra = append(extra, kd.address(target, address.IP))
+			}
+		}
+	}
+	return answer, extra
+}
+
+// ptrRecords returns the PTR records for the reverse name of an IP of a
+// service or of an endpoint of a headless service.
+func (kd *KubeDNS) ptrRecords(name string) []dns.RR {
+	ip := reverseIP(name)
+	if len(ip) == 0 {
+		return nil
+	}
+	answer := []dns.RR{}
+	services, err := kd.services.ByIndex(clusterIPIndex, ip)
+	if err != nil {
+		glog.Errorf("Failed to look up services by cluster IP %s: %v", ip, err)
+	}
+	for _, obj := range services {
+		service := obj.(*api.Service)
+		answer = append(answer, kd.ptr(name, kd.serviceName(service)))
+	}
+	endpoints, err := kd.endpoints.ByIndex(addressIndex, ip)
+	if err != nil {
+		glog.Errorf("Failed to look up endpoints by address %s: %v", ip, err)
+	}
+	for _, obj := range endpoints {
+		e := obj.(*api.Endpoints)
+		service, ok := kd.getService(e.Namespace, e.Name)
+		if !ok || api.IsServiceIPSet(service) || !publishesAddress(service, e, ip) {
+			continue
+		}
+		answer = append(answer, kd.ptr(name, addressLabel(ip)+"."+kd.serviceName(service)))
+	}
+	return answer
+}
+
+func (kd *KubeDNS) getService(namespace, name string) (*api.Service, bool) {
+	obj, exists, err := kd.services.GetByKey(namespace + "/" + name)
+	if err != nil {
+		glog.Errorf("Failed to get service %s/%s from the cache: %v", namespace, name, err)
+		return nil, false
+	}
+	if !exists {
+		return nil, false
+	}
+	return obj.(*api.Service), true
+}
+
+func (kd *KubeDNS) getEndpoints(namespace, name string) (*api.Endpoints, bool) {
+	obj, exists, err := kd.endpoints.GetByKey(namespace + "/" + name)
+	if err != nil {
+		glog.Errorf("Failed to get endpoints %s/%s from the cache: %v", namespace, name, err)
+		return nil, false
+	}
+	if !exists {
+		return nil, false
+	}
+	return obj.(*api.Endpoints), true
+}
+
+// addresses returns the published addresses of the endpoints of service.
+func (kd *KubeDNS) addresses(service *api.Service) []api.EndpointAddress {
+	endpoints, ok := kd.getEndpoints(service.Namespace, service.Name)
+	if !ok {
+		return nil
+	}
+	addresses := []api.EndpointAddress{}
+	for i := range endpoints.Subsets {
+		addresses = append(addresses, publishedAddresses(service, &endpoints.Subsets[i])...)
+	}
+	return addresses
+}
+
+// publishedAddresses returns the addresses of subset that are published for
+// service: the ready ones, and also the not-ready ones if the service
+// tolerates unready endpoints.
+func publishedAddresses(service *api.Service, subset *api.EndpointSubset) []api.EndpointAddress {
+	if tolerate, _ := api.ToleratesUnreadyEndpoints(service.Annotations); !tolerate || len(subset.NotReadyAddresses) == 0 {
+		return subset.Addresses
+	}
+	addresses := make([]api.EndpointAddress, 0, len(subset.Addresses)+len(subset.NotReadyAddresses))
+	addresses = append(addresses, subset.Addresses...)
+	return append(addresses, subset.NotReadyAddresses...)
+}
+
+// publishesAddress returns true if ip is a published address of the endpoints
+// of service.
+func publishesAddress(service *api.Service, endpoints *api.Endpoints, ip string) bool {
+	for i := range endpoints.Subsets {
+		for _, address := range publishedAddresses(service, &endpoints.Subsets[i]) {
+			if address.IP == ip {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (kd *KubeDNS) serviceName(service *api.Service) string {
+	return strings.Join([]string{service.Name, service.Namespace, serviceSubdomain, kd.domain}, ".")
+}
+
+func (kd *KubeDNS) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{Name: name, Rrtype: rrtype, Class: dns.ClassINET, Ttl: recordTTL}
+}
+
+// address returns the A record for an IPv4 address, or the AAAA record for
+// an IPv6 address.
+func (kd *KubeDNS) address(name, ip string) dns.RR {
+	addr := net.ParseIP(ip)
+	if v4 := addr.To4(); v4 != nil {
+		return &dns.A{Hdr: kd.header(name, dns.TypeA), A: v4}
+	}
+	return &dns.AAAA{Hdr: kd.header(name, dns.TypeAAAA), AAAA: addr}
+}
+
+// matchesAddress returns true if a query of the given type is answered with
+// the address record of ip.
+func matchesAddress(qtype uint16, ip string) bool {
+	switch qtype {
+	case dns.TypeANY:
+		return true
+	case dns.TypeA:
+		return net.ParseIP(ip).To4() != nil
+	case dns.TypeAAAA:
+		addr := net.ParseIP(ip)
+		return addr != nil && addr.To4() == nil
+	}
+	return false
+}
+
+func (kd *KubeDNS) cname(name, target string) dns.RR {
+	return &dns.CNAME{Hdr: kd.header(name, dns.TypeCNAME), Target: target}
+}
+
+func (kd *KubeDNS) srv(name, target string, port int) dns.RR {
+	return &dns.SRV{Hdr: kd.header(name, dns.TypeSRV), Priority: 10, Weight: 10, Port: uint16(port), Target: target}
+}
+
+func (kd *KubeDNS) ptr(name, target string) dns.RR {
+	return &dns.PTR{Hdr: kd.header(name, dns.TypePTR), Ptr: target}
+}
+
+func (kd *KubeDNS) soa() dns.RR {
+	return &dns.SOA{
+		Hdr:     kd.header(kd.domain, dns.TypeSOA),
+		Ns:      "ns.dns." + kd.domain,
+		Mbox:    "hostmaster." + kd.domain,
+		Serial:  uint32(time.Now().Unix()),
+		Refresh: 28800,
+		Retry:   7200,
+		Expire:  604800,
+		Minttl:  recordTTL,
+	}
+}
+
+// addressLabel returns the label under the name of a headless service for
+// the endpoint with the given IP.
+func addressLabel(ip string) string {
+	h := fnv.New32a()
+	h.Write([]byte(ip))
+	return fmt.Sprintf("%x", h.Sum32())
+}
+
+// reverseIP returns the address of the name in the in-addr.arpa or ip6.arpa
+// domain, or the empty string if it is not the name of an address.
+func reverseIP(name string) string {
+	var ip net.IP
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa."):
+		labels := dns.SplitDomainName(strings.TrimSuffix(name, ".in-addr.arpa."))
+		if len(labels) != 4 {
+			return ""
+		}
+		for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+			labels[i], labels[j] = labels[j], labels[i]
+		}
+		ip = net.ParseIP(strings.Join(labels, ".")).To4()
+	case strings.HasSuffix(name, ".ip6.arpa."):
+		// The labels are the 32 nibbles of the address, least significant
+		// first.
+		labels := dns.SplitDomainName(strings.TrimSuffix(name, ".ip6.arpa."))
+		if len(labels) != 32 {
+			return ""
+		}
+		buf := make([]byte, 0, 39)
+		for i := len(labels) - 1; i >= 0; i-- {
+			if len(labels[i]) != 1 {
+				return ""
+			}
+			buf = append(buf, labels[i]...)
+			if i > 0 && i%4 == 0 {
+				buf = append(buf, ':')
+			}
+		}
+		ip = net.ParseIP(string(buf))
+	}
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
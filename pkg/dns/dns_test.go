@@ -0,0 +1,462 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+// startServer serves handler on a UDP port of the loopback interface and
+// returns its address.
+func startServer(t *testing.T, handler dns.Handler) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: conn, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go server.ActivateAndServe()
+	<-started
+	return conn.LocalAddr().String(), func() { server.Shutdown() }
+}
+
+// upstream answers A queries for example.com. and denies any other name.
+func upstream(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	if len(req.Question) == 1 && req.Question[0].Name == "example.com." && req.Question[0].Qtype == dns.TypeA {
+		m.Answer = []dns.RR{&dns.A{
+			Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("93.184.216.34").To4(),
+		}}
+	} else {
+		m.Rcode = dns.RcodeNameError
+	}
+	w.WriteMsg(m)
+}
+
+func newTestKubeDNS(t *testing.T, nameservers []string) (*KubeDNS, func()) {
+	services := framework.NewFakeControllerSource()
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+		Spec: api.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+			Ports:     []api.ServicePort{{Name: "https", Protocol: api.ProtocolTCP, Port: 443}},
+		},
+	})
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "db", Namespace: "prod"},
+		Spec: api.ServiceSpec{
+			ClusterIP: api.ClusterIPNone,
+			Ports:     []api.ServicePort{{Name: "mysql", Protocol: api.ProtocolTCP, Port: 3306}},
+		},
+	})
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{
+			Name:        "zk",
+			Namespace:   "prod",
+			Annotations: map[string]string{api.TolerateUnreadyEndpointsAnnotation: "true"},
+		},
+		Spec: api.ServiceSpec{
+			ClusterIP: api.ClusterIPNone,
+			Ports:     []api.ServicePort{{Name: "client", Protocol: api.ProtocolTCP, Port: 2181}},
+		},
+	})
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "prod"},
+		Spec: api.ServiceSpec{
+			ClusterIP: "fd00::10",
+			Ports:     []api.ServicePort{{Name: "http", Protocol: api.ProtocolTCP, Port: 80}},
+		},
+	})
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "cache", Namespace: "prod"},
+		Spec: api.ServiceSpec{
+			ClusterIP: api.ClusterIPNone,
+			Ports:     []api.ServicePort{{Name: "memcache", Protocol: api.ProtocolTCP, Port: 11211}},
+		},
+	})
+	services.Add(&api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "billing", Namespace: "prod"},
+		Spec: api.ServiceSpec{
+			Type:         api.ServiceTypeExternalName,
+			ExternalName: "billing.example.com",
+		},
+	})
+	endpoints := framework.NewFakeControllerSource()
+	endpoints.Add(&api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "192.168.0.1"}},
+			Ports:     []api.EndpointPort{{Name: "https", Protocol: api.ProtocolTCP, Port: 6443}},
+		}},
+	})
+	endpoints.Add(&api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Name: "db", Namespace: "prod"},
+		Subsets: []api.EndpointSubset{{
+			Addresses:         []api.EndpointAddress{{IP: "10.244.1.5"}, {IP: "10.244.2.7"}},
+			NotReadyAddresses: []api.EndpointAddress{{IP: "10.244.3.9"}},
+			Ports:             []api.EndpointPort{{Name: "mysql", Protocol: api.ProtocolTCP, Port: 3306}},
+		}},
+	})
+	endpoints.Add(&api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Name: "zk", Namespace: "prod"},
+		Subsets: []api.EndpointSubset{{
+			Addresses:         []api.EndpointAddress{{IP: "10.244.1.6"}},
+			NotReadyAddresses: []api.EndpointAddress{{IP: "10.244.2.8"}},
+			Ports:             []api.EndpointPort{{Name: "client", Protocol: api.ProtocolTCP, Port: 2181}},
+		}},
+	})
+	endpoints.Add(&api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Name: "cache", Namespace: "prod"},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "fd00:244::5"}},
+			Ports:     []api.EndpointPort{{Name: "memcache", Protocol: api.ProtocolTCP, Port: 11211}},
+		}},
+	})
+
+	kd := NewKubeDNS(services, endpoints, "Cluster.Local", nameservers, 0)
+	stopCh := make(chan struct{})
+	kd.Run(stopCh)
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) { return kd.HasSynced(), nil }); err != nil {
+		t.Fatalf("caches did not sync: %v", err)
+	}
+	return kd, func() { close(stopCh) }
+}
+
+// rrStrings returns the records in rrs without their headers, sorted.
+func rrStrings(rrs []dns.RR) []string {
+	out := []string{}
+	for _, rr := range rrs {
+		switch rr := rr.(type) {
+		case *dns.A:
+			out = append(out, rr.Hdr.Name+" A "+rr.A.String())
+		case *dns.AAAA:
+			out = append(out, rr.Hdr.Name+" AAAA "+rr.AAAA.String())
+		case *dns.SRV:
+			out = append(out, rr.Hdr.Name+" SRV "+rr.Target+":"+strconv.Itoa(int(rr.Port)))
+		case *dns.PTR:
+			out = append(out, rr.Hdr.Name+" PTR "+rr.Ptr)
+		case *dns.CNAME:
+			out = append(out, rr.Hdr.Name+" CNAME "+rr.Target)
+		default:
+			out = append(out, rr.String())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestKubeDNS(t *testing.T) {
+	upstreamAddr, stopUpstream := startServer(t, dns.HandlerFunc(upstream))
+	defer stopUpstream()
+	kd, stop := newTestKubeDNS(t, []string{upstreamAddr})
+	defer stop()
+	addr, stopServer := startServer(t, kd)
+	defer stopServer()
+
+	db1 := addressLabel("10.244.1.5") + ".db.prod.svc.cluster.local."
+	db2 := addressLabel("10.244.2.7") + ".db.prod.svc.cluster.local."
+	zk1 := addressLabel("10.244.1.6") + ".zk.prod.svc.cluster.local."
+	zk2 := addressLabel("10.244.2.8") + ".zk.prod.svc.cluster.local."
+	cache1 := addressLabel("fd00:244::5") + ".cache.prod.svc.cluster.local."
+	tests := []struct {
+		name          string
+		qtype         uint16
+		rcode         int
+		authoritative bool
+		answer        []string
+		extra         []string
+	}{
+		{
+			name:          "kubernetes.default.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"kubernetes.default.svc.cluster.local. A 10.0.0.1"},
+		},
+		{
+			name:          "KUBERNETES.default.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"kubernetes.default.svc.cluster.local. A 10.0.0.1"},
+		},
+		{
+			name:          "db.prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"db.prod.svc.cluster.local. A 10.244.1.5", "db.prod.svc.cluster.local. A 10.244.2.7"},
+		},
+		{
+			name:          db1,
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{db1 + " A 10.244.1.5"},
+		},
+		{
+			name:          "_https._tcp.kubernetes.default.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"_https._tcp.kubernetes.default.svc.cluster.local. SRV kubernetes.default.svc.cluster.local.:443"},
+			extra:         []string{"kubernetes.default.svc.cluster.local. A 10.0.0.1"},
+		},
+		{
+			name:          "_mysql._tcp.db.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"_mysql._tcp.db.prod.svc.cluster.local. SRV " + db1 + ":3306", "_mysql._tcp.db.prod.svc.cluster.local. SRV " + db2 + ":3306"},
+			extra:         []string{db1 + " A 10.244.1.5", db2 + " A 10.244.2.7"},
+		},
+		{
+			// Not-ready addresses are published for services that tolerate them.
+			name:          "zk.prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"zk.prod.svc.cluster.local. A 10.244.1.6", "zk.prod.svc.cluster.local. A 10.244.2.8"},
+		},
+		{
+			name:          zk2,
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{zk2 + " A 10.244.2.8"},
+		},
+		{
+			name:          "_client._tcp.zk.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"_client._tcp.zk.prod.svc.cluster.local. SRV " + zk1 + ":2181", "_client._tcp.zk.prod.svc.cluster.local. SRV " + zk2 + ":2181"},
+			extra:         []string{zk1 + " A 10.244.1.6", zk2 + " A 10.244.2.8"},
+		},
+		{
+			// Not-ready addresses of other services are not published.
+			name:          addressLabel("10.244.3.9") + ".db.prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+		},
+		{
+			name:          "web.prod.svc.cluster.local.",
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+			answer:        []string{"web.prod.svc.cluster.local. AAAA fd00::10"},
+		},
+		{
+			// The service exists, but has no IPv4 address.
+			name:          "web.prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+		},
+		{
+			name:          "_http._tcp.web.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"_http._tcp.web.prod.svc.cluster.local. SRV web.prod.svc.cluster.local.:80"},
+			extra:         []string{"web.prod.svc.cluster.local. AAAA fd00::10"},
+		},
+		{
+			name:          "cache.prod.svc.cluster.local.",
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+			answer:        []string{"cache.prod.svc.cluster.local. AAAA fd00:244::5"},
+		},
+		{
+			name:          cache1,
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+			answer:        []string{cache1 + " AAAA fd00:244::5"},
+		},
+		{
+			name:          cache1,
+			qtype:         dns.TypeA,
+			authoritative: true,
+		},
+		{
+			name:          "_memcache._tcp.cache.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"_memcache._tcp.cache.prod.svc.cluster.local. SRV " + cache1 + ":11211"},
+			extra:         []string{cache1 + " AAAA fd00:244::5"},
+		},
+		{
+			name:          "billing.prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"billing.prod.svc.cluster.local. CNAME billing.example.com."},
+		},
+		{
+			name:          "billing.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			authoritative: true,
+			answer:        []string{"billing.prod.svc.cluster.local. CNAME billing.example.com."},
+		},
+		{
+			name:          "_http._tcp.billing.prod.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+		},
+		{
+			name:          "1.0.0.10.in-addr.arpa.",
+			qtype:         dns.TypePTR,
+			authoritative: true,
+			answer:        []string{"1.0.0.10.in-addr.arpa. PTR kubernetes.default.svc.cluster.local."},
+		},
+		{
+			name:          "7.2.244.10.in-addr.arpa.",
+			qtype:         dns.TypePTR,
+			authoritative: true,
+			answer:        []string{"7.2.244.10.in-addr.arpa. PTR " + db2},
+		},
+		{
+			name:          "8.2.244.10.in-addr.arpa.",
+			qtype:         dns.TypePTR,
+			authoritative: true,
+			answer:        []string{"8.2.244.10.in-addr.arpa. PTR " + zk2},
+		},
+		{
+			name:          "0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa.",
+			qtype:         dns.TypePTR,
+			authoritative: true,
+			answer:        []string{"0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa. PTR web.prod.svc.cluster.local."},
+		},
+		{
+			name:          "5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.4.2.0.0.0.d.f.ip6.arpa.",
+			qtype:         dns.TypePTR,
+			authoritative: true,
+			answer:        []string{"5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.4.4.2.0.0.0.d.f.ip6.arpa. PTR " + cache1},
+		},
+		{
+			name:  "9.3.244.10.in-addr.arpa.",
+			qtype: dns.TypePTR,
+			rcode: dns.RcodeNameError,
+		},
+		{
+			// Not the address of an endpoint of a headless service.
+			name:  "1.0.168.192.in-addr.arpa.",
+			qtype: dns.TypePTR,
+			rcode: dns.RcodeNameError,
+		},
+		{
+			name:          "kubernetes.default.svc.cluster.local.",
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+		},
+		{
+			name:          "prod.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			authoritative: true,
+		},
+		{
+			name:          "missing.default.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+		},
+		{
+			// Only headless services have names for their endpoints.
+			name:          addressLabel("192.168.0.1") + ".kubernetes.default.svc.cluster.local.",
+			qtype:         dns.TypeA,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+		},
+		{
+			name:          "_http._tcp.kubernetes.default.svc.cluster.local.",
+			qtype:         dns.TypeSRV,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+		},
+		{
+			name:   "example.com.",
+			qtype:  dns.TypeA,
+			answer: []string{"example.com. A 93.184.216.34"},
+		},
+		{
+			name:  "missing.example.com.",
+			qtype: dns.TypeA,
+			rcode: dns.RcodeNameError,
+		},
+	}
+	client := &dns.Client{}
+	for _, test := range tests {
+		req := new(dns.Msg)
+		req.SetQuestion(test.name, test.qtype)
+		resp, _, err := client.Exchange(req, addr)
+		if err != nil {
+			t.Errorf("%s %s: unexpected error: %v", test.name, dns.TypeToString[test.qtype], err)
+			continue
+		}
+		if resp.Rcode != test.rcode {
+			t.Errorf("%s %s: expected rcode %s, got %s", test.name, dns.TypeToString[test.qtype], dns.RcodeToString[test.rcode], dns.RcodeToString[resp.Rcode])
+		}
+		if resp.Authoritative != test.authoritative {
+			t.Errorf("%s %s: expected authoritative %t", test.name, dns.TypeToString[test.qtype], test.authoritative)
+		}
+		sort.Strings(test.answer)
+		sort.Strings(test.extra)
+		if answer := rrStrings(resp.Answer); !reflect.DeepEqual(answer, append([]string{}, test.answer...)) {
+			t.Errorf("%s %s: expected answer %v, got %v", test.name, dns.TypeToString[test.qtype], test.answer, answer)
+		}
+		if extra := rrStrings(resp.Extra); !reflect.DeepEqual(extra, append([]string{}, test.extra...)) {
+			t.Errorf("%s %s: expected extra %v, got %v", test.name, dns.TypeToString[test.qtype], test.extra, extra)
+		}
+	}
+}
+
+func TestKubeDNSWithoutUpstream(t *testing.T) {
+	kd, stop := newTestKubeDNS(t, nil)
+	defer stop()
+	addr, stopServer := startServer(t, kd)
+	defer stopServer()
+
+	req := new(dns.Msg)
+	req.SetQuestion("example.com.", dns.TypeA)
+	resp, _, err := (&dns.Client{}).Exchange(req, addr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Rcode != dns.RcodeServerFailure {
+		t.Errorf("expected rcode SERVFAIL, got %s", dns.RcodeToString[resp.Rcode])
+	}
+}
+
+func TestReverseIP(t *testing.T) {
+	tests := map[string]string{
+		"1.0.0.10.in-addr.arpa.":   "10.0.0.1",
+		"1.0.10.in-addr.arpa.":     "",
+		"x.0.0.10.in-addr.arpa.":   "",
+		"256.0.0.10.in-addr.arpa.": "",
+		"0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa.":  "fd00::10",
+		"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa.":  "::1",
+		"0.1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa.":                              "",
+		"10.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa.": "",
+		"g.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.d.f.ip6.arpa.":  "",
+	}
+	for name, expected := range tests {
+		if ip := reverseIP(name); ip != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, ip)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dns implements a DNS server for cluster services. It answers
+// queries for the names of services and of the pods backing headless
+// services straight from informer caches of services and endpoints, and
+// forwards queries for other names to upstream nameservers.
+//
+// The records served under the cluster domain, cluster.local by default, are:
+//
+// A records for SERVICE.NAMESPACE.svc.DOMAIN, resolving to the cluster IP of
+// the service, or to the IPs of all the ready endpoints of a headless service.
+// The not-ready endpoints of a headless service are included too if the
+// service has the api.TolerateUnreadyEndpointsAnnotation set to "true".
+//
+// A records for LABEL.SERVICE.NAMESPACE.svc.DOMAIN for each endpoint of a
+// headless service, where LABEL is derived from the IP of the endpoint.
+//
+// SRV records for _PORT._PROTOCOL.SERVICE.NAMESPACE.svc.DOMAIN for each
+// named port of a service, pointing at the service name, or at the name of
+// each endpoint of a headless service.
+//
+// CNAME records for SERVICE.NAMESPACE.svc.DOMAIN of services of type
+// ExternalName, pointing at their external name.
+//
+// PTR records for the cluster IPs of services and the IPs of the endpoints
+// of headless services.
+package dns
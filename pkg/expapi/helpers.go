@@ -0,0 +1,80 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expapi
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// LabelSelectorAsSelector converts the LabelSelector api type into a struct
+// that implements labels.Selector. A nil LabelSelector selects nothing and an
+// empty one selects everything.
+func LabelSelectorAsSelector(ps *LabelSelector) (labels.Selector, error) {
+	if ps == nil {
+		return labels.Nothing(), nil
+	}
+	if len(ps.MatchLabels)+len(ps.MatchExpressions) == 0 {
+		return labels.Everything(), nil
+	}
+	selector := labels.LabelSelector{}
+	for k, v := range ps.MatchLabels {
+		r, err := labels.NewRequirement(k, labels.InOperator, util.NewStringSet(v))
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, *r)
+	}
+	for _, expr := range ps.MatchExpressions {
+		var op labels.Operator
+		switch expr.Operator {
+		case LabelSelectorOpIn:
+			op = labels.InOperator
+		case LabelSelectorOpNotIn:
+			op = labels.NotInOperator
+		case LabelSelectorOpExists:
+			op = labels.ExistsOperator
+		case LabelSelectorOpDoesNotExist:
+			op = labels.DoesNotExistOperator
+		default:
+			return nil, fmt.Errorf("%q is not a valid label selector operator", expr.Operator)
+		}
+		r, err := labels.NewRequirement(expr.Key, op, util.NewStringSet(expr.Values...))
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, *r)
+	}
+	sort.Sort(labels.ByKey(selector))
+	return selector, nil
+}
+
+// SetAsLabelSelector returns a LabelSelector that matches the given set of
+// labels exactly.
+func SetAsLabelSelector(ls labels.Set) *LabelSelector {
+	if ls == nil {
+		return nil
+	}
+	selector := &LabelSelector{MatchLabels: make(map[string]string)}
+	for k, v := range ls {
+		selector.MatchLabels[k] = v
+	}
+	return selector
+}
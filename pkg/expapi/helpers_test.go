@@ -0,0 +1,124 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expapi
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+func TestLabelSelectorAsSelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *LabelSelector
+		matches   []labels.Set
+		misses    []labels.Set
+		expectErr bool
+	}{
+		{
+			name:   "nil selects nothing",
+			in:     nil,
+			misses: []labels.Set{{}, {"foo": "bar"}},
+		},
+		{
+			name:    "empty selects everything",
+			in:      &LabelSelector{},
+			matches: []labels.Set{{}, {"foo": "bar"}},
+		},
+		{
+			name:    "match labels",
+			in:      &LabelSelector{MatchLabels: map[string]string{"foo": "bar", "baz": "blah"}},
+			matches: []labels.Set{{"foo": "bar", "baz": "blah"}, {"foo": "bar", "baz": "blah", "x": "y"}},
+			misses:  []labels.Set{{"foo": "bar"}, {"foo": "baz", "baz": "blah"}},
+		},
+		{
+			name: "match expressions",
+			in: &LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+				MatchExpressions: []LabelSelectorRequirement{
+					{Key: "tier", Operator: LabelSelectorOpIn, Values: []string{"web", "db"}},
+					{Key: "env", Operator: LabelSelectorOpNotIn, Values: []string{"prod"}},
+					{Key: "track", Operator: LabelSelectorOpExists},
+					{Key: "canary", Operator: LabelSelectorOpDoesNotExist},
+				},
+			},
+			matches: []labels.Set{
+				{"foo": "bar", "tier": "web", "track": "stable"},
+				{"foo": "bar", "tier": "db", "env": "dev", "track": "daily"},
+			},
+			misses: []labels.Set{
+				{"tier": "web", "track": "stable"},
+				{"foo": "bar", "tier": "cache", "track": "stable"},
+				{"foo": "bar", "tier": "web", "env": "prod", "track": "stable"},
+				{"foo": "bar", "tier": "web"},
+				{"foo": "bar", "tier": "web", "track": "stable", "canary": "true"},
+			},
+		},
+		{
+			name: "invalid operator",
+			in: &LabelSelector{
+				MatchExpressions: []LabelSelectorRequirement{{Key: "foo", Operator: "Equals", Values: []string{"bar"}}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "in without values",
+			in: &LabelSelector{
+				MatchExpressions: []LabelSelectorRequirement{{Key: "foo", Operator: LabelSelectorOpIn}},
+			},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		selector, err := LabelSelectorAsSelector(test.in)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: unexpected non-error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		for _, set := range test.matches {
+			if !selector.Matches(set) {
+				t.Errorf("%s: expected %v to match %v", test.name, selector, set)
+			}
+		}
+		for _, set := range test.misses {
+			if selector.Matches(set) {
+				t.Errorf("%s: expected %v not to match %v", test.name, selector, set)
+			}
+		}
+	}
+}
+
+func TestSetAsLabelSelector(t *testing.T) {
+	if SetAsLabelSelector(nil) != nil {
+		t.Errorf("expected a nil set to give a nil selector")
+	}
+	set := labels.Set{"foo": "bar", "baz": "blah"}
+	selector, err := LabelSelectorAsSelector(SetAsLabelSelector(set))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !selector.Matches(set) || selector.Matches(labels.Set{"foo": "bar"}) {
+		t.Errorf("expected %v to match exactly %v", selector, set)
+	}
+}
@@ -38,6 +38,8 @@ func addKnownTypes() {
 		&ThirdPartyResourceList{},
 		&DaemonList{},
 		&Daemon{},
+		&NetworkPolicy{},
+		&NetworkPolicyList{},
 	)
 }
 
@@ -51,3 +53,5 @@ func (*ThirdPartyResource) IsAnAPIObject()          {}
 func (*ThirdPartyResourceList) IsAnAPIObject()      {}
 func (*Daemon) IsAnAPIObject()                      {}
 func (*DaemonList) IsAnAPIObject()                  {}
+func (*NetworkPolicy) IsAnAPIObject()               {}
+func (*NetworkPolicyList) IsAnAPIObject()           {}
@@ -184,7 +184,7 @@ type DeploymentSpec struct {
 
 	// Label selector for pods. Existing ReplicationControllers whose pods are
 	// selected by this will be scaled down.
-	Selector map[string]string `json:"selector,omitempty"`
+	Selector *LabelSelector `json:"selector,omitempty"`
 
 	// Describes the pods that will be created.
 	Template *api.PodTemplateSpec `json:"template,omitempty"`
@@ -274,7 +274,7 @@ type DeploymentList struct {
 // DaemonSpec is the specification of a daemon.
 type DaemonSpec struct {
 	// Selector is a label query over pods that are managed by the daemon.
-	Selector map[string]string `json:"selector"`
+	Selector *LabelSelector `json:"selector"`
 
 	// Template is the object that describes the pod that will be created.
 	// The Daemon will create exactly one copy of this pod on every node
@@ -318,3 +318,108 @@ type DaemonList struct {
 
 	Items []Daemon `json:"items"`
 }
+
+// NetworkPolicy describes which traffic may reach a set of pods. Pods selected
+// by at least one policy are isolated: they only accept connections allowed by
+// the ingress rules of the policies that select them.
+type NetworkPolicy struct {
+	api.TypeMeta   `json:",inline"`
+	api.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired behavior of this network policy.
+	Spec NetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// NetworkPolicySpec is the specification of a network policy.
+type NetworkPolicySpec struct {
+	// PodSelector selects the pods in the policy's namespace to which the
+	// policy applies. An empty selector selects all pods in the namespace.
+	PodSelector LabelSelector `json:"podSelector"`
+
+	// Ingress is a list of rules describing the traffic allowed to reach the
+	// selected pods. Traffic is allowed if it matches at least one rule. A
+	// policy without rules isolates the selected pods from all traffic.
+	Ingress []NetworkPolicyIngressRule `json:"ingress,omitempty"`
+}
+
+// NetworkPolicyIngressRule allows traffic matching both its ports and its
+// sources.
+type NetworkPolicyIngressRule struct {
+	// Ports is the list of ports on the selected pods the rule applies to.
+	// An empty list matches all ports.
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+
+	// From is the list of sources allowed by the rule. An empty list
+	// matches all sources.
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// NetworkPolicyPort describes a port traffic is allowed to.
+type NetworkPolicyPort struct {
+	// Protocol is the protocol of the port, TCP or UDP.
+	Protocol *api.Protocol `json:"protocol,omitempty"`
+
+	// Port is the port number or the name of a container port on the
+	// selected pods. If unset, the rule matches all ports of the protocol.
+	Port *util.IntOrString `json:"port,omitempty"`
+}
+
+// NetworkPolicyPeer describes a source traffic is allowed from. Exactly one
+// of its fields must be set.
+type NetworkPolicyPeer struct {
+	// PodSelector selects pods in the policy's namespace.
+	PodSelector *LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects namespaces whose pods are all allowed.
+	NamespaceSelector *LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// NetworkPolicyList is a collection of network policies.
+type NetworkPolicyList struct {
+	api.TypeMeta `json:",inline"`
+	api.ListMeta `json:"metadata,omitempty"`
+
+	Items []NetworkPolicy `json:"items"`
+}
+
+// A LabelSelector is a label query over a set of resources. The result of
+// MatchLabels and MatchExpressions are ANDed. An empty label selector matches
+// all objects. A nil label selector matches no objects.
+type LabelSelector struct {
+	// MatchLabels is a map of {key,value} pairs. A single {key,value} in the
+	// matchLabels map is equivalent to an element of matchExpressions, whose
+	// key field is "key", the operator is "In", and the values array contains
+	// only "value". The requirements are ANDed.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions is a list of label selector requirements. The
+	// requirements are ANDed.
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty" patchStrategy:"merge" patchMergeKey:"key"`
+}
+
+// A LabelSelectorRequirement is a selector that contains values, a key, and
+// an operator that relates the key and values.
+type LabelSelectorRequirement struct {
+	// Key is the label key that the selector applies to.
+	Key string `json:"key"`
+
+	// Operator represents a key's relationship to a set of values.
+	// Valid operators are In, NotIn, Exists and DoesNotExist.
+	Operator LabelSelectorOperator `json:"operator"`
+
+	// Values is an array of string values. If the operator is In or NotIn,
+	// the values array must be non-empty. If the operator is Exists or
+	// DoesNotExist, the values array must be empty.
+	Values []string `json:"values,omitempty"`
+}
+
+// A LabelSelectorOperator is the set of operators that can be used in a
+// label selector requirement.
+type LabelSelectorOperator string
+
+const (
+	LabelSelectorOpIn           LabelSelectorOperator = "In"
+	LabelSelectorOpNotIn        LabelSelectorOperator = "NotIn"
+	LabelSelectorOpExists       LabelSelectorOperator = "Exists"
+	LabelSelectorOpDoesNotExist LabelSelectorOperator = "DoesNotExist"
+)
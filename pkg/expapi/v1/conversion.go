@@ -38,6 +38,17 @@ func addConversionFuncs() {
 		// If one of the conversion functions is malformed, detect it immediately.
 		panic(err)
 	}
+
+	// Add field conversion funcs. The field labels of these kinds are the same
+	// in v1 and the internal version. Their registries reject the fields which
+	// are not selectable.
+	for _, kind := range []string{"Daemon", "HorizontalPodAutoscaler", "NetworkPolicy", "ThirdPartyResource"} {
+		err = api.Scheme.AddFieldLabelConversionFunc("v1", kind, v1.SameFieldLabel)
+		if err != nil {
+			// If one of the conversion functions is malformed, detect it immediately.
+			panic(err)
+		}
+	}
 }
 
 // The following two PodSpec conversions functions where copied from pkg/api/conversion.go
@@ -181,9 +192,9 @@ func convert_expapi_DeploymentSpec_To_v1_DeploymentSpec(in *expapi.DeploymentSpe
 	out.Replicas = new(int)
 	*out.Replicas = in.Replicas
 	if in.Selector != nil {
-		out.Selector = make(map[string]string)
-		for key, val := range in.Selector {
-			out.Selector[key] = val
+		out.Selector = new(LabelSelector)
+		if err := convert_expapi_LabelSelector_To_v1_LabelSelector(in.Selector, out.Selector, s); err != nil {
+			return err
 		}
 	} else {
 		out.Selector = nil
@@ -214,9 +225,9 @@ func convert_v1_DeploymentSpec_To_expapi_DeploymentSpec(in *DeploymentSpec, out
 	}
 	out.Replicas = *in.Replicas
 	if in.Selector != nil {
-		out.Selector = make(map[string]string)
-		for key, val := range in.Selector {
-			out.Selector[key] = val
+		out.Selector = new(expapi.LabelSelector)
+		if err := convert_v1_LabelSelector_To_expapi_LabelSelector(in.Selector, out.Selector, s); err != nil {
+			return err
 		}
 	} else {
 		out.Selector = nil
@@ -1442,9 +1442,9 @@ func convert_expapi_DaemonSpec_To_v1_DaemonSpec(in *expapi.DaemonSpec, out *Daem
 		defaulting.(func(*expapi.DaemonSpec))(in)
 	}
 	if in.Selector != nil {
-		out.Selector = make(map[string]string)
-		for key, val := range in.Selector {
-			out.Selector[key] = val
+		out.Selector = new(LabelSelector)
+		if err := convert_expapi_LabelSelector_To_v1_LabelSelector(in.Selector, out.Selector, s); err != nil {
+			return err
 		}
 	} else {
 		out.Selector = nil
@@ -1628,6 +1628,177 @@ func convert_expapi_HorizontalPodAutoscalerStatus_To_v1_HorizontalPodAutoscalerS
 	return nil
 }
 
+func convert_expapi_LabelSelector_To_v1_LabelSelector(in *expapi.LabelSelector, out *LabelSelector, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.LabelSelector))(in)
+	}
+	if in.MatchLabels != nil {
+		out.MatchLabels = make(map[string]string)
+		for key, val := range in.MatchLabels {
+			out.MatchLabels[key] = val
+		}
+	} else {
+		out.MatchLabels = nil
+	}
+	if in.MatchExpressions != nil {
+		out.MatchExpressions = make([]LabelSelectorRequirement, len(in.MatchExpressions))
+		for i := range in.MatchExpressions {
+			if err := convert_expapi_LabelSelectorRequirement_To_v1_LabelSelectorRequirement(&in.MatchExpressions[i], &out.MatchExpressions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.MatchExpressions = nil
+	}
+	return nil
+}
+
+func convert_expapi_LabelSelectorRequirement_To_v1_LabelSelectorRequirement(in *expapi.LabelSelectorRequirement, out *LabelSelectorRequirement, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.LabelSelectorRequirement))(in)
+	}
+	out.Key = in.Key
+	out.Operator = LabelSelectorOperator(in.Operator)
+	if in.Values != nil {
+		out.Values = make([]string, len(in.Values))
+		for i := range in.Values {
+			out.Values[i] = in.Values[i]
+		}
+	} else {
+		out.Values = nil
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicy_To_v1_NetworkPolicy(in *expapi.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicy))(in)
+	}
+	if err := convert_api_TypeMeta_To_v1_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_expapi_NetworkPolicySpec_To_v1_NetworkPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicyIngressRule_To_v1_NetworkPolicyIngressRule(in *expapi.NetworkPolicyIngressRule, out *NetworkPolicyIngressRule, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicyIngressRule))(in)
+	}
+	if in.Ports != nil {
+		out.Ports = make([]NetworkPolicyPort, len(in.Ports))
+		for i := range in.Ports {
+			if err := convert_expapi_NetworkPolicyPort_To_v1_NetworkPolicyPort(&in.Ports[i], &out.Ports[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
+	if in.From != nil {
+		out.From = make([]NetworkPolicyPeer, len(in.From))
+		for i := range in.From {
+			if err := convert_expapi_NetworkPolicyPeer_To_v1_NetworkPolicyPeer(&in.From[i], &out.From[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.From = nil
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicyList_To_v1_NetworkPolicyList(in *expapi.NetworkPolicyList, out *NetworkPolicyList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicyList))(in)
+	}
+	if err := convert_api_TypeMeta_To_v1_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := convert_api_ListMeta_To_v1_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]NetworkPolicy, len(in.Items))
+		for i := range in.Items {
+			if err := convert_expapi_NetworkPolicy_To_v1_NetworkPolicy(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicyPeer_To_v1_NetworkPolicyPeer(in *expapi.NetworkPolicyPeer, out *NetworkPolicyPeer, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicyPeer))(in)
+	}
+	if in.PodSelector != nil {
+		out.PodSelector = new(LabelSelector)
+		if err := convert_expapi_LabelSelector_To_v1_LabelSelector(in.PodSelector, out.PodSelector, s); err != nil {
+			return err
+		}
+	} else {
+		out.PodSelector = nil
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = new(LabelSelector)
+		if err := convert_expapi_LabelSelector_To_v1_LabelSelector(in.NamespaceSelector, out.NamespaceSelector, s); err != nil {
+			return err
+		}
+	} else {
+		out.NamespaceSelector = nil
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicyPort_To_v1_NetworkPolicyPort(in *expapi.NetworkPolicyPort, out *NetworkPolicyPort, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicyPort))(in)
+	}
+	if in.Protocol != nil {
+		out.Protocol = new(v1.Protocol)
+		*out.Protocol = v1.Protocol(*in.Protocol)
+	} else {
+		out.Protocol = nil
+	}
+	if in.Port != nil {
+		if err := s.Convert(&in.Port, &out.Port, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Port = nil
+	}
+	return nil
+}
+
+func convert_expapi_NetworkPolicySpec_To_v1_NetworkPolicySpec(in *expapi.NetworkPolicySpec, out *NetworkPolicySpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*expapi.NetworkPolicySpec))(in)
+	}
+	if err := convert_expapi_LabelSelector_To_v1_LabelSelector(&in.PodSelector, &out.PodSelector, s); err != nil {
+		return err
+	}
+	if in.Ingress != nil {
+		out.Ingress = make([]NetworkPolicyIngressRule, len(in.Ingress))
+		for i := range in.Ingress {
+			if err := convert_expapi_NetworkPolicyIngressRule_To_v1_NetworkPolicyIngressRule(&in.Ingress[i], &out.Ingress[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ingress = nil
+	}
+	return nil
+}
+
 func convert_expapi_ReplicationControllerDummy_To_v1_ReplicationControllerDummy(in *expapi.ReplicationControllerDummy, out *ReplicationControllerDummy, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*expapi.ReplicationControllerDummy))(in)
@@ -1821,9 +1992,9 @@ func convert_v1_DaemonSpec_To_expapi_DaemonSpec(in *DaemonSpec, out *expapi.Daem
 		defaulting.(func(*DaemonSpec))(in)
 	}
 	if in.Selector != nil {
-		out.Selector = make(map[string]string)
-		for key, val := range in.Selector {
-			out.Selector[key] = val
+		out.Selector = new(expapi.LabelSelector)
+		if err := convert_v1_LabelSelector_To_expapi_LabelSelector(in.Selector, out.Selector, s); err != nil {
+			return err
 		}
 	} else {
 		out.Selector = nil
@@ -1991,6 +2162,177 @@ func convert_v1_HorizontalPodAutoscalerStatus_To_expapi_HorizontalPodAutoscalerS
 	return nil
 }
 
+func convert_v1_LabelSelector_To_expapi_LabelSelector(in *LabelSelector, out *expapi.LabelSelector, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*LabelSelector))(in)
+	}
+	if in.MatchLabels != nil {
+		out.MatchLabels = make(map[string]string)
+		for key, val := range in.MatchLabels {
+			out.MatchLabels[key] = val
+		}
+	} else {
+		out.MatchLabels = nil
+	}
+	if in.MatchExpressions != nil {
+		out.MatchExpressions = make([]expapi.LabelSelectorRequirement, len(in.MatchExpressions))
+		for i := range in.MatchExpressions {
+			if err := convert_v1_LabelSelectorRequirement_To_expapi_LabelSelectorRequirement(&in.MatchExpressions[i], &out.MatchExpressions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.MatchExpressions = nil
+	}
+	return nil
+}
+
+func convert_v1_LabelSelectorRequirement_To_expapi_LabelSelectorRequirement(in *LabelSelectorRequirement, out *expapi.LabelSelectorRequirement, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*LabelSelectorRequirement))(in)
+	}
+	out.Key = in.Key
+	out.Operator = expapi.LabelSelectorOperator(in.Operator)
+	if in.Values != nil {
+		out.Values = make([]string, len(in.Values))
+		for i := range in.Values {
+			out.Values[i] = in.Values[i]
+		}
+	} else {
+		out.Values = nil
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicy_To_expapi_NetworkPolicy(in *NetworkPolicy, out *expapi.NetworkPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicy))(in)
+	}
+	if err := convert_v1_TypeMeta_To_api_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := convert_v1_NetworkPolicySpec_To_expapi_NetworkPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicyIngressRule_To_expapi_NetworkPolicyIngressRule(in *NetworkPolicyIngressRule, out *expapi.NetworkPolicyIngressRule, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicyIngressRule))(in)
+	}
+	if in.Ports != nil {
+		out.Ports = make([]expapi.NetworkPolicyPort, len(in.Ports))
+		for i := range in.Ports {
+			if err := convert_v1_NetworkPolicyPort_To_expapi_NetworkPolicyPort(&in.Ports[i], &out.Ports[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
+	if in.From != nil {
+		out.From = make([]expapi.NetworkPolicyPeer, len(in.From))
+		for i := range in.From {
+			if err := convert_v1_NetworkPolicyPeer_To_expapi_NetworkPolicyPeer(&in.From[i], &out.From[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.From = nil
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicyList_To_expapi_NetworkPolicyList(in *NetworkPolicyList, out *expapi.NetworkPolicyList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicyList))(in)
+	}
+	if err := convert_v1_TypeMeta_To_api_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
+		return err
+	}
+	if err := convert_v1_ListMeta_To_api_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]expapi.NetworkPolicy, len(in.Items))
+		for i := range in.Items {
+			if err := convert_v1_NetworkPolicy_To_expapi_NetworkPolicy(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicyPeer_To_expapi_NetworkPolicyPeer(in *NetworkPolicyPeer, out *expapi.NetworkPolicyPeer, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicyPeer))(in)
+	}
+	if in.PodSelector != nil {
+		out.PodSelector = new(expapi.LabelSelector)
+		if err := convert_v1_LabelSelector_To_expapi_LabelSelector(in.PodSelector, out.PodSelector, s); err != nil {
+			return err
+		}
+	} else {
+		out.PodSelector = nil
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = new(expapi.LabelSelector)
+		if err := convert_v1_LabelSelector_To_expapi_LabelSelector(in.NamespaceSelector, out.NamespaceSelector, s); err != nil {
+			return err
+		}
+	} else {
+		out.NamespaceSelector = nil
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicyPort_To_expapi_NetworkPolicyPort(in *NetworkPolicyPort, out *expapi.NetworkPolicyPort, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicyPort))(in)
+	}
+	if in.Protocol != nil {
+		out.Protocol = new(api.Protocol)
+		*out.Protocol = api.Protocol(*in.Protocol)
+	} else {
+		out.Protocol = nil
+	}
+	if in.Port != nil {
+		if err := s.Convert(&in.Port, &out.Port, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Port = nil
+	}
+	return nil
+}
+
+func convert_v1_NetworkPolicySpec_To_expapi_NetworkPolicySpec(in *NetworkPolicySpec, out *expapi.NetworkPolicySpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*NetworkPolicySpec))(in)
+	}
+	if err := convert_v1_LabelSelector_To_expapi_LabelSelector(&in.PodSelector, &out.PodSelector, s); err != nil {
+		return err
+	}
+	if in.Ingress != nil {
+		out.Ingress = make([]expapi.NetworkPolicyIngressRule, len(in.Ingress))
+		for i := range in.Ingress {
+			if err := convert_v1_NetworkPolicyIngressRule_To_expapi_NetworkPolicyIngressRule(&in.Ingress[i], &out.Ingress[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ingress = nil
+	}
+	return nil
+}
+
 func convert_v1_ReplicationControllerDummy_To_expapi_ReplicationControllerDummy(in *ReplicationControllerDummy, out *expapi.ReplicationControllerDummy, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*ReplicationControllerDummy))(in)
@@ -2177,6 +2519,14 @@ func init() {
 		convert_expapi_HorizontalPodAutoscalerSpec_To_v1_HorizontalPodAutoscalerSpec,
 		convert_expapi_HorizontalPodAutoscalerStatus_To_v1_HorizontalPodAutoscalerStatus,
 		convert_expapi_HorizontalPodAutoscaler_To_v1_HorizontalPodAutoscaler,
+		convert_expapi_LabelSelectorRequirement_To_v1_LabelSelectorRequirement,
+		convert_expapi_LabelSelector_To_v1_LabelSelector,
+		convert_expapi_NetworkPolicyIngressRule_To_v1_NetworkPolicyIngressRule,
+		convert_expapi_NetworkPolicyList_To_v1_NetworkPolicyList,
+		convert_expapi_NetworkPolicyPeer_To_v1_NetworkPolicyPeer,
+		convert_expapi_NetworkPolicyPort_To_v1_NetworkPolicyPort,
+		convert_expapi_NetworkPolicySpec_To_v1_NetworkPolicySpec,
+		convert_expapi_NetworkPolicy_To_v1_NetworkPolicy,
 		convert_expapi_ReplicationControllerDummy_To_v1_ReplicationControllerDummy,
 		convert_expapi_ResourceConsumption_To_v1_ResourceConsumption,
 		convert_expapi_RollingUpdateDeployment_To_v1_RollingUpdateDeployment,
@@ -2213,10 +2563,18 @@ func init() {
 		convert_v1_HorizontalPodAutoscaler_To_expapi_HorizontalPodAutoscaler,
 		convert_v1_HostPathVolumeSource_To_api_HostPathVolumeSource,
 		convert_v1_ISCSIVolumeSource_To_api_ISCSIVolumeSource,
+		convert_v1_LabelSelectorRequirement_To_expapi_LabelSelectorRequirement,
+		convert_v1_LabelSelector_To_expapi_LabelSelector,
 		convert_v1_Lifecycle_To_api_Lifecycle,
 		convert_v1_ListMeta_To_api_ListMeta,
 		convert_v1_LocalObjectReference_To_api_LocalObjectReference,
 		convert_v1_NFSVolumeSource_To_api_NFSVolumeSource,
+		convert_v1_NetworkPolicyIngressRule_To_expapi_NetworkPolicyIngressRule,
+		convert_v1_NetworkPolicyList_To_expapi_NetworkPolicyList,
+		convert_v1_NetworkPolicyPeer_To_expapi_NetworkPolicyPeer,
+		convert_v1_NetworkPolicyPort_To_expapi_NetworkPolicyPort,
+		convert_v1_NetworkPolicySpec_To_expapi_NetworkPolicySpec,
+		convert_v1_NetworkPolicy_To_expapi_NetworkPolicy,
 		convert_v1_ObjectFieldSelector_To_api_ObjectFieldSelector,
 		convert_v1_ObjectMeta_To_api_ObjectMeta,
 		convert_v1_PersistentVolumeClaimVolumeSource_To_api_PersistentVolumeClaimVolumeSource,
@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1
 
-import "k8s.io/kubernetes/pkg/api"
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
 
 func addDefaultingFuncs() {
 	api.Scheme.AddDefaultingFuncs(
@@ -32,13 +35,19 @@ func addDefaultingFuncs() {
 			}
 			// TODO: support templates defined elsewhere when we support them in the API
 			if labels != nil {
-				if len(obj.Spec.Selector) == 0 {
-					obj.Spec.Selector = labels
+				if obj.Spec.Selector == nil {
+					obj.Spec.Selector = &LabelSelector{MatchLabels: labels}
 				}
 				if len(obj.Labels) == 0 {
 					obj.Labels = labels
 				}
 			}
 		},
+		func(obj *NetworkPolicyPort) {
+			if obj.Protocol == nil {
+				protocol := v1.ProtocolTCP
+				obj.Protocol = &protocol
+			}
+		},
 	)
 }
@@ -80,6 +80,32 @@ func TestSetDefaultDaemon(t *testing.T) {
 				t.Errorf("unexpected equality: %v", dc.Labels)
 			}
 		}
+		if dc.Spec.Selector == nil && !reflect.DeepEqual(dc2.Spec.Selector.MatchLabels, dc2.Spec.Template.Labels) {
+			t.Errorf("expected selector to be defaulted to %v, got %v", dc2.Spec.Template.Labels, dc2.Spec.Selector)
+		}
+	}
+}
+
+func TestSetDefaultNetworkPolicyPort(t *testing.T) {
+	udp := v1.ProtocolUDP
+	np := &NetworkPolicy{
+		Spec: NetworkPolicySpec{
+			Ingress: []NetworkPolicyIngressRule{
+				{Ports: []NetworkPolicyPort{{}, {Protocol: &udp}}},
+			},
+		},
+	}
+	obj2 := roundTrip(t, runtime.Object(np))
+	np2, ok := obj2.(*NetworkPolicy)
+	if !ok {
+		t.Fatalf("unexpected object: %v", obj2)
+	}
+	ports := np2.Spec.Ingress[0].Ports
+	if ports[0].Protocol == nil || *ports[0].Protocol != v1.ProtocolTCP {
+		t.Errorf("expected protocol to be defaulted to %v, got %v", v1.ProtocolTCP, ports[0].Protocol)
+	}
+	if ports[1].Protocol == nil || *ports[1].Protocol != v1.ProtocolUDP {
+		t.Errorf("expected protocol %v to be kept, got %v", v1.ProtocolUDP, ports[1].Protocol)
 	}
 }
 
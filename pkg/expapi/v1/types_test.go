@@ -0,0 +1,58 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/util/strategicpatch"
+)
+
+func TestLabelSelectorStrategicMergePatch(t *testing.T) {
+	original := LabelSelector{
+		MatchExpressions: []LabelSelectorRequirement{
+			{Key: "app", Operator: LabelSelectorOpIn, Values: []string{"web"}},
+			{Key: "tier", Operator: LabelSelectorOpIn, Values: []string{"frontend"}},
+		},
+	}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The requirements are merged by key.
+	patch := []byte(`{"matchExpressions":[{"key":"tier","operator":"NotIn","values":["backend"]},{"key":"track","operator":"Exists"}]}`)
+	patched, err := strategicpatch.StrategicMergePatchData(data, patch, LabelSelector{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := LabelSelector{}
+	if err := json.Unmarshal(patched, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := LabelSelector{
+		MatchExpressions: []LabelSelectorRequirement{
+			{Key: "app", Operator: LabelSelectorOpIn, Values: []string{"web"}},
+			{Key: "tier", Operator: LabelSelectorOpNotIn, Values: []string{"backend"}},
+			{Key: "track", Operator: LabelSelectorOpExists},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %#v, got %#v", expected, got)
+	}
+}
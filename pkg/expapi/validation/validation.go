@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"strconv"
 
 	"k8s.io/kubernetes/pkg/api"
@@ -129,18 +130,12 @@ func ValidateDaemonTemplateUpdate(oldPodTemplate, podTemplate *api.PodTemplateSp
 func ValidateDaemonSpec(spec *expapi.DaemonSpec) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 
-	selector := labels.Set(spec.Selector).AsSelector()
-	if selector.Empty() {
-		allErrs = append(allErrs, errs.NewFieldRequired("selector"))
-	}
+	allErrs = append(allErrs, validateNonEmptyLabelSelector(spec.Selector, "selector")...)
 
 	if spec.Template == nil {
 		allErrs = append(allErrs, errs.NewFieldRequired("template"))
 	} else {
-		labels := labels.Set(spec.Template.Labels)
-		if !selector.Matches(labels) {
-			allErrs = append(allErrs, errs.NewFieldInvalid("template.metadata.labels", spec.Template.Labels, "selector does not match template"))
-		}
+		allErrs = append(allErrs, validateSelectorMatchesTemplate(spec.Selector, spec.Template, "template")...)
 		allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpec(spec.Template).Prefix("template")...)
 		// Daemons typically run on more than one node, so mark Read-Write persistent disks as invalid.
 		allErrs = append(allErrs, apivalidation.ValidateReadOnlyPersistentDisks(spec.Template.Spec.Volumes).Prefix("template.spec.volumes")...)
@@ -234,9 +229,10 @@ func ValidateDeploymentStrategy(strategy *expapi.DeploymentStrategy, fieldName s
 // Validates given deployment spec.
 func ValidateDeploymentSpec(spec *expapi.DeploymentSpec) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
-	allErrs = append(allErrs, apivalidation.ValidateNonEmptySelector(spec.Selector, "selector")...)
+	allErrs = append(allErrs, validateNonEmptyLabelSelector(spec.Selector, "selector")...)
 	allErrs = append(allErrs, apivalidation.ValidatePositiveField(int64(spec.Replicas), "replicas")...)
-	allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpecForRC(spec.Template, spec.Selector, spec.Replicas, "template")...)
+	allErrs = append(allErrs, apivalidation.ValidatePodTemplateSpecForRC(spec.Template, nil, spec.Replicas, "template")...)
+	allErrs = append(allErrs, validateSelectorMatchesTemplate(spec.Selector, spec.Template, "template")...)
 	allErrs = append(allErrs, ValidateDeploymentStrategy(&spec.Strategy, "strategy")...)
 	if spec.UniqueLabelKey != nil {
 		allErrs = append(allErrs, apivalidation.ValidateLabelName(*spec.UniqueLabelKey, "uniqueLabel")...)
@@ -257,3 +253,149 @@ func ValidateDeployment(obj *expapi.Deployment) errs.ValidationErrorList {
 	allErrs = append(allErrs, ValidateDeploymentSpec(&obj.Spec).Prefix("spec")...)
 	return allErrs
 }
+
+// ValidateLabelSelector tests that the requirements of a label selector are
+// well formed and refer to valid label keys and values.
+func ValidateLabelSelector(ps *expapi.LabelSelector, fieldName string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if ps == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, apivalidation.ValidateLabels(ps.MatchLabels, fieldName+".matchLabels")...)
+	for i, expr := range ps.MatchExpressions {
+		allErrs = append(allErrs, ValidateLabelSelectorRequirement(expr).Prefix(fmt.Sprintf("%s.matchExpressions[%d]", fieldName, i))...)
+	}
+	return allErrs
+}
+
+// ValidateLabelSelectorRequirement tests that the operator of a label selector
+// requirement is known and that its key and values are valid for it.
+func ValidateLabelSelectorRequirement(sr expapi.LabelSelectorRequirement) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	switch sr.Operator {
+	case expapi.LabelSelectorOpIn, expapi.LabelSelectorOpNotIn:
+		if len(sr.Values) == 0 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("values", sr.Values, "must be specified when operator is In or NotIn"))
+		}
+	case expapi.LabelSelectorOpExists, expapi.LabelSelectorOpDoesNotExist:
+		if len(sr.Values) > 0 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("values", sr.Values, "may not be specified when operator is Exists or DoesNotExist"))
+		}
+	default:
+		allErrs = append(allErrs, errs.NewFieldValueNotSupported("operator", sr.Operator, []string{
+			string(expapi.LabelSelectorOpIn),
+			string(expapi.LabelSelectorOpNotIn),
+			string(expapi.LabelSelectorOpExists),
+			string(expapi.LabelSelectorOpDoesNotExist),
+		}))
+	}
+	allErrs = append(allErrs, apivalidation.ValidateLabelName(sr.Key, "key")...)
+	for _, value := range sr.Values {
+		if !util.IsValidLabelValue(value) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("values", value, fmt.Sprintf("must have at most %d characters, matching regex %s", util.LabelValueMaxLength, util.LabelValueFmt)))
+		}
+	}
+	return allErrs
+}
+
+// validateNonEmptyLabelSelector tests that a label selector is set, does not
+// select everything, and is valid.
+func validateNonEmptyLabelSelector(ps *expapi.LabelSelector, fieldName string) errs.ValidationErrorList {
+	if ps == nil || len(ps.MatchLabels)+len(ps.MatchExpressions) == 0 {
+		return errs.ValidationErrorList{errs.NewFieldRequired(fieldName)}
+	}
+	return ValidateLabelSelector(ps, fieldName)
+}
+
+// validateSelectorMatchesTemplate tests that a label selector selects the pods
+// created from the given template.
+func validateSelectorMatchesTemplate(ps *expapi.LabelSelector, template *api.PodTemplateSpec, fieldName string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if ps == nil || template == nil {
+		return allErrs
+	}
+	selector, err := expapi.LabelSelectorAsSelector(ps)
+	if err != nil {
+		// The selector itself is reported by ValidateLabelSelector.
+		return allErrs
+	}
+	if !selector.Empty() && !selector.Matches(labels.Set(template.Labels)) {
+		allErrs = append(allErrs, errs.NewFieldInvalid(fieldName+".metadata.labels", template.Labels, "selector does not match labels in "+fieldName))
+	}
+	return allErrs
+}
+
+// ValidateNetworkPolicyName can be used to check whether the given network
+// policy name is valid.
+func ValidateNetworkPolicyName(name string, prefix bool) (bool, string) {
+	return apivalidation.NameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateNetworkPolicy tests if required fields in the network policy are set.
+func ValidateNetworkPolicy(policy *expapi.NetworkPolicy) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&policy.ObjectMeta, true, ValidateNetworkPolicyName).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateNetworkPolicySpec(&policy.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateNetworkPolicyUpdate tests if required fields in the network policy
+// are set.
+func ValidateNetworkPolicyUpdate(oldPolicy, policy *expapi.NetworkPolicy) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&policy.ObjectMeta, &oldPolicy.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateNetworkPolicySpec(&policy.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateNetworkPolicySpec tests that the selector, ports and peers of a
+// network policy spec are valid.
+func ValidateNetworkPolicySpec(spec *expapi.NetworkPolicySpec) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateLabelSelector(&spec.PodSelector, "podSelector")...)
+	for i, rule := range spec.Ingress {
+		ruleErrs := errs.ValidationErrorList{}
+		for j, port := range rule.Ports {
+			ruleErrs = append(ruleErrs, validateNetworkPolicyPort(&port).PrefixIndex(j).Prefix("ports")...)
+		}
+		for j, peer := range rule.From {
+			ruleErrs = append(ruleErrs, validateNetworkPolicyPeer(&peer).PrefixIndex(j).Prefix("from")...)
+		}
+		allErrs = append(allErrs, ruleErrs.PrefixIndex(i).Prefix("ingress")...)
+	}
+	return allErrs
+}
+
+var supportedNetworkPolicyProtocols = util.NewStringSet(string(api.ProtocolTCP), string(api.ProtocolUDP))
+
+func validateNetworkPolicyPort(port *expapi.NetworkPolicyPort) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if port.Protocol == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("protocol"))
+	} else if !supportedNetworkPolicyProtocols.Has(string(*port.Protocol)) {
+		allErrs = append(allErrs, errs.NewFieldValueNotSupported("protocol", *port.Protocol, supportedNetworkPolicyProtocols.List()))
+	}
+	if port.Port != nil {
+		if port.Port.Kind == util.IntstrInt && !util.IsValidPortNum(port.Port.IntVal) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("port", port.Port.IntVal, "must be between 1 and 65535, inclusive"))
+		} else if port.Port.Kind == util.IntstrString && !util.IsValidPortName(port.Port.StrVal) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("port", port.Port.StrVal, "must be a valid container port name"))
+		}
+	}
+	return allErrs
+}
+
+func validateNetworkPolicyPeer(peer *expapi.NetworkPolicyPeer) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	switch {
+	case peer.PodSelector != nil && peer.NamespaceSelector != nil:
+		allErrs = append(allErrs, errs.NewFieldInvalid("namespaceSelector", peer.NamespaceSelector, "may not be specified together with podSelector"))
+	case peer.PodSelector != nil:
+		allErrs = append(allErrs, ValidateLabelSelector(peer.PodSelector, "podSelector")...)
+	case peer.NamespaceSelector != nil:
+		allErrs = append(allErrs, ValidateLabelSelector(peer.NamespaceSelector, "namespaceSelector")...)
+	default:
+		allErrs = append(allErrs, errs.NewFieldRequired("podSelector"))
+	}
+	return allErrs
+}
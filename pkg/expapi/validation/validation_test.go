@@ -220,14 +220,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
@@ -236,14 +236,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector2,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector2},
 					Template: &validPodTemplateAbc2.Template,
 				},
 			},
@@ -252,14 +252,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateNodeSelector.Template,
 				},
 			},
@@ -277,14 +277,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
@@ -293,14 +293,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: invalidSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: invalidSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
@@ -309,14 +309,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &invalidPodTemplate.Template,
 				},
 			},
@@ -325,14 +325,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateDef.Template,
 				},
 			},
@@ -341,14 +341,14 @@ func TestValidateDaemonUpdate(t *testing.T) {
 			old: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &validPodTemplateAbc.Template,
 				},
 			},
 			update: expapi.Daemon{
 				ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 				Spec: expapi.DaemonSpec{
-					Selector: validSelector,
+					Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 					Template: &readWriteVolumePodTemplate.Template,
 				},
 			},
@@ -391,14 +391,26 @@ func TestValidateDaemon(t *testing.T) {
 		{
 			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &validPodTemplate.Template,
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "abc-123", Namespace: api.NamespaceDefault},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
+				Template: &validPodTemplate.Template,
+			},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "set-based", Namespace: api.NamespaceDefault},
+			Spec: expapi.DaemonSpec{
+				Selector: &expapi.LabelSelector{
+					MatchExpressions: []expapi.LabelSelectorRequirement{
+						{Key: "a", Operator: expapi.LabelSelectorOpIn, Values: []string{"b", "c"}},
+						{Key: "d", Operator: expapi.LabelSelectorOpDoesNotExist},
+					},
+				},
 				Template: &validPodTemplate.Template,
 			},
 		},
@@ -413,14 +425,14 @@ func TestValidateDaemon(t *testing.T) {
 		"zero-length ID": {
 			ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &validPodTemplate.Template,
 			},
 		},
 		"missing-namespace": {
 			ObjectMeta: api.ObjectMeta{Name: "abc-123"},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &validPodTemplate.Template,
 			},
 		},
@@ -430,17 +442,28 @@ func TestValidateDaemon(t *testing.T) {
 				Template: &validPodTemplate.Template,
 			},
 		},
+		"selector_expression_doesnt_match": {
+			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
+			Spec: expapi.DaemonSpec{
+				Selector: &expapi.LabelSelector{
+					MatchExpressions: []expapi.LabelSelectorRequirement{
+						{Key: "a", Operator: expapi.LabelSelectorOpDoesNotExist},
+					},
+				},
+				Template: &validPodTemplate.Template,
+			},
+		},
 		"selector_doesnt_match": {
 			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 			Spec: expapi.DaemonSpec{
-				Selector: map[string]string{"foo": "bar"},
+				Selector: &expapi.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
 				Template: &validPodTemplate.Template,
 			},
 		},
 		"invalid manifest": {
 			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 			},
 		},
 		"invalid_label": {
@@ -452,7 +475,7 @@ func TestValidateDaemon(t *testing.T) {
 				},
 			},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &validPodTemplate.Template,
 			},
 		},
@@ -477,7 +500,7 @@ func TestValidateDaemon(t *testing.T) {
 				},
 			},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &validPodTemplate.Template,
 			},
 		},
@@ -487,7 +510,7 @@ func TestValidateDaemon(t *testing.T) {
 				Namespace: api.NamespaceDefault,
 			},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &api.PodTemplateSpec{
 					Spec: api.PodSpec{
 						RestartPolicy: api.RestartPolicyOnFailure,
@@ -506,7 +529,7 @@ func TestValidateDaemon(t *testing.T) {
 				Namespace: api.NamespaceDefault,
 			},
 			Spec: expapi.DaemonSpec{
-				Selector: validSelector,
+				Selector: &expapi.LabelSelector{MatchLabels: validSelector},
 				Template: &api.PodTemplateSpec{
 					Spec: api.PodSpec{
 						RestartPolicy: api.RestartPolicyNever,
@@ -549,8 +572,10 @@ func validDeployment() *expapi.Deployment {
 			Namespace: api.NamespaceDefault,
 		},
 		Spec: expapi.DeploymentSpec{
-			Selector: map[string]string{
-				"name": "abc",
+			Selector: &expapi.LabelSelector{
+				MatchLabels: map[string]string{
+					"name": "abc",
+				},
 			},
 			Template: &api.PodTemplateSpec{
 				ObjectMeta: api.ObjectMeta{
@@ -594,11 +619,22 @@ func TestValidateDeployment(t *testing.T) {
 	}
 	// selector should match the labels in pod template.
 	invalidSelectorDeployment := validDeployment()
-	invalidSelectorDeployment.Spec.Selector = map[string]string{
-		"name": "def",
+	invalidSelectorDeployment.Spec.Selector = &expapi.LabelSelector{
+		MatchLabels: map[string]string{
+			"name": "def",
+		},
 	}
 	errorCases["selector does not match labels"] = invalidSelectorDeployment
 
+	// set-based selectors should match the labels in pod template too.
+	invalidExpressionDeployment := validDeployment()
+	invalidExpressionDeployment.Spec.Selector = &expapi.LabelSelector{
+		MatchExpressions: []expapi.LabelSelectorRequirement{
+			{Key: "name", Operator: expapi.LabelSelectorOpNotIn, Values: []string{"abc"}},
+		},
+	}
+	errorCases["spec.template.metadata.labels: invalid value"] = invalidExpressionDeployment
+
 	// RestartPolicy should be always.
 	invalidRestartPolicyDeployment := validDeployment()
 	invalidRestartPolicyDeployment.Spec.Template.Spec.RestartPolicy = api.RestartPolicyNever
@@ -658,3 +694,187 @@ func TestValidateDeployment(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLabelSelector(t *testing.T) {
+	successCases := []*expapi.LabelSelector{
+		nil,
+		{},
+		{MatchLabels: map[string]string{"a": "b"}},
+		{MatchExpressions: []expapi.LabelSelectorRequirement{
+			{Key: "a", Operator: expapi.LabelSelectorOpIn, Values: []string{"b", ""}},
+			{Key: "c", Operator: expapi.LabelSelectorOpNotIn, Values: []string{"d"}},
+			{Key: "example.com/e", Operator: expapi.LabelSelectorOpExists},
+			{Key: "f", Operator: expapi.LabelSelectorOpDoesNotExist},
+		}},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateLabelSelector(successCase, "selector"); len(errs) != 0 {
+			t.Errorf("expected success for %#v: %v", successCase, errs)
+		}
+	}
+
+	errorCases := map[string]*expapi.LabelSelector{
+		"selector.matchLabels: invalid value": {
+			MatchLabels: map[string]string{"NoUppercaseOrSpecialCharsLike=Equals": "b"},
+		},
+		"selector.matchExpressions[0].operator: unsupported value": {
+			MatchExpressions: []expapi.LabelSelectorRequirement{{Key: "a", Operator: "Equals", Values: []string{"b"}}},
+		},
+		"selector.matchExpressions[0].values: invalid value": {
+			MatchExpressions: []expapi.LabelSelectorRequirement{{Key: "a", Operator: expapi.LabelSelectorOpIn}},
+		},
+		"selector.matchExpressions[1].values: invalid value": {
+			MatchExpressions: []expapi.LabelSelectorRequirement{
+				{Key: "a", Operator: expapi.LabelSelectorOpExists},
+				{Key: "b", Operator: expapi.LabelSelectorOpDoesNotExist, Values: []string{"c"}},
+			},
+		},
+		"selector.matchExpressions[0].key: invalid value": {
+			MatchExpressions: []expapi.LabelSelectorRequirement{{Key: "a/b/c", Operator: expapi.LabelSelectorOpExists}},
+		},
+	}
+	for k, v := range errorCases {
+		errs := ValidateLabelSelector(v, "selector")
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		} else if !strings.Contains(errs[0].Error(), k) {
+			t.Errorf("unexpected error: %v, expected: %s", errs[0], k)
+		}
+	}
+}
+
+func validNetworkPolicy() *expapi.NetworkPolicy {
+	tcp := api.ProtocolTCP
+	port := util.NewIntOrStringFromInt(80)
+	return &expapi.NetworkPolicy{
+		ObjectMeta: api.ObjectMeta{
+			Name:      "abc",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: expapi.NetworkPolicySpec{
+			PodSelector: expapi.LabelSelector{
+				MatchLabels: map[string]string{"name": "db"},
+			},
+			Ingress: []expapi.NetworkPolicyIngressRule{
+				{
+					Ports: []expapi.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+					From: []expapi.NetworkPolicyPeer{
+						{PodSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"name": "frontend"}}},
+						{NamespaceSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"project": "web"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	tcp := api.ProtocolTCP
+	namedPort := util.NewIntOrStringFromString("http")
+	successCases := []*expapi.NetworkPolicy{
+		validNetworkPolicy(),
+		// an empty pod selector isolates the whole namespace.
+		{
+			ObjectMeta: api.ObjectMeta{Name: "deny-all", Namespace: api.NamespaceDefault},
+		},
+		// rules without ports or sources allow everything.
+		{
+			ObjectMeta: api.ObjectMeta{Name: "allow-all", Namespace: api.NamespaceDefault},
+			Spec: expapi.NetworkPolicySpec{
+				Ingress: []expapi.NetworkPolicyIngressRule{{}},
+			},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "named-port", Namespace: api.NamespaceDefault},
+			Spec: expapi.NetworkPolicySpec{
+				Ingress: []expapi.NetworkPolicyIngressRule{
+					{Ports: []expapi.NetworkPolicyPort{{Protocol: &tcp, Port: &namedPort}, {Protocol: &tcp}}},
+				},
+			},
+		},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateNetworkPolicy(successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]*expapi.NetworkPolicy{}
+	errorCases["metadata.name: required value"] = &expapi.NetworkPolicy{
+		ObjectMeta: api.ObjectMeta{
+			Namespace: api.NamespaceDefault,
+		},
+	}
+
+	invalidSelector := validNetworkPolicy()
+	invalidSelector.Spec.PodSelector.MatchExpressions = []expapi.LabelSelectorRequirement{
+		{Key: "name", Operator: "Equals", Values: []string{"db"}},
+	}
+	errorCases["spec.podSelector.matchExpressions[0].operator: unsupported value"] = invalidSelector
+
+	missingProtocol := validNetworkPolicy()
+	missingProtocol.Spec.Ingress[0].Ports[0].Protocol = nil
+	errorCases["spec.ingress[0].ports[0].protocol: required value"] = missingProtocol
+
+	invalidProtocol := validNetworkPolicy()
+	sctp := api.Protocol("SCTP")
+	invalidProtocol.Spec.Ingress[0].Ports[0].Protocol = &sctp
+	errorCases["spec.ingress[0].ports[0].protocol: unsupported value"] = invalidProtocol
+
+	invalidPort := validNetworkPolicy()
+	outOfRange := util.NewIntOrStringFromInt(65536)
+	invalidPort.Spec.Ingress[0].Ports[0].Port = &outOfRange
+	errorCases["spec.ingress[0].ports[0].port: invalid value '65536'"] = invalidPort
+
+	invalidPortName := validNetworkPolicy()
+	badName := util.NewIntOrStringFromString("not_a_port")
+	invalidPortName.Spec.Ingress[0].Ports[0].Port = &badName
+	errorCases["spec.ingress[0].ports[0].port: invalid value 'not_a_port'"] = invalidPortName
+
+	emptyPeer := validNetworkPolicy()
+	emptyPeer.Spec.Ingress[0].From[1] = expapi.NetworkPolicyPeer{}
+	errorCases["spec.ingress[0].from[1].podSelector: required value"] = emptyPeer
+
+	bothPeerSelectors := validNetworkPolicy()
+	bothPeerSelectors.Spec.Ingress[0].From[0].NamespaceSelector = &expapi.LabelSelector{}
+	errorCases["spec.ingress[0].from[0].namespaceSelector: invalid value"] = bothPeerSelectors
+
+	invalidPeerSelector := validNetworkPolicy()
+	invalidPeerSelector.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels = map[string]string{"project": "a b"}
+	errorCases["spec.ingress[0].from[1].namespaceSelector.matchLabels: invalid value"] = invalidPeerSelector
+
+	for k, v := range errorCases {
+		errs := ValidateNetworkPolicy(v)
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		} else if !strings.Contains(errs[0].Error(), k) {
+			t.Errorf("unexpected error: %v, expected: %s", errs[0], k)
+		}
+	}
+}
+
+func TestValidateNetworkPolicyUpdate(t *testing.T) {
+	old := validNetworkPolicy()
+	old.ResourceVersion = "1"
+
+	update := validNetworkPolicy()
+	update.ResourceVersion = "1"
+	update.Spec.Ingress = nil
+	if errs := ValidateNetworkPolicyUpdate(old, update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	renamed := validNetworkPolicy()
+	renamed.ResourceVersion = "1"
+	renamed.Name = "def"
+	if errs := ValidateNetworkPolicyUpdate(old, renamed); len(errs) == 0 {
+		t.Errorf("expected failure when changing the name")
+	}
+
+	invalid := validNetworkPolicy()
+	invalid.ResourceVersion = "1"
+	invalid.Spec.Ingress[0].From[0] = expapi.NetworkPolicyPeer{}
+	if errs := ValidateNetworkPolicyUpdate(old, invalid); len(errs) == 0 {
+		t.Errorf("expected failure for an invalid peer")
+	}
+}
@@ -18,8 +18,13 @@ package fieldpath
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/fields"
 )
 
 // ExtractFieldPathAsString extracts the field from the given object
@@ -45,3 +50,141 @@ func ExtractFieldPathAsString(obj interface{}, fieldPath string) (string, error)
 
 	return "", fmt.Errorf("Unsupported fieldPath: %v", fieldPath)
 }
+
+// FieldPaths extracts the values of a fixed list of field paths from objects
+// of one API type. A path is a dotted list of JSON field names, e.g.
+// "spec.nodeName" or "involvedObject.kind", naming a string, boolean or
+// integer field. The paths are resolved against the type once, so extracting
+// their values only walks precomputed field indexes.
+type FieldPaths struct {
+	names []string
+	// indexes holds, for every path, the index sequence of the field named
+	// by each of its parts, as taken by reflect.Value.FieldByIndex.
+	indexes [][][]int
+}
+
+// NewFieldPaths resolves the field paths against the type of obj, which must
+// be a pointer to an API type.
+func NewFieldPaths(obj interface{}, fieldPaths ...string) (*FieldPaths, error) {
+	p := &FieldPaths{}
+	for _, fieldPath := range fieldPaths {
+		t := reflect.TypeOf(obj)
+		index := [][]int{}
+		for _, name := range strings.Split(fieldPath, ".") {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("Unsupported fieldPath: %v", fieldPath)
+			}
+			field, ok := jsonField(t, name)
+			if !ok {
+				return nil, fmt.Errorf("Unsupported fieldPath: %v", fieldPath)
+			}
+			index = append(index, field.Index)
+			t = field.Type
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("Unsupported fieldPath: %v (not a scalar field)", fieldPath)
+		}
+		p.names = append(p.names, fieldPath)
+		p.indexes = append(p.indexes, index)
+	}
+	return p, nil
+}
+
+// MustNewFieldPaths is like NewFieldPaths, but panics if a field path cannot be
+// resolved. It is meant for the field paths a package declares statically.
+func MustNewFieldPaths(obj interface{}, fieldPaths ...string) *FieldPaths {
+	p, err := NewFieldPaths(obj, fieldPaths...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Names returns the sorted field paths.
+func (p *FieldPaths) Names() []string {
+	names := append([]string{}, p.names...)
+	sort.Strings(names)
+	return names
+}
+
+// Extract returns the values of the field paths in obj, which must be of the
+// type the paths were resolved against. A nil pointer along a path yields the
+// empty string.
+func (p *FieldPaths) Extract(obj interface{}) fields.Set {
+	set := fields.Set{}
+	for i, index := range p.indexes {
+		set[p.names[i]] = fieldValue(reflect.ValueOf(obj), index)
+	}
+	return set
+}
+
+func fieldValue(v reflect.Value, index [][]int) string {
+	for _, fieldIndex := range index {
+		if v = indirect(v); !v.IsValid() {
+			return ""
+		}
+		v = v.FieldByIndex(fieldIndex)
+	}
+	if v = indirect(v); !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+}
+
+// indirect follows the pointers to v, returning the zero Value on nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// jsonField returns the field of the struct type t that is serialized under
+// the given JSON name, looking through embedded structs that are inlined.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch {
+		case jsonName == "-":
+			continue
+		case jsonName == "" && f.Anonymous && f.Type.Kind() == reflect.Struct:
+			if field, ok := jsonField(f.Type, name); ok {
+				field.Index = append([]int{i}, field.Index...)
+				return field, true
+			}
+			continue
+		case jsonName == "":
+			jsonName = f.Name
+		}
+		if jsonName == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package fieldpath
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -84,3 +85,92 @@ func TestExtractFieldPathAsString(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldPaths(t *testing.T) {
+	cases := []struct {
+		name           string
+		fieldPath      string
+		obj            interface{}
+		expectedValue  string
+		expectedErrMsg string
+	}{
+		{
+			name:          "metadata",
+			fieldPath:     "metadata.name",
+			obj:           &api.Pod{ObjectMeta: api.ObjectMeta{Name: "object-name"}},
+			expectedValue: "object-name",
+		},
+		{
+			name:          "inlined type meta",
+			fieldPath:     "kind",
+			obj:           &api.Pod{TypeMeta: api.TypeMeta{Kind: "Pod"}},
+			expectedValue: "Pod",
+		},
+		{
+			name:          "named string type",
+			fieldPath:     "spec.type",
+			obj:           &api.Service{Spec: api.ServiceSpec{Type: api.ServiceTypeNodePort}},
+			expectedValue: "NodePort",
+		},
+		{
+			name:          "nested struct",
+			fieldPath:     "involvedObject.kind",
+			obj:           &api.Event{InvolvedObject: api.ObjectReference{Kind: "Pod"}},
+			expectedValue: "Pod",
+		},
+		{
+			name:          "bool",
+			fieldPath:     "spec.unschedulable",
+			obj:           &api.Node{Spec: api.NodeSpec{Unschedulable: true}},
+			expectedValue: "true",
+		},
+		{
+			name:          "int",
+			fieldPath:     "status.replicas",
+			obj:           &api.ReplicationController{Status: api.ReplicationControllerStatus{Replicas: 3}},
+			expectedValue: "3",
+		},
+		{
+			name:          "nil pointer",
+			fieldPath:     "spec.activeDeadlineSeconds",
+			obj:           &api.Pod{},
+			expectedValue: "",
+		},
+		{
+			name:           "unknown field",
+			fieldPath:      "spec.whoops",
+			obj:            &api.Pod{},
+			expectedErrMsg: "Unsupported fieldPath",
+		},
+		{
+			name:           "not a scalar",
+			fieldPath:      "spec",
+			obj:            &api.Pod{},
+			expectedErrMsg: "not a scalar field",
+		},
+	}
+
+	for _, tc := range cases {
+		paths, err := NewFieldPaths(tc.obj, tc.fieldPath)
+		if tc.expectedErrMsg != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErrMsg) {
+				t.Errorf("%v: expected error containing %q, got %v", tc.name, tc.expectedErrMsg, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if actual := paths.Extract(tc.obj); len(actual) != 1 || actual[tc.fieldPath] != tc.expectedValue {
+			t.Errorf("%v: Unexpected result; got %v, expected %q", tc.name, actual, tc.expectedValue)
+		}
+	}
+}
+
+func TestFieldPathsNames(t *testing.T) {
+	paths := MustNewFieldPaths(&api.Pod{}, "status.phase", "metadata.name")
+	if names := paths.Names(); !reflect.DeepEqual(names, []string{"metadata.name", "status.phase"}) {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
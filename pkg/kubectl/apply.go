@@ -0,0 +1,126 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// LastAppliedConfigAnnotation is the annotation used to store the previous
+// configuration of a resource for use in a three way diff by `kubectl apply`.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// GetOriginalConfiguration retrieves the original configuration of the object
+// from the annotation, or nil if no annotation was found.
+func GetOriginalConfiguration(info *resource.Info) ([]byte, error) {
+	annotations, err := info.Mapping.MetadataAccessor.Annotations(info.Object)
+	if err != nil {
+		return nil, err
+	}
+	original, ok := annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(original), nil
+}
+
+// SetOriginalConfiguration sets the original configuration of the object
+// into the annotation, or removes the annotation if original is nil.
+func SetOriginalConfiguration(info *resource.Info, original []byte) error {
+	accessor := info.Mapping.MetadataAccessor
+	annotations, err := accessor.Annotations(info.Object)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if original == nil {
+		delete(annotations, LastAppliedConfigAnnotation)
+	} else {
+		annotations[LastAppliedConfigAnnotation] = string(original)
+	}
+	return accessor.SetAnnotations(info.Object, annotations)
+}
+
+// GetModifiedConfiguration retrieves the configuration of the object as the
+// user wrote it, before defaulting and conversion when that is available. If
+// annotate is true, the returned configuration also records itself in the last
+// applied annotation, so that applying it stores the configuration for the next
+// three way diff.
+func GetModifiedConfiguration(info *resource.Info, annotate bool) ([]byte, error) {
+	var data []byte
+	var err error
+	if info.VersionedObject != nil {
+		data, err = json.Marshal(info.VersionedObject)
+	} else {
+		data, err = info.Mapping.Codec.Encode(info.Object)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Work on a generic map so the annotation can be updated without
+	// knowing the versioned type.
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	// Status is owned by the system, and serializing the zero value would
+	// otherwise clobber it.
+	delete(obj, "status")
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+		obj["metadata"] = metadata
+	}
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	if _, found := annotations[LastAppliedConfigAnnotation]; found {
+		delete(annotations, LastAppliedConfigAnnotation)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+			annotations = nil
+		}
+	}
+	modified, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	if !annotate {
+		return modified, nil
+	}
+
+	if annotations == nil {
+		annotations = map[string]interface{}{}
+		metadata["annotations"] = annotations
+	}
+	annotations[LastAppliedConfigAnnotation] = string(modified)
+	return json.Marshal(obj)
+}
+
+// UpdateApplyAnnotation records the configuration of the object in the last
+// applied annotation, so that a later `kubectl apply` can compute a three way
+// diff against it.
+func UpdateApplyAnnotation(info *resource.Info) error {
+	modified, err := GetModifiedConfiguration(info, false)
+	if err != nil {
+		return err
+	}
+	return SetOriginalConfiguration(info, modified)
+}
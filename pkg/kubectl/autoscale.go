@@ -0,0 +1,107 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+// HorizontalPodAutoscalerV1 generates a HorizontalPodAutoscaler which keeps
+// the average CPU consumption of the pods of the referenced object at "cpu".
+type HorizontalPodAutoscalerV1 struct{}
+
+func (HorizontalPodAutoscalerV1) ParamNames() []GeneratorParam {
+	return []GeneratorParam{
+		{"default-name", true},
+		{"name", false},
+		{"scaleRef-kind", true},
+		{"scaleRef-name", true},
+		{"scaleRef-namespace", false},
+		{"scaleRef-apiVersion", false},
+		{"min", false},
+		{"max", true},
+		{"cpu", true},
+	}
+}
+
+func (HorizontalPodAutoscalerV1) Generate(genericParams map[string]interface{}) (runtime.Object, error) {
+	params := map[string]string{}
+	for key, value := range genericParams {
+		strVal, isString := value.(string)
+		if !isString {
+			return nil, fmt.Errorf("expected string, saw %v for '%s'", value, key)
+		}
+		params[key] = strVal
+	}
+
+	name, found := params["name"]
+	if !found || len(name) == 0 {
+		name, found = params["default-name"]
+		if !found || len(name) == 0 {
+			return nil, fmt.Errorf("'name' is a required parameter.")
+		}
+	}
+	min := 1
+	if minString, found := params["min"]; found && len(minString) > 0 {
+		var err error
+		if min, err = strconv.Atoi(minString); err != nil {
+			return nil, fmt.Errorf("'min' must be a number: %v", err)
+		}
+	}
+	max, err := strconv.Atoi(params["max"])
+	if err != nil {
+		return nil, fmt.Errorf("'max' must be a number: %v", err)
+	}
+	if min < 1 {
+		return nil, fmt.Errorf("'min' must be at least 1, got %d", min)
+	}
+	if max < min {
+		return nil, fmt.Errorf("'max' must be at least 'min' (%d), got %d", min, max)
+	}
+	cpu, err := resource.ParseQuantity(params["cpu"])
+	if err != nil {
+		return nil, fmt.Errorf("'cpu' must be a quantity: %v", err)
+	}
+
+	scaler := expapi.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{
+			Name: name,
+		},
+		Spec: expapi.HorizontalPodAutoscalerSpec{
+			ScaleRef: &expapi.SubresourceReference{
+				Kind:        params["scaleRef-kind"],
+				Name:        params["scaleRef-name"],
+				Namespace:   params["scaleRef-namespace"],
+				APIVersion:  params["scaleRef-apiVersion"],
+				Subresource: "scale",
+			},
+			MinCount: min,
+			MaxCount: max,
+			Target: expapi.ResourceConsumption{
+				Resource: api.ResourceCPU,
+				Quantity: *cpu,
+			},
+		},
+	}
+	return &scaler, nil
+}
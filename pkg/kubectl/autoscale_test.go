@@ -0,0 +1,148 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/expapi"
+)
+
+func TestHorizontalPodAutoscalerGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		expected  *expapi.HorizontalPodAutoscaler
+		expectErr bool
+	}{
+		{
+			name: "default name and min",
+			params: map[string]interface{}{
+				"default-name":        "foo",
+				"scaleRef-kind":       "ReplicationController",
+				"scaleRef-name":       "foo",
+				"scaleRef-namespace":  "test",
+				"scaleRef-apiVersion": "v1",
+				"max":                 "10",
+				"cpu":                 "400m",
+			},
+			expected: &expapi.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "foo"},
+				Spec: expapi.HorizontalPodAutoscalerSpec{
+					ScaleRef: &expapi.SubresourceReference{
+						Kind:        "ReplicationController",
+						Name:        "foo",
+						Namespace:   "test",
+						APIVersion:  "v1",
+						Subresource: "scale",
+					},
+					MinCount: 1,
+					MaxCount: 10,
+					Target:   expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("400m")},
+				},
+			},
+		},
+		{
+			name: "explicit name and min",
+			params: map[string]interface{}{
+				"default-name":  "foo",
+				"name":          "bar",
+				"scaleRef-kind": "Deployment",
+				"scaleRef-name": "foo",
+				"min":           "2",
+				"max":           "2",
+				"cpu":           "1",
+			},
+			expected: &expapi.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "bar"},
+				Spec: expapi.HorizontalPodAutoscalerSpec{
+					ScaleRef: &expapi.SubresourceReference{
+						Kind:        "Deployment",
+						Name:        "foo",
+						Subresource: "scale",
+					},
+					MinCount: 2,
+					MaxCount: 2,
+					Target:   expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("1")},
+				},
+			},
+		},
+		{
+			name: "max below min",
+			params: map[string]interface{}{
+				"default-name":  "foo",
+				"scaleRef-kind": "ReplicationController",
+				"scaleRef-name": "foo",
+				"min":           "3",
+				"max":           "2",
+				"cpu":           "400m",
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero min",
+			params: map[string]interface{}{
+				"default-name":  "foo",
+				"scaleRef-kind": "ReplicationController",
+				"scaleRef-name": "foo",
+				"min":           "0",
+				"max":           "2",
+				"cpu":           "400m",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid cpu",
+			params: map[string]interface{}{
+				"default-name":  "foo",
+				"scaleRef-kind": "ReplicationController",
+				"scaleRef-name": "foo",
+				"max":           "2",
+				"cpu":           "lots",
+			},
+			expectErr: true,
+		},
+		{
+			name: "non-string param",
+			params: map[string]interface{}{
+				"default-name": "foo",
+				"max":          2,
+			},
+			expectErr: true,
+		},
+	}
+	generator := HorizontalPodAutoscalerV1{}
+	for _, test := range tests {
+		obj, err := generator.Generate(test.params)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, obj) {
+			t.Errorf("%s: expected\n%#v\ngot\n%#v", test.name, test.expected, obj)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util/strategicpatch"
+)
+
+const (
+	apply_long = `Apply a configuration to a resource by filename or stdin.
+
+The configuration that was applied is recorded in an annotation on the resource, so
+that later applies can compute a three way merge between the last applied configuration,
+the new configuration and the live resource. Fields that were set by other actors, such
+as the replica count changed by 'kubectl scale', are preserved unless the new
+configuration specifies them, and fields that were removed from the configuration are
+removed from the resource. The resource is created if it does not exist yet.
+
+JSON and YAML formats are accepted.`
+	apply_example = `# Apply the configuration in pod.json to a pod.
+$ kubectl apply -f ./pod.json
+
+# Apply the JSON passed into stdin to a pod.
+$ cat pod.json | kubectl apply -f -`
+)
+
+func NewCmdApply(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "apply -f FILENAME",
+		Short:   "Apply a configuration to a resource by filename or stdin",
+		Long:    apply_long,
+		Example: apply_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(ValidateArgs(cmd, args))
+			cmdutil.CheckErr(cmdutil.ValidateOutputArgs(cmd))
+			cmdutil.CheckErr(RunApply(f, cmd, out))
+		},
+	}
+
+	usage := "Filename, directory, or URL to file that contains the configuration to apply"
+	kubectl.AddJsonFilenameFlag(cmd, usage)
+	cmd.MarkFlagRequired("filename")
+	cmdutil.AddValidateFlag(cmd)
+	cmdutil.AddOutputFlagsForMutation(cmd)
+	return cmd
+}
+
+func RunApply(f *cmdutil.Factory, cmd *cobra.Command, out io.Writer) error {
+	schema, err := f.Validator(cmdutil.GetFlagBool(cmd, "validate"))
+	if err != nil {
+		return err
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	filenames := cmdutil.GetFlagStringSlice(cmd, "filename")
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		Schema(schema).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+
+	shortOutput := cmdutil.GetFlagString(cmd, "output") == "name"
+	count := 0
+	err = r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// The new configuration, recording itself in the last applied annotation.
+		modified, err := kubectl.GetModifiedConfiguration(info, true)
+		if err != nil {
+			return cmdutil.AddSourceToErr("retrieving modified configuration from", info.Source, err)
+		}
+
+		if err := info.Get(); err != nil {
+			if !errors.IsNotFound(err) {
+				return cmdutil.AddSourceToErr("retrieving current configuration of", info.Source, err)
+			}
+			// The resource does not exist yet, so create it with the annotation set.
+			if err := kubectl.UpdateApplyAnnotation(info); err != nil {
+				return cmdutil.AddSourceToErr("creating", info.Source, err)
+			}
+			data, err := info.Mapping.Codec.Encode(info.Object)
+			if err != nil {
+				return cmdutil.AddSourceToErr("creating", info.Source, err)
+			}
+			obj, err := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, data)
+			if err != nil {
+				return cmdutil.AddSourceToErr("creating", info.Source, err)
+			}
+			count++
+			info.Refresh(obj, true)
+			cmdutil.PrintSuccess(mapper, shortOutput, out, info.Mapping.Resource, info.Name, "created")
+			return nil
+		}
+
+		// info.Object now holds the live object.
+		current, err := info.Mapping.Codec.Encode(info.Object)
+		if err != nil {
+			return cmdutil.AddSourceToErr("serializing current configuration of", info.Source, err)
+		}
+		original, err := kubectl.GetOriginalConfiguration(info)
+		if err != nil {
+			return cmdutil.AddSourceToErr("retrieving original configuration of", info.Source, err)
+		}
+
+		// The patch is computed against the versioned type, whose struct tags
+		// carry the patch strategies for the serialized fields.
+		versionedObject, err := api.Scheme.New(info.Mapping.APIVersion, info.Mapping.Kind)
+		if err != nil {
+			return cmdutil.AddSourceToErr("applying", info.Source, err)
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, versionedObject)
+		if err != nil {
+			return cmdutil.AddSourceToErr("computing patch for", info.Source, err)
+		}
+
+		obj, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
+		if err != nil {
+			return cmdutil.AddSourceToErr("applying patch to", info.Source, err)
+		}
+		count++
+		info.Refresh(obj, true)
+		cmdutil.PrintSuccess(mapper, shortOutput, out, info.Mapping.Resource, info.Name, "configured")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no objects passed to apply")
+	}
+	return nil
+}
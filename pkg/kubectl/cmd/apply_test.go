@@ -0,0 +1,150 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl"
+)
+
+const applyFilename = "../../../examples/guestbook/redis-master-controller.yaml"
+
+func TestApplyObject(t *testing.T) {
+	// The last applied configuration carried an extra label that has since
+	// been removed from the file, and a controller added an annotation.
+	original := `{"apiVersion":"v1","kind":"ReplicationController","metadata":{"labels":{"name":"redis-master","role":"master"},"name":"redis-master"}}`
+	rc := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:      "redis-master",
+			Namespace: "test",
+			Labels:    map[string]string{"name": "redis-master", "role": "master"},
+			Annotations: map[string]string{
+				kubectl.LastAppliedConfigAnnotation: original,
+				"other":                             "value",
+			},
+		},
+		Spec: api.ReplicationControllerSpec{Replicas: 1},
+	}
+
+	var patch map[string]interface{}
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err := json.Unmarshal(data, &patch); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdApply(f, buf)
+	cmd.Flags().Set("filename", applyFilename)
+	cmd.Flags().Set("output", "name")
+	cmd.Run(cmd, []string{})
+
+	if buf.String() != "replicationcontroller/redis-master\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	metadata, _ := patch["metadata"].(map[string]interface{})
+	labels, _ := metadata["labels"].(map[string]interface{})
+	if value, found := labels["role"]; !found || value != nil {
+		t.Errorf("expected the removed label to be deleted, got patch: %#v", patch)
+	}
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	if _, found := annotations["other"]; found {
+		t.Errorf("expected annotations set by others to be left alone, got patch: %#v", patch)
+	}
+	if value, _ := annotations[kubectl.LastAppliedConfigAnnotation].(string); len(value) == 0 || value == original {
+		t.Errorf("expected the last applied configuration to be updated, got patch: %#v", patch)
+	}
+	if spec, found := patch["spec"].(map[string]interface{}); found {
+		if _, found := spec["replicas"]; found {
+			t.Errorf("expected unchanged replicas to be left out, got patch: %#v", patch)
+		}
+	}
+}
+
+func TestApplyNewObject(t *testing.T) {
+	_, _, rc := testData()
+	notFoundError := &errors.NewNotFound("ReplicationController", "redis-master").(*errors.StatusError).ErrStatus
+
+	var created *api.ReplicationController
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+				return &http.Response{StatusCode: 404, Body: objBody(codec, notFoundError)}, nil
+			case p == "/namespaces/test/replicationcontrollers" && m == "POST":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				obj, err := codec.Decode(data)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				created = obj.(*api.ReplicationController)
+				return &http.Response{StatusCode: 201, Body: objBody(codec, &rc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdApply(f, buf)
+	cmd.Flags().Set("filename", applyFilename)
+	cmd.Flags().Set("output", "name")
+	cmd.Run(cmd, []string{})
+
+	if buf.String() != "replicationcontroller/rc1\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	if created == nil || len(created.Annotations[kubectl.LastAppliedConfigAnnotation]) == 0 {
+		t.Errorf("expected the created object to record the applied configuration, got %#v", created)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kresource "k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	autoscale_long = `Creates an autoscaler that automatically chooses and sets the number of pods that run in the
+replication controller or deployment.
+
+Looks up a replication controller or deployment by name and creates a horizontal pod autoscaler
+which keeps the number of its pods between --min and --max. The autoscaler adds pods when their
+average CPU consumption rises above --cpu-percent of the CPU they request, and removes them when
+it falls below.`
+	autoscale_example = `# Auto scale a replication controller "foo", with the number of pods between 2 to 10, target CPU utilization at 80%:
+$ kubectl autoscale rc foo --min=2 --max=10
+
+# Auto scale a replication controller "foo", with the number of pods between 1 to 5, target CPU utilization at 60%:
+$ kubectl autoscale rc foo --max=5 --cpu-percent=60`
+)
+
+func NewCmdAutoscale(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "autoscale (-f FILENAME | TYPE NAME | TYPE/NAME) [--min=MINPODS] --max=MAXPODS [--cpu-percent=CPU] [flags]",
+		Short:   "Auto-scale a replication controller or deployment",
+		Long:    autoscale_long,
+		Example: autoscale_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunAutoscale(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmdutil.AddPrinterFlags(cmd)
+	cmd.Flags().String("generator", "horizontalpodautoscaler/v1", "The name of the API generator to use. Currently there is only 1 generator.")
+	cmd.Flags().Int("min", 1, "The lower limit for the number of pods that can be set by the autoscaler.")
+	cmd.Flags().Int("max", -1, "The upper limit for the number of pods that can be set by the autoscaler. Required.")
+	cmd.MarkFlagRequired("max")
+	cmd.Flags().Int("cpu-percent", 80, "The target average CPU utilization, as a percentage of the CPU requested by each pod.")
+	cmd.Flags().String("name", "", "The name for the newly created object. If not specified, the name of the input resource will be used.")
+	cmd.Flags().Bool("dry-run", false, "If true, only print the object that would be sent, without creating it.")
+	usage := "Filename, directory, or URL to a file identifying the resource to autoscale."
+	kubectl.AddJsonFilenameFlag(cmd, usage)
+	return cmd
+}
+
+func RunAutoscale(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if cmdutil.GetFlagInt(cmd, "max") < 1 {
+		return cmdutil.UsageError(cmd, "--max=MAXPODS is required and must be at least 1")
+	}
+	cpuPercent := cmdutil.GetFlagInt(cmd, "cpu-percent")
+	if cpuPercent < 1 {
+		return cmdutil.UsageError(cmd, "--cpu-percent must be at least 1")
+	}
+
+	namespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	r := kresource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		ContinueOnError().
+		NamespaceParam(namespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, cmdutil.GetFlagStringSlice(cmd, "filename")...).
+		ResourceTypeOrNameArgs(false, args...).
+		Flatten().
+		Do()
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) > 1 {
+		return fmt.Errorf("multiple resources provided: %v", args)
+	}
+	info := infos[0]
+	mapping := info.ResourceMapping()
+	switch mapping.Kind {
+	case "ReplicationController", "Deployment":
+	default:
+		return fmt.Errorf("cannot autoscale a %s", mapping.Kind)
+	}
+
+	// The autoscaler targets an absolute amount of CPU per pod, so derive it
+	// from what the pods request.
+	request, err := cpuRequestForObject(info.Object)
+	if err != nil {
+		return err
+	}
+	if request.MilliValue() == 0 {
+		return fmt.Errorf("cannot autoscale %s %q: its pods do not request any CPU", mapping.Kind, info.Name)
+	}
+	target := resource.NewMilliQuantity(request.MilliValue()*int64(cpuPercent)/100, resource.DecimalSI)
+
+	// Get the generator, setup and validate all required parameters
+	generatorName := cmdutil.GetFlagString(cmd, "generator")
+	generator, found := f.Generator(generatorName)
+	if !found {
+		return cmdutil.UsageError(cmd, "generator %q not found.", generatorName)
+	}
+	names := generator.ParamNames()
+	params := kubectl.MakeParams(cmd, names)
+	params["default-name"] = info.Name
+	params["scaleRef-kind"] = mapping.Kind
+	params["scaleRef-name"] = info.Name
+	params["scaleRef-namespace"] = info.Namespace
+	params["scaleRef-apiVersion"] = mapping.APIVersion
+	params["cpu"] = target.String()
+	if err = kubectl.ValidateParams(names, params); err != nil {
+		return err
+	}
+
+	object, err := generator.Generate(params)
+	if err != nil {
+		return err
+	}
+
+	if !cmdutil.GetFlagBool(cmd, "dry-run") {
+		resourceMapper := &kresource.Mapper{ObjectTyper: typer, RESTMapper: mapper, ClientMapper: f.ClientMapperForCommand()}
+		hpa, err := resourceMapper.InfoForObject(object)
+		if err != nil {
+			return err
+		}
+		data, err := hpa.Mapping.Codec.Encode(object)
+		if err != nil {
+			return err
+		}
+		object, err = kresource.NewHelper(hpa.Client, hpa.Mapping).Create(namespace, false, data)
+		if err != nil {
+			return err
+		}
+	}
+	return f.PrintObject(cmd, object, out)
+}
+
+// cpuRequestForObject returns the CPU requested by each pod of a replication
+// controller or deployment. Containers which do not request CPU count their
+// limit instead.
+func cpuRequestForObject(object runtime.Object) (*resource.Quantity, error) {
+	var template *api.PodTemplateSpec
+	switch t := object.(type) {
+	case *api.ReplicationController:
+		template = t.Spec.Template
+	case *expapi.Deployment:
+		template = t.Spec.Template
+	default:
+		return nil, fmt.Errorf("cannot find the pod template of %T", object)
+	}
+	total := int64(0)
+	if template == nil {
+		return resource.NewMilliQuantity(total, resource.DecimalSI), nil
+	}
+	for _, container := range template.Spec.Containers {
+		if request, found := container.Resources.Requests[api.ResourceCPU]; found {
+			total += request.MilliValue()
+		} else if limit, found := container.Resources.Limits[api.ResourceCPU]; found {
+			total += limit.MilliValue()
+		}
+	}
+	return resource.NewMilliQuantity(total, resource.DecimalSI), nil
+}
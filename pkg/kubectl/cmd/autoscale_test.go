@@ -0,0 +1,153 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/resource"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func autoscaleTestController(cpuRequest string) *api.ReplicationController {
+	container := api.Container{Name: "foo", Image: "foo:v1"}
+	if len(cpuRequest) > 0 {
+		container.Resources.Requests = api.ResourceList{api.ResourceCPU: resource.MustParse(cpuRequest)}
+	}
+	return &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10"},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 1,
+			Selector: map[string]string{"app": "foo"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec:       api.PodSpec{Containers: []api.Container{container}},
+			},
+		},
+	}
+}
+
+func TestRunAutoscale(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   string
+		flags     map[string]string
+		expected  *expapi.HorizontalPodAutoscalerSpec
+		expectErr string
+	}{
+		{
+			name:    "percent of the requested cpu",
+			request: "500m",
+			flags:   map[string]string{"max": "5", "cpu-percent": "60"},
+			expected: &expapi.HorizontalPodAutoscalerSpec{
+				MinCount: 1,
+				MaxCount: 5,
+				Target:   expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("300m")},
+			},
+		},
+		{
+			name:    "default percent",
+			request: "1",
+			flags:   map[string]string{"min": "2", "max": "10"},
+			expected: &expapi.HorizontalPodAutoscalerSpec{
+				MinCount: 2,
+				MaxCount: 10,
+				Target:   expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("800m")},
+			},
+		},
+		{
+			name:      "no cpu request",
+			flags:     map[string]string{"max": "5"},
+			expectErr: "do not request any CPU",
+		},
+		{
+			name:      "max below min",
+			request:   "1",
+			flags:     map[string]string{"min": "3", "max": "2"},
+			expectErr: "'max' must be at least 'min'",
+		},
+		{
+			name:      "missing max",
+			request:   "1",
+			expectErr: "--max=MAXPODS is required",
+		},
+	}
+	for _, test := range tests {
+		rc := autoscaleTestController(test.request)
+		f, tf, codec := NewAPIFactory()
+		// The test mapper only knows the core API; autoscalers are experimental.
+		f.Object = func() (meta.RESTMapper, runtime.ObjectTyper) {
+			return api.RESTMapper, api.Scheme
+		}
+		tf.Printer = &testPrinter{}
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/replicationcontrollers/foo" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", test.name, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdAutoscale(f, buf)
+		cmd.Flags().Set("dry-run", "true")
+		for flag, value := range test.flags {
+			cmd.Flags().Set(flag, value)
+		}
+		err := RunAutoscale(f, buf, cmd, []string{"replicationcontrollers", "foo"})
+		if len(test.expectErr) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.expectErr) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.expectErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		objects := tf.Printer.(*testPrinter).Objects
+		if len(objects) != 1 {
+			t.Errorf("%s: expected one printed object, got %#v", test.name, objects)
+			continue
+		}
+		hpa := objects[0].(*expapi.HorizontalPodAutoscaler)
+		expected := *test.expected
+		expected.ScaleRef = &expapi.SubresourceReference{
+			Kind:        "ReplicationController",
+			Name:        "foo",
+			Namespace:   "test",
+			APIVersion:  hpa.Spec.ScaleRef.APIVersion,
+			Subresource: "scale",
+		}
+		if hpa.Name != "foo" || !api.Semantic.DeepEqual(expected, hpa.Spec) {
+			t.Errorf("%s: expected\n%#v\ngot\n%#v", test.name, expected, hpa.Spec)
+		}
+	}
+}
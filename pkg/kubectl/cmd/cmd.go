@@ -112,6 +112,8 @@ __custom_func() {
    * persistentvolumeclaims (aka 'pvc')
    * limitranges (aka 'limits')
    * resourcequotas (aka 'quota')
+   * horizontalpodautoscalers (aka 'hpa')
+   * networkpolicies
 `
 )
 
@@ -135,24 +137,36 @@ Find more information at https://github.com/kubernetes/kubernetes.`,
 
 	cmds.AddCommand(NewCmdGet(f, out))
 	cmds.AddCommand(NewCmdDescribe(f, out))
+	cmds.AddCommand(NewCmdExplain(f, out))
 	cmds.AddCommand(NewCmdCreate(f, out))
+	cmds.AddCommand(NewCmdDiff(f, out))
 	cmds.AddCommand(NewCmdReplace(f, out))
+	cmds.AddCommand(NewCmdEdit(f, out))
+	cmds.AddCommand(NewCmdApply(f, out))
 	cmds.AddCommand(NewCmdPatch(f, out))
 	cmds.AddCommand(NewCmdDelete(f, out))
 
 	cmds.AddCommand(NewCmdNamespace(out))
 	cmds.AddCommand(NewCmdLog(f, out))
 	cmds.AddCommand(NewCmdRollingUpdate(f, out))
+	cmds.AddCommand(NewCmdRollout(f, out))
+	cmds.AddCommand(NewCmdWait(f, out))
 	cmds.AddCommand(NewCmdScale(f, out))
+	cmds.AddCommand(NewCmdCordon(f, out))
+	cmds.AddCommand(NewCmdUncordon(f, out))
+	cmds.AddCommand(NewCmdDrain(f, out))
+	cmds.AddCommand(NewCmdTop(f, out))
 
 	cmds.AddCommand(NewCmdAttach(f, in, out, err))
 	cmds.AddCommand(NewCmdExec(f, in, out, err))
+	cmds.AddCommand(NewCmdCp(f, out, err))
 	cmds.AddCommand(NewCmdPortForward(f))
 	cmds.AddCommand(NewCmdProxy(f, out))
 
 	cmds.AddCommand(NewCmdRun(f, in, out, err))
 	cmds.AddCommand(NewCmdStop(f, out))
 	cmds.AddCommand(NewCmdExposeService(f, out))
+	cmds.AddCommand(NewCmdAutoscale(f, out))
 
 	cmds.AddCommand(NewCmdLabel(f, out))
 	cmds.AddCommand(NewCmdAnnotate(f, out))
@@ -186,9 +186,10 @@ func NewAPIFactory() (*cmdutil.Factory, *testFactory, runtime.Codec) {
 		Validator: validation.NullSchema{},
 	}
 	generators := map[string]kubectl.Generator{
-		"run/v1":     kubectl.BasicReplicationController{},
-		"service/v1": kubectl.ServiceGeneratorV1{},
-		"service/v2": kubectl.ServiceGeneratorV2{},
+		"run/v1":                     kubectl.BasicReplicationController{},
+		"service/v1":                 kubectl.ServiceGeneratorV1{},
+		"service/v2":                 kubectl.ServiceGeneratorV2{},
+		"horizontalpodautoscaler/v1": kubectl.HorizontalPodAutoscalerV1{},
 	}
 	return &cmdutil.Factory{
 		Object: func() (meta.RESTMapper, runtime.ObjectTyper) {
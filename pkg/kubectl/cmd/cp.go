@@ -0,0 +1,399 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	cp_long = `Copy files and directories to and from containers.
+
+Either the source or the destination is a path in a container, given as [NAMESPACE/]POD:PATH.
+Files are sent as a tar stream over the same connection as 'kubectl exec', so the 'tar'
+binary must be present in the container. When copying out of a container, entries which
+would be written outside of the destination, including through symbolic links, are skipped.`
+	cp_example = `# Copy /tmp/foo_dir local directory to /tmp/bar_dir in a remote pod in the default namespace
+$ kubectl cp /tmp/foo_dir some-pod:/tmp/bar_dir
+
+# Copy /tmp/foo local file to /tmp/bar in a remote pod in a specific container
+$ kubectl cp /tmp/foo some-pod:/tmp/bar -c some-container
+
+# Copy /tmp/foo from a remote pod in namespace some-namespace to /tmp/bar locally
+$ kubectl cp some-namespace/some-pod:/tmp/foo /tmp/bar`
+)
+
+func NewCmdCp(f *cmdutil.Factory, cmdOut, cmdErr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cp SRC DEST [-c CONTAINER]",
+		Short:   "Copy files and directories to and from containers.",
+		Long:    cp_long,
+		Example: cp_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunCp(f, cmd, cmdOut, cmdErr, args, &DefaultRemoteExecutor{})
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringP("container", "c", "", "Container name. If omitted, the first container in the pod will be chosen")
+	return cmd
+}
+
+// fileSpec is one side of a copy: a local path if PodName is empty, and a
+// path in a container otherwise.
+type fileSpec struct {
+	PodNamespace string
+	PodName      string
+	File         string
+}
+
+// extractFileSpec parses [[NAMESPACE/]POD:]PATH. Local paths containing a
+// colon have to start with "/" or "." to be told apart from paths in a pod.
+func extractFileSpec(arg string) (fileSpec, error) {
+	i := strings.Index(arg, ":")
+	if i == -1 || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return fileSpec{File: arg}, nil
+	}
+	pod, file := arg[:i], arg[i+1:]
+	if len(file) == 0 {
+		return fileSpec{}, fmt.Errorf("a path in the container is required in %q", arg)
+	}
+	parts := strings.Split(pod, "/")
+	switch {
+	case len(parts) == 1 && len(parts[0]) > 0:
+		return fileSpec{PodName: parts[0], File: file}, nil
+	case len(parts) == 2 && len(parts[0]) > 0 && len(parts[1]) > 0:
+		return fileSpec{PodNamespace: parts[0], PodName: parts[1], File: file}, nil
+	}
+	return fileSpec{}, fmt.Errorf("unexpected pod specification %q, expected [NAMESPACE/]POD:PATH", pod)
+}
+
+func RunCp(f *cmdutil.Factory, cmd *cobra.Command, out, errOut io.Writer, args []string, executor RemoteExecutor) error {
+	if len(args) != 2 {
+		return cmdutil.UsageError(cmd, "SRC and DEST are required for cp")
+	}
+	src, err := extractFileSpec(args[0])
+	if err != nil {
+		return err
+	}
+	dest, err := extractFileSpec(args[1])
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(src.PodName) > 0 && len(dest.PodName) > 0:
+		return cmdutil.UsageError(cmd, "copying between two containers is not supported")
+	case len(src.PodName) > 0:
+		return copyFromPod(f, cmd, errOut, src, dest, executor)
+	case len(dest.PodName) > 0:
+		return copyToPod(f, cmd, out, errOut, src, dest, executor)
+	}
+	return cmdutil.UsageError(cmd, "one of SRC or DEST must be a path in a container")
+}
+
+// execOptions returns the options to run command in the container of spec.
+func execOptions(f *cmdutil.Factory, cmd *cobra.Command, spec fileSpec, command []string, executor RemoteExecutor) (*ExecOptions, error) {
+	namespace := spec.PodNamespace
+	if len(namespace) == 0 {
+		var err error
+		if namespace, _, err = f.DefaultNamespace(); err != nil {
+			return nil, err
+		}
+	}
+	config, err := f.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return nil, err
+	}
+	return &ExecOptions{
+		Namespace:     namespace,
+		PodName:       spec.PodName,
+		ContainerName: cmdutil.GetFlagString(cmd, "container"),
+		Command:       command,
+
+		Executor: executor,
+		Client:   client,
+		Config:   config,
+	}, nil
+}
+
+func copyToPod(f *cmdutil.Factory, cmd *cobra.Command, out, errOut io.Writer, src, dest fileSpec, executor RemoteExecutor) error {
+	if _, err := os.Lstat(src.File); err != nil {
+		return err
+	}
+	destFile := path.Clean(dest.File)
+	destDir := path.Dir(destFile)
+	options, err := execOptions(f, cmd, dest, []string{"tar", "xf", "-", "-C", destDir}, executor)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	// Unblocks the writer if the remote command stops reading early.
+	defer reader.Close()
+	go func() {
+		writer.CloseWithError(makeTar(src.File, path.Base(destFile), writer))
+	}()
+	options.Stdin = true
+	options.In = reader
+	options.Out = out
+	options.Err = errOut
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	return options.Run()
+}
+
+func copyFromPod(f *cmdutil.Factory, cmd *cobra.Command, errOut io.Writer, src, dest fileSpec, executor RemoteExecutor) error {
+	srcFile := path.Clean(src.File)
+	options, err := execOptions(f, cmd, src, []string{"tar", "cf", "-", srcFile}, executor)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	options.Out = writer
+	options.Err = errOut
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := options.Run()
+		writer.CloseWithError(err)
+		done <- err
+	}()
+	// tar strips the leading slash from the names of the entries it writes.
+	prefix := strings.TrimLeft(srcFile, "/")
+	err = untarAll(reader, dest.File, prefix, errOut)
+	// Drain the stream so that the remote command can finish.
+	io.Copy(ioutil.Discard, reader)
+	if execErr := <-done; execErr != nil {
+		return execErr
+	}
+	return err
+}
+
+// makeTar writes srcPath, and everything below it if it is a directory, to
+// writer as a tar stream whose entries are named starting with destName.
+func makeTar(srcPath, destName string, writer io.Writer) error {
+	tw := tar.NewWriter(writer)
+	if err := addToTar(tw, srcPath, destName); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func addToTar(tw *tar.Writer, srcPath, name string) error {
+	stat, err := os.Lstat(srcPath)
+	if err != nil {
+		return err
+	}
+	link := ""
+	if stat.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(srcPath); err != nil {
+			return err
+		}
+	}
+	hdr, err := tar.FileInfoHeader(stat, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if stat.IsDir() {
+		hdr.Name += "/"
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	switch {
+	case stat.IsDir():
+		files, err := ioutil.ReadDir(srcPath)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := addToTar(tw, filepath.Join(srcPath, file.Name()), path.Join(name, file.Name())); err != nil {
+				return err
+			}
+		}
+	case stat.Mode().IsRegular():
+		f, err := os.Open(srcPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// untarAll extracts the entries of the tar stream in reader whose names
+// start with prefix into destPath, which takes the place of prefix. Entries
+// which would end up outside of destPath once symbolic links are resolved,
+// and symbolic links pointing out of it, are skipped with a warning to
+// errOut. Existing files and links are replaced rather than written through.
+func untarAll(reader io.Reader, destPath, prefix string, errOut io.Writer) error {
+	destPath = filepath.Clean(destPath)
+	root, err := resolvePath(destPath)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(hdr.Name)
+		var rel string
+		switch {
+		case len(prefix) == 0:
+			rel = name
+		case name == prefix:
+			rel = ""
+		case strings.HasPrefix(name, prefix+"/"):
+			rel = name[len(prefix)+1:]
+		default:
+			fmt.Fprintf(errOut, "warning: skipping %q, it is not under %q\n", hdr.Name, prefix)
+			continue
+		}
+		target := filepath.Join(destPath, filepath.FromSlash(rel))
+		if len(rel) > 0 {
+			within, err := resolvesWithin(target, root)
+			if err != nil {
+				return err
+			}
+			if !within {
+				fmt.Fprintf(errOut, "warning: skipping %q, it would be written outside of %q\n", hdr.Name, destPath)
+				continue
+			}
+		}
+
+		mode := hdr.FileInfo().Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, mode.Perm()|0700); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			link := hdr.Linkname
+			if filepath.IsAbs(link) || !isWithin(filepath.Join(filepath.Dir(target), link), destPath) {
+				fmt.Fprintf(errOut, "warning: skipping symlink %q -> %q, it points outside of %q\n", hdr.Name, link, destPath)
+				continue
+			}
+			if err := prepareFile(target); err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			if err := prepareFile(target); err != nil {
+				return err
+			}
+			if err := writeFile(target, mode.Perm(), tr); err != nil {
+				return err
+			}
+		default:
+			fmt.Fprintf(errOut, "warning: skipping %q, it is not a regular file, directory or symlink\n", hdr.Name)
+		}
+	}
+}
+
+// prepareFile creates the parent directories of target and removes whatever
+// other than a directory is at target, so that creating it does not follow
+// an existing symbolic link.
+func prepareFile(target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	stat, err := os.Lstat(target)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case stat.IsDir():
+		return fmt.Errorf("cannot replace directory %q with a file", target)
+	}
+	return os.Remove(target)
+}
+
+func writeFile(target string, perm os.FileMode, reader io.Reader) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+// resolvePath returns file with the symbolic links in the part of it which
+// exists resolved.
+func resolvePath(file string) (string, error) {
+	rest := ""
+	for dir := file; ; dir = filepath.Dir(dir) {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !os.IsNotExist(err) || dir == filepath.Dir(dir) {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
+
+// resolvesWithin returns whether file ends up within root once the symbolic
+// links in its parent directories are resolved. root must be resolved.
+func resolvesWithin(file, root string) (bool, error) {
+	parent, err := resolvePath(filepath.Dir(file))
+	if err != nil {
+		return false, err
+	}
+	return isWithin(filepath.Join(parent, filepath.Base(file)), root), nil
+}
+
+// isWithin returns whether the cleaned file is dir or below it.
+func isWithin(file, dir string) bool {
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func TestExtractFileSpec(t *testing.T) {
+	tests := []struct {
+		arg         string
+		expected    fileSpec
+		expectError bool
+	}{
+		{arg: "foo/bar", expected: fileSpec{File: "foo/bar"}},
+		{arg: "/tmp/a:b", expected: fileSpec{File: "/tmp/a:b"}},
+		{arg: "./a:b", expected: fileSpec{File: "./a:b"}},
+		{arg: "pod:/tmp/foo", expected: fileSpec{PodName: "pod", File: "/tmp/foo"}},
+		{arg: "ns/pod:/tmp/foo", expected: fileSpec{PodNamespace: "ns", PodName: "pod", File: "/tmp/foo"}},
+		{arg: "pod:", expectError: true},
+		{arg: "a/b/c:/tmp/foo", expectError: true},
+		{arg: ":/tmp/foo", expectError: true},
+	}
+	for _, test := range tests {
+		spec, err := extractFileSpec(test.arg)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.arg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.arg, err)
+			continue
+		}
+		if spec != test.expected {
+			t.Errorf("%s: expected %#v, got %#v", test.arg, test.expected, spec)
+		}
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func checkTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, string(data))
+		}
+	}
+}
+
+func TestTarUntar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a":         "a content",
+		"sub/b":     "b content",
+		"sub/sub/c": "c content",
+	}
+	writeTestFiles(t, filepath.Join(dir, "src"), files)
+	if err := os.Symlink("sub/b", filepath.Join(dir, "src", "link")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := makeTar(filepath.Join(dir, "src"), "tmp/copy", buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errOut := &bytes.Buffer{}
+	if err := untarAll(buf, filepath.Join(dir, "dest"), "tmp/copy", errOut); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", errOut.String())
+	}
+	files["link"] = files["sub/b"]
+	checkTestFiles(t, filepath.Join(dir, "dest"), files)
+}
+
+type testTarEntry struct {
+	name, link, content string
+	dir                 bool
+}
+
+func makeTestTar(t *testing.T, entries []testTarEntry) io.Reader {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, entry := range entries {
+		hdr := &tar.Header{Name: entry.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(entry.content))}
+		switch {
+		case entry.dir:
+			hdr.Typeflag, hdr.Mode = tar.TypeDir, 0755
+		case len(entry.link) > 0:
+			hdr.Typeflag, hdr.Linkname = tar.TypeSymlink, entry.link
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := tw.Write([]byte(entry.content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf
+}
+
+func TestUntarOutsideDestination(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "dest")
+
+	entries := []testTarEntry{
+		{name: "root/", dir: true},
+		{name: "root/../evil", content: "traversal"},
+		{name: "other/evil", content: "other prefix"},
+		{name: "root/abs", link: "/etc"},
+		{name: "root/up", link: "../"},
+		// Each link stays within dest as far as its own target goes, but
+		// together they lead out of it.
+		{name: "root/self", link: "."},
+		{name: "root/parent", link: "self/.."},
+		{name: "root/parent/evil", content: "chained links"},
+		{name: "root/abs/evil", content: "absolute link"},
+		{name: "root/ok", content: "ok"},
+	}
+	errOut := &bytes.Buffer{}
+	if err := untarAll(makeTestTar(t, entries), dest, "root", errOut); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTestFiles(t, dest, map[string]string{"ok": "ok"})
+	if _, err := os.Lstat(filepath.Join(dir, "evil")); !os.IsNotExist(err) {
+		t.Errorf("expected no file outside of the destination, got %v", err)
+	}
+	for _, name := range []string{"abs", "up"} {
+		if stat, err := os.Lstat(filepath.Join(dest, name)); err == nil && stat.Mode()&os.ModeSymlink != 0 {
+			t.Errorf("expected symlink %s to be skipped", name)
+		}
+	}
+	if errOut.Len() == 0 {
+		t.Errorf("expected warnings")
+	}
+}
+
+func TestUntarReplacesSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "dest")
+
+	entries := []testTarEntry{
+		{name: "root/", dir: true},
+		{name: "root/self", link: "."},
+		{name: "root/link", link: "self/../outside"},
+		{name: "root/link", content: "replaced"},
+	}
+	if err := untarAll(makeTestTar(t, entries), dest, "root", &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkTestFiles(t, dest, map[string]string{"link": "replaced"})
+	if _, err := os.Lstat(filepath.Join(dir, "outside")); !os.IsNotExist(err) {
+		t.Errorf("expected no file outside of the destination, got %v", err)
+	}
+}
+
+type fakeCpExecutor struct {
+	command []string
+	path    string
+	stdin   []byte
+	stdout  []byte
+}
+
+func (f *fakeCpExecutor) Execute(req *client.Request, config *client.Config, command []string, stdin io.Reader, stdout, stderr io.Writer, tty bool) error {
+	f.command = command
+	f.path = req.URL().Path
+	if stdin != nil {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		f.stdin = data
+	}
+	_, err := stdout.Write(f.stdout)
+	return err
+}
+
+func TestCp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFiles(t, dir, map[string]string{"src/a": "a content"})
+
+	remote := &bytes.Buffer{}
+	if err := makeTar(filepath.Join(dir, "src"), "tmp/foo", remote); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	version := testapi.Version()
+	tests := []struct {
+		name            string
+		args            []string
+		namespace       string
+		podPath         string
+		expectedCommand []string
+		expectStdin     bool
+		expectedFiles   map[string]string
+	}{
+		{
+			name:            "to pod",
+			args:            []string{filepath.Join(dir, "src"), "foo:/tmp/bar"},
+			namespace:       "test",
+			podPath:         "/api/" + version + "/namespaces/test/pods/foo",
+			expectedCommand: []string{"tar", "xf", "-", "-C", "/tmp"},
+			expectStdin:     true,
+		},
+		{
+			name:            "from pod in namespace",
+			args:            []string{"other/foo:/tmp/foo/", filepath.Join(dir, "dest")},
+			namespace:       "other",
+			podPath:         "/api/" + version + "/namespaces/other/pods/foo",
+			expectedCommand: []string{"tar", "cf", "-", "/tmp/foo"},
+			expectedFiles:   map[string]string{"dest/a": "a content"},
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == test.podPath && m == "GET":
+					pod := execPod()
+					pod.Namespace = test.namespace
+					return &http.Response{StatusCode: 200, Body: objBody(codec, pod)}, nil
+				default:
+					t.Errorf("%s: unexpected request: %s %#v\n%#v", test.name, req.Method, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		tf.ClientConfig = &client.Config{Version: version}
+		ex := &fakeCpExecutor{stdout: remote.Bytes()}
+		buf := &bytes.Buffer{}
+
+		cmd := NewCmdCp(f, buf, buf)
+		if err := RunCp(f, cmd, buf, buf, test.args, ex); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(ex.command, test.expectedCommand) {
+			t.Errorf("%s: expected command %v, got %v", test.name, test.expectedCommand, ex.command)
+		}
+		if ex.path != test.podPath+"/exec" {
+			t.Errorf("%s: unexpected exec path %s", test.name, ex.path)
+		}
+		if test.expectStdin {
+			names := []string{}
+			tr := tar.NewReader(bytes.NewReader(ex.stdin))
+			for {
+				hdr, err := tr.Next()
+				if err != nil {
+					break
+				}
+				names = append(names, hdr.Name)
+			}
+			if expected := []string{"bar/", "bar/a"}; !reflect.DeepEqual(names, expected) {
+				t.Errorf("%s: expected tar entries %v, got %v", test.name, expected, names)
+			}
+		}
+		checkTestFiles(t, dir, test.expectedFiles)
+	}
+}
@@ -46,7 +46,7 @@ exists, it will output details for every resource that has a name prefixed with
 Possible resource types include (case insensitive): pods (po), services (svc),
 replicationcontrollers (rc), nodes (no), events (ev), limitranges (limits),
 persistentvolumes (pv), persistentvolumeclaims (pvc), resourcequotas (quota),
-namespaces (ns) or secrets.`
+namespaces (ns), horizontalpodautoscalers (hpa) or secrets.`
 	describe_example = `# Describe a node
 $ kubectl describe nodes kubernetes-minion-emt8.c.myproject.internal
 
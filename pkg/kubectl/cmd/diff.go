@@ -0,0 +1,134 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	diff_long = `Show the differences between resources in files and their live versions.
+
+Looks up each resource in the given files on the server and prints a unified diff from the
+live version to the one in the file. Fields which are populated by the server (status,
+resourceVersion, uid, creationTimestamp and the like) are left out, as are the fields the
+server allocates when the file leaves them unset, e.g. the clusterIP and nodePorts of a
+service. Fields left unset in the file which have defaults are compared with their
+defaults. Any other field only set in the live version, such as a label removed from the
+file, is shown as removed. Resources which do not exist yet are shown as entirely added.
+
+The exit status is 0 if there are no differences, 1 if there are differences and 2 if an
+error occurred.`
+	diff_example = `# Show what 'kubectl replace -f pod.json' would change.
+$ kubectl diff -f pod.json
+
+# Show the differences for all the resources in a directory.
+$ kubectl diff -f ./manifests/`
+)
+
+func NewCmdDiff(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff -f FILENAME",
+		Short:   "Show the differences between resources in files and their live versions.",
+		Long:    diff_long,
+		Example: diff_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			found, err := RunDiff(f, out, cmd, args)
+			cmdutil.CheckErrExitCode(err, 2)
+			if found {
+				os.Exit(1)
+			}
+		},
+	}
+	usage := "Filename, directory, or URL to file containing the resources to compare."
+	kubectl.AddJsonFilenameFlag(cmd, usage)
+	cmd.MarkFlagRequired("filename")
+	cmdutil.AddValidateFlag(cmd)
+	return cmd
+}
+
+// RunDiff prints the differences between the resources in the files given
+// to cmd and their live versions. It returns whether there were any.
+func RunDiff(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) (bool, error) {
+	schema, err := f.Validator(cmdutil.GetFlagBool(cmd, "validate"))
+	if err != nil {
+		return false, err
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return false, err
+	}
+
+	filenames := cmdutil.GetFlagStringSlice(cmd, "filename")
+	if len(filenames) == 0 {
+		return false, cmdutil.UsageError(cmd, "Must specify --filename to diff")
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		Schema(schema).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, filenames...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	err = r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		var live runtime.Object
+		live, err = resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return cmdutil.AddSourceToErr("comparing", info.Source, err)
+			}
+			live = nil
+		}
+		// The namespace of the local object may come from the command line.
+		if len(info.Namespace) > 0 {
+			if err := info.Mapping.MetadataAccessor.SetNamespace(info.Object, info.Namespace); err != nil {
+				return err
+			}
+		}
+		diff, err := kubectl.DiffObjects(fmt.Sprintf("%s/%s", info.Mapping.Resource, info.Name), live, info.Object, info.Mapping.Codec)
+		if err != nil {
+			return cmdutil.AddSourceToErr("comparing", info.Source, err)
+		}
+		if len(diff) > 0 {
+			found = true
+			fmt.Fprint(out, diff)
+		}
+		return nil
+	})
+	return found, err
+}
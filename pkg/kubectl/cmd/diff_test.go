@@ -0,0 +1,133 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func diffTestController() *api.ReplicationController {
+	labels := map[string]string{"name": "redis-master"}
+	return &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Name: "redis-master", Namespace: "test", Labels: labels, ResourceVersion: "10"},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 1,
+			Selector: labels,
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: labels},
+				Spec: api.PodSpec{
+					Containers: []api.Container{{
+						Name:  "master",
+						Image: "redis",
+						Ports: []api.ContainerPort{{ContainerPort: 6379}},
+					}},
+				},
+			},
+		},
+		Status: api.ReplicationControllerStatus{Replicas: 1},
+	}
+}
+
+func runDiffTest(t *testing.T, status int, live func() *api.ReplicationController) (string, bool, error) {
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master" && m == "GET":
+				if status == 404 {
+					notFound := &errors.NewNotFound("ReplicationController", "redis-master").(*errors.StatusError).ErrStatus
+					return &http.Response{StatusCode: 404, Body: objBody(codec, notFound)}, nil
+				}
+				return &http.Response{StatusCode: status, Body: objBody(codec, live())}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdDiff(f, buf)
+	cmd.Flags().Set("filename", "../../../examples/guestbook/redis-master-controller.yaml")
+	found, err := RunDiff(f, buf, cmd, []string{})
+	return buf.String(), found, err
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	out, found, err := runDiffTest(t, 200, diffTestController)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found || out != "" {
+		t.Errorf("expected no differences, got:\n%s", out)
+	}
+}
+
+func TestDiffChanged(t *testing.T) {
+	out, found, err := runDiffTest(t, 200, func() *api.ReplicationController {
+		rc := diffTestController()
+		rc.Spec.Replicas = 3
+		return rc
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found {
+		t.Errorf("expected differences")
+	}
+	for _, expected := range []string{"--- live/replicationcontrollers/redis-master\n", "-  replicas: 3\n", "+  replicas: 1\n"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, out)
+		}
+	}
+}
+
+func TestDiffRemovedLabel(t *testing.T) {
+	out, found, err := runDiffTest(t, 200, func() *api.ReplicationController {
+		rc := diffTestController()
+		rc.Labels = map[string]string{"name": "redis-master", "tier": "backend"}
+		return rc
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found {
+		t.Errorf("expected the label removed from the file to be a difference")
+	}
+	if !strings.Contains(out, "-    tier: backend\n") {
+		t.Errorf("expected the removed label in output:\n%s", out)
+	}
+}
+
+func TestDiffNotFound(t *testing.T) {
+	out, found, err := runDiffTest(t, 404, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found || !strings.Contains(out, "+kind: ReplicationController\n") {
+		t.Errorf("expected the whole object to be added, got:\n%s", out)
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/latest"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/fields"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+// DrainOptions have the data required to perform the cordon, uncordon and
+// drain operations
+type DrainOptions struct {
+	factory  *cmdutil.Factory
+	client   *client.Client
+	out      io.Writer
+	nodeName string
+
+	force              bool
+	gracePeriodSeconds int
+	timeout            time.Duration
+}
+
+// mirrorPodAnnotation marks pods that the kubelet created from a static
+// manifest; they are not managed through the API server and cannot be deleted.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+const (
+	cordon_long = `Mark node as unschedulable.
+
+New pods will not be scheduled onto the node, but pods already running on it are left alone.`
+	cordon_example = `# Mark node "foo" as unschedulable.
+$ kubectl cordon foo`
+
+	uncordon_long    = `Mark node as schedulable.`
+	uncordon_example = `# Mark node "foo" as schedulable.
+$ kubectl uncordon foo`
+
+	drain_long = `Drain node in preparation for maintenance.
+
+The given node will be marked unschedulable to prevent new pods from arriving.
+Then drain deletes all pods on the node, honoring their grace periods, and waits
+until they are gone. Pods managed by a replication controller or daemon are
+deleted and recreated elsewhere by their controller. If there are pods that are
+not managed by a replication controller or daemon, drain will not delete any
+pods unless you use --force. Mirror pods, which kubelets create from static
+manifests, cannot be deleted through the API server and are left alone.
+
+When you are ready to put the node back into service, use 'kubectl uncordon',
+which will make the node schedulable again.`
+	drain_example = `# Drain node "foo", even if there are pods not managed by a replication controller or daemon on it.
+$ kubectl drain foo --force
+
+# As above, but give each pod 15 minutes to terminate, and wait at most 20 minutes in total.
+$ kubectl drain foo --grace-period=900 --timeout=20m`
+)
+
+func NewCmdCordon(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{factory: f, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "cordon NODE",
+		Short:   "Mark node as unschedulable",
+		Long:    cordon_long,
+		Example: cordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(cmd, args))
+			cmdutil.CheckErr(options.RunCordonOrUncordon(true))
+		},
+	}
+	return cmd
+}
+
+func NewCmdUncordon(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{factory: f, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "uncordon NODE",
+		Short:   "Mark node as schedulable",
+		Long:    uncordon_long,
+		Example: uncordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(cmd, args))
+			cmdutil.CheckErr(options.RunCordonOrUncordon(false))
+		},
+	}
+	return cmd
+}
+
+func NewCmdDrain(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	options := &DrainOptions{factory: f, out: out}
+
+	cmd := &cobra.Command{
+		Use:     "drain NODE [--force] [--grace-period=SECONDS] [--timeout=DURATION]",
+		Short:   "Drain node in preparation for maintenance",
+		Long:    drain_long,
+		Example: drain_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(options.Complete(cmd, args))
+			cmdutil.CheckErr(options.RunDrain())
+		},
+	}
+	cmd.Flags().BoolVar(&options.force, "force", false, "Continue even if there are pods not managed by a replication controller or daemon.")
+	cmd.Flags().IntVar(&options.gracePeriodSeconds, "grace-period", -1, "Period of time in seconds given to each pod to terminate gracefully. If negative, the default value specified in the pod will be used.")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", 0, "The length of time to wait for the pods to be deleted, zero means wait forever.")
+	return cmd
+}
+
+// Complete populates some fields from the factory, grabs command line
+// arguments and looks up the node using Builder
+func (o *DrainOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageError(cmd, "USAGE: %s NODE", cmd.Use)
+	}
+	o.nodeName = args[0]
+
+	var err error
+	o.client, err = o.factory.Client()
+	return err
+}
+
+// RunCordonOrUncordon marks the node unschedulable if desired is true, and
+// schedulable otherwise.
+func (o *DrainOptions) RunCordonOrUncordon(desired bool) error {
+	node, err := o.client.Nodes().Get(o.nodeName)
+	if err != nil {
+		return err
+	}
+	mapper, _ := o.factory.Object()
+	if node.Spec.Unschedulable == desired {
+		cmdutil.PrintSuccess(mapper, false, o.out, "nodes", o.nodeName, already(desired))
+		return nil
+	}
+	node.Spec.Unschedulable = desired
+	if _, err := o.client.Nodes().Update(node); err != nil {
+		return err
+	}
+	cmdutil.PrintSuccess(mapper, false, o.out, "nodes", o.nodeName, changed(desired))
+	return nil
+}
+
+func already(desired bool) string {
+	if desired {
+		return "already cordoned"
+	}
+	return "already uncordoned"
+}
+
+func changed(desired bool) string {
+	if desired {
+		return "cordoned"
+	}
+	return "uncordoned"
+}
+
+// RunDrain cordons the node, then deletes its pods and waits until they are
+// gone.
+func (o *DrainOptions) RunDrain() error {
+	if err := o.RunCordonOrUncordon(true); err != nil {
+		return err
+	}
+
+	pods, err := o.getPodsForDeletion()
+	if err != nil {
+		return err
+	}
+	if err := o.deletePods(pods); err != nil {
+		return err
+	}
+
+	mapper, _ := o.factory.Object()
+	cmdutil.PrintSuccess(mapper, false, o.out, "nodes", o.nodeName, "drained")
+	return nil
+}
+
+// getPodsForDeletion returns the pods on the node that should be deleted. It
+// fails if there are pods that are not managed by a replication controller
+// or daemon and --force was not given.
+func (o *DrainOptions) getPodsForDeletion() ([]api.Pod, error) {
+	podList, err := o.client.Pods(api.NamespaceAll).List(labels.Everything(), fields.SelectorFromSet(fields.Set{client.PodHost: o.nodeName}))
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []api.Pod{}
+	unreplicated := []string{}
+	for _, pod := range podList.Items {
+		if _, found := pod.Annotations[mirrorPodAnnotation]; found {
+			continue
+		}
+		replicated, err := o.isReplicated(&pod)
+		if err != nil {
+			return nil, err
+		}
+		if !replicated {
+			unreplicated = append(unreplicated, pod.Name)
+		}
+		pods = append(pods, pod)
+	}
+
+	if len(unreplicated) > 0 && !o.force {
+		return nil, fmt.Errorf("refusing to continue because the following pods are not managed by a replication controller or daemon: %s (use --force to override)", strings.Join(unreplicated, ", "))
+	}
+	if len(unreplicated) > 0 {
+		fmt.Fprintf(o.out, "WARNING: deleting pods not managed by a replication controller or daemon: %s\n", strings.Join(unreplicated, ", "))
+	}
+	return pods, nil
+}
+
+// isReplicated returns true if the pod was created by a replication
+// controller or daemon that still exists.
+func (o *DrainOptions) isReplicated(pod *api.Pod) (bool, error) {
+	creatorRef, found := pod.Annotations[controller.CreatedByAnnotation]
+	if !found {
+		return false, nil
+	}
+	// Now verify that the specified creator actually exists.
+	var sr api.SerializedReference
+	if err := latest.Codec.DecodeInto([]byte(creatorRef), &sr); err != nil {
+		return false, err
+	}
+	switch sr.Reference.Kind {
+	case "ReplicationController":
+		_, err := o.client.ReplicationControllers(sr.Reference.Namespace).Get(sr.Reference.Name)
+		return existsOrError(err)
+	case "Daemon":
+		expClient, err := o.factory.ExperimentalClient()
+		if err != nil {
+			return false, err
+		}
+		_, err = expClient.Daemons(sr.Reference.Namespace).Get(sr.Reference.Name)
+		return existsOrError(err)
+	}
+	return false, nil
+}
+
+func existsOrError(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// deletePods deletes the pods and waits until they are gone.
+func (o *DrainOptions) deletePods(pods []api.Pod) error {
+	mapper, _ := o.factory.Object()
+	for _, pod := range pods {
+		var options *api.DeleteOptions
+		if o.gracePeriodSeconds >= 0 {
+			options = api.NewDeleteOptions(int64(o.gracePeriodSeconds))
+		}
+		if err := o.client.Pods(pod.Namespace).Delete(pod.Name, options); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		cmdutil.PrintSuccess(mapper, false, o.out, "pods", pod.Name, "deleted")
+	}
+
+	condition := func() (bool, error) {
+		for _, pod := range pods {
+			p, err := o.client.Pods(pod.Namespace).Get(pod.Name)
+			if errors.IsNotFound(err) || (err == nil && p.UID != pod.UID) {
+				// the pod is gone, or was replaced by a new pod with the same name
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+		return true, nil
+	}
+	if o.timeout == 0 {
+		return wait.PollInfinite(drainPollInterval, condition)
+	}
+	if err := wait.Poll(drainPollInterval, o.timeout, condition); err != nil {
+		return fmt.Errorf("timed out waiting for the pods on node %q to be deleted: %v", o.nodeName, err)
+	}
+	return nil
+}
+
+// drainPollInterval is how often drain checks whether the deleted pods are gone.
+var drainPollInterval = time.Second
@@ -0,0 +1,225 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/latest"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func testNode() *api.Node {
+	return &api.Node{
+		ObjectMeta: api.ObjectMeta{Name: "node", ResourceVersion: "10"},
+	}
+}
+
+func TestCordonAndUncordon(t *testing.T) {
+	tests := []struct {
+		description   string
+		unschedulable bool
+		cordon        bool
+		expectUpdate  bool
+		expectOutput  string
+	}{
+		{"cordon a schedulable node", false, true, true, "node \"node\" cordoned\n"},
+		{"cordon an unschedulable node", true, true, false, "node \"node\" already cordoned\n"},
+		{"uncordon an unschedulable node", true, false, true, "node \"node\" uncordoned\n"},
+		{"uncordon a schedulable node", false, false, false, "node \"node\" already uncordoned\n"},
+	}
+
+	for _, test := range tests {
+		node := testNode()
+		node.Spec.Unschedulable = test.unschedulable
+		updated := false
+
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/api/v1/nodes/node" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, node)}, nil
+				case p == "/api/v1/nodes/node" && m == "PUT":
+					updated = true
+					data, err := ioutil.ReadAll(req.Body)
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", test.description, err)
+					}
+					newNode := &api.Node{}
+					if err := codec.DecodeInto(data, newNode); err != nil {
+						t.Fatalf("%s: unexpected error: %v", test.description, err)
+					}
+					if newNode.Spec.Unschedulable != test.cordon {
+						t.Errorf("%s: expected unschedulable to be %t", test.description, test.cordon)
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, newNode)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", test.description, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.ClientConfig = &client.Config{Version: testapi.Version()}
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdCordon(f, buf)
+		if !test.cordon {
+			cmd = NewCmdUncordon(f, buf)
+		}
+		cmd.Run(cmd, []string{"node"})
+
+		if updated != test.expectUpdate {
+			t.Errorf("%s: expected update to be %t", test.description, test.expectUpdate)
+		}
+		if buf.String() != test.expectOutput {
+			t.Errorf("%s: unexpected output: %s", test.description, buf.String())
+		}
+	}
+}
+
+func createdByAnnotation(t *testing.T, obj runtime.Object) map[string]string {
+	ref, err := api.GetReference(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := latest.Codec.Encode(&api.SerializedReference{Reference: *ref})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return map[string]string{controller.CreatedByAnnotation: string(data)}
+}
+
+func TestDrain(t *testing.T) {
+	defer func(interval time.Duration) { drainPollInterval = interval }(drainPollInterval)
+	drainPollInterval = time.Millisecond
+
+	rc := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Name: "rc", Namespace: "default", SelfLink: "/api/v1/namespaces/default/replicationcontrollers/rc"},
+	}
+	replicated := api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "replicated", Namespace: "default", UID: "1", Annotations: createdByAnnotation(t, rc)},
+	}
+	bare := api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "bare", Namespace: "default", UID: "2"},
+	}
+	mirror := api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "mirror", Namespace: "default", UID: "3", Annotations: map[string]string{mirrorPodAnnotation: "mirror"}},
+	}
+
+	tests := []struct {
+		description   string
+		pods          []api.Pod
+		force         bool
+		expectErr     bool
+		expectDeleted []string
+	}{
+		{
+			description:   "replicated pods are deleted",
+			pods:          []api.Pod{replicated, mirror},
+			expectDeleted: []string{"replicated"},
+		},
+		{
+			description: "bare pods are refused",
+			pods:        []api.Pod{replicated, bare},
+			expectErr:   true,
+		},
+		{
+			description:   "bare pods are deleted with force",
+			pods:          []api.Pod{replicated, bare},
+			force:         true,
+			expectDeleted: []string{"replicated", "bare"},
+		},
+	}
+
+	for _, test := range tests {
+		node := testNode()
+		deleted := []string{}
+
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/api/v1/nodes/node" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, node)}, nil
+				case p == "/api/v1/nodes/node" && m == "PUT":
+					node.Spec.Unschedulable = true
+					return &http.Response{StatusCode: 200, Body: objBody(codec, node)}, nil
+				case p == "/api/v1/pods" && m == "GET":
+					if selector := req.URL.Query().Get("fieldSelector"); selector != "spec.nodeName=node" {
+						t.Errorf("%s: unexpected field selector: %s", test.description, selector)
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &api.PodList{Items: test.pods})}, nil
+				case p == "/api/v1/namespaces/default/replicationcontrollers/rc" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, rc)}, nil
+				case strings.HasPrefix(p, "/api/v1/namespaces/default/pods/") && m == "DELETE":
+					deleted = append(deleted, strings.TrimPrefix(p, "/api/v1/namespaces/default/pods/"))
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &api.Status{Status: api.StatusSuccess})}, nil
+				case strings.HasPrefix(p, "/api/v1/namespaces/default/pods/") && m == "GET":
+					status := errors.NewNotFound("Pod", strings.TrimPrefix(p, "/api/v1/namespaces/default/pods/")).(*errors.StatusError).ErrStatus
+					return &http.Response{StatusCode: 404, Body: objBody(codec, &status)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", test.description, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.ClientConfig = &client.Config{Version: testapi.Version()}
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdDrain(f, buf)
+		options := &DrainOptions{factory: f, out: buf, force: test.force, gracePeriodSeconds: -1}
+		if err := options.Complete(cmd, []string{"node"}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.description, err)
+		}
+		err := options.RunDrain()
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.description)
+			}
+			if len(deleted) != 0 {
+				t.Errorf("%s: expected no pods to be deleted, got %v", test.description, deleted)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.description, err)
+			continue
+		}
+		if !node.Spec.Unschedulable {
+			t.Errorf("%s: expected the node to be cordoned", test.description)
+		}
+		if strings.Join(deleted, ",") != strings.Join(test.expectDeleted, ",") {
+			t.Errorf("%s: expected %v to be deleted, got %v", test.description, test.expectDeleted, deleted)
+		}
+		if !strings.HasSuffix(buf.String(), "node \"node\" drained\n") {
+			t.Errorf("%s: unexpected output: %s", test.description, buf.String())
+		}
+	}
+}
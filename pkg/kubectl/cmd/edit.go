@@ -0,0 +1,379 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/util/editor"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/strategicpatch"
+)
+
+const (
+	edit_long = `Edit a resource from the default editor.
+
+The edit command allows you to directly edit any API resource you can retrieve via the
+command line tools. It will open the editor defined by your KUBE_EDITOR or EDITOR
+environment variables, or fall back to 'vi'. You can edit multiple objects, although
+changes are applied one at a time. The command accepts filenames as well as command
+line arguments, although the files you point to must be previously saved versions of
+resources.
+
+The files to edit will be output in the default API version, or a version specified
+by --output-version. The default format is YAML - if you would like to edit in JSON
+pass -o json.
+
+The changes you make are sent to the server as a strategic merge patch against the
+object as it was retrieved, so fields changed by others in the meantime are kept. If
+the update fails because of a validation error or a conflict, the editor is reopened
+with the error described in a comment at the top of the file. If you then exit the
+editor without making changes, the file is preserved in a temporary location so that
+you can retry later.`
+
+	edit_example = `  # Edit the service named 'docker-registry':
+  $ kubectl edit svc/docker-registry
+
+  # Use an alternative editor
+  $ KUBE_EDITOR="nano" kubectl edit svc/docker-registry
+
+  # Edit the service 'docker-registry' in JSON using the v1 API format:
+  $ kubectl edit svc/docker-registry --output-version=v1 -o json`
+)
+
+var errExit = fmt.Errorf("exit directly")
+
+func NewCmdEdit(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "edit (RESOURCE/NAME | -f FILENAME)",
+		Short:   "Edit a resource on the server",
+		Long:    edit_long,
+		Example: edit_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunEdit(f, out, cmd, args)
+			if err == errExit {
+				os.Exit(1)
+			}
+			cmdutil.CheckErr(err)
+		},
+	}
+	usage := "Filename, directory, or URL to file to use to edit the resource"
+	kubectl.AddJsonFilenameFlag(cmd, usage)
+	cmd.Flags().StringP("output", "o", "yaml", "Output format. One of: yaml|json.")
+	cmd.Flags().String("output-version", "", "Output the formatted object with the given version (default api-version).")
+	return cmd
+}
+
+func RunEdit(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	var printer kubectl.ResourcePrinter
+	var ext string
+	switch format := cmdutil.GetFlagString(cmd, "output"); format {
+	case "json":
+		printer = &kubectl.JSONPrinter{}
+		ext = ".json"
+	case "yaml":
+		printer = &kubectl.YAMLPrinter{}
+		ext = ".yaml"
+	default:
+		return cmdutil.UsageError(cmd, "The flag 'output' must be one of yaml|json")
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	rmap := &resource.Mapper{
+		ObjectTyper:  typer,
+		RESTMapper:   mapper,
+		ClientMapper: f.ClientMapperForCommand(),
+	}
+
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, cmdutil.GetFlagStringSlice(cmd, "filename")...).
+		ResourceTypeOrNameArgs(true, args...).
+		Latest().
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+	infos, err := r.Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("no objects passed to edit")
+	}
+
+	version := cmdutil.GetFlagString(cmd, "output-version")
+	if len(version) == 0 {
+		clientConfig, err := f.ClientConfig()
+		if err != nil {
+			return err
+		}
+		version = clientConfig.Version
+	}
+
+	// The objects as they were retrieved, which every edit is diffed against.
+	originals := map[string]runtime.Object{}
+	for _, info := range infos {
+		originals[editKey(info)] = info.Object
+	}
+
+	results := editResults{}
+	// unparseable holds the content of a file that had a syntax error, so it
+	// can be shown to the user again as they left it.
+	var unparseable []byte
+	for {
+		// generate the file to edit
+		buf := &bytes.Buffer{}
+		if err := results.header.writeTo(buf); err != nil {
+			return preservedFile(err, results.file, out)
+		}
+		if unparseable != nil {
+			buf.Write(unparseable)
+		} else {
+			obj, err := resource.AsVersionedObject(infos, false, version)
+			if err != nil {
+				return preservedFile(err, results.file, out)
+			}
+			if err := printer.PrintObj(obj, buf); err != nil {
+				return preservedFile(err, results.file, out)
+			}
+		}
+		original := append([]byte{}, buf.Bytes()...)
+
+		// launch the editor
+		edit := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
+		edited, file, err := edit.LaunchTempFile("kubectl-edit-", ext, buf)
+		if err != nil {
+			return preservedFile(err, results.file, out)
+		}
+
+		// cleanup any file from the previous pass
+		if len(results.file) > 0 {
+			os.Remove(results.file)
+		}
+		results.file = file
+		glog.V(4).Infof("User edited:\n%s", string(edited))
+
+		// compare the content without the header comments
+		stripped := stripComments(edited)
+		if bytes.Equal(stripComments(original), stripped) {
+			if results.retries > 0 {
+				fmt.Fprintln(out, "Edit cancelled, no valid changes were saved.")
+				return preservedFile(errExit, file, out)
+			}
+			os.Remove(file)
+			fmt.Fprintln(out, "Edit cancelled, no changes made.")
+			return nil
+		}
+		if len(bytes.TrimSpace(stripped)) == 0 {
+			os.Remove(file)
+			fmt.Fprintln(out, "Edit cancelled, saved file was empty.")
+			return nil
+		}
+
+		results.header = editHeader{}
+		results.retries++
+		unparseable = nil
+
+		// parse the edited file
+		updates, err := rmap.InfoForData(stripped, "edited-file")
+		if err != nil {
+			results.header.reasons = append(results.header.reasons, editReason{
+				head: fmt.Sprintf("The edited file had a syntax error: %v", err),
+			})
+			unparseable = stripped
+			continue
+		}
+
+		failed := []*resource.Info{}
+		visitor := resource.NewFlattenListVisitor(updates, rmap)
+		err = visitor.Visit(func(info *resource.Info, err error) error {
+			if err != nil {
+				return err
+			}
+			original, ok := originals[editKey(info)]
+			if !ok {
+				return fmt.Errorf("%s %q in namespace %q was not one of the edited objects; the kind, name and namespace of an object may not be changed", info.Mapping.Kind, info.Name, info.Namespace)
+			}
+			originalJS, err := info.Mapping.Codec.Encode(original)
+			if err != nil {
+				return err
+			}
+			editedJS, err := info.Mapping.Codec.Encode(info.Object)
+			if err != nil {
+				return err
+			}
+			versioned, err := api.Scheme.New(info.Mapping.APIVersion, info.Mapping.Kind)
+			if err != nil {
+				return err
+			}
+			patch, err := strategicpatch.CreateTwoWayMergePatch(originalJS, editedJS, versioned)
+			if err != nil {
+				return err
+			}
+			if string(patch) == "{}" {
+				return nil
+			}
+
+			patched, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, api.StrategicMergePatchType, patch)
+			if err != nil {
+				fmt.Fprintln(out, results.addError(err, info))
+				failed = append(failed, info)
+				return nil
+			}
+			info.Refresh(patched, true)
+			cmdutil.PrintSuccess(mapper, false, out, info.Mapping.Resource, info.Name, "edited")
+			return nil
+		})
+		if err != nil {
+			return preservedFile(err, file, out)
+		}
+
+		if len(failed) == 0 {
+			os.Remove(file)
+			return nil
+		}
+
+		// reopen the editor with the objects that could not be saved, as the
+		// user left them, and the reasons at the top
+		infos = failed
+	}
+}
+
+// editKey identifies an object across edits of the file.
+func editKey(info *resource.Info) string {
+	return fmt.Sprintf("%s/%s/%s", info.Mapping.Kind, info.Namespace, info.Name)
+}
+
+// editReason preserves a message about the reason this file must be edited again
+type editReason struct {
+	head  string
+	other []string
+}
+
+// editHeader includes a list of reasons the edit must be retried
+type editHeader struct {
+	reasons []editReason
+}
+
+// writeTo outputs the current header information into a stream
+func (h *editHeader) writeTo(w io.Writer) error {
+	fmt.Fprint(w, `# Please edit the object below. The lines beginning with a '#' at the top will be ignored,
+# and an empty file will abort the edit. If an error occurs while saving this file will be
+# reopened with the relevant failures.
+#
+`)
+	for _, r := range h.reasons {
+		if len(r.other) > 0 {
+			fmt.Fprintf(w, "# %s:\n", r.head)
+		} else {
+			fmt.Fprintf(w, "# %s\n", r.head)
+		}
+		for _, o := range r.other {
+			fmt.Fprintf(w, "# * %s\n", o)
+		}
+		fmt.Fprintln(w, "#")
+	}
+	return nil
+}
+
+// editResults capture the result of an update
+type editResults struct {
+	header  editHeader
+	retries int
+	file    string
+}
+
+// addError records the reason an update failed in the header, and returns
+// a message for the user.
+func (r *editResults) addError(err error, info *resource.Info) string {
+	switch {
+	case errors.IsInvalid(err):
+		reason := editReason{
+			head: fmt.Sprintf("%s %s was not valid", info.Mapping.Kind, info.Name),
+		}
+		if err, ok := err.(*errors.StatusError); ok {
+			if err.ErrStatus.Details != nil {
+				for _, cause := range err.ErrStatus.Details.Causes {
+					reason.other = append(reason.other, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+				}
+			}
+		}
+		r.header.reasons = append(r.header.reasons, reason)
+		return fmt.Sprintf("Error: %s %q is invalid", info.Mapping.Kind, info.Name)
+	case errors.IsConflict(err):
+		r.header.reasons = append(r.header.reasons, editReason{
+			head: fmt.Sprintf("%s %s could not be saved because it conflicts with a change on the server: %v", info.Mapping.Kind, info.Name, err),
+		})
+		return fmt.Sprintf("Error: %s %q could not be saved because of a conflict", info.Mapping.Kind, info.Name)
+	default:
+		r.header.reasons = append(r.header.reasons, editReason{
+			head: fmt.Sprintf("%s %s could not be saved: %v", info.Mapping.Kind, info.Name, err),
+		})
+		return fmt.Sprintf("Error: %s %q could not be saved: %v", info.Mapping.Kind, info.Name, err)
+	}
+}
+
+// preservedFile writes out a message about the provided file if it exists to the
+// provided output stream when an error happens. Used to notify the user where
+// their updates were preserved.
+func preservedFile(err error, path string, out io.Writer) error {
+	if len(path) > 0 {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			fmt.Fprintf(out, "A copy of your changes has been stored to %q\n", path)
+		}
+	}
+	return err
+}
+
+// stripComments removes the block of lines beginning with '#' at the top of
+// the file, which holds the instructions and errors written by writeTo.
+// Comments in the object below it are kept.
+func stripComments(file []byte) []byte {
+	stripped := &bytes.Buffer{}
+	header := true
+	scanner := bufio.NewScanner(bytes.NewBuffer(file))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if header && bytes.HasPrefix(line, []byte("#")) {
+			continue
+		}
+		header = false
+		stripped.Write(line)
+		stripped.WriteByte('\n')
+	}
+	return stripped.Bytes()
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/util/fielderrors"
+)
+
+// setTestEditor points the editor at a command that edits the file without
+// user interaction, and returns a function that restores the environment.
+func setTestEditor(editor string) func() {
+	kubeEditor := os.Getenv("KUBE_EDITOR")
+	os.Setenv("KUBE_EDITOR", editor)
+	return func() {
+		os.Setenv("KUBE_EDITOR", kubeEditor)
+	}
+}
+
+func TestEditObject(t *testing.T) {
+	defer setTestEditor("sed -i -e s/ClusterIP/NodePort/")()
+	_, svc, _ := testData()
+
+	var patch string
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			case p == "/namespaces/test/services/baz" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				patch = string(data)
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// NodePort services default their external traffic policy.
+	if patch != `{"spec":{"externalTrafficPolicy":"Cluster","type":"NodePort"}}` {
+		t.Errorf("unexpected patch: %s", patch)
+	}
+	if !strings.Contains(buf.String(), `"baz" edited`) {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestEditObjectNoChanges(t *testing.T) {
+	defer setTestEditor("true")()
+	_, svc, _ := testData()
+
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "Edit cancelled, no changes made.\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestEditObjectInvalid(t *testing.T) {
+	defer setTestEditor("sed -i -e s/ClusterIP/NodePort/")()
+	_, svc, _ := testData()
+	invalidError := errors.NewInvalid("Service", "baz", fielderrors.ValidationErrorList{
+		fielderrors.NewFieldInvalid("spec.type", "NodePort", "not allowed"),
+	}).(*errors.StatusError).ErrStatus
+
+	patches := 0
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/services/baz" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+			case p == "/namespaces/test/services/baz" && m == "PATCH":
+				patches++
+				return &http.Response{StatusCode: 422, Body: objBody(codec, &invalidError)}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	// The editor is reopened with the error, and the second edit makes no
+	// further changes, so the edit is abandoned and the file preserved.
+	cmd := NewCmdEdit(f, buf)
+	if err := RunEdit(f, buf, cmd, []string{"services/baz"}); err != errExit {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches != 1 {
+		t.Errorf("expected one patch, got %d", patches)
+	}
+
+	match := regexp.MustCompile(`A copy of your changes has been stored to "(.*)"`).FindStringSubmatch(buf.String())
+	if match == nil {
+		t.Fatalf("expected the file to be preserved: %s", buf.String())
+	}
+	defer os.Remove(match[1])
+	data, err := ioutil.ReadFile(match[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "# * spec.type: invalid value 'NodePort'") || !strings.Contains(string(data), "type: NodePort") {
+		t.Errorf("expected the file to contain the error and the changes: %s", string(data))
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	tests := map[string]string{
+		"":                                     "",
+		"kind: Pod\n":                          "kind: Pod\n",
+		"# header\n#\nkind: Pod\n":             "kind: Pod\n",
+		"# header\nkind: Pod\n# keep\n":        "kind: Pod\n# keep\n",
+		"# header\ndata:\n  key: \"#value\"\n": "data:\n  key: \"#value\"\n",
+		"kind: Pod\nmetadata:\n  # keep\n":     "kind: Pod\nmetadata:\n  # keep\n",
+	}
+	for file, expected := range tests {
+		if actual := string(stripComments([]byte(file))); actual != expected {
+			t.Errorf("%q: expected %q, got %q", file, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/emicklei/go-restful/swagger"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	explain_example = `# Get the documentation of the resource and its fields
+$ kubectl explain pods
+
+# Get the documentation of a specific field of a resource
+$ kubectl explain pods.spec.containers
+
+# Get the names and types of all nested fields of a resource
+$ kubectl explain services.spec --recursive`
+
+	explain_long = `Documentation of resources.
+
+Possible resource types include: pods (po), services (svc),
+replicationcontrollers (rc), nodes (no), events (ev), componentstatuses (cs),
+limitranges (limits), persistentvolumes (pv), persistentvolumeclaims (pvc),
+resourcequotas (quota), namespaces (ns), endpoints (ep), secrets,
+serviceaccounts, horizontalpodautoscalers, daemons, deployments or
+networkpolicies.
+
+Fields of a resource can be selected with a dot separated path, e.g.
+'pods.spec.containers.livenessProbe'.`
+)
+
+// NewCmdExplain returns a cobra command for swagger docs
+func NewCmdExplain(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "explain RESOURCE[.FIELD...]",
+		Short:   "Documentation of resources.",
+		Long:    explain_long,
+		Example: explain_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunExplain(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Bool("recursive", false, "Print the names and types of all nested fields instead of the descriptions of the immediate ones")
+	cmd.Flags().String("api-version", "", "The API version of the resource to explain. Defaults to the server's preferred version.")
+	return cmd
+}
+
+// RunExplain executes the appropriate steps to print a model's documentation
+func RunExplain(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageError(cmd, "a single resource is required, e.g. 'kubectl explain pods'")
+	}
+
+	mapper, _ := f.Object()
+	kind, fieldsPath, err := kubectl.SplitAndParseResourceRequest(args[0], mapper)
+	if err != nil {
+		return err
+	}
+
+	version := cmdutil.GetFlagString(cmd, "api-version")
+	mapping, err := mapper.RESTMapping(kind, version)
+	if err != nil {
+		return err
+	}
+	group, err := mapper.GroupForResource(mapping.Resource)
+	if err != nil {
+		return err
+	}
+	if len(version) == 0 {
+		version = mapping.APIVersion
+	}
+
+	var schema *swagger.ApiDeclaration
+	switch group {
+	case "api":
+		client, err := f.Client()
+		if err != nil {
+			return err
+		}
+		schema, err = client.SwaggerSchema(version)
+		if err != nil {
+			return err
+		}
+	case "experimental":
+		client, err := f.ExperimentalClient()
+		if err != nil {
+			return err
+		}
+		schema, err = client.SwaggerSchema(version)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unable to explain resources of the %q API group", group)
+	}
+
+	return kubectl.PrintModelDescription(version, kind, fieldsPath, out, schema, cmdutil.GetFlagBool(cmd, "recursive"))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func TestExplain(t *testing.T) {
+	swaggerSpec, err := ioutil.ReadFile("../../../api/swagger-spec/v1.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/swaggerapi/api/v1" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader(swaggerSpec))}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.ClientConfig = &client.Config{Version: "v1"}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdExplain(f, buf)
+	cmd.Run(cmd, []string{"replicationcontrollers.spec.template"})
+
+	for _, expected := range []string{"RESOURCE: template <Object>", "FIELDS:\n", "   metadata\t<Object>\n", "   spec\t<Object>\n"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
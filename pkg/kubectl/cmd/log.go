@@ -19,13 +19,17 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/validation"
 	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	libutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/errors"
 )
 
 const (
@@ -36,7 +40,13 @@ $ kubectl logs 123456-7890 ruby-container
 $ kubectl logs -p 123456-7890 ruby-container
 
 # Starts streaming of ruby-container logs from pod 123456-7890.
-$ kubectl logs -f 123456-7890 ruby-container`
+$ kubectl logs -f 123456-7890 ruby-container
+
+# Display only the most recent 20 lines of output in pod 123456-7890.
+$ kubectl logs --tail=20 123456-7890
+
+# Show all logs from pod 123456-7890 written in the last hour, with timestamps.
+$ kubectl logs --since=1h --timestamps 123456-7890`
 )
 
 func selectContainer(pod *api.Pod, in io.Reader, out io.Writer) string {
@@ -82,6 +92,11 @@ func NewCmdLog(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolP("follow", "f", false, "Specify if the logs should be streamed.")
 	cmd.Flags().Bool("interactive", true, "If true, prompt the user for input when required. Default true.")
 	cmd.Flags().BoolP("previous", "p", false, "If true, print the logs for the previous instance of the container in a pod if it exists.")
+	cmd.Flags().Int("limit-bytes", 0, "Maximum bytes of logs to return. Defaults to no limit.")
+	cmd.Flags().Int("tail", -1, "Lines of recent log file to display. Defaults to -1, showing all log lines.")
+	cmd.Flags().String("since-time", "", "Only return logs after a specific date (RFC3339). Defaults to all logs. Only one of since-time / since may be used.")
+	cmd.Flags().Duration("since", 0, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs. Only one of since-time / since may be used.")
+	cmd.Flags().Bool("timestamps", false, "Include timestamps on each line in the log output")
 	cmd.Flags().StringVarP(&params.containerName, "container", "c", "", "Container name")
 	return cmd
 }
@@ -132,25 +147,58 @@ func RunLog(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string
 		}
 	}
 
-	follow := false
-	if cmdutil.GetFlagBool(cmd, "follow") {
-		follow = true
+	logOptions := &api.PodLogOptions{
+		Container:  container,
+		Follow:     cmdutil.GetFlagBool(cmd, "follow"),
+		Previous:   cmdutil.GetFlagBool(cmd, "previous"),
+		Timestamps: cmdutil.GetFlagBool(cmd, "timestamps"),
 	}
-
-	previous := false
-	if cmdutil.GetFlagBool(cmd, "previous") {
-		previous = true
+	if sinceTime := cmdutil.GetFlagString(cmd, "since-time"); len(sinceTime) > 0 {
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return cmdutil.UsageError(cmd, "--since-time must be a valid RFC3339 timestamp: %v", err)
+		}
+		st := libutil.NewTime(t)
+		logOptions.SinceTime = &st
+	}
+	if since := cmdutil.GetFlagDuration(cmd, "since"); since != 0 {
+		sec := int64(math.Ceil(since.Seconds()))
+		logOptions.SinceSeconds = &sec
+	}
+	if limit := cmdutil.GetFlagInt(cmd, "limit-bytes"); limit != 0 {
+		i := int64(limit)
+		logOptions.LimitBytes = &i
+	}
+	if tail := cmdutil.GetFlagInt(cmd, "tail"); tail != -1 {
+		i := int64(tail)
+		logOptions.TailLines = &i
+	}
+	if errs := validation.ValidatePodLogOptions(logOptions); len(errs) > 0 {
+		return errors.NewAggregate(errs)
 	}
 
-	readCloser, err := client.RESTClient.Get().
+	req := client.RESTClient.Get().
 		Namespace(namespace).
 		Name(podID).
 		Resource("pods").
 		SubResource("log").
-		Param("follow", strconv.FormatBool(follow)).
-		Param("container", container).
-		Param("previous", strconv.FormatBool(previous)).
-		Stream()
+		Param("follow", strconv.FormatBool(logOptions.Follow)).
+		Param("container", logOptions.Container).
+		Param("previous", strconv.FormatBool(logOptions.Previous)).
+		Param("timestamps", strconv.FormatBool(logOptions.Timestamps))
+	if logOptions.SinceSeconds != nil {
+		req.Param("sinceSeconds", strconv.FormatInt(*logOptions.SinceSeconds, 10))
+	}
+	if logOptions.SinceTime != nil {
+		req.Param("sinceTime", logOptions.SinceTime.Format(time.RFC3339))
+	}
+	if logOptions.LimitBytes != nil {
+		req.Param("limitBytes", strconv.FormatInt(*logOptions.LimitBytes, 10))
+	}
+	if logOptions.TailLines != nil {
+		req.Param("tailLines", strconv.FormatInt(*logOptions.TailLines, 10))
+	}
+	readCloser, err := req.Stream()
 	if err != nil {
 		return err
 	}
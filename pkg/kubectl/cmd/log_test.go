@@ -196,6 +196,76 @@ func TestLog(t *testing.T) {
 	}
 }
 
+func TestLogParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected map[string]string
+		absent   []string
+	}{
+		{
+			name:     "defaults",
+			expected: map[string]string{"follow": "false", "previous": "false", "timestamps": "false", "container": "bar"},
+			absent:   []string{"tailLines", "limitBytes", "sinceSeconds", "sinceTime"},
+		},
+		{
+			name:     "tail, limit and timestamps",
+			flags:    map[string]string{"tail": "20", "limit-bytes": "1024", "timestamps": "true"},
+			expected: map[string]string{"tailLines": "20", "limitBytes": "1024", "timestamps": "true"},
+			absent:   []string{"sinceSeconds", "sinceTime"},
+		},
+		{
+			name:     "since",
+			flags:    map[string]string{"since": "1m30s"},
+			expected: map[string]string{"sinceSeconds": "90"},
+			absent:   []string{"sinceTime"},
+		},
+		{
+			name:     "since time",
+			flags:    map[string]string{"since-time": "2015-10-01T12:00:00Z"},
+			expected: map[string]string{"sinceTime": "2015-10-01T12:00:00Z"},
+			absent:   []string{"sinceSeconds"},
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/api/v1/namespaces/test/pods/foo" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, testPod())}, nil
+				case p == "/api/v1/namespaces/test/pods/foo/log" && m == "GET":
+					query := req.URL.Query()
+					for k, v := range test.expected {
+						if query.Get(k) != v {
+							t.Errorf("%s: expected %s=%s, got %q", test.name, k, v, query.Get(k))
+						}
+					}
+					for _, k := range test.absent {
+						if _, ok := query[k]; ok {
+							t.Errorf("%s: unexpected parameter %s in %v", test.name, k, query)
+						}
+					}
+					return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString(""))}, nil
+				default:
+					t.Errorf("%s: unexpected request: %#v\n%#v", test.name, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		tf.ClientConfig = &client.Config{Version: "v1"}
+
+		cmd := NewCmdLog(f, bytes.NewBuffer([]byte{}))
+		cmd.Flags().Set("namespace", "test")
+		for k, v := range test.flags {
+			cmd.Flags().Set(k, v)
+		}
+		cmd.Run(cmd, []string{"foo"})
+	}
+}
+
 func testPod() *api.Pod {
 	return &api.Pod{
 		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10"},
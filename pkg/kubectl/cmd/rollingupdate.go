@@ -39,7 +39,10 @@ const (
 
 Replaces the specified replication controller with a new replication controller by updating one pod at a time to use the
 new PodTemplate. The new-controller.json must specify the same namespace as the
-existing replication controller and overwrite at least one (common) label in its replicaSelector.`
+existing replication controller and overwrite at least one (common) label in its replicaSelector.
+
+Unless --revision-history-limit is 0, the replaced controller is kept, scaled to zero, as
+a past revision that 'kubectl rollout undo' can return to.`
 	rollingUpdate_example = `# Update pods of frontend-v1 using new replication controller data in frontend-v2.json.
 $ kubectl rolling-update frontend-v1 -f frontend-v2.json
 
@@ -85,6 +88,8 @@ func NewCmdRollingUpdate(f *cmdutil.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().String("deployment-label-key", "deployment", "The key to use to differentiate between two different controllers, default 'deployment'.  Only relevant when --image is specified, ignored otherwise")
 	cmd.Flags().Bool("dry-run", false, "If true, print out the changes that would be made, but don't actually make them.")
 	cmd.Flags().Bool("rollback", false, "If true, this is a request to abort an existing rollout that is partially rolled out. It effectively reverses current and next and runs a rollout")
+	addRevisionHistoryLimitFlag(cmd)
+	addRecordFlag(cmd)
 	cmdutil.AddValidateFlag(cmd)
 	cmdutil.AddPrinterFlags(cmd)
 	return cmd
@@ -272,14 +277,16 @@ func RunRollingUpdate(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, arg
 		updateCleanupPolicy = kubectl.RenameRollingUpdateCleanupPolicy
 	}
 	config := &kubectl.RollingUpdaterConfig{
-		Out:            out,
-		OldRc:          oldRc,
-		NewRc:          newRc,
-		UpdatePeriod:   period,
-		Interval:       interval,
-		Timeout:        timeout,
-		CleanupPolicy:  updateCleanupPolicy,
-		UpdateAcceptor: kubectl.DefaultUpdateAcceptor,
+		Out:                  out,
+		OldRc:                oldRc,
+		NewRc:                newRc,
+		UpdatePeriod:         period,
+		Interval:             interval,
+		Timeout:              timeout,
+		CleanupPolicy:        updateCleanupPolicy,
+		UpdateAcceptor:       kubectl.DefaultUpdateAcceptor,
+		ChangeCause:          changeCause(cmd),
+		RevisionHistoryLimit: cmdutil.GetFlagInt(cmd, "revision-history-limit"),
 	}
 	if cmdutil.GetFlagBool(cmd, "rollback") {
 		kubectl.AbortRollingUpdate(config)
@@ -0,0 +1,137 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+const (
+	rollout_long = `Manage the rollout of a replication controller.
+
+Each rolling update records a new revision of the controller, along with the command that
+caused it when run with --record. The replaced controllers are kept, scaled to zero, so that a previous revision
+can be inspected and rolled back to.`
+	rollout_example = `# Roll back to the previous revision of frontend
+$ kubectl rollout undo rc frontend`
+)
+
+// NewCmdRollout returns the parent command of 'rollout history', 'rollout undo'
+// and 'rollout status'.
+func NewCmdRollout(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rollout SUBCOMMAND",
+		Short:   "Manage the rollout of a replication controller.",
+		Long:    rollout_long,
+		Example: rollout_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(NewCmdRolloutHistory(f, out))
+	cmd.AddCommand(NewCmdRolloutUndo(f, out))
+	cmd.AddCommand(NewCmdRolloutStatus(f, out))
+	return cmd
+}
+
+// addRevisionHistoryLimitFlag adds the flag controlling how many replaced
+// controllers a rollout keeps.
+func addRevisionHistoryLimitFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("revision-history-limit", 10, "The number of replaced controllers to keep, scaled to zero, as rollout history. If 0, replaced controllers are deleted.")
+}
+
+// addRecordFlag adds the flag recording the command on the revisions it
+// rolls out.
+func addRecordFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("record", false, "Record the command in the change-cause annotation of the new revision. Credentials passed as flags are left out.")
+}
+
+// credentialFlags are the flags whose values are never recorded.
+var credentialFlags = util.NewStringSet("token", "password", "username")
+
+// changeCause describes the running command, to be recorded on the revisions
+// it rolls out, or returns the empty string unless --record is set.
+func changeCause(cmd *cobra.Command) string {
+	if !cmdutil.GetFlagBool(cmd, "record") || len(os.Args) == 0 {
+		return ""
+	}
+	return commandLine(os.Args)
+}
+
+// commandLine joins args into a command line, leaving out the credential
+// flags and their values.
+func commandLine(args []string) string {
+	words := []string{filepath.Base(args[0])}
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			words = append(words, arg)
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)
+		if !credentialFlags.Has(parts[0]) {
+			words = append(words, arg)
+			continue
+		}
+		if len(parts) == 1 {
+			// the value is the next argument
+			i++
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// rolloutTarget returns the namespace and name of the single replication
+// controller identified by args.
+func rolloutTarget(f *cmdutil.Factory, cmd *cobra.Command, args []string) (string, string, error) {
+	if len(args) == 0 {
+		return "", "", cmdutil.UsageError(cmd, "Must specify the replication controller, e.g. 'rc NAME'")
+	}
+	cmdNamespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return "", "", err
+	}
+
+	mapper, typer := f.Object()
+	infos, err := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(false, args...).
+		SingleResourceType().
+		Flatten().
+		Do().
+		Infos()
+	if err != nil {
+		return "", "", err
+	}
+	if len(infos) != 1 {
+		return "", "", cmdutil.UsageError(cmd, "rollout requires exactly one replication controller, got %d", len(infos))
+	}
+	info := infos[0]
+	if info.Mapping.Kind != "ReplicationController" {
+		return "", "", fmt.Errorf("rollout is not supported for %s", info.Mapping.Resource)
+	}
+	return info.Namespace, info.Name, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	rolloutHistory_long = `View the rollout history of a replication controller.
+
+Lists the recorded revisions of the controller along with the change that caused each
+one. With --revision, shows the pod template of a single revision.`
+	rolloutHistory_example = `# View the rollout history of frontend
+$ kubectl rollout history rc frontend
+
+# View the details of revision 3 of frontend
+$ kubectl rollout history rc frontend --revision=3`
+)
+
+func NewCmdRolloutHistory(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "history (TYPE NAME | TYPE/NAME) [--revision=REVISION]",
+		Short:   "View the rollout history of a replication controller.",
+		Long:    rolloutHistory_long,
+		Example: rolloutHistory_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutHistory(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Int("revision", 0, "Show the details of this revision, including its pod template.")
+	return cmd
+}
+
+func RunRolloutHistory(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	revision := cmdutil.GetFlagInt(cmd, "revision")
+	if revision < 0 {
+		return cmdutil.UsageError(cmd, "--revision must be a positive number")
+	}
+	namespace, name, err := rolloutTarget(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	history, err := kubectl.RolloutHistory(kubectl.NewRollingUpdaterClient(client), namespace, name)
+	if err != nil {
+		return err
+	}
+	if revision > 0 {
+		entry, err := kubectl.FindRevision(history, int64(revision))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "replicationcontrollers %q with revision #%d\n", name, entry.Revision)
+		return kubectl.PrintRolloutRevision(entry, out)
+	}
+	fmt.Fprintf(out, "replicationcontrollers %q\n", name)
+	return kubectl.PrintRolloutHistory(history, out)
+}
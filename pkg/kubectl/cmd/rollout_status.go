@@ -0,0 +1,92 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+const (
+	rolloutStatus_long = `Watch the status of the rollout of a replication controller.
+
+Reports progress until the controller has finished rolling out, or --timeout passes.
+Use --watch=false to report the current status once.`
+	rolloutStatus_example = `# Watch the rollout of frontend until it finishes
+$ kubectl rollout status rc frontend`
+)
+
+func NewCmdRolloutStatus(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status (TYPE NAME | TYPE/NAME)",
+		Short:   "Watch the status of the rollout of a replication controller.",
+		Long:    rolloutStatus_long,
+		Example: rolloutStatus_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutStatus(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolP("watch", "w", true, "Watch the status of the rollout until it finishes.")
+	cmd.Flags().Duration("poll-interval", pollInterval, `Time delay between polling for the status of the rollout. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().Duration("timeout", timeout, `Max time to wait for the rollout to finish before giving up. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	return cmd
+}
+
+func RunRolloutStatus(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	namespace, name, err := rolloutTarget(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	updaterClient := kubectl.NewRollingUpdaterClient(client)
+
+	message, done, err := kubectl.RolloutStatus(updaterClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, message)
+	if done || !cmdutil.GetFlagBool(cmd, "watch") {
+		return nil
+	}
+
+	interval := cmdutil.GetFlagDuration(cmd, "poll-interval")
+	timeout := cmdutil.GetFlagDuration(cmd, "timeout")
+	err = wait.Poll(interval, timeout, func() (bool, error) {
+		next, done, err := kubectl.RolloutStatus(updaterClient, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		if next != message {
+			fmt.Fprint(out, next)
+			message = next
+		}
+		return done, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out waiting for the rollout of %q to finish", name)
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+func rolloutTestControllers() (*api.ReplicationController, *api.ReplicationControllerList) {
+	current := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:      "foo",
+			Namespace: "test",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/revision":     "2",
+				"kubectl.kubernetes.io/change-cause": "kubectl rolling-update foo --image=foo:v2",
+			},
+		},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: 2,
+			Selector: map[string]string{"app": "foo"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+				Spec: api.PodSpec{
+					Containers: []api.Container{{Name: "foo", Image: "foo:v2"}},
+				},
+			},
+		},
+		Status: api.ReplicationControllerStatus{Replicas: 2},
+	}
+	past := *current
+	past.Name = "foo-rev1"
+	past.Annotations = map[string]string{
+		"kubectl.kubernetes.io/revision":   "1",
+		"kubectl.kubernetes.io/history-of": "foo",
+	}
+	past.Spec.Template = &api.PodTemplateSpec{
+		ObjectMeta: api.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "foo", Image: "foo:v1"}},
+		},
+	}
+	return current, &api.ReplicationControllerList{Items: []api.ReplicationController{*current, past}}
+}
+
+func rolloutTestFactory(t *testing.T) (*bytes.Buffer, func(args []string, flags map[string]string) error) {
+	current, list := rolloutTestControllers()
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			// The resource builder and the client address the server differently.
+			switch p, m := strings.TrimPrefix(req.URL.Path, "/api/v1"), req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/foo" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, current)}, nil
+			case p == "/namespaces/test/pods/foo" && m == "GET":
+				pod := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test"}}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, pod)}, nil
+			case p == "/namespaces/test/replicationcontrollers" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, list)}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+	return buf, func(args []string, flags map[string]string) error {
+		cmd := NewCmdRollout(f, buf)
+		sub, _, err := cmd.Find(args[:1])
+		if err != nil {
+			return err
+		}
+		for key, value := range flags {
+			sub.Flags().Set(key, value)
+		}
+		switch args[0] {
+		case "history":
+			return RunRolloutHistory(f, buf, sub, args[1:])
+		case "status":
+			return RunRolloutStatus(f, buf, sub, args[1:])
+		default:
+			return RunRolloutUndo(f, buf, sub, args[1:])
+		}
+	}
+}
+
+func TestRolloutHistory(t *testing.T) {
+	buf, run := rolloutTestFactory(t)
+	if err := run([]string{"history", "replicationcontrollers", "foo"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `replicationcontrollers "foo"
+REVISION   CHANGE-CAUSE
+1          <none>
+2          kubectl rolling-update foo --image=foo:v2
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := run([]string{"history", "replicationcontrollers", "foo"}, map[string]string{"revision": "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "revision #1") || !strings.Contains(buf.String(), "foo:v1") {
+		t.Errorf("unexpected revision details:\n%s", buf.String())
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	buf, run := rolloutTestFactory(t)
+	if err := run([]string{"status", "replicationcontrollers", "foo"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "replication controller \"foo\" successfully rolled out\n", buf.String(); e != a {
+		t.Errorf("expected %q, got %q", e, a)
+	}
+}
+
+func TestRolloutUndoErrors(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		flags    map[string]string
+		expected string
+	}{
+		"current revision": {
+			args:     []string{"undo", "replicationcontrollers", "foo"},
+			flags:    map[string]string{"to-revision": "2"},
+			expected: "already at revision 2",
+		},
+		"unknown revision": {
+			args:     []string{"undo", "replicationcontrollers", "foo"},
+			flags:    map[string]string{"to-revision": "5"},
+			expected: "unable to find revision 5",
+		},
+		"unsupported kind": {
+			args:     []string{"undo", "pods", "foo"},
+			expected: "rollout is not supported for pods",
+		},
+	}
+	for name, test := range tests {
+		_, run := rolloutTestFactory(t)
+		err := run(test.args, test.flags)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected error containing %q, got %v", name, test.expected, err)
+		}
+	}
+}
+
+func TestCommandLineLeavesOutCredentials(t *testing.T) {
+	tests := map[string]string{
+		"/usr/bin/kubectl rolling-update foo --image=foo:v2":                           "kubectl rolling-update foo --image=foo:v2",
+		"kubectl rolling-update foo --image=foo:v2 --token=secret":                     "kubectl rolling-update foo --image=foo:v2",
+		"kubectl --token secret rolling-update foo --image=foo:v2":                     "kubectl rolling-update foo --image=foo:v2",
+		"kubectl --username=admin --password secret rolling-update foo -f foo-v2.yaml": "kubectl rolling-update foo -f foo-v2.yaml",
+		"kubectl rolling-update foo --token-file=foo --image=foo:v2":                   "kubectl rolling-update foo --token-file=foo --image=foo:v2",
+	}
+	for args, expected := range tests {
+		if actual := commandLine(strings.Split(args, " ")); actual != expected {
+			t.Errorf("%q: expected %q, got %q", args, expected, actual)
+		}
+	}
+}
+
+func TestChangeCauseRequiresRecord(t *testing.T) {
+	f, _, _ := NewAPIFactory()
+	cmd := NewCmdRollingUpdate(f, bytes.NewBuffer([]byte{}))
+	if cause := changeCause(cmd); cause != "" {
+		t.Errorf("expected no change cause without --record, got %q", cause)
+	}
+	cmd.Flags().Set("record", "true")
+	if cause := changeCause(cmd); cause == "" {
+		t.Errorf("expected the command to be recorded with --record")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	rolloutUndo_long = `Roll back a replication controller to a previous revision.
+
+Performs a rolling update from the current controller to the pod template of the chosen
+revision, reporting progress until the rollout finishes. The rolled back template is
+recorded as a new revision.`
+	rolloutUndo_example = `# Roll back frontend to the previous revision
+$ kubectl rollout undo rc frontend
+
+# Roll back frontend to revision 3
+$ kubectl rollout undo rc frontend --to-revision=3`
+)
+
+func NewCmdRolloutUndo(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undo (TYPE NAME | TYPE/NAME) [--to-revision=REVISION]",
+		Short:   "Roll back a replication controller to a previous revision.",
+		Long:    rolloutUndo_long,
+		Example: rolloutUndo_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutUndo(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().Int("to-revision", 0, "The revision to roll back to. Default to 0 (the previous revision).")
+	cmd.Flags().Duration("update-period", updatePeriod, `Time to wait between updating pods. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().Duration("poll-interval", pollInterval, `Time delay between polling for replication controller status after the update. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().Duration("timeout", timeout, `Max time to wait for a replication controller to update before giving up. Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+	cmd.Flags().String("deployment-label-key", "deployment", "The key to use to differentiate between the current and the rolled back controller.")
+	addRevisionHistoryLimitFlag(cmd)
+	return cmd
+}
+
+func RunRolloutUndo(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	toRevision := cmdutil.GetFlagInt(cmd, "to-revision")
+	if toRevision < 0 {
+		return cmdutil.UsageError(cmd, "--to-revision must be a positive number")
+	}
+	deploymentKey := cmdutil.GetFlagString(cmd, "deployment-label-key")
+	if len(deploymentKey) == 0 {
+		return cmdutil.UsageError(cmd, "--deployment-label-key can not be empty")
+	}
+	namespace, name, err := rolloutTarget(f, cmd, args)
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	updaterClient := kubectl.NewRollingUpdaterClient(client)
+
+	history, err := kubectl.RolloutHistory(updaterClient, namespace, name)
+	if err != nil {
+		return err
+	}
+	target, err := kubectl.FindRevision(history, int64(toRevision))
+	if err != nil {
+		return err
+	}
+	current := history[len(history)-1]
+	if target.Revision == current.Revision {
+		return fmt.Errorf("replication controller %q is already at revision %d", name, target.Revision)
+	}
+	oldRc := current.Controller
+	if next, found := kubectl.GetNextControllerAnnotation(oldRc); found {
+		return fmt.Errorf("replication controller %q has a rollout to %s in progress, use rolling-update to resume or abort it", name, next)
+	}
+
+	newRc, err := kubectl.NewRollbackController(oldRc, target.Controller, deploymentKey, client.Codec)
+	if err != nil {
+		return err
+	}
+	// Point the current controller at the rolled back one and make sure its
+	// pods can be told apart from the new ones by <deploymentKey>.
+	oldHash, err := api.HashObject(oldRc, client.Codec)
+	if err != nil {
+		return err
+	}
+	oldRc, err = kubectl.UpdateExistingReplicationController(client, oldRc, namespace, newRc.Name, deploymentKey, oldHash, out)
+	if err != nil {
+		return err
+	}
+
+	config := &kubectl.RollingUpdaterConfig{
+		Out:                  out,
+		OldRc:                oldRc,
+		NewRc:                newRc,
+		UpdatePeriod:         cmdutil.GetFlagDuration(cmd, "update-period"),
+		Interval:             cmdutil.GetFlagDuration(cmd, "poll-interval"),
+		Timeout:              cmdutil.GetFlagDuration(cmd, "timeout"),
+		CleanupPolicy:        kubectl.RenameRollingUpdateCleanupPolicy,
+		UpdateAcceptor:       kubectl.DefaultUpdateAcceptor,
+		ChangeCause:          fmt.Sprintf("rollback to revision %d", target.Revision),
+		RevisionHistoryLimit: cmdutil.GetFlagInt(cmd, "revision-history-limit"),
+	}
+	if err := kubectl.NewRollingUpdater(namespace, updaterClient).Update(config); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "replicationcontroller %q rolled back to revision %d\n", name, target.Revision)
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+)
+
+const (
+	top_long = `Display resource (CPU/memory) usage.
+
+The top command allows you to see the resource consumption of nodes and pods.
+The usage is computed from the stats the kubelets collect, which are read
+through the apiserver's node proxy.`
+)
+
+// NewCmdTop returns the parent command of 'top node' and 'top pod'.
+func NewCmdTop(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Display resource (CPU/memory) usage of nodes or pods.",
+		Long:  top_long,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(NewCmdTopNode(f, out))
+	cmd.AddCommand(NewCmdTopPod(f, out))
+	return cmd
+}
+
+// validateTopSortBy checks that the --sort-by flag names a supported resource.
+func validateTopSortBy(cmd *cobra.Command, sortBy string) error {
+	switch sortBy {
+	case "", "cpu", "memory":
+		return nil
+	}
+	return cmdutil.UsageError(cmd, "--sort-by must be either 'cpu' or 'memory', got %q", sortBy)
+}
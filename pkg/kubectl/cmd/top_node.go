@@ -0,0 +1,87 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+const (
+	top_node_long = `Display resource (CPU/memory) usage of nodes.
+
+The CPU% and MEMORY% columns are relative to the capacity of each node.`
+	top_node_example = `# Show metrics for all nodes
+$ kubectl top node
+
+# Show metrics for a given node
+$ kubectl top node NODE_NAME
+
+# Show metrics for the nodes labelled zone=us-east, busiest CPU first
+$ kubectl top node -l zone=us-east --sort-by=cpu`
+)
+
+// NewCmdTopNode returns a command to display the resource usage of nodes.
+func NewCmdTopNode(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "node [NAME | -l label]",
+		Short:   "Display resource (CPU/memory) usage of nodes.",
+		Long:    top_node_long,
+		Example: top_node_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunTopNode(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+		Aliases: []string{"nodes"},
+	}
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().String("sort-by", "", "If non-empty, sort the nodes by the usage of this resource, either 'cpu' or 'memory', busiest first.")
+	return cmd
+}
+
+// RunTopNode prints the resource usage of one or more nodes.
+func RunTopNode(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageError(cmd, "at most one node name may be specified")
+	}
+	sortBy := cmdutil.GetFlagString(cmd, "sort-by")
+	if err := validateTopSortBy(cmd, sortBy); err != nil {
+		return err
+	}
+	selector, err := labels.Parse(cmdutil.GetFlagString(cmd, "selector"))
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	metrics, err := kubectl.NewMetricsClient(client).GetNodeMetrics(name, selector)
+	if err != nil {
+		return err
+	}
+	return kubectl.PrintNodeMetrics(out, metrics, sortBy)
+}
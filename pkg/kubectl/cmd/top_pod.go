@@ -0,0 +1,106 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+const (
+	top_pod_long = `Display resource (CPU/memory) usage of pods.
+
+Only running pods are shown. The usage of a pod is the sum of the usage of its
+containers.`
+	top_pod_example = `# Show metrics for all pods in the default namespace
+$ kubectl top pod
+
+# Show metrics for all pods in all namespaces, most memory hungry first
+$ kubectl top pod --all-namespaces --sort-by=memory
+
+# Show metrics for a given pod and its containers
+$ kubectl top pod POD_NAME --containers
+
+# Show metrics for the pods defined by label name=myLabel
+$ kubectl top pod -l name=myLabel`
+)
+
+// NewCmdTopPod returns a command to display the resource usage of pods.
+func NewCmdTopPod(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pod [NAME | -l label]",
+		Short:   "Display resource (CPU/memory) usage of pods.",
+		Long:    top_pod_long,
+		Example: top_pod_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunTopPod(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+		Aliases: []string{"pods"},
+	}
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().Bool("all-namespaces", false, "If present, list the pods across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().Bool("containers", false, "If present, print the usage of each container of the pods.")
+	cmd.Flags().String("sort-by", "", "If non-empty, sort the pods by the usage of this resource, either 'cpu' or 'memory', busiest first.")
+	return cmd
+}
+
+// RunTopPod prints the resource usage of one or more pods.
+func RunTopPod(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageError(cmd, "at most one pod name may be specified")
+	}
+	sortBy := cmdutil.GetFlagString(cmd, "sort-by")
+	if err := validateTopSortBy(cmd, sortBy); err != nil {
+		return err
+	}
+	selector, err := labels.Parse(cmdutil.GetFlagString(cmd, "selector"))
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	allNamespaces := cmdutil.GetFlagBool(cmd, "all-namespaces")
+	if allNamespaces {
+		if len(name) > 0 {
+			return cmdutil.UsageError(cmd, "a pod name cannot be combined with --all-namespaces")
+		}
+		namespace = api.NamespaceAll
+	}
+
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	metrics, err := kubectl.NewMetricsClient(client).GetPodMetrics(namespace, name, selector)
+	if err != nil {
+		return err
+	}
+	return kubectl.PrintPodMetrics(out, metrics, cmdutil.GetFlagBool(cmd, "containers"), allNamespaces, sortBy)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
+)
+
+// topStats returns two stats samples a second apart for a container using
+// the given millicores and bytes of memory, with the given labels.
+func topStats(milliCores int64, memory uint64, labels map[string]string) *cadvisorApi.ContainerInfo {
+	start := time.Date(2015, 10, 1, 12, 0, 0, 0, time.UTC)
+	return &cadvisorApi.ContainerInfo{
+		Spec: cadvisorApi.ContainerSpec{Labels: labels},
+		Stats: []*cadvisorApi.ContainerStats{
+			{Timestamp: start, Cpu: cadvisorApi.CpuStats{Usage: cadvisorApi.CpuUsage{Total: 0}}},
+			{
+				Timestamp: start.Add(time.Second),
+				Cpu:       cadvisorApi.CpuStats{Usage: cadvisorApi.CpuUsage{Total: uint64(milliCores * 1e6)}},
+				Memory:    cadvisorApi.MemoryStats{WorkingSet: memory},
+			},
+		},
+	}
+}
+
+// topStatsBody returns a kubelet raw container stats response.
+func topStatsBody(t *testing.T, infos map[string]*cadvisorApi.ContainerInfo) *http.Response {
+	data, err := json.Marshal(infos)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader(data))}
+}
+
+func TestTopNode(t *testing.T) {
+	nodes := &api.NodeList{Items: []api.Node{
+		{
+			ObjectMeta: api.ObjectMeta{Name: "node1"},
+			Status: api.NodeStatus{Capacity: api.ResourceList{
+				api.ResourceCPU:    resource.MustParse("1"),
+				api.ResourceMemory: resource.MustParse("1Gi"),
+			}},
+		},
+	}}
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/v1/nodes" && m == "GET":
+				if req.URL.Query().Get("labelSelector") != "zone=a" {
+					t.Errorf("unexpected query: %v", req.URL.Query())
+				}
+				return &http.Response{StatusCode: 200, Body: objBody(codec, nodes)}, nil
+			case p == "/api/v1/proxy/nodes/node1/stats/container" && m == "POST":
+				return topStatsBody(t, map[string]*cadvisorApi.ContainerInfo{"/": topStats(250, 256*1024*1024, nil)}), nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdTopNode(f, buf)
+	cmd.Flags().Set("selector", "zone=a")
+	cmd.Run(cmd, []string{})
+
+	expected := []string{"NAME", "CPU%", "node1", "250m", "25%", "256Mi"}
+	for _, s := range expected {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, buf.String())
+		}
+	}
+}
+
+func TestTopPodAllNamespaces(t *testing.T) {
+	pods := &api.PodList{Items: []api.Pod{
+		{
+			ObjectMeta: api.ObjectMeta{Namespace: "ns1", Name: "foo", UID: "1"},
+			Spec:       api.PodSpec{NodeName: "node1", Containers: []api.Container{{Name: "c"}}},
+			Status:     api.PodStatus{Phase: api.PodRunning},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Namespace: "ns2", Name: "bar", UID: "2"},
+			Spec:       api.PodSpec{NodeName: "node1", Containers: []api.Container{{Name: "c"}}},
+			Status:     api.PodStatus{Phase: api.PodRunning},
+		},
+	}}
+	f, tf, codec := NewAPIFactory()
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/api/v1/pods" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, pods)}, nil
+			case p == "/api/v1/proxy/nodes/node1/stats/container" && m == "POST":
+				return topStatsBody(t, map[string]*cadvisorApi.ContainerInfo{
+					"/docker/foo": topStats(100, 10*1024*1024, map[string]string{kubeletTypes.KubernetesPodUIDLabel: "1", kubeletTypes.KubernetesContainerNameLabel: "c"}),
+					"/docker/bar": topStats(200, 20*1024*1024, map[string]string{kubeletTypes.KubernetesPodUIDLabel: "2", kubeletTypes.KubernetesContainerNameLabel: "c"}),
+				}), nil
+			default:
+				t.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, fmt.Errorf("unexpected request")
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	tf.ClientConfig = &client.Config{Version: testapi.Version()}
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := NewCmdTopPod(f, buf)
+	cmd.Flags().Set("all-namespaces", "true")
+	cmd.Flags().Set("sort-by", "cpu")
+	cmd.Run(cmd, []string{})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAMESPACE") || !strings.HasPrefix(lines[1], "ns2") || !strings.HasPrefix(lines[2], "ns1") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	if e, a := []string{"/bin/bash", "-c \"test\""}, (Editor{Args: []string{"/bin/bash", "-c"}, Shell: true}).args("test"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+	if e, a := []string{"/bin/bash", "-c", "test"}, (Editor{Args: []string{"/bin/bash", "-c"}, Shell: false}).args("test"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+	if e, a := []string{"/bin/bash", "-i -c \"test\""}, (Editor{Args: []string{"/bin/bash", "-i -c"}, Shell: true}).args("test"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+	if e, a := []string{"/test", "test \"test\""}, (Editor{Args: []string{"/test", "test"}, Shell: true}).args("test"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+}
+
+func TestDefaultEnvEditor(t *testing.T) {
+	defer os.Setenv("KUBE_TEST_EDITOR", os.Getenv("KUBE_TEST_EDITOR"))
+
+	os.Setenv("KUBE_TEST_EDITOR", "")
+	if args, shell := defaultEnvEditor([]string{"KUBE_TEST_EDITOR"}); !reflect.DeepEqual(args, []string{defaultEditor}) || shell {
+		t.Errorf("unexpected editor: %v %t", args, shell)
+	}
+	os.Setenv("KUBE_TEST_EDITOR", "emacs -nw")
+	if args, shell := defaultEnvEditor([]string{"KUBE_TEST_EDITOR"}); !reflect.DeepEqual(args, []string{"emacs", "-nw"}) || shell {
+		t.Errorf("unexpected editor: %v %t", args, shell)
+	}
+	os.Setenv("KUBE_TEST_EDITOR", "sed -i 's/a/b/'")
+	if args, shell := defaultEnvEditor([]string{"KUBE_TEST_EDITOR"}); args[len(args)-1] != "sed -i 's/a/b/'" || !shell {
+		t.Errorf("unexpected editor: %v %t", args, shell)
+	}
+}
+
+func TestEditor(t *testing.T) {
+	edit := Editor{Args: []string{"cat"}}
+	contents, path, err := edit.LaunchTempFile("prefix", ".yaml", bytes.NewBufferString("test something"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(path)
+	if !strings.HasSuffix(path, ".yaml") {
+		t.Errorf("expected the temporary file to keep the suffix: %s", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("no temp file: %s", path)
+	}
+	if disk, err := ioutil.ReadFile(path); err != nil || !bytes.Equal(contents, disk) {
+		t.Errorf("unexpected file on disk: %v %s", err, string(disk))
+	}
+	if !bytes.Equal(contents, []byte("test something")) {
+		t.Errorf("unexpected contents: %s", string(contents))
+	}
+}
@@ -98,10 +98,11 @@ func NewFactory(optionalClientConfig clientcmd.ClientConfig) *Factory {
 	flags.SetNormalizeFunc(util.WarnWordSepNormalizeFunc) // Warn for "_" flags
 
 	generators := map[string]kubectl.Generator{
-		"run/v1":     kubectl.BasicReplicationController{},
-		"run-pod/v1": kubectl.BasicPod{},
-		"service/v1": kubectl.ServiceGeneratorV1{},
-		"service/v2": kubectl.ServiceGeneratorV2{},
+		"run/v1":                     kubectl.BasicReplicationController{},
+		"run-pod/v1":                 kubectl.BasicPod{},
+		"service/v1":                 kubectl.ServiceGeneratorV1{},
+		"service/v2":                 kubectl.ServiceGeneratorV2{},
+		"horizontalpodautoscaler/v1": kubectl.HorizontalPodAutoscalerV1{},
 	}
 
 	clientConfig := optionalClientConfig
@@ -71,6 +71,14 @@ func CheckErr(err error) {
 	checkErr(err, fatal)
 }
 
+// CheckErrExitCode is like CheckErr, but exits with code. Commands whose exit
+// code carries meaning of its own use it to tell errors apart.
+func CheckErrExitCode(err error, code int) {
+	checkErr(err, func(msg string) {
+		fatalExitCode(msg, code)
+	})
+}
+
 func checkErr(err error, handleErr func(string)) {
 	if err == nil {
 		return
@@ -183,16 +191,21 @@ func messageForError(err error) string {
 // fatal prints the message and then exits. If V(2) or greater, glog.Fatal
 // is invoked for extended information.
 func fatal(msg string) {
+	fatalExitCode(msg, 1)
+}
+
+// fatalExitCode is like fatal, but exits with code.
+func fatalExitCode(msg string, code int) {
 	// add newline if needed
 	if !strings.HasSuffix(msg, "\n") {
 		msg += "\n"
 	}
 
 	if glog.V(2) {
-		glog.FatalDepth(2, msg)
+		glog.FatalDepth(3, msg)
 	}
 	fmt.Fprint(os.Stderr, msg)
-	os.Exit(1)
+	os.Exit(code)
 }
 
 func UsageError(cmd *cobra.Command, format string, args ...interface{}) error {
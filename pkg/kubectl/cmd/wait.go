@@ -0,0 +1,137 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubernetes/pkg/kubectl"
+	cmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+const (
+	wait_long = `Wait for a specific condition on one or many resources.
+
+The resources are selected by type and name, label selector or file. Each of them is
+watched until the condition given with --for is met:
+
+ * delete: the resource is deleted.
+ * condition=NAME[=VALUE]: the status condition NAME is VALUE, True if not given. Replication
+   controllers are Ready once their status has the desired number of replicas.
+ * jsonpath={EXPRESSION}=VALUE: the jsonpath expression evaluates to VALUE.
+
+The command exits with a non-zero status if the timeout expires before all the resources
+meet the condition.`
+	wait_example = `# Wait for the pod "busybox1" to contain the status condition of type "Ready".
+$ kubectl wait --for=condition=Ready pod/busybox1
+
+# Wait for all the pods labeled app=frontend to be running, for up to two minutes.
+$ kubectl wait --for=jsonpath={.status.phase}=Running pods -l app=frontend --timeout=2m
+
+# Wait for the replication controllers in rc.yaml to have their desired replicas.
+$ kubectl wait --for=condition=Ready -f rc.yaml
+
+# Wait for the pod "busybox1" to be deleted.
+$ kubectl delete pod/busybox1
+$ kubectl wait --for=delete pod/busybox1`
+)
+
+func NewCmdWait(f *cmdutil.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "wait (-f FILENAME | TYPE [NAME_PREFIX | -l label] | TYPE/NAME ...) --for=CONDITION [--timeout=DURATION]",
+		Short:   "Wait for a specific condition on one or many resources.",
+		Long:    wait_long,
+		Example: wait_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunWait(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().String("for", "", "The condition to wait on: delete, condition=NAME[=VALUE] or jsonpath={EXPRESSION}=VALUE.")
+	cmd.Flags().Duration("timeout", 30*time.Second, "The length of time to wait before giving up. Zero means check once and don't wait.")
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	cmd.Flags().Bool("all", false, "Select all resources of the given types in the namespace.")
+	usage := "Filename, directory, or URL to a file identifying the resources to wait on."
+	kubectl.AddJsonFilenameFlag(cmd, usage)
+	return cmd
+}
+
+func RunWait(f *cmdutil.Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	forValue := cmdutil.GetFlagString(cmd, "for")
+	if len(forValue) == 0 {
+		return cmdutil.UsageError(cmd, "--for is required")
+	}
+	condition, err := kubectl.ParseWaitCondition(forValue)
+	if err != nil {
+		return err
+	}
+	timeout := cmdutil.GetFlagDuration(cmd, "timeout")
+	if timeout < 0 {
+		return cmdutil.UsageError(cmd, "--timeout must not be negative")
+	}
+
+	cmdNamespace, enforceNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		FilenameParam(enforceNamespace, cmdutil.GetFlagStringSlice(cmd, "filename")...).
+		SelectorParam(cmdutil.GetFlagString(cmd, "selector")).
+		SelectAllParam(cmdutil.GetFlagBool(cmd, "all")).
+		ResourceTypeOrNameArgs(false, args...).
+		RequireObject(false).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+
+	// Every resource has to meet the condition within the same timeout.
+	deadline := time.Now().Add(timeout)
+	found := 0
+	err = r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		found++
+		if _, err := kubectl.WaitForResource(info, condition, deadline.Sub(time.Now())); err != nil {
+			return fmt.Errorf("error waiting for %s/%s: %v", info.Mapping.Resource, info.Name, err)
+		}
+		if forValue == "delete" {
+			fmt.Fprintf(out, "%s/%s deleted\n", info.Mapping.Resource, info.Name)
+		} else {
+			fmt.Fprintf(out, "%s/%s condition met\n", info.Mapping.Resource, info.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if found == 0 {
+		return fmt.Errorf("no resources found to wait on")
+	}
+	return nil
+}
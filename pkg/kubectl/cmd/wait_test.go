@@ -0,0 +1,142 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+func waitTestPod(ready bool) *api.Pod {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10"},
+		Spec: api.PodSpec{
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+		},
+	}
+	if ready {
+		pod.ResourceVersion = "11"
+		pod.Status.Conditions = []api.PodCondition{{Type: api.PodReady, Status: api.ConditionTrue}}
+	}
+	return pod
+}
+
+func TestWait(t *testing.T) {
+	notFound := &errors.NewNotFound("pods", "foo").(*errors.StatusError).ErrStatus
+	tests := []struct {
+		name           string
+		forValue       string
+		timeout        string
+		get            func() (int, runtime.Object)
+		events         []watch.Event
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name:           "already met",
+			forValue:       "condition=Ready",
+			get:            func() (int, runtime.Object) { return 200, waitTestPod(true) },
+			expectedOutput: "pods/foo condition met\n",
+		},
+		{
+			name:     "met after watch",
+			forValue: "condition=Ready",
+			get:      func() (int, runtime.Object) { return 200, waitTestPod(false) },
+			events: []watch.Event{
+				{Type: watch.Modified, Object: waitTestPod(false)},
+				{Type: watch.Modified, Object: waitTestPod(true)},
+			},
+			expectedOutput: "pods/foo condition met\n",
+		},
+		{
+			name:           "already deleted",
+			forValue:       "delete",
+			get:            func() (int, runtime.Object) { return 404, notFound },
+			expectedOutput: "pods/foo deleted\n",
+		},
+		{
+			name:           "deleted after watch",
+			forValue:       "delete",
+			get:            func() (int, runtime.Object) { return 200, waitTestPod(false) },
+			events:         []watch.Event{{Type: watch.Deleted, Object: waitTestPod(false)}},
+			expectedOutput: "pods/foo deleted\n",
+		},
+		{
+			name:          "deleted while waiting for a condition",
+			forValue:      "condition=Ready",
+			get:           func() (int, runtime.Object) { return 200, waitTestPod(false) },
+			events:        []watch.Event{{Type: watch.Deleted, Object: waitTestPod(false)}},
+			expectedError: "the resource was deleted",
+		},
+		{
+			name:          "timeout",
+			forValue:      "condition=Ready",
+			timeout:       "0",
+			get:           func() (int, runtime.Object) { return 200, waitTestPod(false) },
+			expectedError: "timed out waiting for the condition",
+		},
+	}
+	for _, test := range tests {
+		f, tf, codec := NewAPIFactory()
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/pods/foo" && m == "GET":
+					status, obj := test.get()
+					return &http.Response{StatusCode: status, Body: objBody(codec, obj)}, nil
+				case p == "/watch/namespaces/test/pods/foo" && m == "GET" && len(test.events) > 0:
+					return &http.Response{StatusCode: 200, Body: watchBody(codec, test.events)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %s %#v\n%#v", test.name, req.Method, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := NewCmdWait(f, buf)
+		cmd.Flags().Set("for", test.forValue)
+		if len(test.timeout) > 0 {
+			cmd.Flags().Set("timeout", test.timeout)
+		}
+		err := RunWait(f, buf, cmd, []string{"pods/foo"})
+		if len(test.expectedError) > 0 {
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if buf.String() != test.expectedOutput {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expectedOutput, buf.String())
+		}
+	}
+}
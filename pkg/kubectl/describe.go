@@ -921,6 +921,9 @@ func describeService(service *api.Service, endpoints *api.Endpoints, events *api
 		fmt.Fprintf(out, "Selector:\t%s\n", labels.FormatLabels(service.Spec.Selector))
 		fmt.Fprintf(out, "Type:\t%s\n", service.Spec.Type)
 		fmt.Fprintf(out, "IP:\t%s\n", service.Spec.ClusterIP)
+		if service.Spec.ExternalName != "" {
+			fmt.Fprintf(out, "External Name:\t%s\n", service.Spec.ExternalName)
+		}
 		if len(service.Status.LoadBalancer.Ingress) > 0 {
 			list := buildIngressString(service.Status.LoadBalancer.Ingress)
 			fmt.Fprintf(out, "LoadBalancer Ingress:\t%s\n", list)
@@ -939,6 +942,15 @@ func describeService(service *api.Service, endpoints *api.Endpoints, events *api
 			fmt.Fprintf(out, "Endpoints:\t%s\n", formatEndpoints(endpoints, util.NewStringSet(sp.Name)))
 		}
 		fmt.Fprintf(out, "Session Affinity:\t%s\n", service.Spec.SessionAffinity)
+		if service.Spec.SessionAffinityTimeoutSeconds != 0 {
+			fmt.Fprintf(out, "Session Affinity Timeout:\t%ds\n", service.Spec.SessionAffinityTimeoutSeconds)
+		}
+		if service.Spec.ExternalTrafficPolicy != "" {
+			fmt.Fprintf(out, "External Traffic Policy:\t%s\n", service.Spec.ExternalTrafficPolicy)
+		}
+		if service.Spec.HealthCheckNodePort != 0 {
+			fmt.Fprintf(out, "HealthCheck NodePort:\t%d\n", service.Spec.HealthCheckNodePort)
+		}
 		if events != nil {
 			DescribeEvents(events, out)
 		}
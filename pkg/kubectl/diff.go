@@ -0,0 +1,158 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/unifieddiff"
+)
+
+// serverPopulatedMetadata lists the metadata fields which are set by the
+// server rather than by whoever wrote the object.
+var serverPopulatedMetadata = []string{
+	"selfLink",
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+}
+
+// serverPopulatedFields lists the paths of the fields outside of the metadata
+// which the server allocates or defaults when the object leaves them unset.
+// "[]" stands for every item of a list.
+var serverPopulatedFields = [][]string{
+	// allocated to services
+	{"spec", "clusterIP"},
+	{"spec", "ports", "[]", "nodePort"},
+	// set on pods by the scheduler and the service account admission
+	// controller
+	{"spec", "nodeName"},
+	{"spec", "serviceAccountName"},
+	{"spec", "serviceAccount"},
+	// the token secrets added to service accounts
+	{"secrets"},
+}
+
+// DiffObjects returns a unified diff from the live version of an object to a
+// local one, or the empty string if they are the same. live is nil if the
+// object does not exist on the server. Both objects are encoded with codec,
+// which defaults any fields they leave unset, and compared without their
+// status and server populated metadata. The fields the server allocates or
+// defaults, e.g. the cluster IP and node ports of a service, are left out of
+// the live object when the local one does not set them. Any other field the
+// local object does not set shows up as removed.
+func DiffObjects(name string, live, local runtime.Object, codec runtime.Codec) (string, error) {
+	from, err := comparableFields(live, codec)
+	if err != nil {
+		return "", err
+	}
+	to, err := comparableFields(local, codec)
+	if err != nil {
+		return "", err
+	}
+	if from != nil && to != nil {
+		for _, path := range serverPopulatedFields {
+			removeUnsetField(from, to, path)
+		}
+	}
+	fromYAML, err := fieldsYAML(from)
+	if err != nil {
+		return "", err
+	}
+	toYAML, err := fieldsYAML(to)
+	if err != nil {
+		return "", err
+	}
+	return unifieddiff.Diff("live/"+name, "local/"+name, fromYAML, toYAML, unifieddiff.DefaultContext), nil
+}
+
+// comparableFields returns the fields of obj encoded with codec, leaving out
+// the fields populated by the server. It returns nil if obj is nil.
+func comparableFields(obj runtime.Object, codec runtime.Codec) (map[string]interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	data, err := codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverPopulatedMetadata {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, LastAppliedConfigAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return fields, nil
+}
+
+// removeUnsetField removes the field at path from live if local does not set
+// it. The items of lists are matched by their index.
+func removeUnsetField(live, local interface{}, path []string) {
+	switch live := live.(type) {
+	case map[string]interface{}:
+		local, _ := local.(map[string]interface{})
+		if len(path) == 1 {
+			if _, ok := local[path[0]]; !ok {
+				delete(live, path[0])
+			}
+			return
+		}
+		if value, ok := live[path[0]]; ok {
+			removeUnsetField(value, local[path[0]], path[1:])
+		}
+	case []interface{}:
+		if path[0] != "[]" {
+			return
+		}
+		local, _ := local.([]interface{})
+		for i := range live {
+			var localItem interface{}
+			if i < len(local) {
+				localItem = local[i]
+			}
+			removeUnsetField(live[i], localItem, path[1:])
+		}
+	}
+}
+
+// fieldsYAML returns fields encoded as YAML, or the empty string if fields is
+// nil.
+func fieldsYAML(fields map[string]interface{}) (string, error) {
+	if fields == nil {
+		return "", nil
+	}
+	out, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+func diffTestPod() *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", Labels: map[string]string{"app": "foo"}},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "foo", Image: "foo:v1"}},
+		},
+	}
+}
+
+func TestDiffObjectsIgnoresServerFields(t *testing.T) {
+	local := diffTestPod()
+	live := diffTestPod()
+	live.SelfLink = "/api/v1/namespaces/test/pods/foo"
+	live.UID = "1234"
+	live.ResourceVersion = "10"
+	live.CreationTimestamp = util.Now()
+	live.Annotations = map[string]string{LastAppliedConfigAnnotation: "{}"}
+	live.Status.Phase = api.PodRunning
+
+	diff, err := DiffObjects("pods/foo", live, local, testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no differences, got:\n%s", diff)
+	}
+}
+
+func TestDiffObjectsIgnoresAllocatedServiceFields(t *testing.T) {
+	local := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test"},
+		Spec: api.ServiceSpec{
+			Type:     api.ServiceTypeNodePort,
+			Selector: map[string]string{"app": "foo"},
+			Ports:    []api.ServicePort{{Name: "http", Protocol: api.ProtocolTCP, Port: 80, TargetPort: util.NewIntOrStringFromInt(8080)}},
+		},
+	}
+	live := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "test", ResourceVersion: "10"},
+		Spec: api.ServiceSpec{
+			Type:      api.ServiceTypeNodePort,
+			ClusterIP: "10.0.0.12",
+			Selector:  map[string]string{"app": "foo"},
+			Ports:     []api.ServicePort{{Name: "http", Protocol: api.ProtocolTCP, Port: 80, TargetPort: util.NewIntOrStringFromInt(8080), NodePort: 30080}},
+		},
+	}
+
+	diff, err := DiffObjects("services/foo", live, local, testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no differences, got:\n%s", diff)
+	}
+
+	// Fields set in both are still compared.
+	local.Spec.Ports[0].Port = 81
+	diff, err = DiffObjects("services/foo", live, local, testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"-    port: 80\n", "+    port: 81\n"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("expected %q in diff:\n%s", expected, diff)
+		}
+	}
+	if strings.Contains(diff, "nodePort") || strings.Contains(diff, "clusterIP") {
+		t.Errorf("unexpected allocated fields in diff:\n%s", diff)
+	}
+}
+
+func TestDiffObjectsRemovedFields(t *testing.T) {
+	local := diffTestPod()
+	live := diffTestPod()
+	live.Labels = map[string]string{"app": "foo", "tier": "web"}
+	live.Annotations = map[string]string{"owner": "ops"}
+	live.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+	live.Spec.NodeName = "node1"
+
+	diff, err := DiffObjects("pods/foo", live, local, testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"-    tier: web\n", "-    owner: ops\n", "-    disk: ssd\n"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("expected %q in diff:\n%s", expected, diff)
+		}
+	}
+	if strings.Contains(diff, "nodeName") {
+		t.Errorf("unexpected scheduled node in diff:\n%s", diff)
+	}
+}
+
+func TestDiffObjectsChanged(t *testing.T) {
+	local := diffTestPod()
+	local.Spec.Containers[0].Image = "foo:v2"
+	live := diffTestPod()
+	live.ResourceVersion = "10"
+
+	diff, err := DiffObjects("pods/foo", live, local, testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"--- live/pods/foo\n", "+++ local/pods/foo\n", "-  - image: foo:v1\n", "+  - image: foo:v2\n"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("expected %q in diff:\n%s", expected, diff)
+		}
+	}
+	if strings.Contains(diff, "resourceVersion") {
+		t.Errorf("unexpected resourceVersion in diff:\n%s", diff)
+	}
+}
+
+func TestDiffObjectsMissing(t *testing.T) {
+	diff, err := DiffObjects("pods/foo", nil, diffTestPod(), testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, "+kind: Pod\n") || strings.Contains(diff, "\n-") {
+		t.Errorf("expected the whole object to be added, got:\n%s", diff)
+	}
+}
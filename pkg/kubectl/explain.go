@@ -0,0 +1,200 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful/swagger"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// SplitAndParseResourceRequest separates the users input into a model and fields
+func SplitAndParseResourceRequest(inResource string, mapper meta.RESTMapper) (string, []string, error) {
+	inResource, fieldsPath := splitDotNotation(inResource)
+	_, kind, err := mapper.VersionAndKindForResource(inResource)
+	if err != nil {
+		return "", nil, err
+	}
+	return kind, fieldsPath, nil
+}
+
+// PrintModelDescription prints the description of a specific model or dot path.
+// If recursive, all components nested within the fields of the schema will be
+// printed.
+func PrintModelDescription(version, kind string, fieldsPath []string, w io.Writer, swaggerSchema *swagger.ApiDeclaration, recursive bool) error {
+	modelName := version + "." + kind
+	model, ok := swaggerSchema.Models.At(modelName)
+	if !ok {
+		return fmt.Errorf("couldn't find resource for %q", modelName)
+	}
+
+	if len(fieldsPath) == 0 {
+		return printModel(w, &model, swaggerSchema, recursive)
+	}
+
+	var (
+		fieldName string
+		prop      swagger.ModelProperty
+	)
+	for i, field := range fieldsPath {
+		prop, ok = model.Properties.At(field)
+		if !ok {
+			return fmt.Errorf("field %q does not exist", field)
+		}
+		fieldName = field
+		if i == len(fieldsPath)-1 {
+			break
+		}
+		ref, ok := propertyRef(&prop)
+		if !ok {
+			return fmt.Errorf("field %q has no sub-fields", field)
+		}
+		model, ok = swaggerSchema.Models.At(ref)
+		if !ok {
+			return fmt.Errorf("couldn't find resource for %q", ref)
+		}
+	}
+	return printField(w, fieldName, &prop, swaggerSchema, recursive)
+}
+
+// printModel prints the description and fields of a top level model.
+func printModel(w io.Writer, model *swagger.Model, schema *swagger.ApiDeclaration, recursive bool) error {
+	fmt.Fprintf(w, "DESCRIPTION:\n%s\n\n", model.Description)
+	fmt.Fprintf(w, "FIELDS:\n")
+	printFields(w, model, schema, recursive, util.NewStringSet(model.Id), 1)
+	return nil
+}
+
+// printField prints the type, description and sub-fields of a single field.
+func printField(w io.Writer, name string, prop *swagger.ModelProperty, schema *swagger.ApiDeclaration, recursive bool) error {
+	fmt.Fprintf(w, "RESOURCE: %s <%s>\n\n", name, propertyType(prop))
+	fmt.Fprintf(w, "DESCRIPTION:\n")
+	printIndented(w, prop.Description, 5)
+	ref, ok := propertyRef(prop)
+	if !ok {
+		return nil
+	}
+	model, ok := schema.Models.At(ref)
+	if !ok {
+		return nil
+	}
+	if len(model.Description) > 0 {
+		fmt.Fprintf(w, "\n")
+		printIndented(w, model.Description, 4)
+	}
+	fmt.Fprintf(w, "\nFIELDS:\n")
+	printFields(w, &model, schema, recursive, util.NewStringSet(model.Id), 1)
+	return nil
+}
+
+// printFields prints each property of the model, sorted by name, at the given
+// depth. In recursive mode only the names and types are printed, descending into
+// every referenced model that is not already being printed; otherwise the
+// descriptions of the immediate fields are printed.
+func printFields(w io.Writer, model *swagger.Model, schema *swagger.ApiDeclaration, recursive bool, seen util.StringSet, depth int) {
+	required := util.NewStringSet(model.Required...)
+	indent := strings.Repeat("   ", depth)
+	names := []string{}
+	model.Properties.Do(func(name string, prop swagger.ModelProperty) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+	for _, name := range names {
+		prop, _ := model.Properties.At(name)
+		suffix := ""
+		if required.Has(name) {
+			suffix = " -required-"
+		}
+		fmt.Fprintf(w, "%s%s\t<%s>%s\n", indent, name, propertyType(&prop), suffix)
+		if !recursive {
+			printIndented(w, prop.Description, len(indent)+2)
+			fmt.Fprintf(w, "\n")
+			continue
+		}
+		ref, ok := propertyRef(&prop)
+		if !ok || seen.Has(ref) {
+			continue
+		}
+		sub, ok := schema.Models.At(ref)
+		if !ok {
+			continue
+		}
+		seen.Insert(ref)
+		printFields(w, &sub, schema, recursive, seen, depth+1)
+		seen.Delete(ref)
+	}
+}
+
+// propertyRef returns the name of the model a property refers to, either
+// directly or as the element type of an array.
+func propertyRef(prop *swagger.ModelProperty) (string, bool) {
+	if prop.Ref != nil {
+		return *prop.Ref, true
+	}
+	if prop.Items != nil && prop.Items.Ref != nil {
+		return *prop.Items.Ref, true
+	}
+	return "", false
+}
+
+// propertyType returns a human readable type for a property: Object for
+// references to other models, []T for arrays and the swagger type otherwise.
+func propertyType(prop *swagger.ModelProperty) string {
+	if prop.Ref != nil {
+		return "Object"
+	}
+	if prop.Type == nil {
+		return "unknown"
+	}
+	if *prop.Type == "array" && prop.Items != nil {
+		switch {
+		case prop.Items.Ref != nil:
+			return "[]Object"
+		case prop.Items.Type != nil:
+			return "[]" + *prop.Items.Type
+		}
+	}
+	return *prop.Type
+}
+
+// printIndented prints a description with every line indented by the given
+// number of spaces.
+func printIndented(w io.Writer, text string, indent int) {
+	prefix := strings.Repeat(" ", indent)
+	for _, line := range strings.Split(text, "\n") {
+		if len(line) == 0 {
+			fmt.Fprintf(w, "\n")
+			continue
+		}
+		fmt.Fprintf(w, "%s%s\n", prefix, line)
+	}
+}
+
+// splitDotNotation splits a resource and its dot separated field path.
+func splitDotNotation(model string) (string, []string) {
+	var fieldsPath []string
+	dotModel := strings.Split(model, ".")
+	if len(dotModel) > 1 {
+		fieldsPath = dotModel[1:]
+	}
+	return dotModel[0], fieldsPath
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful/swagger"
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func loadSwaggerSchemaForTest(t *testing.T) *swagger.ApiDeclaration {
+	data, err := ioutil.ReadFile("../../api/swagger-spec/v1.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := &swagger.ApiDeclaration{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestSplitAndParseResourceRequest(t *testing.T) {
+	mapper := ShortcutExpander{RESTMapper: api.RESTMapper}
+	tests := []struct {
+		input      string
+		kind       string
+		fieldsPath []string
+		expectErr  bool
+	}{
+		{input: "pods", kind: "Pod"},
+		{input: "po.spec.containers", kind: "Pod", fieldsPath: []string{"spec", "containers"}},
+		{input: "svc.spec", kind: "Service", fieldsPath: []string{"spec"}},
+		{input: "unknown.spec", expectErr: true},
+	}
+	for _, test := range tests {
+		kind, fieldsPath, err := SplitAndParseResourceRequest(test.input, mapper)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+			continue
+		}
+		if kind != test.kind || strings.Join(fieldsPath, ".") != strings.Join(test.fieldsPath, ".") {
+			t.Errorf("%s: expected %s %v, got %s %v", test.input, test.kind, test.fieldsPath, kind, fieldsPath)
+		}
+	}
+}
+
+func TestPrintModelDescription(t *testing.T) {
+	schema := loadSwaggerSchemaForTest(t)
+	tests := []struct {
+		kind       string
+		fieldsPath []string
+		recursive  bool
+		expected   []string
+		unexpected []string
+		expectErr  bool
+	}{
+		{
+			kind:     "Pod",
+			expected: []string{"DESCRIPTION:\nPod is a collection of containers", "FIELDS:\n", "   metadata\t<Object>\n", "   spec\t<Object>\n"},
+		},
+		{
+			kind:       "Pod",
+			fieldsPath: []string{"spec", "containers"},
+			expected:   []string{"RESOURCE: containers <[]Object>", "List of containers belonging to the pod.", "   name\t<string> -required-\n", "   args\t<[]string>\n"},
+		},
+		{
+			kind:       "Pod",
+			fieldsPath: []string{"spec", "containers", "livenessProbe"},
+			expected:   []string{"RESOURCE: livenessProbe <Object>", "   httpGet\t<Object>\n"},
+		},
+		{
+			kind:       "Pod",
+			fieldsPath: []string{"spec"},
+			recursive:  true,
+			expected:   []string{"   containers\t<[]Object> -required-\n      args\t<[]string>\n", "      name\t<string> -required-\n", "         httpGet\t<Object>\n"},
+			unexpected: []string{"List of containers belonging to the pod."},
+		},
+		{
+			kind:       "Pod",
+			fieldsPath: []string{"spec", "unknown"},
+			expectErr:  true,
+		},
+		{
+			kind:       "Pod",
+			fieldsPath: []string{"metadata", "name", "other"},
+			expectErr:  true,
+		},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		err := PrintModelDescription("v1", test.kind, test.fieldsPath, buf, schema, test.recursive)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s %v: expected an error", test.kind, test.fieldsPath)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %v: unexpected error: %v", test.kind, test.fieldsPath, err)
+			continue
+		}
+		out := buf.String()
+		for _, s := range test.expected {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s %v: expected output to contain %q:\n%s", test.kind, test.fieldsPath, s, out)
+			}
+		}
+		for _, s := range test.unexpected {
+			if strings.Contains(out, s) {
+				t.Errorf("%s %v: unexpected %q in output:\n%s", test.kind, test.fieldsPath, s, out)
+			}
+		}
+	}
+}
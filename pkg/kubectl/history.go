@@ -0,0 +1,302 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	// revisionAnnotation records the rollout revision of a controller.
+	revisionAnnotation = kubectlAnnotationPrefix + "revision"
+	// changeCauseAnnotation records why a revision was rolled out.
+	changeCauseAnnotation = kubectlAnnotationPrefix + "change-cause"
+	// historyOfAnnotation marks a retained controller as a past revision of
+	// the controller it names.
+	historyOfAnnotation = kubectlAnnotationPrefix + "history-of"
+)
+
+// RolloutRevision is a single entry in the rollout history of a controller.
+type RolloutRevision struct {
+	Revision    int64
+	ChangeCause string
+	// Controller is the live controller for the current revision, or the
+	// retained copy for a past one.
+	Controller *api.ReplicationController
+}
+
+type revisionsByNumber []RolloutRevision
+
+func (r revisionsByNumber) Len() int           { return len(r) }
+func (r revisionsByNumber) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r revisionsByNumber) Less(i, j int) bool { return r[i].Revision < r[j].Revision }
+
+// revisionOf returns the rollout revision recorded on rc. Controllers that
+// were not created by a recorded rollout are the first revision.
+func revisionOf(rc *api.ReplicationController) int64 {
+	revision, err := strconv.ParseInt(rc.Annotations[revisionAnnotation], 10, 64)
+	if err != nil || revision < 1 {
+		return 1
+	}
+	return revision
+}
+
+// setRevision records revision and the cause of the change on rc.
+func setRevision(rc *api.ReplicationController, revision int64, changeCause string) {
+	if rc.Annotations == nil {
+		rc.Annotations = map[string]string{}
+	}
+	rc.Annotations[revisionAnnotation] = strconv.FormatInt(revision, 10)
+	if len(changeCause) > 0 {
+		rc.Annotations[changeCauseAnnotation] = changeCause
+	} else {
+		delete(rc.Annotations, changeCauseAnnotation)
+	}
+}
+
+func newRolloutRevision(rc *api.ReplicationController) RolloutRevision {
+	return RolloutRevision{
+		Revision:    revisionOf(rc),
+		ChangeCause: rc.Annotations[changeCauseAnnotation],
+		Controller:  rc,
+	}
+}
+
+// pastRevisions returns the retained revisions of the controller called name,
+// oldest first.
+func pastRevisions(c RollingUpdaterClient, namespace, name string) ([]RolloutRevision, error) {
+	list, err := c.ListReplicationControllers(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	history := []RolloutRevision{}
+	for i := range list.Items {
+		if list.Items[i].Annotations[historyOfAnnotation] == name {
+			history = append(history, newRolloutRevision(&list.Items[i]))
+		}
+	}
+	sort.Sort(revisionsByNumber(history))
+	return history, nil
+}
+
+// RolloutHistory returns the rollout history of the named controller, oldest
+// first. The last entry is always the controller's current revision.
+func RolloutHistory(c RollingUpdaterClient, namespace, name string) ([]RolloutRevision, error) {
+	rc, err := c.GetReplicationController(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	history, err := pastRevisions(c, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return append(history, newRolloutRevision(rc)), nil
+}
+
+// FindRevision returns the entry for revision in history. A revision of 0
+// selects the revision before the current one.
+func FindRevision(history []RolloutRevision, revision int64) (*RolloutRevision, error) {
+	if revision == 0 {
+		if len(history) < 2 {
+			return nil, fmt.Errorf("no previous revision found")
+		}
+		return &history[len(history)-2], nil
+	}
+	for i := range history {
+		if history[i].Revision == revision {
+			return &history[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find revision %d", revision)
+}
+
+// retainRevision saves a copy of oldRc, scaled to zero, as a past revision of
+// the controller called name, and prunes that history down to limit entries.
+// History recorded for oldRc under its own name is carried over to name.
+func (r *RollingUpdater) retainRevision(oldRc *api.ReplicationController, name string, limit int) error {
+	obj, err := api.Scheme.Copy(oldRc)
+	if err != nil {
+		return err
+	}
+	revision := revisionOf(oldRc)
+	saved := obj.(*api.ReplicationController)
+	saved.ObjectMeta = api.ObjectMeta{
+		Name:        fmt.Sprintf("%s-rev%d", name, revision),
+		Namespace:   oldRc.Namespace,
+		Labels:      saved.Labels,
+		Annotations: saved.Annotations,
+	}
+	if saved.Annotations == nil {
+		saved.Annotations = map[string]string{}
+	}
+	delete(saved.Annotations, nextControllerAnnotation)
+	saved.Annotations[historyOfAnnotation] = name
+	saved.Annotations[revisionAnnotation] = strconv.FormatInt(revision, 10)
+	saved.Spec.Replicas = 0
+	saved.Status = api.ReplicationControllerStatus{}
+	if _, err := r.c.CreateReplicationController(r.ns, saved); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	history, err := pastRevisions(r.c, r.ns, name)
+	if err != nil {
+		return err
+	}
+	if oldRc.Name != name {
+		inherited, err := pastRevisions(r.c, r.ns, oldRc.Name)
+		if err != nil {
+			return err
+		}
+		for _, entry := range inherited {
+			entry.Controller.Annotations[historyOfAnnotation] = name
+			if _, err := r.c.UpdateReplicationController(r.ns, entry.Controller); err != nil {
+				return err
+			}
+		}
+		history = append(history, inherited...)
+		sort.Sort(revisionsByNumber(history))
+	}
+	for ; len(history) > limit; history = history[1:] {
+		if err := r.c.DeleteReplicationController(r.ns, history[0].Controller.Name); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewRollbackController returns a controller which replaces current with the
+// pod template and selector of target. The new controller is told apart from
+// current by the hash stored under deploymentKey.
+func NewRollbackController(current, target *api.ReplicationController, deploymentKey string, codec runtime.Codec) (*api.ReplicationController, error) {
+	obj, err := api.Scheme.Copy(current)
+	if err != nil {
+		return nil, err
+	}
+	newRc := obj.(*api.ReplicationController)
+	obj, err = api.Scheme.Copy(target)
+	if err != nil {
+		return nil, err
+	}
+	targetSpec := obj.(*api.ReplicationController).Spec
+	if targetSpec.Template == nil {
+		return nil, fmt.Errorf("revision %d of %s has no pod template", revisionOf(target), current.Name)
+	}
+	newRc.Spec.Selector = targetSpec.Selector
+	newRc.Spec.Template = targetSpec.Template
+	if newRc.Spec.Selector == nil {
+		newRc.Spec.Selector = map[string]string{}
+	}
+	if newRc.Spec.Template.Labels == nil {
+		newRc.Spec.Template.Labels = map[string]string{}
+	}
+
+	newHash, err := api.HashObject(newRc, codec)
+	if err != nil {
+		return nil, err
+	}
+	newRc.Name = fmt.Sprintf("%s-%s", current.Name, newHash)
+	newRc.Spec.Selector[deploymentKey] = newHash
+	newRc.Spec.Template.Labels[deploymentKey] = newHash
+	// Clear resource version after hashing so that identical rollbacks get different hashes.
+	newRc.ResourceVersion = ""
+	return newRc, nil
+}
+
+// RolloutStatus reports the progress of the rollout of the named controller.
+// It returns a message describing the progress, and whether the rollout has
+// finished.
+func RolloutStatus(c RollingUpdaterClient, namespace, name string) (string, bool, error) {
+	rc, err := c.GetReplicationController(namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The controller is briefly missing while a rollout renames it.
+			return fmt.Sprintf("Waiting for replication controller %q to be renamed...\n", name), false, nil
+		}
+		return "", false, err
+	}
+	if next, found := GetNextControllerAnnotation(rc); found {
+		nextRc, err := c.GetReplicationController(namespace, next)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Sprintf("Waiting for rollout to %s to start...\n", next), false, nil
+			}
+			return "", false, err
+		}
+		desired := nextRc.Spec.Replicas
+		if replicas, found := nextRc.Annotations[desiredReplicasAnnotation]; found {
+			if desired, err = strconv.Atoi(replicas); err != nil {
+				return "", false, fmt.Errorf("Unable to parse annotation for %s: %s=%s", next, desiredReplicasAnnotation, replicas)
+			}
+		}
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d updated replicas are created...\n", nextRc.Status.Replicas, desired), false, nil
+	}
+	if rc.Status.ObservedGeneration < rc.Generation {
+		return fmt.Sprintf("Waiting for replication controller %q to be observed...\n", name), false, nil
+	}
+	if rc.Status.Replicas != rc.Spec.Replicas {
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d replicas are created...\n", rc.Status.Replicas, rc.Spec.Replicas), false, nil
+	}
+	return fmt.Sprintf("replication controller %q successfully rolled out\n", name), true, nil
+}
+
+// PrintRolloutHistory prints a table of the revisions in history.
+func PrintRolloutHistory(history []RolloutRevision, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintf(w, "REVISION\tCHANGE-CAUSE\n")
+	for _, entry := range history {
+		changeCause := entry.ChangeCause
+		if len(changeCause) == 0 {
+			changeCause = "<none>"
+		}
+		fmt.Fprintf(w, "%d\t%s\n", entry.Revision, changeCause)
+	}
+	return w.Flush()
+}
+
+// PrintRolloutRevision prints the details of a single revision.
+func PrintRolloutRevision(entry *RolloutRevision, out io.Writer) error {
+	s, err := tabbedString(func(out io.Writer) error {
+		fmt.Fprintf(out, "Revision:\t%d\n", entry.Revision)
+		fmt.Fprintf(out, "Change-Cause:\t%s\n", entry.ChangeCause)
+		fmt.Fprintf(out, "Selector:\t%s\n", labels.FormatLabels(entry.Controller.Spec.Selector))
+		template := entry.Controller.Spec.Template
+		if template == nil {
+			return nil
+		}
+		fmt.Fprintf(out, "Labels:\t%s\n", labels.FormatLabels(template.Labels))
+		fmt.Fprintf(out, "Containers:\n")
+		for _, container := range template.Spec.Containers {
+			fmt.Fprintf(out, "  %s:\n", container.Name)
+			fmt.Fprintf(out, "    Image:\t%s\n", container.Image)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, s)
+	return err
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+// fakeControllerStore returns a RollingUpdaterClient which keeps controllers
+// in the given map, keyed by name.
+func fakeControllerStore(store map[string]*api.ReplicationController) *rollingUpdaterClientImpl {
+	return &rollingUpdaterClientImpl{
+		ListReplicationControllersFn: func(namespace string, selector labels.Selector) (*api.ReplicationControllerList, error) {
+			list := &api.ReplicationControllerList{}
+			for _, rc := range store {
+				list.Items = append(list.Items, *rc)
+			}
+			return list, nil
+		},
+		GetReplicationControllerFn: func(namespace, name string) (*api.ReplicationController, error) {
+			if rc, found := store[name]; found {
+				return rc, nil
+			}
+			return nil, errors.NewNotFound("replicationControllers", name)
+		},
+		UpdateReplicationControllerFn: func(namespace string, rc *api.ReplicationController) (*api.ReplicationController, error) {
+			store[rc.Name] = rc
+			return rc, nil
+		},
+		CreateReplicationControllerFn: func(namespace string, rc *api.ReplicationController) (*api.ReplicationController, error) {
+			if _, found := store[rc.Name]; found {
+				return nil, errors.NewAlreadyExists("replicationControllers", rc.Name)
+			}
+			store[rc.Name] = rc
+			return rc, nil
+		},
+		DeleteReplicationControllerFn: func(namespace, name string) error {
+			delete(store, name)
+			return nil
+		},
+		ControllerHasDesiredReplicasFn: func(rc *api.ReplicationController) wait.ConditionFunc {
+			return func() (done bool, err error) {
+				return true, nil
+			}
+		},
+	}
+}
+
+// pastRevision returns a retained copy of a controller at revision.
+func pastRevision(name, historyOf, revision string) *api.ReplicationController {
+	rc := oldRc(0)
+	rc.Name = name
+	rc.Annotations = map[string]string{
+		historyOfAnnotation:   historyOf,
+		revisionAnnotation:    revision,
+		changeCauseAnnotation: "cause " + revision,
+	}
+	return rc
+}
+
+func revisionNumbers(history []RolloutRevision) []int64 {
+	numbers := []int64{}
+	for _, entry := range history {
+		numbers = append(numbers, entry.Revision)
+	}
+	return numbers
+}
+
+func TestRolloutHistory(t *testing.T) {
+	current := oldRc(1)
+	setRevision(current, 3, "cause 3")
+	store := map[string]*api.ReplicationController{
+		"foo-v1":      current,
+		"foo-v1-rev2": pastRevision("foo-v1-rev2", "foo-v1", "2"),
+		"foo-v1-rev1": pastRevision("foo-v1-rev1", "foo-v1", "1"),
+		"bar-rev1":    pastRevision("bar-rev1", "bar", "1"),
+	}
+	history, err := RolloutHistory(fakeControllerStore(store), "default", "foo-v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []int64{1, 2, 3}, revisionNumbers(history); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected revisions %v, got %v", e, a)
+	}
+	if history[2].Controller != current {
+		t.Errorf("expected the current controller last, got %#v", history[2].Controller)
+	}
+
+	out := &bytes.Buffer{}
+	if err := PrintRolloutHistory(history, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "REVISION   CHANGE-CAUSE\n1          cause 1\n2          cause 2\n3          cause 3\n"
+	if out.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, out.String())
+	}
+}
+
+func TestFindRevision(t *testing.T) {
+	history := []RolloutRevision{{Revision: 1}, {Revision: 2}, {Revision: 4}}
+	tests := []struct {
+		revision  int64
+		expected  int64
+		expectErr bool
+	}{
+		{revision: 0, expected: 2},
+		{revision: 1, expected: 1},
+		{revision: 4, expected: 4},
+		{revision: 3, expectErr: true},
+	}
+	for _, test := range tests {
+		entry, err := FindRevision(history, test.revision)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%d: expected an error", test.revision)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", test.revision, err)
+			continue
+		}
+		if entry.Revision != test.expected {
+			t.Errorf("%d: expected revision %d, got %d", test.revision, test.expected, entry.Revision)
+		}
+	}
+	if _, err := FindRevision(history[:1], 0); err == nil {
+		t.Errorf("expected an error without a previous revision")
+	}
+}
+
+// TestRollingUpdater_retainRevision ensures that a rolling update records the
+// new revision and keeps the replaced controller, along with its own history,
+// as the history of the new controller.
+func TestRollingUpdater_retainRevision(t *testing.T) {
+	tests := []struct {
+		policy   RollingUpdaterCleanupPolicy
+		limit    int
+		name     string
+		expected map[string]string
+	}{
+		{
+			policy: DeleteRollingUpdateCleanupPolicy,
+			limit:  2,
+			name:   "foo-v2",
+			expected: map[string]string{
+				"foo-v1-rev1": "1",
+				"foo-v2-rev2": "2",
+				"foo-v2":      "3",
+			},
+		},
+		{
+			policy: DeleteRollingUpdateCleanupPolicy,
+			limit:  1,
+			name:   "foo-v2",
+			expected: map[string]string{
+				"foo-v2-rev2": "2",
+				"foo-v2":      "3",
+			},
+		},
+		{
+			policy: RenameRollingUpdateCleanupPolicy,
+			limit:  2,
+			name:   "foo-v1",
+			expected: map[string]string{
+				"foo-v1-rev1": "1",
+				"foo-v1-rev2": "2",
+				"foo-v1":      "3",
+			},
+		},
+		{
+			policy: DeleteRollingUpdateCleanupPolicy,
+			limit:  0,
+			name:   "foo-v2",
+			expected: map[string]string{
+				"foo-v1-rev1": "1",
+				"foo-v2":      "3",
+			},
+		},
+	}
+	for i, test := range tests {
+		current := oldRc(1)
+		setRevision(current, 2, "")
+		next := newRc(1, 1)
+		next.Annotations = nil
+		store := map[string]*api.ReplicationController{
+			"foo-v1":      current,
+			"foo-v1-rev1": pastRevision("foo-v1-rev1", "foo-v1", "1"),
+		}
+		client := fakeControllerStore(store)
+		updater := &RollingUpdater{
+			ns: "default",
+			c:  client,
+			scaleAndWait: func(rc *api.ReplicationController, retry *RetryParams, wait *RetryParams) (*api.ReplicationController, error) {
+				rc.Status.Replicas = rc.Spec.Replicas
+				store[rc.Name] = rc
+				return rc, nil
+			},
+		}
+		config := &RollingUpdaterConfig{
+			Out:                  ioutil.Discard,
+			OldRc:                current,
+			NewRc:                next,
+			Interval:             time.Millisecond,
+			Timeout:              time.Millisecond,
+			CleanupPolicy:        test.policy,
+			UpdateAcceptor:       DefaultUpdateAcceptor,
+			ChangeCause:          "kubectl rolling-update foo-v1",
+			RevisionHistoryLimit: test.limit,
+		}
+		if err := updater.Update(config); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+
+		revisions := map[string]string{}
+		for name, rc := range store {
+			revisions[name] = rc.Annotations[revisionAnnotation]
+		}
+		if !reflect.DeepEqual(test.expected, revisions) {
+			t.Errorf("%d: expected controllers %v, got %v", i, test.expected, revisions)
+			continue
+		}
+		if e, a := "kubectl rolling-update foo-v1", store[test.name].Annotations[changeCauseAnnotation]; e != a {
+			t.Errorf("%d: expected change cause %q, got %q", i, e, a)
+		}
+		if test.limit == 0 {
+			continue
+		}
+		saved := store[test.name+"-rev2"]
+		if saved.Spec.Replicas != 0 || saved.Annotations[historyOfAnnotation] != test.name {
+			t.Errorf("%d: unexpected retained controller: %#v", i, saved)
+		}
+		history, err := RolloutHistory(client, "default", test.name)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if e, a := len(test.expected), len(history); e != a {
+			t.Errorf("%d: expected %d revisions, got %v", i, e, revisionNumbers(history))
+		}
+	}
+}
+
+func TestNewRollbackController(t *testing.T) {
+	current := oldRc(3)
+	current.Name = "foo"
+	current.Spec.Selector = map[string]string{"app": "foo", "deployment": "current"}
+	current.Spec.Template.Labels = map[string]string{"app": "foo", "deployment": "current"}
+	current.Spec.Template.Spec.Containers = []api.Container{{Name: "foo", Image: "foo:v2"}}
+	setRevision(current, 2, "upgrade")
+
+	target := oldRc(0)
+	target.Name = "foo-rev1"
+	target.Spec.Selector = map[string]string{"app": "foo", "deployment": "target"}
+	target.Spec.Template.Labels = map[string]string{"app": "foo", "deployment": "target"}
+	target.Spec.Template.Spec.Containers = []api.Container{{Name: "foo", Image: "foo:v1"}}
+
+	rc, err := NewRollbackController(current, target, "deployment", testapi.Codec())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hash := rc.Spec.Selector["deployment"]
+	if hash == "current" || hash == "target" || rc.Name != "foo-"+hash {
+		t.Errorf("unexpected name %s for selector %v", rc.Name, rc.Spec.Selector)
+	}
+	if !reflect.DeepEqual(rc.Spec.Selector, rc.Spec.Template.Labels) {
+		t.Errorf("expected the selector %v to match the template labels %v", rc.Spec.Selector, rc.Spec.Template.Labels)
+	}
+	if rc.Spec.Replicas != 3 || rc.Spec.Template.Spec.Containers[0].Image != "foo:v1" {
+		t.Errorf("unexpected rollback controller: %#v", rc.Spec)
+	}
+	if current.Spec.Template.Spec.Containers[0].Image != "foo:v2" || target.Spec.Selector["deployment"] != "target" {
+		t.Errorf("expected the current and target controllers to be left unchanged")
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	steady := oldRc(2)
+	inProgress := oldRc(2)
+	inProgress.Name = "foo"
+	SetNextControllerAnnotation(inProgress, "foo-v2")
+	scaling := oldRc(2)
+	scaling.Name = "scaling"
+	scaling.Status.Replicas = 1
+	unobserved := oldRc(2)
+	unobserved.Name = "unobserved"
+	unobserved.Generation = 2
+	unobserved.Status.ObservedGeneration = 1
+	store := map[string]*api.ReplicationController{
+		steady.Name:     steady,
+		inProgress.Name: inProgress,
+		"foo-v2":        newRc(1, 3),
+		scaling.Name:    scaling,
+		unobserved.Name: unobserved,
+	}
+	tests := []struct {
+		name     string
+		expected string
+		done     bool
+	}{
+		{steady.Name, "replication controller \"foo-v1\" successfully rolled out\n", true},
+		{inProgress.Name, "Waiting for rollout to finish: 1 of 3 updated replicas are created...\n", false},
+		{scaling.Name, "Waiting for rollout to finish: 1 of 2 replicas are created...\n", false},
+		{unobserved.Name, "Waiting for replication controller \"unobserved\" to be observed...\n", false},
+	}
+	for _, test := range tests {
+		message, done, err := RolloutStatus(fakeControllerStore(store), "default", test.name)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if message != test.expected || done != test.done {
+			t.Errorf("%s: expected %q (%t), got %q (%t)", test.name, test.expected, test.done, message, done)
+		}
+	}
+}
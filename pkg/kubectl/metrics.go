@@ -0,0 +1,452 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/golang/glog"
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fields"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// NodeMetrics is the resource usage of a node.
+type NodeMetrics struct {
+	Name      string
+	Timestamp util.Time
+	// Usage is the current cpu and memory usage of the node.
+	Usage api.ResourceList
+	// Capacity is the cpu and memory capacity of the node.
+	Capacity api.ResourceList
+}
+
+// PodMetrics is the resource usage of a pod, aggregated over its containers.
+type PodMetrics struct {
+	Namespace  string
+	Name       string
+	Timestamp  util.Time
+	Usage      api.ResourceList
+	Containers []ContainerMetrics
+}
+
+// ContainerMetrics is the resource usage of a single container of a pod.
+type ContainerMetrics struct {
+	Name  string
+	Usage api.ResourceList
+}
+
+// kubeletStatsRequest is the request body understood by the kubelet's /stats/ endpoint.
+type kubeletStatsRequest struct {
+	ContainerName string `json:"containerName,omitempty"`
+	NumStats      int    `json:"num_stats,omitempty"`
+	Subcontainers bool   `json:"subcontainers,omitempty"`
+}
+
+// podContainerKey identifies a container of a pod in the stats of a node.
+type podContainerKey struct {
+	podUID    types.UID
+	container string
+}
+
+// MetricsClient collects the cpu and memory usage of nodes and pods from the
+// kubelets' stats endpoints, going through the apiserver's node proxy.
+type MetricsClient struct {
+	client *client.Client
+}
+
+// NewMetricsClient returns a MetricsClient that talks to the kubelets through c.
+func NewMetricsClient(c *client.Client) *MetricsClient {
+	return &MetricsClient{client: c}
+}
+
+// GetNodeMetrics returns the usage of the named node, or of all nodes matching
+// the selector when name is empty. Nodes whose kubelet cannot be reached are
+// skipped when listing.
+func (m *MetricsClient) GetNodeMetrics(name string, selector labels.Selector) ([]NodeMetrics, error) {
+	var nodes []api.Node
+	if len(name) > 0 {
+		node, err := m.client.Nodes().Get(name)
+		if err != nil {
+			return nil, err
+		}
+		nodes = []api.Node{*node}
+	} else {
+		list, err := m.client.Nodes().List(selector, fields.Everything())
+		if err != nil {
+			return nil, err
+		}
+		nodes = list.Items
+	}
+
+	// Every node is asked for its stats concurrently.
+	infos := make([]*cadvisorApi.ContainerInfo, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i], errs[i] = m.getNodeStats(nodes[i].Name)
+		}(i)
+	}
+	wg.Wait()
+
+	result := []NodeMetrics{}
+	for i := range nodes {
+		node := &nodes[i]
+		err := errs[i]
+		if err == nil {
+			var usage api.ResourceList
+			var timestamp util.Time
+			usage, timestamp, err = usageFromStats(infos[i])
+			if err == nil {
+				result = append(result, NodeMetrics{Name: node.Name, Timestamp: timestamp, Usage: usage, Capacity: node.Status.Capacity})
+				continue
+			}
+		}
+		if len(name) > 0 {
+			return nil, fmt.Errorf("unable to get metrics for node %q: %v", node.Name, err)
+		}
+		glog.V(2).Infof("Skipping node %q: %v", node.Name, err)
+	}
+	return result, nil
+}
+
+// GetPodMetrics returns the usage of the named pod, or of all pods in the
+// namespace matching the selector when name is empty. Use api.NamespaceAll to
+// list pods in every namespace. Pods that are not running are skipped.
+func (m *MetricsClient) GetPodMetrics(namespace, name string, selector labels.Selector) ([]PodMetrics, error) {
+	var pods []api.Pod
+	if len(name) > 0 {
+		pod, err := m.client.Pods(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if pod.Status.Phase != api.PodRunning || len(pod.Spec.NodeName) == 0 {
+			return nil, fmt.Errorf("pod %q is not running", name)
+		}
+		pods = []api.Pod{*pod}
+	} else {
+		list, err := m.client.Pods(namespace).List(selector, fields.Everything())
+		if err != nil {
+			return nil, err
+		}
+		pods = list.Items
+	}
+
+	// The stats of all containers of a node are fetched in a single request,
+	// and every node is asked concurrently.
+	running := []*api.Pod{}
+	nodeStats := map[string]map[podContainerKey]*cadvisorApi.ContainerInfo{}
+	nodeErrs := map[string]error{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != api.PodRunning || len(pod.Spec.NodeName) == 0 {
+			continue
+		}
+		running = append(running, pod)
+		nodeStats[pod.Spec.NodeName] = nil
+	}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for node := range nodeStats {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			stats, err := m.getPodContainerStats(node)
+			lock.Lock()
+			defer lock.Unlock()
+			nodeStats[node], nodeErrs[node] = stats, err
+		}(node)
+	}
+	wg.Wait()
+
+	result := []PodMetrics{}
+	for _, pod := range running {
+		err := nodeErrs[pod.Spec.NodeName]
+		var metrics *PodMetrics
+		if err == nil {
+			metrics, err = podMetricsFromStats(pod, nodeStats[pod.Spec.NodeName])
+		}
+		if err != nil {
+			if len(name) > 0 {
+				return nil, fmt.Errorf("unable to get metrics for pod %q: %v", pod.Name, err)
+			}
+			glog.V(2).Infof("Skipping pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		result = append(result, *metrics)
+	}
+	return result, nil
+}
+
+// podMetricsFromStats sums the usage of all containers of a running pod, taken
+// from the container stats of its node.
+func podMetricsFromStats(pod *api.Pod, stats map[podContainerKey]*cadvisorApi.ContainerInfo) (*PodMetrics, error) {
+	metrics := &PodMetrics{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Usage:     api.ResourceList{},
+	}
+	for _, container := range pod.Spec.Containers {
+		info, ok := stats[podContainerKey{pod.UID, container.Name}]
+		if !ok {
+			return nil, fmt.Errorf("no stats returned for container %q", container.Name)
+		}
+		usage, timestamp, err := usageFromStats(info)
+		if err != nil {
+			return nil, fmt.Errorf("container %q: %v", container.Name, err)
+		}
+		if timestamp.After(metrics.Timestamp.Time) {
+			metrics.Timestamp = timestamp
+		}
+		for resourceName, quantity := range usage {
+			total, found := metrics.Usage[resourceName]
+			if !found {
+				metrics.Usage[resourceName] = *quantity.Copy()
+				continue
+			}
+			total.Add(quantity)
+			metrics.Usage[resourceName] = total
+		}
+		metrics.Containers = append(metrics.Containers, ContainerMetrics{Name: container.Name, Usage: usage})
+	}
+	return metrics, nil
+}
+
+// getNodeStats fetches the latest two stats samples of the root container of
+// the given node.
+func (m *MetricsClient) getNodeStats(node string) (*cadvisorApi.ContainerInfo, error) {
+	infos := map[string]*cadvisorApi.ContainerInfo{}
+	if err := m.postStats(node, "stats/container", kubeletStatsRequest{ContainerName: "/", NumStats: 2}, &infos); err != nil {
+		return nil, err
+	}
+	info, ok := infos["/"]
+	if !ok {
+		return nil, fmt.Errorf("no stats returned for the root container")
+	}
+	return info, nil
+}
+
+// getPodContainerStats fetches the latest two stats samples of every container
+// of the given node in one request, and returns those of the containers the
+// kubelet runs for pods, keyed by pod uid and container name. The containers
+// are told apart by the labels the kubelet sets on them, whatever the runtime.
+func (m *MetricsClient) getPodContainerStats(node string) (map[podContainerKey]*cadvisorApi.ContainerInfo, error) {
+	infos := map[string]*cadvisorApi.ContainerInfo{}
+	if err := m.postStats(node, "stats/container", kubeletStatsRequest{ContainerName: "/", NumStats: 2, Subcontainers: true}, &infos); err != nil {
+		return nil, err
+	}
+	stats := map[podContainerKey]*cadvisorApi.ContainerInfo{}
+	for _, info := range infos {
+		podUID, container := info.Spec.Labels[kubeletTypes.KubernetesPodUIDLabel], info.Spec.Labels[kubeletTypes.KubernetesContainerNameLabel]
+		if len(podUID) == 0 || len(container) == 0 {
+			continue
+		}
+		stats[podContainerKey{types.UID(podUID), container}] = info
+	}
+	return stats, nil
+}
+
+// postStats sends a stats request to the kubelet of the given node through the
+// apiserver's node proxy and decodes the response into out.
+func (m *MetricsClient) postStats(node, path string, req kubeletStatsRequest, out interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	data, err := m.client.Post().
+		Prefix("proxy").
+		Resource("nodes").
+		Name(node).
+		Suffix(path).
+		Body(body).
+		DoRaw()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("unable to decode stats: %v", err)
+	}
+	return nil
+}
+
+// usageFromStats computes the cpu usage rate from the two most recent samples
+// and the memory working set from the latest one.
+func usageFromStats(info *cadvisorApi.ContainerInfo) (api.ResourceList, util.Time, error) {
+	if len(info.Stats) < 2 {
+		return nil, util.Time{}, fmt.Errorf("not enough stats samples to compute usage")
+	}
+	stats := info.Stats
+	sort.Sort(byTimestamp(stats))
+	prev, latest := stats[len(stats)-2], stats[len(stats)-1]
+	interval := latest.Timestamp.Sub(prev.Timestamp).Nanoseconds()
+	if interval <= 0 || latest.Cpu.Usage.Total < prev.Cpu.Usage.Total {
+		return nil, util.Time{}, fmt.Errorf("invalid stats samples")
+	}
+	// cpu usage is in nanoseconds of cpu time, so the rate in millicores is
+	// the usage delta in milliseconds per second.
+	milliCores := int64((latest.Cpu.Usage.Total - prev.Cpu.Usage.Total) * 1000 / uint64(interval))
+	usage := api.ResourceList{
+		api.ResourceCPU:    *resource.NewMilliQuantity(milliCores, resource.DecimalSI),
+		api.ResourceMemory: *resource.NewQuantity(int64(latest.Memory.WorkingSet), resource.BinarySI),
+	}
+	return usage, util.NewTime(latest.Timestamp), nil
+}
+
+type byTimestamp []*cadvisorApi.ContainerStats
+
+func (s byTimestamp) Len() int           { return len(s) }
+func (s byTimestamp) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTimestamp) Less(i, j int) bool { return s[i].Timestamp.Before(s[j].Timestamp) }
+
+// PrintNodeMetrics prints the usage of the nodes as a table, sorted by name or,
+// when sortBy is "cpu" or "memory", by that usage in decreasing order.
+func PrintNodeMetrics(out io.Writer, metrics []NodeMetrics, sortBy string) error {
+	sort.Sort(nodeMetricsSorter{metrics, api.ResourceName(sortBy)})
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAME\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%")
+	for _, m := range metrics {
+		cpu, memory := m.Usage[api.ResourceCPU], m.Usage[api.ResourceMemory]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", m.Name,
+			formatCPU(cpu), formatPercent(cpu, m.Capacity[api.ResourceCPU]),
+			formatMemory(memory), formatPercent(memory, m.Capacity[api.ResourceMemory]))
+	}
+	return nil
+}
+
+// PrintPodMetrics prints the usage of the pods as a table, sorted by namespace
+// and name or, when sortBy is "cpu" or "memory", by that usage in decreasing
+// order. If printContainers is set, every container is printed on its own line.
+func PrintPodMetrics(out io.Writer, metrics []PodMetrics, printContainers, withNamespace bool, sortBy string) error {
+	sort.Sort(podMetricsSorter{metrics, api.ResourceName(sortBy)})
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	if withNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	if printContainers {
+		fmt.Fprint(w, "POD\t")
+	}
+	fmt.Fprintln(w, "NAME\tCPU(cores)\tMEMORY(bytes)")
+	for _, m := range metrics {
+		if !printContainers {
+			if withNamespace {
+				fmt.Fprintf(w, "%s\t", m.Namespace)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", m.Name, formatCPU(m.Usage[api.ResourceCPU]), formatMemory(m.Usage[api.ResourceMemory]))
+			continue
+		}
+		for _, c := range m.Containers {
+			if withNamespace {
+				fmt.Fprintf(w, "%s\t", m.Namespace)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Name, c.Name, formatCPU(c.Usage[api.ResourceCPU]), formatMemory(c.Usage[api.ResourceMemory]))
+		}
+	}
+	return nil
+}
+
+func formatCPU(q resource.Quantity) string {
+	if q.Amount == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+func formatMemory(q resource.Quantity) string {
+	if q.Amount == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+func formatPercent(usage, capacity resource.Quantity) string {
+	if usage.Amount == nil || capacity.Amount == nil || capacity.MilliValue() == 0 {
+		return "<none>"
+	}
+	return fmt.Sprintf("%d%%", usage.MilliValue()*100/capacity.MilliValue())
+}
+
+// usageGreater reports whether a uses strictly more of the resource than b.
+func usageGreater(a, b api.ResourceList, name api.ResourceName) bool {
+	qa, qb := a[name], b[name]
+	var va, vb int64
+	if qa.Amount != nil {
+		va = qa.MilliValue()
+	}
+	if qb.Amount != nil {
+		vb = qb.MilliValue()
+	}
+	return va > vb
+}
+
+type nodeMetricsSorter struct {
+	metrics []NodeMetrics
+	sortBy  api.ResourceName
+}
+
+func (s nodeMetricsSorter) Len() int      { return len(s.metrics) }
+func (s nodeMetricsSorter) Swap(i, j int) { s.metrics[i], s.metrics[j] = s.metrics[j], s.metrics[i] }
+func (s nodeMetricsSorter) Less(i, j int) bool {
+	a, b := &s.metrics[i], &s.metrics[j]
+	if len(s.sortBy) > 0 {
+		if usageGreater(a.Usage, b.Usage, s.sortBy) {
+			return true
+		}
+		if usageGreater(b.Usage, a.Usage, s.sortBy) {
+			return false
+		}
+	}
+	return a.Name < b.Name
+}
+
+type podMetricsSorter struct {
+	metrics []PodMetrics
+	sortBy  api.ResourceName
+}
+
+func (s podMetricsSorter) Len() int      { return len(s.metrics) }
+func (s podMetricsSorter) Swap(i, j int) { s.metrics[i], s.metrics[j] = s.metrics[j], s.metrics[i] }
+func (s podMetricsSorter) Less(i, j int) bool {
+	a, b := &s.metrics[i], &s.metrics[j]
+	if len(s.sortBy) > 0 {
+		if usageGreater(a.Usage, b.Usage, s.sortBy) {
+			return true
+		}
+		if usageGreater(b.Usage, a.Usage, s.sortBy) {
+			return false
+		}
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/testapi"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// testContainerStats returns two stats samples taken a second apart for a
+// container using the given millicores and bytes of memory.
+func testContainerStats(milliCores int64, memory uint64) *cadvisorApi.ContainerInfo {
+	start := time.Date(2015, 10, 1, 12, 0, 0, 0, time.UTC)
+	return &cadvisorApi.ContainerInfo{
+		Stats: []*cadvisorApi.ContainerStats{
+			{
+				Timestamp: start.Add(time.Second),
+				Cpu:       cadvisorApi.CpuStats{Usage: cadvisorApi.CpuUsage{Total: uint64(1e9 + milliCores*1e6)}},
+				Memory:    cadvisorApi.MemoryStats{WorkingSet: memory},
+			},
+			{
+				Timestamp: start,
+				Cpu:       cadvisorApi.CpuStats{Usage: cadvisorApi.CpuUsage{Total: 1e9}},
+				Memory:    cadvisorApi.MemoryStats{WorkingSet: memory / 2},
+			},
+		},
+	}
+}
+
+// testPodContainerStats returns the stats of testContainerStats for the
+// container the kubelet runs for a container of a pod.
+func testPodContainerStats(milliCores int64, memory uint64, podUID, container string) *cadvisorApi.ContainerInfo {
+	info := testContainerStats(milliCores, memory)
+	info.Spec.Labels = map[string]string{
+		kubeletTypes.KubernetesPodUIDLabel:        podUID,
+		kubeletTypes.KubernetesContainerNameLabel: container,
+	}
+	return info
+}
+
+// fakeKubeletStatsServer serves the given objects from the API and the given
+// raw container stats, keyed by node and container name, from the node proxy.
+// It counts the stats requests sent to every node in requests.
+func fakeKubeletStatsServer(t *testing.T, objects map[string]runtime.Object, stats map[string]map[string]*cadvisorApi.ContainerInfo, requests map[string]int) *httptest.Server {
+	var lock sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, "/api/v1/proxy/nodes/") {
+			parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/api/v1/proxy/nodes/"), "/", 2)
+			if req.Method != "POST" || len(parts) != 2 || parts[1] != "stats/container" {
+				t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+			}
+			lock.Lock()
+			requests[parts[0]]++
+			lock.Unlock()
+			infos, ok := stats[parts[0]]
+			if !ok {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			statsReq := kubeletStatsRequest{}
+			if err := json.NewDecoder(req.Body).Decode(&statsReq); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if statsReq.Subcontainers {
+				json.NewEncoder(w).Encode(infos)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]*cadvisorApi.ContainerInfo{statsReq.ContainerName: infos[statsReq.ContainerName]})
+			return
+		}
+		obj, ok := objects[req.URL.Path]
+		if !ok || req.Method != "GET" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(runtime.EncodeOrDie(testapi.Codec(), obj)))
+	}))
+}
+
+func testMetricsNode(name string, cpu, memory string) api.Node {
+	return api.Node{
+		ObjectMeta: api.ObjectMeta{Name: name},
+		Status: api.NodeStatus{
+			Capacity: api.ResourceList{
+				api.ResourceCPU:    resource.MustParse(cpu),
+				api.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func testMetricsPod(namespace, name, node string, phase api.PodPhase, containers ...string) api.Pod {
+	pod := api.Pod{
+		ObjectMeta: api.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name)},
+		Spec:       api.PodSpec{NodeName: node},
+		Status:     api.PodStatus{Phase: phase},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, api.Container{Name: c})
+	}
+	return pod
+}
+
+func TestGetNodeMetrics(t *testing.T) {
+	nodes := &api.NodeList{Items: []api.Node{
+		testMetricsNode("node1", "2", "4Gi"),
+		testMetricsNode("node2", "4", "8Gi"),
+		testMetricsNode("broken", "1", "1Gi"),
+	}}
+	server := fakeKubeletStatsServer(t,
+		map[string]runtime.Object{"/api/v1/nodes": nodes, "/api/v1/nodes/node2": &nodes.Items[1]},
+		map[string]map[string]*cadvisorApi.ContainerInfo{
+			"node1": {"/": testContainerStats(500, 1024*1024*1024)},
+			"node2": {"/": testContainerStats(3000, 2*1024*1024*1024)},
+		}, map[string]int{})
+	defer server.Close()
+	c := client.NewOrDie(&client.Config{Host: server.URL, Version: testapi.Version()})
+
+	metrics, err := NewMetricsClient(c).GetNodeMetrics("", labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("expected the unreachable node to be skipped, got %#v", metrics)
+	}
+	cpu, memory := metrics[0].Usage[api.ResourceCPU], metrics[0].Usage[api.ResourceMemory]
+	if metrics[0].Name != "node1" || cpu.MilliValue() != 500 || memory.Value() != 1024*1024*1024 {
+		t.Errorf("unexpected metrics for node1: %#v", metrics[0])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := PrintNodeMetrics(buf, metrics, "cpu"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "node2") || !strings.Contains(lines[1], "3000m") || !strings.Contains(lines[1], "75%") || !strings.Contains(lines[2], "1024Mi") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	metrics, err = NewMetricsClient(c).GetNodeMetrics("node2", labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Name != "node2" {
+		t.Errorf("unexpected metrics: %#v", metrics)
+	}
+}
+
+func TestGetPodMetrics(t *testing.T) {
+	pods := &api.PodList{Items: []api.Pod{
+		testMetricsPod("test", "foo", "node1", api.PodRunning, "a", "b"),
+		testMetricsPod("test", "bar", "node1", api.PodRunning, "c"),
+		testMetricsPod("test", "pending", "", api.PodPending, "d"),
+		testMetricsPod("test", "baz", "node2", api.PodRunning, "e"),
+		testMetricsPod("test", "lost", "broken", api.PodRunning, "f"),
+	}}
+	requests := map[string]int{}
+	server := fakeKubeletStatsServer(t,
+		map[string]runtime.Object{"/api/v1/namespaces/test/pods": pods},
+		map[string]map[string]*cadvisorApi.ContainerInfo{
+			"node1": {
+				"/":             testContainerStats(1000, 100*1024*1024),
+				"/docker":       testContainerStats(600, 40*1024*1024),
+				"/docker/a":     testPodContainerStats(100, 10*1024*1024, "uid-foo", "a"),
+				"/docker/b":     testPodContainerStats(50, 20*1024*1024, "uid-foo", "b"),
+				"/docker/c":     testPodContainerStats(400, 5*1024*1024, "uid-bar", "c"),
+				"/docker/pod":   testPodContainerStats(1, 1024*1024, "uid-foo", "POD"),
+				"/docker/other": testContainerStats(200, 50*1024*1024),
+			},
+			"node2": {
+				"/": testContainerStats(1000, 100*1024*1024),
+			},
+		}, requests)
+	defer server.Close()
+	c := client.NewOrDie(&client.Config{Host: server.URL, Version: testapi.Version()})
+
+	metrics, err := NewMetricsClient(c).GetPodMetrics("test", "", labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("expected the pending pod and the pods without stats to be skipped, got %#v", metrics)
+	}
+	if requests["node1"] != 1 || requests["node2"] != 1 || requests["broken"] != 1 {
+		t.Errorf("expected a single stats request per node, got %v", requests)
+	}
+	cpu, memory := metrics[0].Usage[api.ResourceCPU], metrics[0].Usage[api.ResourceMemory]
+	if metrics[0].Name != "foo" || cpu.MilliValue() != 150 || memory.Value() != 30*1024*1024 || len(metrics[0].Containers) != 2 {
+		t.Errorf("unexpected metrics for foo: %#v", metrics[0])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := PrintPodMetrics(buf, metrics, false, false, "memory"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "NAME      CPU(cores)   MEMORY(bytes)\nfoo       150m         30Mi\nbar       400m         5Mi\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := PrintPodMetrics(buf, metrics, true, true, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = "NAMESPACE   POD       NAME      CPU(cores)   MEMORY(bytes)\ntest        bar       c         400m         5Mi\ntest        foo       a         100m         10Mi\ntest        foo       b         50m          20Mi\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
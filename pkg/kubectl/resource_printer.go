@@ -340,6 +340,9 @@ var persistentVolumeColumns = []string{"NAME", "LABELS", "CAPACITY", "ACCESSMODE
 var persistentVolumeClaimColumns = []string{"NAME", "LABELS", "STATUS", "VOLUME", "CAPACITY", "ACCESSMODES", "AGE"}
 var componentStatusColumns = []string{"NAME", "STATUS", "MESSAGE", "ERROR"}
 var thirdPartyResourceColumns = []string{"NAME", "DESCRIPTION", "VERSION(S)"}
+var horizontalPodAutoscalerColumns = []string{"NAME", "REFERENCE", "TARGET", "CURRENT", "MINPODS", "MAXPODS", "REPLICAS", "AGE"}
+var deploymentColumns = []string{"NAME", "UPDATEDREPLICAS", "AGE"}
+var networkPolicyColumns = []string{"NAME", "POD-SELECTOR", "AGE"}
 var withNamespacePrefixColumns = []string{"NAMESPACE"} // TODO(erictune): print cluster name too.
 
 // addDefaultHandlers adds print handlers for default Kubernetes types.
@@ -376,6 +379,12 @@ func (h *HumanReadablePrinter) addDefaultHandlers() {
 	h.Handler(componentStatusColumns, printComponentStatusList)
 	h.Handler(thirdPartyResourceColumns, printThirdPartyResource)
 	h.Handler(thirdPartyResourceColumns, printThirdPartyResourceList)
+	h.Handler(horizontalPodAutoscalerColumns, printHorizontalPodAutoscaler)
+	h.Handler(horizontalPodAutoscalerColumns, printHorizontalPodAutoscalerList)
+	h.Handler(deploymentColumns, printDeployment)
+	h.Handler(deploymentColumns, printDeploymentList)
+	h.Handler(networkPolicyColumns, printNetworkPolicy)
+	h.Handler(networkPolicyColumns, printNetworkPolicyList)
 }
 
 func (h *HumanReadablePrinter) unknown(data []byte, w io.Writer) error {
@@ -399,24 +408,36 @@ func formatEndpoints(endpoints *api.Endpoints, ports util.StringSet) string {
 	max := 3
 	more := false
 	count := 0
+	add := func(addr *api.EndpointAddress, port *api.EndpointPort, ready bool) {
+		if len(list) == max {
+			more = true
+		}
+		if !more {
+			endpoint := fmt.Sprintf("%s:%d", addr.IP, port.Port)
+			if !ready {
+				endpoint += " (not ready)"
+			}
+			list = append(list, endpoint)
+		}
+		count++
+	}
 	for i := range endpoints.Subsets {
 		ss := &endpoints.Subsets[i]
 		for i := range ss.Ports {
 			port := &ss.Ports[i]
 			if ports == nil || ports.Has(port.Name) {
 				for i := range ss.Addresses {
-					if len(list) == max {
-						more = true
-					}
-					addr := &ss.Addresses[i]
-					if !more {
-						list = append(list, fmt.Sprintf("%s:%d", addr.IP, port.Port))
-					}
-					count++
+					add(&ss.Addresses[i], port, true)
+				}
+				for i := range ss.NotReadyAddresses {
+					add(&ss.NotReadyAddresses[i], port, false)
 				}
 			}
 		}
 	}
+	if len(list) == 0 {
+		return "<none>"
+	}
 	ret := strings.Join(list, ",")
 	if more {
 		return fmt.Sprintf("%s + %d more...", ret, count-max)
@@ -668,6 +689,8 @@ func getServiceExternalIP(svc *api.Service) string {
 			result = append(result, svc.Spec.ExternalIPs...)
 		}
 		return strings.Join(result, ",")
+	case api.ServiceTypeExternalName:
+		return svc.Spec.ExternalName
 	}
 	return "unknown"
 }
@@ -1096,6 +1119,103 @@ func printThirdPartyResourceList(list *expapi.ThirdPartyResourceList, w io.Write
 	return nil
 }
 
+func printHorizontalPodAutoscaler(hpa *expapi.HorizontalPodAutoscaler, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	reference := "<none>"
+	if ref := hpa.Spec.ScaleRef; ref != nil {
+		reference = fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	target := fmt.Sprintf("%s %s", hpa.Spec.Target.Quantity.String(), hpa.Spec.Target.Resource)
+	current := "<waiting>"
+	replicas := "<waiting>"
+	if hpa.Status != nil {
+		if hpa.Status.CurrentConsumption != nil {
+			current = fmt.Sprintf("%s %s", hpa.Status.CurrentConsumption.Quantity.String(), hpa.Status.CurrentConsumption.Resource)
+		}
+		replicas = fmt.Sprintf("%d", hpa.Status.CurrentReplicas)
+	}
+
+	if withNamespace {
+		if _, err := fmt.Fprintf(w, "%s\t", hpa.Namespace); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s",
+		hpa.Name,
+		reference,
+		target,
+		current,
+		hpa.Spec.MinCount,
+		hpa.Spec.MaxCount,
+		replicas,
+		translateTimestamp(hpa.CreationTimestamp),
+	); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, appendLabels(hpa.Labels, columnLabels))
+	return err
+}
+
+func printHorizontalPodAutoscalerList(list *expapi.HorizontalPodAutoscalerList, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	for i := range list.Items {
+		if err := printHorizontalPodAutoscaler(&list.Items[i], w, withNamespace, wide, showAll, columnLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printDeployment(deployment *expapi.Deployment, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	if withNamespace {
+		if _, err := fmt.Fprintf(w, "%s\t", deployment.Namespace); err != nil {
+			return err
+		}
+	}
+	updatedReplicas := fmt.Sprintf("%d/%d", deployment.Status.UpdatedReplicas, deployment.Spec.Replicas)
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%s", deployment.Name, updatedReplicas, translateTimestamp(deployment.CreationTimestamp)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, appendLabels(deployment.Labels, columnLabels))
+	return err
+}
+
+func printDeploymentList(list *expapi.DeploymentList, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	for i := range list.Items {
+		if err := printDeployment(&list.Items[i], w, withNamespace, wide, showAll, columnLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printNetworkPolicy(policy *expapi.NetworkPolicy, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	podSelector := "<none>"
+	if selector, err := expapi.LabelSelectorAsSelector(&policy.Spec.PodSelector); err != nil {
+		podSelector = "<invalid>"
+	} else if !selector.Empty() {
+		podSelector = selector.String()
+	}
+
+	if withNamespace {
+		if _, err := fmt.Fprintf(w, "%s\t", policy.Namespace); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s\t%s\t%s", policy.Name, podSelector, translateTimestamp(policy.CreationTimestamp)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, appendLabels(policy.Labels, columnLabels))
+	return err
+}
+
+func printNetworkPolicyList(list *expapi.NetworkPolicyList, w io.Writer, withNamespace bool, wide bool, showAll bool, columnLabels []string) error {
+	for i := range list.Items {
+		if err := printNetworkPolicy(&list.Items[i], w, withNamespace, wide, showAll, columnLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func appendLabels(itemLabels map[string]string, columnLabels []string) string {
 	var buffer bytes.Buffer
 
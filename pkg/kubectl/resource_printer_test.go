@@ -27,8 +27,10 @@ import (
 	"time"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
 
@@ -743,6 +745,18 @@ func TestPrintHumanReadableService(t *testing.T) {
 				},
 			},
 		},
+		{
+			Spec: api.ServiceSpec{
+				Type:         api.ServiceTypeExternalName,
+				ExternalName: "db.example.com",
+				Ports: []api.ServicePort{
+					{
+						Port:     5432,
+						Protocol: "TCP",
+					},
+				},
+			},
+		},
 	}
 
 	for _, svc := range tests {
@@ -754,6 +768,10 @@ func TestPrintHumanReadableService(t *testing.T) {
 			t.Errorf("expected to contain ClusterIP %s, but doesn't: %s", ip, output)
 		}
 
+		if !strings.Contains(output, svc.Spec.ExternalName) {
+			t.Errorf("expected to contain external name %s, but doesn't: %s", svc.Spec.ExternalName, output)
+		}
+
 		for _, ingress := range svc.Status.LoadBalancer.Ingress {
 			ip = ingress.IP
 			if !strings.Contains(output, ip) {
@@ -1221,3 +1239,132 @@ func TestTranslateTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintHorizontalPodAutoscaler(t *testing.T) {
+	spec := expapi.HorizontalPodAutoscalerSpec{
+		ScaleRef: &expapi.SubresourceReference{Kind: "ReplicationController", Name: "frontend", Subresource: "scale"},
+		MinCount: 1,
+		MaxCount: 10,
+		Target:   expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("400m")},
+	}
+	tests := []struct {
+		hpa    expapi.HorizontalPodAutoscaler
+		expect string
+	}{
+		{
+			expapi.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "frontend"},
+				Spec:       spec,
+			},
+			"frontend\tReplicationController/frontend\t400m cpu\t<waiting>\t1\t10\t<waiting>\t<unknown>\n",
+		},
+		{
+			expapi.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "frontend"},
+				Spec:       spec,
+				Status: &expapi.HorizontalPodAutoscalerStatus{
+					CurrentReplicas:    3,
+					DesiredReplicas:    4,
+					CurrentConsumption: &expapi.ResourceConsumption{Resource: api.ResourceCPU, Quantity: resource.MustParse("550m")},
+				},
+			},
+			"frontend\tReplicationController/frontend\t400m cpu\t550m cpu\t1\t10\t3\t<unknown>\n",
+		},
+	}
+	buf := bytes.NewBuffer([]byte{})
+	for _, test := range tests {
+		printHorizontalPodAutoscaler(&test.hpa, buf, false, false, false, []string{})
+		if buf.String() != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, buf.String())
+		}
+		buf.Reset()
+	}
+}
+
+func TestPrintNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		policy expapi.NetworkPolicy
+		expect string
+	}{
+		{
+			expapi.NetworkPolicy{
+				ObjectMeta: api.ObjectMeta{Name: "deny-all"},
+			},
+			"deny-all\t<none>\t<unknown>\n",
+		},
+		{
+			expapi.NetworkPolicy{
+				ObjectMeta: api.ObjectMeta{Name: "db"},
+				Spec: expapi.NetworkPolicySpec{
+					PodSelector: expapi.LabelSelector{
+						MatchLabels: map[string]string{"name": "db"},
+						MatchExpressions: []expapi.LabelSelectorRequirement{
+							{Key: "tier", Operator: expapi.LabelSelectorOpIn, Values: []string{"backend"}},
+						},
+					},
+				},
+			},
+			"db\tname in (db),tier in (backend)\t<unknown>\n",
+		},
+	}
+	buf := bytes.NewBuffer([]byte{})
+	for _, test := range tests {
+		printNetworkPolicy(&test.policy, buf, false, false, false, []string{})
+		if buf.String() != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, buf.String())
+		}
+		buf.Reset()
+	}
+}
+
+func TestPrintEndpoints(t *testing.T) {
+	tests := []struct {
+		endpoints api.Endpoints
+		expect    string
+	}{
+		{
+			api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "empty"},
+			},
+			"empty\t<none>\t<unknown>\n",
+		},
+		{
+			api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "starting"},
+				Subsets: []api.EndpointSubset{{
+					NotReadyAddresses: []api.EndpointAddress{{IP: "10.1.0.1"}},
+					Ports:             []api.EndpointPort{{Port: 80}},
+				}},
+			},
+			"starting\t10.1.0.1:80 (not ready)\t<unknown>\n",
+		},
+		{
+			api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "no-addresses"},
+				Subsets: []api.EndpointSubset{{
+					Ports: []api.EndpointPort{{Port: 80}},
+				}},
+			},
+			"no-addresses\t<none>\t<unknown>\n",
+		},
+		{
+			api.Endpoints{
+				ObjectMeta: api.ObjectMeta{Name: "web"},
+				Subsets: []api.EndpointSubset{{
+					Addresses:         []api.EndpointAddress{{IP: "10.1.0.1"}, {IP: "10.1.0.2"}},
+					NotReadyAddresses: []api.EndpointAddress{{IP: "10.1.0.3"}, {IP: "10.1.0.4"}},
+					Ports:             []api.EndpointPort{{Port: 80}},
+				}},
+			},
+			"web\t10.1.0.1:80,10.1.0.2:80,10.1.0.3:80 (not ready) + 1 more...\t<unknown>\n",
+		},
+	}
+	buf := bytes.NewBuffer([]byte{})
+	for _, test := range tests {
+		printEndpoints(&test.endpoints, buf, false, false, false, []string{})
+		if buf.String() != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, buf.String())
+		}
+		buf.Reset()
+	}
+}
@@ -77,6 +77,14 @@ type RollingUpdaterConfig struct {
 	// scaled up and down each interval. If UpdatePercent is negative, the order
 	// of scaling will be down/up instead of up/down.
 	UpdatePercent *int
+	// ChangeCause is recorded on the new controller to describe why it was
+	// rolled out. If empty, no cause is recorded.
+	ChangeCause string
+	// RevisionHistoryLimit is the number of old controllers to retain, scaled
+	// to zero, as the rollout history of the new controller. The old
+	// controller is only retained when it would otherwise be deleted. If 0,
+	// no history is kept.
+	RevisionHistoryLimit int
 }
 
 // RollingUpdaterCleanupPolicy is a cleanup action to take after the
@@ -403,6 +411,7 @@ func (r *RollingUpdater) Update(config *RollingUpdaterConfig) error {
 		}
 		newRc.ObjectMeta.Annotations[desiredReplicasAnnotation] = fmt.Sprintf("%d", desired)
 		newRc.ObjectMeta.Annotations[sourceIdAnnotation] = sourceId
+		setRevision(newRc, revisionOf(oldRc)+1, config.ChangeCause)
 		newRc.Spec.Replicas = 0
 		newRc, err = r.c.CreateReplicationController(r.ns, newRc)
 		if err != nil {
@@ -465,6 +474,18 @@ func (r *RollingUpdater) Update(config *RollingUpdaterConfig) error {
 		return err
 	}
 
+	// Retain the old controller as history of the new one before it is deleted.
+	cleanupDeletes := config.CleanupPolicy == DeleteRollingUpdateCleanupPolicy || config.CleanupPolicy == RenameRollingUpdateCleanupPolicy
+	if config.RevisionHistoryLimit > 0 && cleanupDeletes {
+		historyName := newName
+		if config.CleanupPolicy == RenameRollingUpdateCleanupPolicy {
+			historyName = oldName
+		}
+		if err := r.retainRevision(oldRc, historyName, config.RevisionHistoryLimit); err != nil {
+			return err
+		}
+	}
+
 	switch config.CleanupPolicy {
 	case DeleteRollingUpdateCleanupPolicy:
 		// delete old rc
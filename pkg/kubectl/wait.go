@@ -0,0 +1,205 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/jsonpath"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// WaitCondition returns whether obj, the current version of a resource,
+// meets the condition waited for. obj is nil once the resource is deleted.
+type WaitCondition func(obj runtime.Object) (bool, error)
+
+// ParseWaitCondition parses the value of the --for flag of kubectl wait:
+// "delete" waits for the resource to be deleted, "condition=NAME[=VALUE]"
+// for its status condition NAME to be VALUE, True by default, and
+// "jsonpath={EXPRESSION}=VALUE" for the expression to evaluate to VALUE.
+func ParseWaitCondition(value string) (WaitCondition, error) {
+	switch {
+	case value == "delete":
+		return func(obj runtime.Object) (bool, error) {
+			return obj == nil, nil
+		}, nil
+	case strings.HasPrefix(value, "condition="):
+		name := strings.TrimPrefix(value, "condition=")
+		status := string(api.ConditionTrue)
+		if i := strings.Index(name, "="); i != -1 {
+			name, status = name[:i], name[i+1:]
+		}
+		if len(name) == 0 || len(status) == 0 {
+			return nil, fmt.Errorf("expected condition=NAME[=VALUE], got %q", value)
+		}
+		return statusCondition(name, status), nil
+	case strings.HasPrefix(value, "jsonpath="):
+		expr := strings.TrimPrefix(value, "jsonpath=")
+		i := strings.LastIndex(expr, "}=")
+		if i == -1 {
+			return nil, fmt.Errorf("expected jsonpath={EXPRESSION}=VALUE, got %q", value)
+		}
+		expr, expected := expr[:i+1], expr[i+2:]
+		j := jsonpath.New("wait")
+		if err := j.Parse(expr); err != nil {
+			return nil, fmt.Errorf("error parsing jsonpath %s: %v", expr, err)
+		}
+		return jsonPathCondition(j, expected), nil
+	}
+	return nil, fmt.Errorf("unrecognized condition %q, expected delete, condition=NAME[=VALUE] or jsonpath={EXPRESSION}=VALUE", value)
+}
+
+// statusCondition returns a condition which is met when the status condition
+// of the given type has the given status. Replication controllers have no
+// status conditions, so for them Ready means they have the desired replicas.
+func statusCondition(conditionType, status string) WaitCondition {
+	return func(obj runtime.Object) (bool, error) {
+		if obj == nil {
+			return false, fmt.Errorf("the resource was deleted")
+		}
+		if rc, ok := obj.(*api.ReplicationController); ok && conditionType == "Ready" {
+			ready := client.ControllerStatusHasDesiredReplicas(rc, rc.Generation)
+			return strings.EqualFold(status, fmt.Sprintf("%t", ready)), nil
+		}
+		fields, err := objectFields(obj)
+		if err != nil {
+			return false, err
+		}
+		statusFields, _ := fields["status"].(map[string]interface{})
+		conditions, _ := statusFields["conditions"].([]interface{})
+		for _, condition := range conditions {
+			condition, ok := condition.(map[string]interface{})
+			if !ok || condition["type"] != conditionType {
+				continue
+			}
+			current, _ := condition["status"].(string)
+			return strings.EqualFold(current, status), nil
+		}
+		return false, nil
+	}
+}
+
+// jsonPathCondition returns a condition which is met when j evaluates to
+// expected.
+func jsonPathCondition(j *jsonpath.JSONPath, expected string) WaitCondition {
+	return func(obj runtime.Object) (bool, error) {
+		if obj == nil {
+			return false, fmt.Errorf("the resource was deleted")
+		}
+		fields, err := objectFields(obj)
+		if err != nil {
+			return false, err
+		}
+		buf := &bytes.Buffer{}
+		if err := j.Execute(buf, fields); err != nil {
+			// The fields may not have been populated yet.
+			return false, nil
+		}
+		return buf.String() == expected, nil
+	}
+}
+
+// objectFields returns the fields of obj as they are serialized.
+func objectFields(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// WaitForResource waits until the resource described by info meets
+// condition, watching it for changes, and returns its last version. It gives
+// up with wait.ErrWaitTimeout once timeout has passed, and checks the
+// condition only once if timeout is not positive.
+func WaitForResource(info *resource.Info, condition WaitCondition, timeout time.Duration) (runtime.Object, error) {
+	deadline := time.Now().Add(timeout)
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	for {
+		obj, err := helper.Get(info.Namespace, info.Name)
+		if errors.IsNotFound(err) {
+			obj, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if met, err := condition(obj); met || err != nil {
+			return obj, err
+		}
+		remaining := deadline.Sub(time.Now())
+		if remaining <= 0 {
+			return obj, wait.ErrWaitTimeout
+		}
+
+		resourceVersion, err := info.Mapping.MetadataAccessor.ResourceVersion(obj)
+		if err != nil {
+			return nil, err
+		}
+		w, err := helper.WatchSingle(info.Namespace, info.Name, resourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		obj, done, err := waitForEvent(w, condition, time.After(remaining))
+		w.Stop()
+		if done || err != nil {
+			return obj, err
+		}
+		// The watch ended early, start over from the current version.
+	}
+}
+
+// waitForEvent checks the objects of the events received from w against
+// condition until it is met. It returns false without an error if the watch
+// ends before that.
+func waitForEvent(w watch.Interface, condition WaitCondition, timeout <-chan time.Time) (runtime.Object, bool, error) {
+	for {
+		select {
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil, false, nil
+			}
+			var obj runtime.Object
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				obj = event.Object
+			case watch.Deleted:
+				obj = nil
+			default:
+				return nil, false, nil
+			}
+			if met, err := condition(obj); met || err != nil {
+				return obj, true, err
+			}
+		case <-timeout:
+			return nil, false, wait.ErrWaitTimeout
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	readyPod := &api.Pod{
+		Status: api.PodStatus{
+			Phase:      api.PodRunning,
+			Conditions: []api.PodCondition{{Type: api.PodReady, Status: api.ConditionTrue}},
+		},
+	}
+	pendingPod := &api.Pod{
+		Status: api.PodStatus{Phase: api.PodPending},
+	}
+	syncedRC := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Generation: 2},
+		Spec:       api.ReplicationControllerSpec{Replicas: 3},
+		Status:     api.ReplicationControllerStatus{Replicas: 3, ObservedGeneration: 2},
+	}
+	staleRC := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Generation: 2},
+		Spec:       api.ReplicationControllerSpec{Replicas: 3},
+		Status:     api.ReplicationControllerStatus{Replicas: 3, ObservedGeneration: 1},
+	}
+
+	tests := []struct {
+		value       string
+		obj         runtime.Object
+		expected    bool
+		expectError bool
+	}{
+		{value: "delete", obj: nil, expected: true},
+		{value: "delete", obj: readyPod, expected: false},
+		{value: "condition=Ready", obj: readyPod, expected: true},
+		{value: "condition=Ready=false", obj: readyPod, expected: false},
+		{value: "condition=Ready", obj: pendingPod, expected: false},
+		{value: "condition=Ready=False", obj: pendingPod, expected: false},
+		{value: "condition=Ready", obj: nil, expectError: true},
+		{value: "condition=Ready", obj: syncedRC, expected: true},
+		{value: "condition=Ready", obj: staleRC, expected: false},
+		{value: "condition=Ready=false", obj: staleRC, expected: true},
+		{value: "jsonpath={.status.phase}=Running", obj: readyPod, expected: true},
+		{value: "jsonpath={.status.phase}=Running", obj: pendingPod, expected: false},
+		{value: "jsonpath={.status.replicas}=3", obj: syncedRC, expected: true},
+		{value: "jsonpath={.status.missing}=3", obj: syncedRC, expected: false},
+		{value: "jsonpath={.status.phase}=Running", obj: nil, expectError: true},
+	}
+	for _, test := range tests {
+		condition, err := ParseWaitCondition(test.value)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.value, err)
+			continue
+		}
+		met, err := condition(test.obj)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.value, err)
+			continue
+		}
+		if met != test.expected {
+			t.Errorf("%s: expected %t for %#v", test.value, test.expected, test.obj)
+		}
+	}
+}
+
+func TestParseWaitConditionInvalid(t *testing.T) {
+	for _, value := range []string{"", "deleted", "condition=", "condition==True", "condition=Ready=", "jsonpath=.status", "jsonpath={.status=Running", "jsonpath={.status[}=1"} {
+		if _, err := ParseWaitCondition(value); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
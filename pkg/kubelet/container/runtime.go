@@ -76,10 +76,10 @@ type Runtime interface {
 	RemoveImage(image ImageSpec) error
 	// TODO(vmarmol): Unify pod and containerID args.
 	// GetContainerLogs returns logs of a specific container. By
-	// default, it returns a snapshot of the container log. Set 'follow' to true to
-	// stream the log. Set 'follow' to false and specify the number of lines (e.g.
-	// "100" or "all") to tail the log.
-	GetContainerLogs(pod *api.Pod, containerID, tail string, follow bool, stdout, stderr io.Writer) (err error)
+	// default, it returns a snapshot of the container log. Set 'Follow' in
+	// logOptions to true to stream the log, and use 'SinceSeconds', 'SinceTime',
+	// 'TailLines' and 'Timestamps' to narrow or annotate the output.
+	GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) (err error)
 	// ContainerCommandRunner encapsulates the command runner interfaces for testability.
 	ContainerCommandRunner
 	// ContainerAttach encapsulates the attaching to containers for testability
@@ -50,6 +50,7 @@ type FakeDockerClient struct {
 	Information         docker.Env
 	ExecInspect         *docker.ExecInspect
 	execCmd             []string
+	createdLabels       []map[string]string
 }
 
 func (f *FakeDockerClient) ClearCalls() {
@@ -59,6 +60,7 @@ func (f *FakeDockerClient) ClearCalls() {
 	f.Stopped = []string{}
 	f.pulled = []string{}
 	f.Created = []string{}
+	f.createdLabels = nil
 	f.Removed = []string{}
 }
 
@@ -182,6 +184,7 @@ func (f *FakeDockerClient) CreateContainer(c docker.CreateContainerOptions) (*do
 	err := f.popError("create")
 	if err == nil {
 		f.Created = append(f.Created, c.Name)
+		f.createdLabels = append(f.createdLabels, c.Config.Labels)
 		// This is not a very good fake. We'll just add this container's name to the list.
 		// Docker likes to add a '/', so copy that behavior.
 		name := "/" + c.Name
@@ -42,6 +42,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/network/cni"
 	"k8s.io/kubernetes/pkg/kubelet/prober"
 	"k8s.io/kubernetes/pkg/kubelet/qos"
 	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
@@ -70,7 +71,7 @@ const (
 	kubernetesNameLabel                   = "io.kubernetes.pod.name"
 	kubernetesPodLabel                    = "io.kubernetes.pod.data"
 	kubernetesTerminationGracePeriodLabel = "io.kubernetes.pod.terminationGracePeriod"
-	kubernetesContainerLabel              = "io.kubernetes.container.name"
+	kubernetesContainerLabel              = kubeletTypes.KubernetesContainerNameLabel
 )
 
 // DockerManager implements the Runtime interface.
@@ -243,24 +244,33 @@ func (sc *stringCache) Get(uid types.UID, name string) (string, bool) {
 }
 
 // GetContainerLogs returns logs of a specific container. By
-// default, it returns a snapshot of the container log. Set 'follow' to true to
-// stream the log. Set 'follow' to false and specify the number of lines (e.g.
-// "100" or "all") to tail the log.
+// default, it returns a snapshot of the container log. Set 'Follow' to true to
+// stream the log. Set 'TailLines' to restrict the output to the last lines of
+// the log, and 'SinceSeconds' or 'SinceTime' to skip older entries.
 // TODO: Make 'RawTerminal' option  flagable.
-func (dm *DockerManager) GetContainerLogs(pod *api.Pod, containerID, tail string, follow bool, stdout, stderr io.Writer) (err error) {
+func (dm *DockerManager) GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) (err error) {
+	var since int64
+	if logOptions.SinceSeconds != nil {
+		t := util.Now().Add(-time.Duration(*logOptions.SinceSeconds) * time.Second)
+		since = t.Unix()
+	}
+	if logOptions.SinceTime != nil {
+		since = logOptions.SinceTime.Unix()
+	}
 	opts := docker.LogsOptions{
 		Container:    containerID,
 		Stdout:       true,
 		Stderr:       true,
 		OutputStream: stdout,
 		ErrorStream:  stderr,
-		Timestamps:   false,
+		Timestamps:   logOptions.Timestamps,
+		Since:        since,
+		Follow:       logOptions.Follow,
 		RawTerminal:  false,
-		Follow:       follow,
 	}
 
-	if !follow {
-		opts.Tail = tail
+	if logOptions.TailLines != nil {
+		opts.Tail = strconv.FormatInt(*logOptions.TailLines, 10)
 	}
 
 	err = dm.client.Logs(opts)
@@ -314,6 +324,10 @@ func (dm *DockerManager) inspectContainer(dockerID, containerName, tPath string,
 		if containerName == PodInfraContainerName {
 			if inspectResult.NetworkSettings != nil {
 				result.ip = inspectResult.NetworkSettings.IPAddress
+				if result.ip == "" {
+					// Containers on an IPv6 only docker network have no IPv4 address.
+					result.ip = inspectResult.NetworkSettings.GlobalIPv6Address
+				}
 			}
 			// override the above if a network plugin exists
 			if dm.networkPlugin.Name() != network.DefaultPluginName {
@@ -613,7 +627,9 @@ func (dm *DockerManager) runContainer(
 	// TODO: keep these labels up to date if the pod changes
 	namespacedName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 	labels := map[string]string{
-		kubernetesNameLabel: namespacedName.String(),
+		kubernetesNameLabel:                namespacedName.String(),
+		kubeletTypes.KubernetesPodUIDLabel: string(pod.UID),
+		kubernetesContainerLabel:           container.Name,
 	}
 	if pod.Spec.TerminationGracePeriodSeconds != nil {
 		labels[kubernetesTerminationGracePeriodLabel] = strconv.FormatInt(*pod.Spec.TerminationGracePeriodSeconds, 10)
@@ -625,7 +641,6 @@ func (dm *DockerManager) runContainer(
 			glog.Errorf("Failed to encode pod: %s for prestop hook", pod.Name)
 		} else {
 			labels[kubernetesPodLabel] = string(data)
-			labels[kubernetesContainerLabel] = container.Name
 		}
 	}
 	memoryLimit := container.Resources.Limits.Memory().Value()
@@ -1090,6 +1105,23 @@ func (dm *DockerManager) AttachContainer(containerId string, stdin io.Reader, st
 	return dm.client.AttachToContainer(opts)
 }
 
+// GetNetNs returns the path of the network namespace of a running container.
+// hostNetwork is true, and the path empty, when the container uses the
+// network namespace of the host.
+func (dm *DockerManager) GetNetNs(containerID string) (netns string, hostNetwork bool, err error) {
+	inspectResult, err := dm.client.InspectContainer(containerID)
+	if err != nil {
+		return "", false, err
+	}
+	if inspectResult.HostConfig != nil && inspectResult.HostConfig.NetworkMode == "host" {
+		return "", true, nil
+	}
+	if !inspectResult.State.Running || inspectResult.State.Pid == 0 {
+		return "", false, fmt.Errorf("container not running (%s)", containerID)
+	}
+	return fmt.Sprintf("/proc/%d/ns/net", inspectResult.State.Pid), false, nil
+}
+
 func noPodInfraContainerError(podName, podNamespace string) error {
 	return fmt.Errorf("cannot find pod infra container in pod %q", kubecontainer.BuildPodFullName(podName, podNamespace))
 }
@@ -1476,6 +1508,10 @@ func (dm *DockerManager) createPodInfraContainer(pod *api.Pod) (kubeletTypes.Doc
 
 	if pod.Spec.HostNetwork {
 		netNamespace = "host"
+	} else if dm.networkPlugin.Name() == cni.CNIPluginName {
+		// The CNI plugin sets up the network namespace of the pod, and
+		// ports are not exported.
+		netNamespace = "none"
 	} else {
 		// Docker only exports ports from the pod infra container.  Let's
 		// collect all of the relevant ports and export them.
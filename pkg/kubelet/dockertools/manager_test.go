@@ -37,7 +37,9 @@ import (
 	"k8s.io/kubernetes/pkg/client/unversioned/record"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/network"
+	"k8s.io/kubernetes/pkg/kubelet/network/cni"
 	kubeprober "k8s.io/kubernetes/pkg/kubelet/prober"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/probe"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
@@ -909,6 +911,37 @@ func TestSyncPodCreateNetAndContainer(t *testing.T) {
 	fakeDocker.Unlock()
 }
 
+func TestSyncPodLabelsContainersWithPodAndContainer(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	fakeDocker.ContainerList = []docker.APIContainers{}
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{Name: "bar"},
+			},
+		},
+	}
+
+	runSyncPod(t, dm, fakeDocker, pod, nil)
+
+	fakeDocker.Lock()
+	defer fakeDocker.Unlock()
+	if len(fakeDocker.createdLabels) != 2 {
+		t.Fatalf("unexpected containers created %v", fakeDocker.Created)
+	}
+	for i, name := range []string{PodInfraContainerName, "bar"} {
+		labels := fakeDocker.createdLabels[i]
+		if labels[kubeletTypes.KubernetesPodUIDLabel] != "12345678" || labels[kubeletTypes.KubernetesContainerNameLabel] != name {
+			t.Errorf("unexpected labels of container %q: %v", name, labels)
+		}
+	}
+}
+
 func TestSyncPodCreatesNetAndContainerPullsImage(t *testing.T) {
 	dm, fakeDocker := newTestDockerManager()
 	dm.podInfraContainerImage = "pod_infra_image"
@@ -1778,6 +1811,44 @@ func TestGetPodCreationFailureReason(t *testing.T) {
 	}
 }
 
+func TestGetPodStatusIPv6(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "bar"}},
+		},
+	}
+
+	fakeDocker.ContainerList = []docker.APIContainers{
+		{
+			Names: []string{"/k8s_POD." + strconv.FormatUint(generatePodInfraContainerHash(pod), 16) + "_foo_new_12345678_0"},
+			ID:    "9876",
+		},
+	}
+	fakeDocker.ContainerMap = map[string]*docker.Container{
+		"9876": {
+			ID:              "9876",
+			HostConfig:      &docker.HostConfig{},
+			Config:          &docker.Config{},
+			State:           docker.State{Running: true},
+			NetworkSettings: &docker.NetworkSettings{GlobalIPv6Address: "fd00::4"},
+		},
+	}
+
+	status, err := dm.GetPodStatus(pod)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if status.PodIP != "fd00::4" {
+		t.Errorf("expected pod IP fd00::4, got %q", status.PodIP)
+	}
+}
+
 func TestGetPodPullImageFailureReason(t *testing.T) {
 	dm, fakeDocker := newTestDockerManager()
 	// Initialize the FakeDockerPuller so that it'd try to pull non-existent
@@ -2151,6 +2222,64 @@ func TestSyncPodWithHostNetwork(t *testing.T) {
 	fakeDocker.Unlock()
 }
 
+func TestCreatePodInfraContainerWithCNI(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	dm.networkPlugin = cni.ProbeNetworkPlugins("", "")[0]
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "new",
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{Name: "bar", Ports: []api.ContainerPort{{ContainerPort: 8080, HostPort: 80}}},
+			},
+		},
+	}
+
+	id, err := dm.createPodInfraContainer(pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fakeDocker.Lock()
+	hostConfig := fakeDocker.Container.HostConfig
+	if hostConfig.NetworkMode != "none" {
+		t.Errorf("Pod infra container must have \"none\" networkMode with the CNI plugin, actual: %q", hostConfig.NetworkMode)
+	}
+	if len(hostConfig.PortBindings) != 0 {
+		t.Errorf("Pod infra container must not export ports with the CNI plugin, actual: %v", hostConfig.PortBindings)
+	}
+	fakeDocker.Unlock()
+
+	netns, hostNetwork, err := dm.GetNetNs(string(id))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := fmt.Sprintf("/proc/%d/ns/net", os.Getpid()); netns != expected || hostNetwork {
+		t.Errorf("expected network namespace %q, got %q (host network %t)", expected, netns, hostNetwork)
+	}
+}
+
+func TestGetNetNsOfHostNetworkContainer(t *testing.T) {
+	dm, fakeDocker := newTestDockerManager()
+	fakeDocker.ContainerMap = map[string]*docker.Container{
+		"infra": {
+			ID:         "infra",
+			State:      docker.State{Running: true, Pid: 42},
+			HostConfig: &docker.HostConfig{NetworkMode: "host"},
+		},
+	}
+	netns, hostNetwork, err := dm.GetNetNs("infra")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hostNetwork || netns != "" {
+		t.Errorf("expected the host network, got network namespace %q (host network %t)", netns, hostNetwork)
+	}
+}
+
 func TestGetPodStatusSortedContainers(t *testing.T) {
 	dm, fakeDocker := newTestDockerManager()
 	dockerInspect := map[string]*docker.Container{}
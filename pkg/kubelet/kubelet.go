@@ -58,6 +58,7 @@ import (
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
 	utilErrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/mount"
 	nodeutil "k8s.io/kubernetes/pkg/util/node"
@@ -280,6 +281,9 @@ func NewMainKubelet(
 		pods:                           pods,
 		syncLoopMonitor:                util.AtomicValue{},
 		resolverConfig:                 resolverConfig,
+		newPodShaper: func(netns string) bandwidth.BandwidthShaper {
+			return bandwidth.NewTCShaperInNetNs(netns, "eth0")
+		},
 	}
 
 	if plug, err := network.InitNetworkPlugin(networkPlugins, networkPluginName, &networkHost{klet}); err != nil {
@@ -536,6 +540,15 @@ type Kubelet struct {
 	configureCBR0 bool
 	podCIDR       string
 
+	// Limits the bandwidth sent to pods on cbr0, set up along with cbr0 by
+	// the node status loop and used by the pod workers, hence shaperLock.
+	shaperLock sync.Mutex
+	shaper     bandwidth.BandwidthShaper
+
+	// Returns the shaper limiting the bandwidth sent by the pod running in
+	// the given network namespace.
+	newPodShaper func(netns string) bandwidth.BandwidthShaper
+
 	// Number of Pods which can be run by this Kubelet
 	pods int
 
@@ -1330,6 +1343,140 @@ func (kl *Kubelet) syncPod(pod *api.Pod, mirrorPod *api.Pod, runningPod kubecont
 			}
 		}
 	}
+
+	if err := kl.reconcileBandwidth(pod); err != nil {
+		glog.Errorf("Failed to limit the bandwidth of pod %q: %v", podFullName, err)
+		return err
+	}
+	return nil
+}
+
+// reconcileBandwidth applies the bandwidth limits of a running pod, if any.
+// The traffic sent to the pod is shaped on cbr0, and the traffic sent by the
+// pod on its own interface, inside its network namespace.
+func (kl *Kubelet) reconcileBandwidth(pod *api.Pod) error {
+	ingress, egress, err := bandwidth.ExtractPodBandwidthResources(pod.Annotations)
+	if err != nil {
+		return err
+	}
+	if ingress == nil && egress == nil {
+		return nil
+	}
+	if pod.Spec.HostNetwork {
+		kl.recorder.Eventf(pod, "HostNetworkNotSupported", "Bandwidth shaping is not supported for pods on the host network")
+		return nil
+	}
+	status, err := kl.containerRuntime.GetPodStatus(pod)
+	if err != nil {
+		return err
+	}
+	if len(status.PodIP) == 0 {
+		return nil
+	}
+	if ip := net.ParseIP(status.PodIP); ip == nil || ip.To4() == nil {
+		kl.recorder.Eventf(pod, "IPv6NotSupported", "Bandwidth shaping is not supported for pods with the IPv6 address %s", status.PodIP)
+		return nil
+	}
+	cidr := util.HostCIDR(status.PodIP)
+	if ingress != nil {
+		if err := kl.reconcileIngressBandwidth(pod, cidr, ingress); err != nil {
+			return err
+		}
+	}
+	if egress != nil {
+		netns, err := kl.getPodNetNs(pod)
+		if err != nil {
+			return err
+		}
+		if len(netns) == 0 {
+			kl.recorder.Eventf(pod, "EgressShapingNotSupported", "Pod requests egress bandwidth shaping, but the container runtime does not expose its network namespace")
+			return nil
+		}
+		shaper := kl.newPodShaper(netns)
+		if err := shaper.ReconcileInterface(); err != nil {
+			return err
+		}
+		return shaper.ReconcileCIDR(cidr, egress, nil)
+	}
+	return nil
+}
+
+// reconcileIngressBandwidth limits the bandwidth sent to the pod with the
+// given CIDR on cbr0.
+func (kl *Kubelet) reconcileIngressBandwidth(pod *api.Pod, cidr string, ingress *resource.Quantity) error {
+	kl.shaperLock.Lock()
+	defer kl.shaperLock.Unlock()
+	if kl.shaper == nil {
+		kl.recorder.Eventf(pod, "NilShaper", "Pod requests ingress bandwidth shaping, but the kubelet does not configure cbr0")
+		return nil
+	}
+	return kl.shaper.ReconcileCIDR(cidr, nil, ingress)
+}
+
+// netNsGetter is implemented by the container runtimes which can tell the
+// network namespace of a container.
+type netNsGetter interface {
+	GetNetNs(containerID string) (netns string, hostNetwork bool, err error)
+}
+
+// getPodNetNs returns the network namespace of a running pod, or an empty
+// string if the container runtime does not expose it.
+func (kl *Kubelet) getPodNetNs(pod *api.Pod) (string, error) {
+	getter, ok := kl.containerRuntime.(netNsGetter)
+	if !ok {
+		return "", nil
+	}
+	runningPods, err := kl.containerRuntime.GetPods(false)
+	if err != nil {
+		return "", err
+	}
+	runningPod := kubecontainer.Pods(runningPods).FindPodByID(pod.UID)
+	infraContainer := runningPod.FindContainerByName(dockertools.PodInfraContainerName)
+	if infraContainer == nil {
+		return "", fmt.Errorf("no infra container found for pod %q", kubecontainer.GetPodFullName(pod))
+	}
+	netns, _, err := getter.GetNetNs(string(infraContainer.ID))
+	return netns, err
+}
+
+// cleanupBandwidthLimits removes the bandwidth limits of the pods which are no
+// longer running.
+func (kl *Kubelet) cleanupBandwidthLimits(allPods []*api.Pod) error {
+	kl.shaperLock.Lock()
+	defer kl.shaperLock.Unlock()
+	if kl.shaper == nil {
+		return nil
+	}
+	currentCIDRs, err := kl.shaper.GetCIDRs()
+	if err != nil {
+		return err
+	}
+	possibleCIDRs := util.StringSet{}
+	for _, pod := range allPods {
+		ingress, egress, err := bandwidth.ExtractPodBandwidthResources(pod.Annotations)
+		if err != nil || ingress == nil && egress == nil {
+			continue
+		}
+		status, found := kl.statusManager.GetPodStatus(pod.UID)
+		if !found {
+			statusPtr, err := kl.containerRuntime.GetPodStatus(pod)
+			if err != nil {
+				return err
+			}
+			status = *statusPtr
+		}
+		if status.Phase == api.PodRunning {
+			possibleCIDRs.Insert(util.HostCIDR(status.PodIP))
+		}
+	}
+	for _, cidr := range currentCIDRs {
+		if !possibleCIDRs.Has(cidr) {
+			glog.V(2).Infof("Removing CIDR: %s (%v)", cidr, possibleCIDRs)
+			if err := kl.shaper.Reset(cidr); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -1623,6 +1770,11 @@ func (kl *Kubelet) HandlePodCleanups() error {
 		glog.Errorf("Failed to cleanup terminated pods: %v", err)
 	}
 
+	// Remove the bandwidth limits of pods which are gone.
+	if err := kl.cleanupBandwidthLimits(allPods); err != nil {
+		glog.Errorf("Failed cleaning up bandwidth limits: %v", err)
+	}
+
 	kl.backOff.GC()
 	return err
 }
@@ -1964,7 +2116,7 @@ func (kl *Kubelet) validateContainerStatus(podStatus *api.PodStatus, containerNa
 // GetKubeletContainerLogs returns logs from the container
 // TODO: this method is returning logs of random container attempts, when it should be returning the most recent attempt
 // or all of them.
-func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
+func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 	// TODO(vmarmol): Refactor to not need the pod status and verification.
 	// Pod workers periodically write status to statusManager. If status is not
 	// cached there, something is wrong (or kubelet just restarted and hasn't
@@ -1988,13 +2140,13 @@ func (kl *Kubelet) GetKubeletContainerLogs(podFullName, containerName, tail stri
 		// No log is available if pod is not in a "known" phase (e.g. Unknown).
 		return err
 	}
-	containerID, err := kl.validateContainerStatus(&podStatus, containerName, previous)
+	containerID, err := kl.validateContainerStatus(&podStatus, containerName, logOptions.Previous)
 	if err != nil {
 		// No log is available if the container status is missing or is in the
 		// waiting state.
 		return err
 	}
-	return kl.containerRuntime.GetContainerLogs(pod, containerID, tail, follow, stdout, stderr)
+	return kl.containerRuntime.GetContainerLogs(pod, containerID, logOptions, stdout, stderr)
 }
 
 // GetHostname Returns the hostname as the kubelet sees it.
@@ -2067,11 +2219,17 @@ func (kl *Kubelet) reconcileCBR0(podCIDR string) error {
 		return err
 	}
 	// Set cbr0 interface address to first address in IPNet
-	cidr.IP.To4()[3] += 1
+	cidr.IP[len(cidr.IP)-1] += 1
 	if err := ensureCbr0(cidr); err != nil {
 		return err
 	}
-	return nil
+	kl.shaperLock.Lock()
+	defer kl.shaperLock.Unlock()
+	if kl.shaper == nil {
+		glog.V(5).Info("Shaper is nil, creating")
+		kl.shaper = bandwidth.NewTCShaper("cbr0")
+	}
+	return kl.shaper.ReconcileInterface()
 }
 
 // updateNodeStatus updates node status to master with retries.
@@ -2148,8 +2306,10 @@ func (kl *Kubelet) setNodeStatus(node *api.Node) error {
 			} else if len(addrs) == 0 {
 				return fmt.Errorf("no ip address for node %v", node.Name)
 			} else {
-				// check all ip addresses for this node.Name and try to find the first non-loopback IPv4 address.
+				// check all ip addresses for this node.Name and try to find the first non-loopback IPv4 address,
+				// falling back to the first non-loopback IPv6 address.
 				// If no match is found, it uses the IP of the interface with gateway on it.
+				var ipv6 net.IP
 				for _, ip := range addrs {
 					if ip.IsLoopback() {
 						continue
@@ -2162,6 +2322,16 @@ func (kl *Kubelet) setNodeStatus(node *api.Node) error {
 						}
 						break
 					}
+					if ipv6 == nil {
+						ipv6 = ip
+					}
+				}
+
+				if len(node.Status.Addresses) == 0 && ipv6 != nil {
+					node.Status.Addresses = []api.NodeAddress{
+						{Type: api.NodeLegacyHostIP, Address: ipv6.String()},
+						{Type: api.NodeInternalIP, Address: ipv6.String()},
+					}
 				}
 
 				if len(node.Status.Addresses) == 0 {
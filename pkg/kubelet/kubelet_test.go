@@ -44,10 +44,12 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
 	"k8s.io/kubernetes/pkg/kubelet/container"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/kubelet/network"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/types"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/bandwidth"
 	"k8s.io/kubernetes/pkg/version"
 	"k8s.io/kubernetes/pkg/volume"
 	_ "k8s.io/kubernetes/pkg/volume/host_path"
@@ -3387,3 +3389,226 @@ func TestDoesNotDeletePodDirsIfContainerIsRunning(t *testing.T) {
 	testKubelet.fakeRuntime.PodList = []*kubecontainer.Pod{}
 	syncAndVerifyPodDir(t, testKubelet, pods, []*api.Pod{apiPod}, false)
 }
+
+func TestCleanupBandwidthLimits(t *testing.T) {
+	limitedPod := func(uid string) *api.Pod {
+		return &api.Pod{
+			ObjectMeta: api.ObjectMeta{
+				UID:         types.UID(uid),
+				Name:        "foo" + uid,
+				Namespace:   "new",
+				Annotations: map[string]string{bandwidth.IngressBandwidthAnnotation: "10M"},
+			},
+		}
+	}
+	tests := []struct {
+		status           *api.PodStatus
+		pods             []*api.Pod
+		inputCIDRs       []string
+		expectResetCIDRs []string
+		cacheStatus      bool
+		expectedCalls    []string
+		name             string
+	}{
+		{
+			status: &api.PodStatus{
+				PodIP: "1.2.3.4",
+				Phase: api.PodRunning,
+			},
+			pods:             []*api.Pod{limitedPod("1"), limitedPod("2")},
+			inputCIDRs:       []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			expectResetCIDRs: []string{"2.3.4.5/32", "5.6.7.8/32"},
+			expectedCalls:    []string{"GetPodStatus", "GetPodStatus"},
+			name:             "pod running",
+		},
+		{
+			status: &api.PodStatus{
+				PodIP: "1.2.3.4",
+				Phase: api.PodRunning,
+			},
+			pods:             []*api.Pod{limitedPod("1"), limitedPod("2")},
+			inputCIDRs:       []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			expectResetCIDRs: []string{"2.3.4.5/32", "5.6.7.8/32"},
+			cacheStatus:      true,
+			name:             "pod running with cache",
+		},
+		{
+			status: &api.PodStatus{
+				PodIP: "fd00::4",
+				Phase: api.PodRunning,
+			},
+			pods:             []*api.Pod{limitedPod("1"), limitedPod("2")},
+			inputCIDRs:       []string{"fd00::4/128", "fd00::5/128", "5.6.7.8/32"},
+			expectResetCIDRs: []string{"fd00::5/128", "5.6.7.8/32"},
+			expectedCalls:    []string{"GetPodStatus", "GetPodStatus"},
+			name:             "ipv6 pod running",
+		},
+		{
+			status: &api.PodStatus{
+				PodIP: "1.2.3.4",
+				Phase: api.PodFailed,
+			},
+			pods:             []*api.Pod{limitedPod("1"), limitedPod("2")},
+			inputCIDRs:       []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			expectResetCIDRs: []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			expectedCalls:    []string{"GetPodStatus", "GetPodStatus"},
+			name:             "pod not running",
+		},
+		{
+			status: &api.PodStatus{
+				PodIP: "1.2.3.4",
+				Phase: api.PodRunning,
+			},
+			pods: []*api.Pod{
+				{ObjectMeta: api.ObjectMeta{UID: "1", Name: "foo1", Namespace: "new"}},
+			},
+			inputCIDRs:       []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			expectResetCIDRs: []string{"1.2.3.4/32", "2.3.4.5/32", "5.6.7.8/32"},
+			name:             "no bandwidth limits",
+		},
+	}
+	for _, test := range tests {
+		shaper := &bandwidth.FakeShaper{
+			CIDRs: test.inputCIDRs,
+		}
+
+		testKube := newTestKubelet(t)
+		testKube.kubelet.shaper = shaper
+		testKube.fakeRuntime.PodStatus = *test.status
+
+		if test.cacheStatus {
+			for _, pod := range test.pods {
+				testKube.kubelet.statusManager.SetPodStatus(pod, *test.status)
+			}
+		}
+
+		err := testKube.kubelet.cleanupBandwidthLimits(test.pods)
+		if err != nil {
+			t.Errorf("unexpected error: %v (%s)", err, test.name)
+		}
+		if !reflect.DeepEqual(shaper.ResetCIDRs, test.expectResetCIDRs) {
+			t.Errorf("[%s]\nexpected: %v, saw: %v", test.name, test.expectResetCIDRs, shaper.ResetCIDRs)
+		}
+
+		if test.cacheStatus {
+			if len(testKube.fakeRuntime.CalledFunctions) != 0 {
+				t.Errorf("unexpected function calls: %v", testKube.fakeRuntime.CalledFunctions)
+			}
+		} else if !reflect.DeepEqual(testKube.fakeRuntime.CalledFunctions, test.expectedCalls) {
+			t.Errorf("[%s], expected %v, saw %v", test.name, test.expectedCalls, testKube.fakeRuntime.CalledFunctions)
+		}
+	}
+}
+
+// netNsRuntime is a FakeRuntime which can tell the network namespace of the
+// containers it runs.
+type netNsRuntime struct {
+	*kubecontainer.FakeRuntime
+	netns map[string]string
+}
+
+func (r *netNsRuntime) GetNetNs(containerID string) (string, bool, error) {
+	return r.netns[containerID], false, nil
+}
+
+func TestReconcileBandwidth(t *testing.T) {
+	testKube := newTestKubelet(t)
+	shaper := &bandwidth.FakeShaper{}
+	testKube.kubelet.shaper = shaper
+	podShapers := map[string]*bandwidth.FakeShaper{}
+	testKube.kubelet.newPodShaper = func(netns string) bandwidth.BandwidthShaper {
+		if podShapers[netns] == nil {
+			podShapers[netns] = &bandwidth.FakeShaper{}
+		}
+		return podShapers[netns]
+	}
+	testKube.fakeRuntime.PodStatus = api.PodStatus{PodIP: "1.2.3.4", Phase: api.PodRunning}
+	testKube.fakeRuntime.PodList = []*kubecontainer.Pod{
+		{
+			ID:         "1",
+			Name:       "foo",
+			Namespace:  "new",
+			Containers: []*kubecontainer.Container{{ID: "infra1234", Name: dockertools.PodInfraContainerName}},
+		},
+	}
+	testKube.kubelet.containerRuntime = &netNsRuntime{testKube.fakeRuntime, map[string]string{"infra1234": "/proc/42/ns/net"}}
+
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{UID: "1", Name: "foo", Namespace: "new"}}
+	if err := testKube.kubelet.reconcileBandwidth(pod); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(shaper.CIDRs) != 0 || len(podShapers) != 0 {
+		t.Errorf("expected no limits for a pod without bandwidth annotations, saw: %v, %v", shaper.CIDRs, podShapers)
+	}
+
+	// The traffic sent by the pod is limited inside its network namespace,
+	// and the traffic sent to it on cbr0.
+	pod.Annotations = map[string]string{
+		bandwidth.EgressBandwidthAnnotation:  "1M",
+		bandwidth.IngressBandwidthAnnotation: "2M",
+	}
+	if err := testKube.kubelet.reconcileBandwidth(pod); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := []string{"1.2.3.4/32"}
+	if !reflect.DeepEqual(shaper.IngressCIDRs, expected) || len(shaper.EgressCIDRs) != 0 {
+		t.Errorf("expected only ingress limits %v on cbr0, saw ingress: %v, egress: %v", expected, shaper.IngressCIDRs, shaper.EgressCIDRs)
+	}
+	if len(podShapers) != 1 || podShapers["/proc/42/ns/net"] == nil {
+		t.Fatalf("expected a shaper in the pod network namespace, saw: %v", podShapers)
+	}
+	podShaper := podShapers["/proc/42/ns/net"]
+	if !reflect.DeepEqual(podShaper.EgressCIDRs, expected) || len(podShaper.IngressCIDRs) != 0 {
+		t.Errorf("expected only egress limits %v in the pod, saw egress: %v, ingress: %v", expected, podShaper.EgressCIDRs, podShaper.IngressCIDRs)
+	}
+
+	// Pods on the host network are not limited.
+	shaper.CIDRs, shaper.IngressCIDRs = nil, nil
+	podShaper.CIDRs, podShaper.EgressCIDRs = nil, nil
+	pod.Spec.HostNetwork = true
+	if err := testKube.kubelet.reconcileBandwidth(pod); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(shaper.CIDRs) != 0 || len(podShaper.CIDRs) != 0 {
+		t.Errorf("expected no limits for a pod on the host network, saw: %v, %v", shaper.CIDRs, podShaper.CIDRs)
+	}
+
+	// Pods with IPv6 addresses are not limited, as the shaper only handles
+	// IPv4.
+	pod.Spec.HostNetwork = false
+	testKube.fakeRuntime.PodStatus = api.PodStatus{PodIP: "fd00::4", Phase: api.PodRunning}
+	if err := testKube.kubelet.reconcileBandwidth(pod); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(shaper.CIDRs) != 0 || len(podShaper.CIDRs) != 0 {
+		t.Errorf("expected no limits for a pod with an IPv6 address, saw: %v, %v", shaper.CIDRs, podShaper.CIDRs)
+	}
+}
+
+func TestReconcileBandwidthWithoutNetNs(t *testing.T) {
+	testKube := newTestKubelet(t)
+	shaper := &bandwidth.FakeShaper{}
+	testKube.kubelet.shaper = shaper
+	testKube.kubelet.newPodShaper = func(netns string) bandwidth.BandwidthShaper {
+		t.Errorf("unexpected shaper in network namespace %q", netns)
+		return &bandwidth.FakeShaper{}
+	}
+	testKube.fakeRuntime.PodStatus = api.PodStatus{PodIP: "1.2.3.4", Phase: api.PodRunning}
+
+	// The egress limits are skipped when the runtime cannot tell the network
+	// namespace of the pod, and they are never applied on cbr0.
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:         "1",
+			Name:        "foo",
+			Namespace:   "new",
+			Annotations: map[string]string{bandwidth.EgressBandwidthAnnotation: "1M"},
+		},
+	}
+	if err := testKube.kubelet.reconcileBandwidth(pod); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(shaper.CIDRs) != 0 {
+		t.Errorf("expected no limits on cbr0, saw: %v", shaper.CIDRs)
+	}
+}
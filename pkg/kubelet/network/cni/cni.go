@@ -0,0 +1,344 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cni implements a network plugin for the kubelet which speaks the
+// Container Network Interface (https://github.com/appc/cni).
+//
+// The network of the pods is read from the first valid network configuration
+// in the configuration directory (by default /etc/cni/net.d/), in
+// lexicographic order of the file names.  A configuration is a JSON file with
+// the extension .conf, e.g.
+//     {
+//         "name": "mynet",
+//         "type": "bridge",
+//         "bridge": "cni0",
+//         "ipam": {
+//             "type": "host-local",
+//             "subnet": "10.22.0.0/16"
+//         }
+//     }
+// where "type" names the plugin binary in the binary directory (by default
+// /opt/cni/bin/) which is invoked, with the configuration on its stdin, to add
+// the pod infra container to the network and to delete it from the network.
+// The network namespace of the infra container is passed in CNI_NETNS, and the
+// pod in CNI_ARGS as K8S_POD_NAMESPACE, K8S_POD_NAME and
+// K8S_POD_INFRA_CONTAINER_ID.
+//
+// The infra containers of pods are created without a network (docker's
+// --net=none) so that the CNI plugin is the only one configuring it, and the
+// pod IP is the one returned by the plugin rather than the one reported by
+// docker.  Pods using the host network are left alone.
+package cni
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	kubeletTypes "k8s.io/kubernetes/pkg/kubelet/types"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+const (
+	CNIPluginName = "cni"
+
+	// DefaultNetDir is the default directory of the network configurations.
+	DefaultNetDir = "/etc/cni/net.d"
+	// DefaultCNIDir is the default directory of the CNI plugin binaries.
+	DefaultCNIDir = "/opt/cni/bin"
+
+	// The name of the interface of the pod network, in its namespace.
+	podInterfaceName = "eth0"
+
+	addCmd = "ADD"
+	delCmd = "DEL"
+)
+
+type cniNetworkPlugin struct {
+	confDir string
+	binDir  string
+	host    network.Host
+	execer  utilexec.Interface
+
+	// network is the configuration of the pod network, loaded by Init.
+	network *netConfig
+
+	mu sync.Mutex
+	// podIPs holds the IPs of pods returned by the CNI plugin, by pod infra
+	// container.
+	podIPs map[kubeletTypes.DockerID]net.IP
+}
+
+// netConfig is a CNI network configuration.  Only the fields the kubelet
+// needs are decoded; bytes holds the whole configuration for the plugin.
+type netConfig struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	bytes []byte
+}
+
+// cniResult is the result of the ADD command of a CNI plugin.
+type cniResult struct {
+	IP4 *cniIPConfig `json:"ip4,omitempty"`
+	IP6 *cniIPConfig `json:"ip6,omitempty"`
+}
+
+type cniIPConfig struct {
+	// IP is the address of the interface, in CIDR notation.
+	IP string `json:"ip"`
+}
+
+// cniError is the error a CNI plugin prints when it fails.
+type cniError struct {
+	Code    uint   `json:"code"`
+	Msg     string `json:"msg"`
+	Details string `json:"details,omitempty"`
+}
+
+// netNsGetter is implemented by the container runtimes which can run the
+// CNI plugin.
+type netNsGetter interface {
+	// GetNetNs returns the path of the network namespace of a running
+	// container, or hostNetwork true if the container uses the network of
+	// the host.
+	GetNetNs(containerID string) (netns string, hostNetwork bool, err error)
+}
+
+// ProbeNetworkPlugins returns the CNI network plugin, which will read network
+// configurations from confDir and invoke the plugin binaries in binDir.
+// Empty directories default to DefaultNetDir and DefaultCNIDir.
+func ProbeNetworkPlugins(confDir, binDir string) []network.NetworkPlugin {
+	if confDir == "" {
+		confDir = DefaultNetDir
+	}
+	if binDir == "" {
+		binDir = DefaultCNIDir
+	}
+	return []network.NetworkPlugin{&cniNetworkPlugin{
+		confDir: confDir,
+		binDir:  binDir,
+		execer:  utilexec.New(),
+		podIPs:  map[kubeletTypes.DockerID]net.IP{},
+	}}
+}
+
+func (plugin *cniNetworkPlugin) Init(host network.Host) error {
+	conf, err := loadDefaultNetwork(plugin.confDir)
+	if err != nil {
+		return err
+	}
+	binary := path.Join(plugin.binDir, conf.Type)
+	if info, err := os.Stat(binary); err != nil || info.Mode()&0111 == 0 {
+		return fmt.Errorf("CNI plugin %q of network %q is not an executable in %s", conf.Type, conf.Name, plugin.binDir)
+	}
+	plugin.network = conf
+	plugin.host = host
+	glog.V(1).Infof("Using CNI network %q of type %q", conf.Name, conf.Type)
+	return nil
+}
+
+// loadDefaultNetwork returns the first valid network configuration in confDir.
+func loadDefaultNetwork(confDir string) (*netConfig, error) {
+	files, err := ioutil.ReadDir(confDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CNI network configurations: %v", err)
+	}
+	// ReadDir sorts by file name.
+	for _, f := range files {
+		if f.IsDir() || path.Ext(f.Name()) != ".conf" {
+			continue
+		}
+		file := path.Join(confDir, f.Name())
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			glog.Warningf("Skipping CNI network configuration %s: %v", file, err)
+			continue
+		}
+		conf := &netConfig{bytes: data}
+		if err := json.Unmarshal(data, conf); err != nil {
+			glog.Warningf("Skipping CNI network configuration %s: %v", file, err)
+			continue
+		}
+		if conf.Name == "" || conf.Type == "" {
+			glog.Warningf("Skipping CNI network configuration %s: the name and the type are required", file)
+			continue
+		}
+		return conf, nil
+	}
+	return nil, fmt.Errorf("no valid CNI network configuration in %s", confDir)
+}
+
+func (plugin *cniNetworkPlugin) Name() string {
+	return CNIPluginName
+}
+
+func (plugin *cniNetworkPlugin) SetUpPod(namespace string, name string, id kubeletTypes.DockerID) error {
+	netns, hostNetwork, err := plugin.getNetNs(id)
+	if err != nil || hostNetwork {
+		return err
+	}
+	out, err := plugin.invoke(addCmd, namespace, name, id, netns)
+	if err != nil {
+		return err
+	}
+	result := &cniResult{}
+	if err := json.Unmarshal(out, result); err != nil {
+		return fmt.Errorf("invalid result of CNI plugin %q for pod %s/%s: %v", plugin.network.Type, namespace, name, err)
+	}
+	ipConfig := result.IP4
+	if ipConfig == nil {
+		ipConfig = result.IP6
+	}
+	if ipConfig == nil {
+		return fmt.Errorf("CNI plugin %q returned no IP for pod %s/%s", plugin.network.Type, namespace, name)
+	}
+	ip, _, err := net.ParseCIDR(ipConfig.IP)
+	if err != nil {
+		return fmt.Errorf("CNI plugin %q returned an invalid IP for pod %s/%s: %v", plugin.network.Type, namespace, name, err)
+	}
+	glog.V(4).Infof("CNI plugin %q set up pod %s/%s with IP %s", plugin.network.Type, namespace, name, ip)
+
+	plugin.mu.Lock()
+	defer plugin.mu.Unlock()
+	plugin.podIPs[id] = ip
+	return nil
+}
+
+func (plugin *cniNetworkPlugin) TearDownPod(namespace string, name string, id kubeletTypes.DockerID) error {
+	plugin.mu.Lock()
+	delete(plugin.podIPs, id)
+	plugin.mu.Unlock()
+
+	// The infra container is inspected rather than the pod, which is already
+	// gone from the kubelet when orphaned pods are cleaned up. Running DEL in
+	// the namespace of the host would delete its interface.
+	netns, hostNetwork, err := plugin.getNetNs(id)
+	if err != nil || hostNetwork {
+		return err
+	}
+	_, err = plugin.invoke(delCmd, namespace, name, id, netns)
+	return err
+}
+
+// Status returns the IP the CNI plugin returned for the pod.  Pods set up
+// before the kubelet restarted have their IP read from their network
+// namespace.
+func (plugin *cniNetworkPlugin) Status(namespace string, name string, id kubeletTypes.DockerID) (*network.PodNetworkStatus, error) {
+	plugin.mu.Lock()
+	ip, found := plugin.podIPs[id]
+	plugin.mu.Unlock()
+	if found {
+		return &network.PodNetworkStatus{IP: ip}, nil
+	}
+
+	netns, hostNetwork, err := plugin.getNetNs(id)
+	if err != nil || hostNetwork {
+		return nil, err
+	}
+	ip, err = plugin.readPodIP(netns)
+	if err != nil {
+		return nil, err
+	}
+	plugin.mu.Lock()
+	defer plugin.mu.Unlock()
+	plugin.podIPs[id] = ip
+	return &network.PodNetworkStatus{IP: ip}, nil
+}
+
+// getNetNs returns the network namespace of the pod infra container id, or
+// hostNetwork true if the pod uses the network of the host, and so has no
+// network namespace of its own to set up.
+func (plugin *cniNetworkPlugin) getNetNs(id kubeletTypes.DockerID) (netns string, hostNetwork bool, err error) {
+	runtime, ok := plugin.host.GetRuntime().(netNsGetter)
+	if !ok {
+		return "", false, fmt.Errorf("the CNI network plugin is not supported by the container runtime")
+	}
+	return runtime.GetNetNs(string(id))
+}
+
+// invoke runs the CNI plugin of the network, and returns its output.
+func (plugin *cniNetworkPlugin) invoke(command, namespace, name string, id kubeletTypes.DockerID, netns string) ([]byte, error) {
+	cmd := exec.Command(path.Join(plugin.binDir, plugin.network.Type))
+	cmd.Env = append(os.Environ(),
+		"CNI_COMMAND="+command,
+		"CNI_CONTAINERID="+string(id),
+		"CNI_NETNS="+netns,
+		"CNI_IFNAME="+podInterfaceName,
+		"CNI_PATH="+plugin.binDir,
+		fmt.Sprintf("CNI_ARGS=K8S_POD_NAMESPACE=%s;K8S_POD_NAME=%s;K8S_POD_INFRA_CONTAINER_ID=%s", namespace, name, id),
+	)
+	cmd.Stdin = bytes.NewReader(plugin.network.bytes)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	glog.V(5).Infof("%s of CNI plugin %q for pod %s/%s output: %s, %s, %v", command, plugin.network.Type, namespace, name, stdout.String(), stderr.String(), err)
+	if err != nil {
+		cniErr := &cniError{}
+		if jsonErr := json.Unmarshal(stdout.Bytes(), cniErr); jsonErr == nil && cniErr.Msg != "" {
+			if cniErr.Details != "" {
+				return nil, fmt.Errorf("%s of CNI plugin %q for pod %s/%s failed: %s; %s", command, plugin.network.Type, namespace, name, cniErr.Msg, cniErr.Details)
+			}
+			return nil, fmt.Errorf("%s of CNI plugin %q for pod %s/%s failed: %s", command, plugin.network.Type, namespace, name, cniErr.Msg)
+		}
+		return nil, fmt.Errorf("%s of CNI plugin %q for pod %s/%s failed: %v", command, plugin.network.Type, namespace, name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// readPodIP returns the global address of the pod interface in the network
+// namespace netns, preferring IPv4 when the pod has addresses of both
+// families.
+func (plugin *cniNetworkPlugin) readPodIP(netns string) (net.IP, error) {
+	out, err := plugin.execer.Command("nsenter", "--net="+netns, "-F", "--", "ip", "-o", "addr", "show", "dev", podInterfaceName, "scope", "global").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the address of %s in %s: %v: %s", podInterfaceName, netns, err, string(out))
+	}
+	// e.g. "3: eth0    inet 10.22.0.5/16 scope global eth0\       valid_lft forever preferred_lft forever"
+	// or "3: eth0    inet6 fd00::5/64 scope global \       valid_lft forever preferred_lft forever"
+	var ipv6 net.IP
+	fields := strings.Fields(string(out))
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] != "inet" && fields[i] != "inet6" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(fields[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of %s in %s: %v", podInterfaceName, netns, err)
+		}
+		if fields[i] == "inet" {
+			return ip, nil
+		}
+		if ipv6 == nil {
+			ipv6 = ip
+		}
+	}
+	if ipv6 != nil {
+		return ipv6, nil
+	}
+	return nil, fmt.Errorf("no address on %s in %s", podInterfaceName, netns)
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/network"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+)
+
+const testNetConf = `{"name": "mynet", "type": "fake-plugin", "bridge": "cni0"}`
+
+// The fake plugin records its environment and stdin next to itself, and
+// prints a result with the IP in $RESULT_IP on ADD, or fails with the message
+// in $FAIL_MSG.
+const fakePluginScript = `#!/bin/sh
+dir=$(dirname "$0")
+env | grep ^CNI_ | sort > "$dir/$CNI_COMMAND.env"
+cat > "$dir/$CNI_COMMAND.stdin"
+if [ -n "$FAIL_MSG" ]; then
+  echo "{\"cniVersion\": \"0.1.0\", \"code\": 100, \"msg\": \"$FAIL_MSG\"}"
+  exit 1
+fi
+if [ "$CNI_COMMAND" = "ADD" ]; then
+  echo "{\"ip4\": {\"ip\": \"$RESULT_IP\", \"gateway\": \"10.22.0.1\"}}"
+fi
+`
+
+type fakeNetworkHost struct {
+	pods    map[string]*api.Pod
+	runtime kubecontainer.Runtime
+}
+
+func (fnh *fakeNetworkHost) GetPodByName(namespace, name string) (*api.Pod, bool) {
+	pod, found := fnh.pods[namespace+"/"+name]
+	return pod, found
+}
+
+func (fnh *fakeNetworkHost) GetKubeClient() client.Interface {
+	return nil
+}
+
+func (fnh *fakeNetworkHost) GetRuntime() kubecontainer.Runtime {
+	return fnh.runtime
+}
+
+type fakeRuntime struct {
+	kubecontainer.FakeRuntime
+	netNs map[string]string
+	// hostNetwork holds the containers using the network of the host.
+	hostNetwork map[string]bool
+}
+
+func (r *fakeRuntime) GetNetNs(containerID string) (string, bool, error) {
+	if r.hostNetwork[containerID] {
+		return "", true, nil
+	}
+	netns, found := r.netNs[containerID]
+	if !found {
+		return "", false, fmt.Errorf("container not running (%s)", containerID)
+	}
+	return netns, false, nil
+}
+
+func newFakeHost() *fakeNetworkHost {
+	return &fakeNetworkHost{
+		pods: map[string]*api.Pod{},
+		runtime: &fakeRuntime{
+			netNs:       map[string]string{"infra1234": "/proc/42/ns/net"},
+			hostNetwork: map[string]bool{},
+		},
+	}
+}
+
+// installFakePlugin writes the network configurations and the fake plugin
+// binary into a new directory, and returns the configuration and binary
+// directories.
+func installFakePlugin(t *testing.T, confs map[string]string) (string, string) {
+	tmpDir, err := ioutil.TempDir("", "cni-test")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	confDir := path.Join(tmpDir, "net.d")
+	binDir := path.Join(tmpDir, "bin")
+	for _, dir := range []string{confDir, binDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("error creating %s: %v", dir, err)
+		}
+	}
+	for name, conf := range confs {
+		if err := ioutil.WriteFile(path.Join(confDir, name), []byte(conf), 0644); err != nil {
+			t.Fatalf("error writing network configuration: %v", err)
+		}
+	}
+	if err := ioutil.WriteFile(path.Join(binDir, "fake-plugin"), []byte(fakePluginScript), 0755); err != nil {
+		t.Fatalf("error writing fake plugin: %v", err)
+	}
+	return confDir, binDir
+}
+
+func initPlugin(t *testing.T, confDir, binDir string, host network.Host) *cniNetworkPlugin {
+	plug, err := network.InitNetworkPlugin(ProbeNetworkPlugins(confDir, binDir), CNIPluginName, host)
+	if err != nil {
+		t.Fatalf("Failed to select the CNI plugin: %v", err)
+	}
+	return plug.(*cniNetworkPlugin)
+}
+
+func readFile(t *testing.T, file string) string {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("error reading %s: %v", file, err)
+	}
+	return string(data)
+}
+
+func TestSelectDefaultNetwork(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{
+		"00-invalid.conf": `{"name": `,
+		"05-notype.conf":  `{"name": "notype"}`,
+		"10-mynet.conf":   testNetConf,
+		"20-other.conf":   `{"name": "other", "type": "fake-plugin"}`,
+		"mynet.json":      `{"name": "json", "type": "fake-plugin"}`,
+	})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	plug := initPlugin(t, confDir, binDir, newFakeHost())
+	if plug.Name() != CNIPluginName {
+		t.Errorf("Wrong plugin selected, expected %s, got %s", CNIPluginName, plug.Name())
+	}
+	if plug.network.Name != "mynet" || string(plug.network.bytes) != testNetConf {
+		t.Errorf("Expected the network mynet, got %+v", plug.network)
+	}
+}
+
+func TestInitFailures(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": `{"name": "mynet", "type": "missing-plugin"}`})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	if _, err := network.InitNetworkPlugin(ProbeNetworkPlugins(confDir, binDir), CNIPluginName, newFakeHost()); err == nil {
+		t.Errorf("Expected an error for a missing plugin binary")
+	}
+	if _, err := network.InitNetworkPlugin(ProbeNetworkPlugins(binDir, binDir), CNIPluginName, newFakeHost()); err == nil {
+		t.Errorf("Expected an error without network configurations")
+	}
+}
+
+func TestSetUpAndTearDownPod(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+	os.Setenv("RESULT_IP", "10.22.0.5/16")
+	defer os.Unsetenv("RESULT_IP")
+
+	plug := initPlugin(t, confDir, binDir, newFakeHost())
+	if err := plug.SetUpPod("podNamespace", "podName", "infra1234"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedEnv := strings.Join([]string{
+		"CNI_ARGS=K8S_POD_NAMESPACE=podNamespace;K8S_POD_NAME=podName;K8S_POD_INFRA_CONTAINER_ID=infra1234",
+		"CNI_COMMAND=ADD",
+		"CNI_CONTAINERID=infra1234",
+		"CNI_IFNAME=eth0",
+		"CNI_NETNS=/proc/42/ns/net",
+		"CNI_PATH=" + binDir,
+	}, "\n") + "\n"
+	if env := readFile(t, path.Join(binDir, "ADD.env")); env != expectedEnv {
+		t.Errorf("Expected the environment\n%s\ngot\n%s", expectedEnv, env)
+	}
+	if stdin := readFile(t, path.Join(binDir, "ADD.stdin")); stdin != testNetConf {
+		t.Errorf("Expected the network configuration on stdin, got %q", stdin)
+	}
+
+	status, err := plug.Status("podNamespace", "podName", "infra1234")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status == nil || status.IP.String() != "10.22.0.5" {
+		t.Errorf("Expected the IP 10.22.0.5, got %+v", status)
+	}
+
+	if err := plug.TearDownPod("podNamespace", "podName", "infra1234"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if env := readFile(t, path.Join(binDir, "DEL.env")); !strings.Contains(env, "CNI_NETNS=/proc/42/ns/net\n") {
+		t.Errorf("Expected DEL in the network namespace of the pod, got\n%s", env)
+	}
+	if _, found := plug.podIPs["infra1234"]; found {
+		t.Errorf("Expected the IP of the pod to be forgotten")
+	}
+}
+
+func TestPluginFailure(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+	os.Setenv("FAIL_MSG", "no more addresses")
+	defer os.Unsetenv("FAIL_MSG")
+
+	plug := initPlugin(t, confDir, binDir, newFakeHost())
+	err := plug.SetUpPod("podNamespace", "podName", "infra1234")
+	if err == nil || !strings.Contains(err.Error(), "no more addresses") {
+		t.Errorf("Expected the error of the plugin, got %v", err)
+	}
+	if _, found := plug.podIPs["infra1234"]; found {
+		t.Errorf("Expected no IP for the pod")
+	}
+}
+
+func TestHostNetworkPodsAreSkipped(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	host := newFakeHost()
+	host.runtime.(*fakeRuntime).hostNetwork["infra5678"] = true
+	plug := initPlugin(t, confDir, binDir, host)
+	if err := plug.SetUpPod("podNamespace", "podName", "infra5678"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path.Join(binDir, "ADD.env")); !os.IsNotExist(err) {
+		t.Errorf("Expected the plugin not to be invoked for a pod using the host network")
+	}
+	if status, err := plug.Status("podNamespace", "podName", "infra5678"); err != nil || status != nil {
+		t.Errorf("Expected no status for a pod using the host network, got %+v, %v", status, err)
+	}
+}
+
+func TestTearDownDeletedHostNetworkPod(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	// The pod was deleted, so only its infra container tells that it uses
+	// the network of the host.
+	host := newFakeHost()
+	host.runtime.(*fakeRuntime).hostNetwork["infra5678"] = true
+	plug := initPlugin(t, confDir, binDir, host)
+	if err := plug.TearDownPod("podNamespace", "podName", "infra5678"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path.Join(binDir, "DEL.env")); !os.IsNotExist(err) {
+		t.Errorf("Expected the plugin not to be invoked in the network namespace of the host")
+	}
+}
+
+func TestStatusReadsIPOfExistingPods(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	plug := initPlugin(t, confDir, binDir, newFakeHost())
+	fcmd := utilexec.FakeCmd{
+		CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+			func() ([]byte, error) {
+				return []byte("3: eth0    inet 10.22.0.7/16 scope global eth0\\       valid_lft forever preferred_lft forever\n"), nil
+			},
+		},
+	}
+	plug.execer = &utilexec.FakeExec{
+		CommandScript: []utilexec.FakeCommandAction{
+			func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+
+	// The IP is read once, and remembered.
+	for i := 0; i < 2; i++ {
+		status, err := plug.Status("podNamespace", "podName", "infra1234")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if status == nil || status.IP.String() != "10.22.0.7" {
+			t.Errorf("Expected the IP 10.22.0.7, got %+v", status)
+		}
+	}
+	expectedArgv := "nsenter --net=/proc/42/ns/net -F -- ip -o addr show dev eth0 scope global"
+	if argv := strings.Join(fcmd.CombinedOutputLog[0], " "); argv != expectedArgv {
+		t.Errorf("Expected %q, got %q", expectedArgv, argv)
+	}
+}
+
+func TestStatusReadsIPv6AddressOfExistingPods(t *testing.T) {
+	confDir, binDir := installFakePlugin(t, map[string]string{"10-mynet.conf": testNetConf})
+	defer os.RemoveAll(path.Dir(confDir))
+
+	plug := initPlugin(t, confDir, binDir, newFakeHost())
+	fcmd := utilexec.FakeCmd{
+		CombinedOutputScript: []utilexec.FakeCombinedOutputAction{
+			func() ([]byte, error) {
+				return []byte("3: eth0    inet6 fd00::7/64 scope global \\       valid_lft forever preferred_lft forever\n"), nil
+			},
+		},
+	}
+	plug.execer = &utilexec.FakeExec{
+		CommandScript: []utilexec.FakeCommandAction{
+			func(cmd string, args ...string) utilexec.Cmd { return utilexec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+
+	status, err := plug.Status("podNamespace", "podName", "infra1234")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status == nil || status.IP.String() != "fd00::7" {
+		t.Errorf("Expected the IP fd00::7, got %+v", status)
+	}
+}
@@ -22,6 +22,7 @@ package network
 import (
 	"k8s.io/kubernetes/pkg/api"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 )
 
 type fakeNetworkHost struct {
@@ -40,3 +41,7 @@ func (fnh *fakeNetworkHost) GetPodByName(name, namespace string) (*api.Pod, bool
 func (fnh *fakeNetworkHost) GetKubeClient() client.Interface {
 	return nil
 }
+
+func (fnh *fakeNetworkHost) GetRuntime() kubecontainer.Runtime {
+	return nil
+}
@@ -76,6 +76,9 @@ const (
 	defaultExpirePrepared = "1m"
 
 	defaultImageTag = "latest"
+
+	// The time layout accepted by journalctl's --since flag.
+	journalctlTimeFormat = "2006-01-02 15:04:05"
 )
 
 // runtime implements the Containerruntime for rkt. The implementation
@@ -960,32 +963,51 @@ func (r *runtime) SyncPod(pod *api.Pod, runningPod kubecontainer.Pod, podStatus
 }
 
 // GetContainerLogs uses journalctl to get the logs of the container.
-// By default, it returns a snapshot of the container log. Set |follow| to true to
-// stream the log. Set |follow| to false and specify the number of lines (e.g.
-// "100" or "all") to tail the log.
+// By default, it returns a snapshot of the container log. Set 'Follow' to true to
+// stream the log. Set 'TailLines' to restrict the output to the last lines of
+// the log, and 'SinceSeconds' or 'SinceTime' to skip older entries.
 //
 // In rkt runtime's implementation, per container log is get via 'journalctl -M [rkt-$UUID] -u [APP_NAME]'.
 // See https://github.com/coreos/rkt/blob/master/Documentation/commands.md#logging for more details.
-func (r *runtime) GetContainerLogs(pod *api.Pod, containerID string, tail string, follow bool, stdout, stderr io.Writer) error {
+func (r *runtime) GetContainerLogs(pod *api.Pod, containerID string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 	id, err := parseContainerID(containerID)
 	if err != nil {
 		return err
 	}
 
-	cmd := exec.Command("journalctl", "-M", fmt.Sprintf("rkt-%s", id.uuid), "-u", id.appName)
-	if follow {
-		cmd.Args = append(cmd.Args, "-f")
+	cmd := exec.Command("journalctl", "-M", fmt.Sprintf("rkt-%s", id.uuid), "-u", id.appName, "-a")
+	cmd.Args = append(cmd.Args, journalctlArgs(logOptions, util.Now())...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	return cmd.Run()
+}
+
+// journalctlArgs translates the pod log options into journalctl arguments,
+// using now as the reference point for a relative SinceSeconds.
+func journalctlArgs(logOptions *api.PodLogOptions, now util.Time) []string {
+	var args []string
+	if logOptions.Follow {
+		args = append(args, "-f")
+	}
+	if logOptions.TailLines != nil {
+		args = append(args, "-n", strconv.FormatInt(*logOptions.TailLines, 10))
 	}
-	if tail == "all" {
-		cmd.Args = append(cmd.Args, "-a")
+	var since *util.Time
+	if logOptions.SinceSeconds != nil {
+		t := util.NewTime(now.Add(-time.Duration(*logOptions.SinceSeconds) * time.Second))
+		since = &t
+	}
+	if logOptions.SinceTime != nil {
+		since = logOptions.SinceTime
+	}
+	if since != nil {
+		args = append(args, "--since", since.UTC().Format(journalctlTimeFormat))
+	}
+	if logOptions.Timestamps {
+		args = append(args, "-o", "short-iso")
 	} else {
-		_, err := strconv.Atoi(tail)
-		if err == nil {
-			cmd.Args = append(cmd.Args, "-n", tail)
-		}
+		args = append(args, "-o", "cat")
 	}
-	cmd.Stdout, cmd.Stderr = stdout, stderr
-	return cmd.Run()
+	return args
 }
 
 // GarbageCollect collects the pods/containers. TODO(yifan): Enforce the gc policy.
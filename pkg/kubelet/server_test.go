@@ -34,9 +34,11 @@ import (
 
 	cadvisorApi "github.com/google/cadvisor/info/v1"
 	"k8s.io/kubernetes/pkg/api"
+	apierrs "k8s.io/kubernetes/pkg/api/errors"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/dockertools"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/httpstream"
 	"k8s.io/kubernetes/pkg/util/httpstream/spdy"
 )
@@ -54,7 +56,7 @@ type fakeKubelet struct {
 	execFunc                           func(pod string, uid types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	attachFunc                         func(pod string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	portForwardFunc                    func(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error
-	containerLogsFunc                  func(podFullName, containerName, tail string, follow, pervious bool, stdout, stderr io.Writer) error
+	containerLogsFunc                  func(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error
 	streamingConnectionIdleTimeoutFunc func() time.Duration
 	hostnameFunc                       func() string
 	resyncInterval                     time.Duration
@@ -101,8 +103,8 @@ func (fk *fakeKubelet) ServeLogs(w http.ResponseWriter, req *http.Request) {
 	fk.logFunc(w, req)
 }
 
-func (fk *fakeKubelet) GetKubeletContainerLogs(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
-	return fk.containerLogsFunc(podFullName, containerName, tail, follow, previous, stdout, stderr)
+func (fk *fakeKubelet) GetKubeletContainerLogs(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
+	return fk.containerLogsFunc(podFullName, containerName, logOptions, stdout, stderr)
 }
 
 func (fk *fakeKubelet) GetHostname() string {
@@ -558,22 +560,16 @@ func setPodByNameFunc(fw *serverTestFramework, namespace, pod, container string)
 	}
 }
 
-func setGetContainerLogsFunc(fw *serverTestFramework, t *testing.T, expectedPodName, expectedContainerName, expectedTail string, expectedFollow, expectedPrevious bool, output string) {
-	fw.fakeKubelet.containerLogsFunc = func(podFullName, containerName, tail string, follow, previous bool, stdout, stderr io.Writer) error {
+func setGetContainerLogsFunc(fw *serverTestFramework, t *testing.T, expectedPodName, expectedContainerName string, expectedLogOptions *api.PodLogOptions, output string) {
+	fw.fakeKubelet.containerLogsFunc = func(podFullName, containerName string, logOptions *api.PodLogOptions, stdout, stderr io.Writer) error {
 		if podFullName != expectedPodName {
 			t.Errorf("expected %s, got %s", expectedPodName, podFullName)
 		}
 		if containerName != expectedContainerName {
 			t.Errorf("expected %s, got %s", expectedContainerName, containerName)
 		}
-		if tail != expectedTail {
-			t.Errorf("expected %s, got %s", expectedTail, tail)
-		}
-		if follow != expectedFollow {
-			t.Errorf("expected %t, got %t", expectedFollow, follow)
-		}
-		if previous != expectedPrevious {
-			t.Errorf("expected %t, got %t", expectedPrevious, previous)
+		if !api.Semantic.DeepEqual(expectedLogOptions, logOptions) {
+			t.Errorf("expected %#v, got %#v", expectedLogOptions, logOptions)
 		}
 
 		io.WriteString(stdout, output)
@@ -581,6 +577,21 @@ func setGetContainerLogsFunc(fw *serverTestFramework, t *testing.T, expectedPodN
 	}
 }
 
+// getContainerLogs GETs the container logs for the given query and returns the body.
+func getContainerLogs(fw *serverTestFramework, t *testing.T, podNamespace, podName, containerName, query string) string {
+	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + containerName + query)
+	if err != nil {
+		t.Fatalf("Got error GETing: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Errorf("Error reading container logs: %v", err)
+	}
+	return string(body)
+}
+
 func TestContainerLogs(t *testing.T) {
 	fw := newServerTest()
 	output := "foo bar"
@@ -588,78 +599,122 @@ func TestContainerLogs(t *testing.T) {
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := ""
-	expectedFollow := false
-	expectedPrevious := false
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
-	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName)
-	if err != nil {
-		t.Errorf("Got error GETing: %v", err)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "")
+	if result != output {
+		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
-	defer resp.Body.Close()
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		t.Errorf("Error reading container logs: %v", err)
+func TestContainerLogsWithLimitBytes(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	limit := int64(3)
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{LimitBytes: &limit}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?limitBytes=3")
+	if result != output[:limit] {
+		t.Errorf("Expected: '%v', got: '%v'", output[:limit], result)
 	}
-	result := string(body)
+}
+
+func TestContainerLogsWithTail(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	expectedTail := int64(5)
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{TailLines: &expectedTail}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?tailLines=5")
 	if result != output {
 		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
 }
 
-func TestContainerLogsWithTail(t *testing.T) {
+func TestContainerLogsWithLegacyTail(t *testing.T) {
 	fw := newServerTest()
 	output := "foo bar"
 	podNamespace := "other"
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := "5"
-	expectedFollow := false
-	expectedPrevious := false
+	expectedTail := int64(5)
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
-	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?tail=5")
-	if err != nil {
-		t.Errorf("Got error GETing: %v", err)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{TailLines: &expectedTail}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?tail=5")
+	if result != output {
+		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
-	defer resp.Body.Close()
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		t.Errorf("Error reading container logs: %v", err)
-	}
-	result := string(body)
+func TestContainerLogsWithTailAll(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?tail=all")
 	if result != output {
 		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
 }
 
-func TestContainerLogsWithFollow(t *testing.T) {
+func TestContainerLogsWithInvalidTail(t *testing.T) {
 	fw := newServerTest()
 	output := "foo bar"
 	podNamespace := "other"
 	podName := "foo"
 	expectedPodName := getPodName(podName, podNamespace)
 	expectedContainerName := "baz"
-	expectedTail := ""
-	expectedFollow := true
-	expectedPrevious := false
 	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
-	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, expectedTail, expectedFollow, expectedPrevious, output)
-	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?follow=1")
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{}, output)
+	resp, err := http.Get(fw.testHTTPServer.URL + "/containerLogs/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?tail=-1")
 	if err != nil {
-		t.Errorf("Got error GETing: %v", err)
+		t.Fatalf("Got error GETing: %v", err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != apierrs.StatusUnprocessableEntity {
+		t.Errorf("Unexpected non-error reading container logs: %#v", resp)
+	}
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		t.Errorf("Error reading container logs: %v", err)
+func TestContainerLogsWithSinceTimeAndTimestamps(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	since := util.Date(2015, 10, 1, 12, 0, 0, 0, time.UTC)
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{SinceTime: &since, Timestamps: true}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?sinceTime=2015-10-01T12:00:00Z&timestamps=true")
+	if result != output {
+		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
-	result := string(body)
+}
+
+func TestContainerLogsWithFollow(t *testing.T) {
+	fw := newServerTest()
+	output := "foo bar"
+	podNamespace := "other"
+	podName := "foo"
+	expectedPodName := getPodName(podName, podNamespace)
+	expectedContainerName := "baz"
+	setPodByNameFunc(fw, podNamespace, podName, expectedContainerName)
+	setGetContainerLogsFunc(fw, t, expectedPodName, expectedContainerName, &api.PodLogOptions{Follow: true}, output)
+	result := getContainerLogs(fw, t, podNamespace, podName, expectedContainerName, "?follow=1")
 	if result != output {
 		t.Errorf("Expected: '%v', got: '%v'", output, result)
 	}
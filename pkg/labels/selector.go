@@ -46,6 +46,18 @@ func Everything() Selector {
 	return LabelSelector{}
 }
 
+type nothingSelector struct{}
+
+func (n nothingSelector) Matches(_ Labels) bool                         { return false }
+func (n nothingSelector) Empty() bool                                   { return false }
+func (n nothingSelector) String() string                                { return "<null>" }
+func (n nothingSelector) Add(_ string, _ Operator, _ []string) Selector { return n }
+
+// Nothing returns a selector that matches no labels.
+func Nothing() Selector {
+	return nothingSelector{}
+}
+
 // Operator represents a key's relationship
 // to a set of values in a Requirement.
 type Operator string
@@ -57,6 +69,7 @@ const (
 	NotEqualsOperator    Operator = "!="
 	NotInOperator        Operator = "notin"
 	ExistsOperator       Operator = "exists"
+	DoesNotExistOperator Operator = "!"
 )
 
 //LabelSelector is a list of Requirements.
@@ -84,7 +97,7 @@ type Requirement struct {
 
 // NewRequirement is the constructor for a Requirement.
 // If any of these rules is violated, an error is returned:
-// (1) The operator can only be In, NotIn or Exists.
+// (1) The operator can only be In, NotIn, Exists or DoesNotExist.
 // (2) If the operator is In or NotIn, the values set must
 //     be non-empty.
 // (3) The key is invalid due to its length, or sequence
@@ -104,7 +117,7 @@ func NewRequirement(key string, op Operator, vals util.StringSet) (*Requirement,
 		if len(vals) != 1 {
 			return nil, fmt.Errorf("exact match compatibility requires one single value")
 		}
-	case ExistsOperator:
+	case ExistsOperator, DoesNotExistOperator:
 	default:
 		return nil, fmt.Errorf("operator '%v' is not recognized", op)
 	}
@@ -126,6 +139,8 @@ func NewRequirement(key string, op Operator, vals util.StringSet) (*Requirement,
 //     Labels' value for that key is not in Requirement's value set.
 // (4) The operator is NotIn and Labels does not have the
 //     Requirement's key.
+// (5) The operator is DoesNotExist and Labels does not have the
+//     Requirement's key.
 func (r *Requirement) Matches(ls Labels) bool {
 	switch r.operator {
 	case InOperator, EqualsOperator, DoubleEqualsOperator:
@@ -140,6 +155,8 @@ func (r *Requirement) Matches(ls Labels) bool {
 		return !r.strValues.Has(ls.Get(r.key))
 	case ExistsOperator:
 		return ls.Has(r.key)
+	case DoesNotExistOperator:
+		return !ls.Has(r.key)
 	default:
 		return false
 	}
@@ -158,6 +175,9 @@ func (lsel LabelSelector) Empty() bool {
 // returned. See NewRequirement for creating a valid Requirement.
 func (r *Requirement) String() string {
 	var buffer bytes.Buffer
+	if r.operator == DoesNotExistOperator {
+		buffer.WriteString("!")
+	}
 	buffer.WriteString(r.key)
 
 	switch r.operator {
@@ -171,7 +191,7 @@ func (r *Requirement) String() string {
 		buffer.WriteString(" in ")
 	case NotInOperator:
 		buffer.WriteString(" notin ")
-	case ExistsOperator:
+	case ExistsOperator, DoesNotExistOperator:
 		return buffer.String()
 	}
 
@@ -234,6 +254,7 @@ const (
 	EndOfStringToken
 	ClosedParToken
 	CommaToken
+	DoesNotExistToken
 	DoubleEqualsToken
 	EqualsToken
 	IdentifierToken // to represent keys and values
@@ -248,6 +269,7 @@ const (
 var string2token = map[string]Token{
 	")":     ClosedParToken,
 	",":     CommaToken,
+	"!":     DoesNotExistToken,
 	"==":    DoubleEqualsToken,
 	"=":     EqualsToken,
 	"in":    InToken,
@@ -324,7 +346,7 @@ IdentifierLoop:
 }
 
 // scanSpecialSymbol scans string starting with special symbol.
-// special symbol identify non literal operators. "!=", "==", "="
+// special symbol identify non literal operators. "!=", "==", "=", "!"
 func (l *Lexer) scanSpecialSymbol() (Token, string) {
 	lastScannedItem := ScannedItem{}
 	var buffer []byte
@@ -442,7 +464,7 @@ func (p *Parser) parse() ([]Requirement, error) {
 	for {
 		tok, lit := p.lookahead(Values)
 		switch tok {
-		case IdentifierToken:
+		case IdentifierToken, DoesNotExistToken:
 			r, err := p.parseRequirement()
 			if err != nil {
 				return nil, fmt.Errorf("unable to parse requiremnt: %v", err)
@@ -454,7 +476,7 @@ func (p *Parser) parse() ([]Requirement, error) {
 				return requirements, nil
 			case CommaToken:
 				t2, l2 := p.lookahead(Values)
-				if t2 != IdentifierToken {
+				if t2 != IdentifierToken && t2 != DoesNotExistToken {
 					return nil, fmt.Errorf("found '%s', expected: identifier after ','", l2)
 				}
 			default:
@@ -473,7 +495,7 @@ func (p *Parser) parseRequirement() (*Requirement, error) {
 	if err != nil {
 		return nil, err
 	}
-	if operator == ExistsOperator { // operator Exists found lookahead set checked
+	if operator == ExistsOperator || operator == DoesNotExistOperator { // operator found lookahead set checked
 		return NewRequirement(key, operator, nil)
 	}
 	operator, err = p.parseOperator()
@@ -496,9 +518,15 @@ func (p *Parser) parseRequirement() (*Requirement, error) {
 
 // parseKeyAndInferOperator parse literals.
 // in case of no operator 'in, notin, ==, =, !=' are found
-// the 'exists' operattor is inferred
+// the 'exists' operattor is inferred. A key prefixed
+// with '!' infers the 'doesnotexist' operator.
 func (p *Parser) parseKeyAndInferOperator() (string, Operator, error) {
+	var operator Operator
 	tok, literal := p.consume(Values)
+	if tok == DoesNotExistToken {
+		operator = DoesNotExistOperator
+		tok, literal = p.consume(Values)
+	}
 	if tok != IdentifierToken {
 		err := fmt.Errorf("found '%s', expected: identifier", literal)
 		return "", "", err
@@ -506,7 +534,9 @@ func (p *Parser) parseKeyAndInferOperator() (string, Operator, error) {
 	if err := validateLabelKey(literal); err != nil {
 		return "", "", err
 	}
-	var operator Operator
+	if operator == DoesNotExistOperator {
+		return literal, operator, nil
+	}
 	if t, _ := p.lookahead(Values); t == EndOfStringToken || t == CommaToken {
 		operator = ExistsOperator
 	}
@@ -613,7 +643,7 @@ func (p *Parser) parseExactValue() (util.StringSet, error) {
 // The input will cause an error if it does not follow this form:
 //
 // <selector-syntax> ::= <requirement> | <requirement> "," <selector-syntax> ]
-// <requirement> ::= KEY [ <set-based-restriction> | <exact-match-restriction>
+// <requirement> ::= [!] KEY [ <set-based-restriction> | <exact-match-restriction>
 // <set-based-restriction> ::= "" | <inclusion-exclusion> <value-set>
 // <inclusion-exclusion> ::= <inclusion> | <exclusion>
 //           <exclusion> ::= "notin"
@@ -625,7 +655,7 @@ func (p *Parser) parseExactValue() (util.StringSet, error) {
 // VALUE is a sequence of zero or more characters "([A-Za-z0-9_-\.])". Max length is 64 character.
 // Delimiter is white space: (' ', '\t')
 // Example of valid syntax:
-//  "x in (foo,,baz),y,z notin ()"
+//  "x in (foo,,baz),y,z notin (),!w"
 //
 // Note:
 //  (1) Inclusion - " in " - denotes that the KEY is equal to any of the
@@ -635,6 +665,8 @@ func (p *Parser) parseExactValue() (util.StringSet, error) {
 //  (3) The empty string is a valid VALUE
 //  (4) A requirement with just a KEY - as in "y" above - denotes that
 //      the KEY exists and can be any VALUE.
+//  (5) A requirement with just !KEY - as in "!w" above - denotes that
+//      the KEY does not exist.
 //
 func Parse(selector string) (Selector, error) {
 	p := &Parser{l: &Lexer{s: selector, pos: 0}}
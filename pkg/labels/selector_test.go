@@ -93,6 +93,18 @@ func TestEverything(t *testing.T) {
 	}
 }
 
+func TestNothing(t *testing.T) {
+	if Nothing().Matches(Set{"x": "y"}) {
+		t.Errorf("Nothing matched")
+	}
+	if Nothing().Matches(Set{}) {
+		t.Errorf("Nothing matched an empty set")
+	}
+	if Nothing().Empty() {
+		t.Errorf("Nothing was empty")
+	}
+}
+
 func TestSelectorMatches(t *testing.T) {
 	expectMatch(t, "", Set{"x": "y"})
 	expectMatch(t, "x=y", Set{"x": "y"})
@@ -178,7 +190,7 @@ func TestLexer(t *testing.T) {
 		{"(", OpenParToken},
 		{")", ClosedParToken},
 		{"||", IdentifierToken},
-		{"!", ErrorToken},
+		{"!", DoesNotExistToken},
 	}
 	for _, v := range testcases {
 		l := &Lexer{s: v.s, pos: 0}
@@ -212,6 +224,7 @@ func TestLexerSequence(t *testing.T) {
 		{"()", []Token{OpenParToken, ClosedParToken}},
 		{"x in (),y", []Token{IdentifierToken, InToken, OpenParToken, ClosedParToken, CommaToken, IdentifierToken}},
 		{"== != (), = notin", []Token{DoubleEqualsToken, NotEqualsToken, OpenParToken, ClosedParToken, CommaToken, EqualsToken, NotInToken}},
+		{"!key,x!=y", []Token{DoesNotExistToken, IdentifierToken, CommaToken, IdentifierToken, NotEqualsToken, IdentifierToken}},
 	}
 	for _, v := range testcases {
 		var literals []string
@@ -281,6 +294,7 @@ func TestRequirementConstructor(t *testing.T) {
 		{"x", InOperator, util.NewStringSet("foo"), true},
 		{"x", NotInOperator, util.NewStringSet("foo"), true},
 		{"x", ExistsOperator, nil, true},
+		{"x", DoesNotExistOperator, nil, true},
 		{"1foo", InOperator, util.NewStringSet("bar"), true},
 		{"1234", InOperator, util.NewStringSet("bar"), true},
 		{strings.Repeat("a", 254), ExistsOperator, nil, false}, //breaks DNS rule that len(key) <= 253
@@ -320,6 +334,10 @@ func TestToString(t *testing.T) {
 			getRequirement("y", DoubleEqualsOperator, util.NewStringSet("jkl"), t),
 			getRequirement("z", NotEqualsOperator, util.NewStringSet("a"), t)},
 			"x=abc,y==jkl,z!=a", true},
+		{&LabelSelector{
+			getRequirement("x", DoesNotExistOperator, nil, t),
+			getRequirement("y", ExistsOperator, nil, t)},
+			"!x,y", true},
 	}
 	for _, ts := range toStringTests {
 		if out := ts.In.String(); out == "" && ts.Valid {
@@ -355,6 +373,12 @@ func TestRequirementLabelSelectorMatching(t *testing.T) {
 		{Set{"y": "baz"}, &LabelSelector{
 			getRequirement("x", InOperator, util.NewStringSet(""), t),
 		}, false},
+		{Set{"y": "baz"}, &LabelSelector{
+			getRequirement("x", DoesNotExistOperator, nil, t),
+		}, true},
+		{Set{"x": "", "y": "baz"}, &LabelSelector{
+			getRequirement("x", DoesNotExistOperator, nil, t),
+		}, false},
 	}
 	for _, lsm := range labelSelectorMatchingTests {
 		if match := lsm.Sel.Matches(lsm.Set); match != lsm.Match {
@@ -441,6 +465,15 @@ func TestSetSelectorParser(t *testing.T) {
 		{"a in (x,y,notin, z,in)", LabelSelector{
 			getRequirement("a", InOperator, util.NewStringSet("in", "notin", "x", "y", "z"), t),
 		}, true, true}, // operator 'in' inside list of identifiers
+		{"!x", LabelSelector{
+			getRequirement("x", DoesNotExistOperator, nil, t),
+		}, true, true},
+		{"x in (a),!y", LabelSelector{
+			getRequirement("x", InOperator, util.NewStringSet("a"), t),
+			getRequirement("y", DoesNotExistOperator, nil, t),
+		}, true, true},
+		{"!x=a", nil, true, false},
+		{"!", nil, true, false},
 		{"a in (xyz abc)", nil, false, false}, // no comma
 		{"a notin(", nil, true, false},        // bad formed
 		{"a (", nil, false, false},            // cpar
@@ -55,6 +55,7 @@ import (
 	endpointsetcd "k8s.io/kubernetes/pkg/registry/endpoint/etcd"
 	eventetcd "k8s.io/kubernetes/pkg/registry/event/etcd"
 	expcontrolleretcd "k8s.io/kubernetes/pkg/registry/experimental/controller/etcd"
+	"k8s.io/kubernetes/pkg/registry/generic"
 	limitrangeetcd "k8s.io/kubernetes/pkg/registry/limitrange/etcd"
 	"k8s.io/kubernetes/pkg/registry/minion"
 	nodeetcd "k8s.io/kubernetes/pkg/registry/minion/etcd"
@@ -80,6 +81,7 @@ import (
 
 	daemonetcd "k8s.io/kubernetes/pkg/registry/daemon/etcd"
 	horizontalpodautoscaleretcd "k8s.io/kubernetes/pkg/registry/horizontalpodautoscaler/etcd"
+	networkpolicyetcd "k8s.io/kubernetes/pkg/registry/networkpolicy/etcd"
 
 	"github.com/emicklei/go-restful"
 	"github.com/emicklei/go-restful/swagger"
@@ -112,6 +114,7 @@ type Config struct {
 	// allow downstream consumers to disable the index route
 	EnableIndex           bool
 	EnableProfiling       bool
+	EnableWatchCache      bool
 	APIPrefix             string
 	ExpAPIPrefix          string
 	CorsAllowedOriginList []string
@@ -172,6 +175,15 @@ type Config struct {
 
 type InstallSSHKey func(user string, data []byte) error
 
+// storageDecorator returns the decorator applied to the storage of all
+// registries, depending on whether the watch cache is enabled.
+func (c *Config) storageDecorator() generic.StorageDecorator {
+	if c.EnableWatchCache {
+		return generic.StorageWithCacher
+	}
+	return generic.UndecoratedStorage
+}
+
 // Master contains state for a Kubernetes cluster master/api server.
 type Master struct {
 	// "Inputs", Copied from Config
@@ -430,32 +442,32 @@ func logStackOnRecover(panicReason interface{}, httpWriter http.ResponseWriter)
 
 // init initializes master.
 func (m *Master) init(c *Config) {
-	enableCacher := true
+	storageDecorator := c.storageDecorator()
 	healthzChecks := []healthz.HealthzChecker{}
 	m.clock = util.RealClock{}
-	podStorage := podetcd.NewStorage(c.DatabaseStorage, enableCacher, c.KubeletClient)
+	podStorage := podetcd.NewStorage(c.DatabaseStorage, storageDecorator, c.KubeletClient)
 
-	podTemplateStorage := podtemplateetcd.NewREST(c.DatabaseStorage)
+	podTemplateStorage := podtemplateetcd.NewREST(c.DatabaseStorage, storageDecorator)
 
-	eventStorage := eventetcd.NewREST(c.DatabaseStorage, uint64(c.EventTTL.Seconds()))
-	limitRangeStorage := limitrangeetcd.NewREST(c.DatabaseStorage)
+	eventStorage := eventetcd.NewREST(c.DatabaseStorage, storageDecorator, uint64(c.EventTTL.Seconds()))
+	limitRangeStorage := limitrangeetcd.NewREST(c.DatabaseStorage, storageDecorator)
 
-	resourceQuotaStorage, resourceQuotaStatusStorage := resourcequotaetcd.NewREST(c.DatabaseStorage)
-	secretStorage := secretetcd.NewREST(c.DatabaseStorage)
-	serviceAccountStorage := serviceaccountetcd.NewREST(c.DatabaseStorage)
-	persistentVolumeStorage, persistentVolumeStatusStorage := pvetcd.NewREST(c.DatabaseStorage)
-	persistentVolumeClaimStorage, persistentVolumeClaimStatusStorage := pvcetcd.NewREST(c.DatabaseStorage)
+	resourceQuotaStorage, resourceQuotaStatusStorage := resourcequotaetcd.NewREST(c.DatabaseStorage, storageDecorator)
+	secretStorage := secretetcd.NewREST(c.DatabaseStorage, storageDecorator)
+	serviceAccountStorage := serviceaccountetcd.NewREST(c.DatabaseStorage, storageDecorator)
+	persistentVolumeStorage, persistentVolumeStatusStorage := pvetcd.NewREST(c.DatabaseStorage, storageDecorator)
+	persistentVolumeClaimStorage, persistentVolumeClaimStatusStorage := pvcetcd.NewREST(c.DatabaseStorage, storageDecorator)
 
-	namespaceStorage, namespaceStatusStorage, namespaceFinalizeStorage := namespaceetcd.NewREST(c.DatabaseStorage)
+	namespaceStorage, namespaceStatusStorage, namespaceFinalizeStorage := namespaceetcd.NewREST(c.DatabaseStorage, storageDecorator)
 	m.namespaceRegistry = namespace.NewRegistry(namespaceStorage)
 
-	endpointsStorage := endpointsetcd.NewREST(c.DatabaseStorage, enableCacher)
+	endpointsStorage := endpointsetcd.NewREST(c.DatabaseStorage, storageDecorator)
 	m.endpointRegistry = endpoint.NewRegistry(endpointsStorage)
 
-	nodeStorage, nodeStatusStorage := nodeetcd.NewREST(c.DatabaseStorage, enableCacher, c.KubeletClient)
+	nodeStorage, nodeStatusStorage := nodeetcd.NewREST(c.DatabaseStorage, storageDecorator, c.KubeletClient)
 	m.nodeRegistry = minion.NewRegistry(nodeStorage)
 
-	serviceStorage := serviceetcd.NewREST(c.DatabaseStorage)
+	serviceStorage := serviceetcd.NewREST(c.DatabaseStorage, storageDecorator)
 	m.serviceRegistry = service.NewRegistry(serviceStorage)
 
 	var serviceClusterIPRegistry service.RangeRegistry
@@ -476,7 +488,7 @@ func (m *Master) init(c *Config) {
 	})
 	m.serviceNodePortAllocator = serviceNodePortRegistry
 
-	controllerStorage := controlleretcd.NewREST(c.DatabaseStorage)
+	controllerStorage := controlleretcd.NewREST(c.DatabaseStorage, storageDecorator)
 
 	// TODO: Factor out the core API registration
 	m.storage = map[string]rest.Storage{
@@ -777,15 +789,18 @@ func (m *Master) api_v1() *apiserver.APIGroupVersion {
 	version.Storage = storage
 	version.Version = "v1"
 	version.Codec = v1.Codec
+	version.ProtobufCodec = v1.ProtobufCodec
 	return version
 }
 
 // expapi returns the resources and codec for the experimental api
 func (m *Master) expapi(c *Config) *apiserver.APIGroupVersion {
+	storageDecorator := c.storageDecorator()
 	controllerStorage := expcontrolleretcd.NewStorage(c.ExpDatabaseStorage)
-	autoscalerStorage := horizontalpodautoscaleretcd.NewREST(c.ExpDatabaseStorage)
-	thirdPartyResourceStorage := thirdpartyresourceetcd.NewREST(c.ExpDatabaseStorage)
-	daemonStorage := daemonetcd.NewREST(c.ExpDatabaseStorage)
+	autoscalerStorage := horizontalpodautoscaleretcd.NewREST(c.ExpDatabaseStorage, storageDecorator)
+	thirdPartyResourceStorage := thirdpartyresourceetcd.NewREST(c.ExpDatabaseStorage, storageDecorator)
+	daemonStorage := daemonetcd.NewREST(c.ExpDatabaseStorage, storageDecorator)
+	networkPolicyStorage := networkpolicyetcd.NewREST(c.ExpDatabaseStorage, storageDecorator)
 
 	storage := map[string]rest.Storage{
 		strings.ToLower("replicationControllers"):       controllerStorage.ReplicationController,
@@ -793,6 +808,7 @@ func (m *Master) expapi(c *Config) *apiserver.APIGroupVersion {
 		strings.ToLower("horizontalpodautoscalers"):     autoscalerStorage,
 		strings.ToLower("thirdpartyresources"):          thirdPartyResourceStorage,
 		strings.ToLower("daemons"):                      daemonStorage,
+		strings.ToLower("networkpolicies"):              networkPolicyStorage,
 	}
 
 	return &apiserver.APIGroupVersion{
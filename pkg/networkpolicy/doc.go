@@ -0,0 +1,29 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package networkpolicy enforces the network policies of the experimental
+// API on a node with iptables. The filter table's FORWARD chain jumps to a
+// KUBE-NETWORK-POLICY chain, which sends the traffic to every pod of the node
+// selected by a network policy to a chain of its own. That chain accepts the
+// replies to connections already allowed and the new connections allowed by
+// the ingress rules of the policies selecting the pod, and drops the rest.
+//
+// Only traffic forwarded by the node is filtered. Traffic between pods on the
+// same bridge is seen by iptables only when bridge-nf-call-iptables is set,
+// which EnsureBridgeNetfilter does before the enforcer is started. Traffic
+// from the node itself, such as health checks, is always allowed. Pods using the host network are neither isolated nor matched as
+// sources.
+package networkpolicy
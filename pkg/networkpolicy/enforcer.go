@@ -0,0 +1,491 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/unversioned/cache"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+// the chain dispatching forwarded traffic to the chains of isolated pods
+const networkPolicyChain utiliptables.Chain = "KUBE-NETWORK-POLICY"
+
+// the prefix of the chains holding the rules of each isolated pod
+const podChainPrefix = "KUBE-NP-"
+
+const sysctlBase = "/proc/sys"
+const sysctlBridgeCallIptables = "net/bridge/bridge-nf-call-iptables"
+const sysctlBridgeCallIp6tables = "net/bridge/bridge-nf-call-ip6tables"
+
+// Enforcer programs iptables to enforce the network policies selecting the
+// pods of a node.
+type Enforcer struct {
+	hostname   string
+	iptables   utiliptables.Interface
+	syncPeriod time.Duration
+
+	// Caches of all the network policies, pods and namespaces, kept up to
+	// date by the informers which fill them.
+	policies             cache.Store
+	policiesController   *framework.Controller
+	pods                 cache.Store
+	podsController       *framework.Controller
+	namespaces           cache.Store
+	namespacesController *framework.Controller
+
+	// changed is signaled when a cache changes and the rules are out of date.
+	changed chan struct{}
+}
+
+// NewEnforcer returns an Enforcer for the pods scheduled to the node named
+// hostname, using the network policies, pods and namespaces listed and
+// watched through policiesLW, podsLW and namespacesLW. Besides syncing the
+// rules on every change, it resyncs them every syncPeriod. It has to be
+// started with Run.
+func NewEnforcer(policiesLW, podsLW, namespacesLW cache.ListerWatcher, hostname string, ipt utiliptables.Interface, syncPeriod time.Duration) *Enforcer {
+	e := &Enforcer{
+		hostname:   hostname,
+		iptables:   ipt,
+		syncPeriod: syncPeriod,
+		changed:    make(chan struct{}, 1),
+	}
+	handler := framework.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { e.queueSync() },
+		UpdateFunc: func(old, cur interface{}) { e.queueSync() },
+		DeleteFunc: func(obj interface{}) { e.queueSync() },
+	}
+	e.policies, e.policiesController = framework.NewInformer(policiesLW, &expapi.NetworkPolicy{}, 0, handler)
+	e.pods, e.podsController = framework.NewInformer(podsLW, &api.Pod{}, 0, handler)
+	e.namespaces, e.namespacesController = framework.NewInformer(namespacesLW, &api.Namespace{}, 0, handler)
+	return e
+}
+
+// CleanupLeftovers removes all iptables rules and chains created by the Enforcer.
+// It returns true if an error was encountered. Errors are logged.
+func CleanupLeftovers(ipt utiliptables.Interface) (encounteredError bool) {
+	if err := ipt.DeleteRule(utiliptables.TableFilter, utiliptables.ChainForward, jumpArgs()...); err != nil {
+		glog.Errorf("Error removing network policy rule: %v", err)
+		encounteredError = true
+	}
+	save, err := ipt.Save(utiliptables.TableFilter)
+	if err != nil {
+		glog.Errorf("Error listing network policy chains: %v", err)
+		return true
+	}
+	existingChains := utiliptables.GetChainLines(utiliptables.TableFilter, save)
+	chainsLines := bytes.NewBuffer(nil)
+	rulesLines := bytes.NewBuffer(nil)
+	writeLine(chainsLines, "*filter")
+	for chain, line := range existingChains {
+		if chain == networkPolicyChain || strings.HasPrefix(string(chain), podChainPrefix) {
+			writeLine(chainsLines, line)
+			writeLine(rulesLines, "-X", string(chain))
+		}
+	}
+	writeLine(rulesLines, "COMMIT")
+	lines := append(chainsLines.Bytes(), rulesLines.Bytes()...)
+	if err := ipt.Restore(utiliptables.TableFilter, lines, utiliptables.NoFlushTables, utiliptables.RestoreCounters); err != nil {
+		glog.Errorf("Error removing network policy chains: %v", err)
+		encounteredError = true
+	}
+	return encounteredError
+}
+
+// EnsureBridgeNetfilter makes the traffic bridged between the pods of the
+// node go through iptables, so that the FORWARD chain also filters the
+// traffic between pods on the same bridge. It returns an error if the sysctl
+// cannot be set, since the policies would not isolate those pods otherwise.
+func EnsureBridgeNetfilter(ipt utiliptables.Interface) error {
+	sysctl := sysctlBridgeCallIptables
+	if ipt.IsIpv6() {
+		sysctl = sysctlBridgeCallIp6tables
+	}
+	// br_netfilter may be a module of its own. Loading it may fail when it is
+	// built into the bridge module, so only the error of the sysctl matters.
+	exec.Command("modprobe", "br-netfilter").CombinedOutput()
+	if err := ioutil.WriteFile(path.Join(sysctlBase, sysctl), []byte("1"), 0640); err != nil {
+		return fmt.Errorf("can't set sysctl %s: %v", sysctl, err)
+	}
+	return nil
+}
+
+// Run starts the informers and keeps the rules in sync with the caches
+// until stopCh is closed.
+func (e *Enforcer) Run(stopCh <-chan struct{}) {
+	go e.policiesController.Run(stopCh)
+	go e.podsController.Run(stopCh)
+	go e.namespacesController.Run(stopCh)
+
+	t := time.NewTicker(e.syncPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-e.changed:
+		case <-t.C:
+			glog.V(6).Infof("Periodic sync")
+		}
+		if !e.hasSynced() {
+			glog.V(2).Info("Not syncing iptables until network policies, pods and namespaces have been received from master")
+			continue
+		}
+		e.syncRules()
+	}
+}
+
+// hasSynced returns true once the initial lists of network policies, pods
+// and namespaces have been loaded.
+func (e *Enforcer) hasSynced() bool {
+	return e.policiesController.HasSynced() && e.podsController.HasSynced() && e.namespacesController.HasSynced()
+}
+
+// queueSync requests a sync of the rules without blocking.
+func (e *Enforcer) queueSync() {
+	select {
+	case e.changed <- struct{}{}:
+	default:
+	}
+}
+
+// syncRules rewrites the rules of the isolated pods of the node from the caches.
+func (e *Enforcer) syncRules() {
+	glog.V(3).Infof("Syncing network policy rules")
+
+	// Ensure the dispatch chain exists and all forwarded traffic goes
+	// through it before any rule accepting it.
+	if _, err := e.iptables.EnsureChain(utiliptables.TableFilter, networkPolicyChain); err != nil {
+		glog.Errorf("Failed to ensure that chain %s exists: %v", networkPolicyChain, err)
+		return
+	}
+	if _, err := e.iptables.EnsureRule(utiliptables.Prepend, utiliptables.TableFilter, utiliptables.ChainForward, jumpArgs()...); err != nil {
+		glog.Errorf("Failed to ensure that chain %s jumps to %s: %v", utiliptables.ChainForward, networkPolicyChain, err)
+		return
+	}
+
+	existingChains := make(map[utiliptables.Chain]string)
+	iptablesSaveRaw, err := e.iptables.Save(utiliptables.TableFilter)
+	if err != nil {
+		glog.Errorf("Failed to execute iptable-save, syncing all rules. %s", err.Error())
+	} else {
+		existingChains = utiliptables.GetChainLines(utiliptables.TableFilter, iptablesSaveRaw)
+	}
+
+	lines := e.buildRules(existingChains)
+	glog.V(3).Infof("Syncing rules: %s", lines)
+	// NOTE: NoFlushTables is used so we don't flush non-kubernetes chains in the table.
+	if err := e.iptables.Restore(utiliptables.TableFilter, lines, utiliptables.NoFlushTables, utiliptables.RestoreCounters); err != nil {
+		glog.Errorf("Failed to sync network policy rules: %v", err)
+	}
+}
+
+// buildRules returns the iptables-restore data of the filter table for the
+// current contents of the caches, given the chains of the table.
+func (e *Enforcer) buildRules(existingChains map[utiliptables.Chain]string) []byte {
+	chainsLines := bytes.NewBuffer(nil)
+	rulesLines := bytes.NewBuffer(nil)
+
+	writeLine(chainsLines, "*filter")
+	if chain, ok := existingChains[networkPolicyChain]; ok {
+		writeLine(chainsLines, chain)
+	} else {
+		writeLine(chainsLines, utiliptables.MakeChainLine(networkPolicyChain))
+	}
+
+	podsByNamespace := e.podsByNamespace()
+	policiesByNamespace := e.policiesByNamespace()
+	activeChains := map[utiliptables.Chain]bool{}
+
+	namespaces := make([]string, 0, len(podsByNamespace))
+	for namespace := range podsByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		for _, pod := range podsByNamespace[namespace] {
+			if pod.Spec.NodeName != e.hostname {
+				continue
+			}
+			policies := selectingPolicies(pod, policiesByNamespace[namespace])
+			if len(policies) == 0 {
+				continue
+			}
+			podName := namespace + "/" + pod.Name
+
+			podChain := podChainName(pod)
+			if chain, ok := existingChains[podChain]; ok {
+				writeLine(chainsLines, chain)
+			} else {
+				writeLine(chainsLines, utiliptables.MakeChainLine(podChain))
+			}
+			activeChains[podChain] = true
+
+			writeLine(rulesLines,
+				"-A", string(networkPolicyChain),
+				"-m", "comment", "--comment", fmt.Sprintf("\"%s\"", podName),
+				"-d", util.HostCIDR(pod.Status.PodIP),
+				"-j", string(podChain))
+			writeLine(rulesLines,
+				"-A", string(podChain),
+				"-m", "comment", "--comment", fmt.Sprintf("\"%s\"", podName),
+				"-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED",
+				"-j", "ACCEPT")
+			for _, policy := range policies {
+				for i := range policy.Spec.Ingress {
+					for _, match := range e.ingressRuleMatches(pod, policy, &policy.Spec.Ingress[i], podsByNamespace) {
+						args := []string{
+							"-A", string(podChain),
+							"-m", "comment", "--comment", fmt.Sprintf("\"%s/%s\"", namespace, policy.Name),
+						}
+						args = append(args, match...)
+						writeLine(rulesLines, append(args, "-j", "ACCEPT")...)
+					}
+				}
+			}
+			writeLine(rulesLines,
+				"-A", string(podChain),
+				"-m", "comment", "--comment", fmt.Sprintf("\"%s\"", podName),
+				"-j", "DROP")
+		}
+	}
+
+	// Delete chains no longer in use.
+	for chain := range existingChains {
+		if !activeChains[chain] && strings.HasPrefix(string(chain), podChainPrefix) {
+			// We must (as per iptables) write a chain-line for it, which has
+			// the nice effect of flushing the chain.  Then we can remove the
+			// chain.
+			writeLine(chainsLines, existingChains[chain])
+			writeLine(rulesLines, "-X", string(chain))
+		}
+	}
+
+	writeLine(rulesLines, "COMMIT")
+	return append(chainsLines.Bytes(), rulesLines.Bytes()...)
+}
+
+// podsByNamespace returns the pods with an IP of their own, grouped by
+// namespace and sorted by name.
+func (e *Enforcer) podsByNamespace() map[string][]*api.Pod {
+	pods := map[string][]*api.Pod{}
+	for _, obj := range e.pods.List() {
+		pod := obj.(*api.Pod)
+		if pod.Status.PodIP == "" || pod.Spec.HostNetwork {
+			continue
+		}
+		// The IPs of terminated pods may already belong to other pods.
+		if pod.Status.Phase == api.PodSucceeded || pod.Status.Phase == api.PodFailed {
+			continue
+		}
+		// The rules of the pods of the other IP family belong to the
+		// enforcer of the other table.
+		if isIPv6(pod.Status.PodIP) != e.iptables.IsIpv6() {
+			continue
+		}
+		pods[pod.Namespace] = append(pods[pod.Namespace], pod)
+	}
+	for _, list := range pods {
+		sort.Sort(podsByName(list))
+	}
+	return pods
+}
+
+// isIPv6 returns true if ip is an IPv6 address.
+func isIPv6(ip string) bool {
+	addr := net.ParseIP(ip)
+	return addr != nil && addr.To4() == nil
+}
+
+// policiesByNamespace returns the network policies grouped by namespace and
+// sorted by name.
+func (e *Enforcer) policiesByNamespace() map[string][]*expapi.NetworkPolicy {
+	policies := map[string][]*expapi.NetworkPolicy{}
+	for _, obj := range e.policies.List() {
+		policy := obj.(*expapi.NetworkPolicy)
+		policies[policy.Namespace] = append(policies[policy.Namespace], policy)
+	}
+	for _, list := range policies {
+		sort.Sort(policiesByName(list))
+	}
+	return policies
+}
+
+// selectingPolicies returns the policies whose pod selector selects pod.
+func selectingPolicies(pod *api.Pod, policies []*expapi.NetworkPolicy) []*expapi.NetworkPolicy {
+	var selecting []*expapi.NetworkPolicy
+	for _, policy := range policies {
+		selector, err := expapi.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			glog.Errorf("Ignoring network policy %s/%s with invalid pod selector: %v", policy.Namespace, policy.Name, err)
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			selecting = append(selecting, policy)
+		}
+	}
+	return selecting
+}
+
+// ingressRuleMatches returns the iptables match arguments of the traffic to
+// pod allowed by rule, one list per combination of source and port. It
+// returns nothing if the rule allows no traffic, e.g. because no pod matches
+// its sources.
+func (e *Enforcer) ingressRuleMatches(pod *api.Pod, policy *expapi.NetworkPolicy, rule *expapi.NetworkPolicyIngressRule, podsByNamespace map[string][]*api.Pod) [][]string {
+	sources := [][]string{nil}
+	if len(rule.From) > 0 {
+		sources = nil
+		for _, ip := range e.peerIPs(policy, rule.From, podsByNamespace) {
+			sources = append(sources, []string{"-s", util.HostCIDR(ip)})
+		}
+	}
+	ports := [][]string{nil}
+	if len(rule.Ports) > 0 {
+		ports = nil
+		for i := range rule.Ports {
+			if args, ok := portArgs(pod, &rule.Ports[i]); ok {
+				ports = append(ports, args)
+			}
+		}
+	}
+	var matches [][]string
+	for _, source := range sources {
+		for _, port := range ports {
+			matches = append(matches, append(append([]string{}, source...), port...))
+		}
+	}
+	return matches
+}
+
+// peerIPs returns the sorted IPs of the pods matched by peers.
+func (e *Enforcer) peerIPs(policy *expapi.NetworkPolicy, peers []expapi.NetworkPolicyPeer, podsByNamespace map[string][]*api.Pod) []string {
+	ips := util.NewStringSet()
+	for i := range peers {
+		peer := &peers[i]
+		switch {
+		case peer.PodSelector != nil:
+			selector, err := expapi.LabelSelectorAsSelector(peer.PodSelector)
+			if err != nil {
+				glog.Errorf("Ignoring invalid pod selector of network policy %s/%s: %v", policy.Namespace, policy.Name, err)
+				continue
+			}
+			for _, pod := range podsByNamespace[policy.Namespace] {
+				if selector.Matches(labels.Set(pod.Labels)) {
+					ips.Insert(pod.Status.PodIP)
+				}
+			}
+		case peer.NamespaceSelector != nil:
+			selector, err := expapi.LabelSelectorAsSelector(peer.NamespaceSelector)
+			if err != nil {
+				glog.Errorf("Ignoring invalid namespace selector of network policy %s/%s: %v", policy.Namespace, policy.Name, err)
+				continue
+			}
+			for _, obj := range e.namespaces.List() {
+				namespace := obj.(*api.Namespace)
+				if selector.Matches(labels.Set(namespace.Labels)) {
+					for _, pod := range podsByNamespace[namespace.Name] {
+						ips.Insert(pod.Status.PodIP)
+					}
+				}
+			}
+		}
+	}
+	return ips.List()
+}
+
+// portArgs returns the iptables match arguments of port on pod. Named ports
+// are looked up in the containers of pod; it returns false if pod has no
+// port of that name.
+func portArgs(pod *api.Pod, port *expapi.NetworkPolicyPort) ([]string, bool) {
+	protocol := api.ProtocolTCP
+	if port.Protocol != nil {
+		protocol = *port.Protocol
+	}
+	proto := strings.ToLower(string(protocol))
+	if port.Port == nil {
+		return []string{"-p", proto}, true
+	}
+	number := port.Port.IntVal
+	if port.Port.Kind == util.IntstrString {
+		var found bool
+		if number, found = findContainerPort(pod, port.Port.StrVal, protocol); !found {
+			return nil, false
+		}
+	}
+	return []string{"-p", proto, "-m", proto, "--dport", strconv.Itoa(number)}, true
+}
+
+// findContainerPort returns the number of the container port of pod named
+// name with the given protocol.
+func findContainerPort(pod *api.Pod, name string, protocol api.Protocol) (int, bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == name && port.Protocol == protocol {
+				return port.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// podChainName returns the name of the chain holding the rules of pod.
+func podChainName(pod *api.Pod) utiliptables.Chain {
+	hash := sha256.Sum256([]byte(pod.Namespace + "/" + pod.Name))
+	encoded := base32.StdEncoding.EncodeToString(hash[:])
+	return utiliptables.Chain(podChainPrefix + encoded[:16])
+}
+
+// jumpArgs returns the arguments of the rule of the FORWARD chain jumping to
+// the dispatch chain.
+func jumpArgs() []string {
+	return []string{"-m", "comment", "--comment", "kubernetes network policy", "-j", string(networkPolicyChain)}
+}
+
+// Join all words with spaces, terminate with newline and write to buf.
+func writeLine(buf *bytes.Buffer, words ...string) {
+	buf.WriteString(strings.Join(words, " ") + "\n")
+}
+
+type podsByName []*api.Pod
+
+func (p podsByName) Len() int           { return len(p) }
+func (p podsByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p podsByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
+
+type policiesByName []*expapi.NetworkPolicy
+
+func (p policiesByName) Len() int           { return len(p) }
+func (p policiesByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p policiesByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
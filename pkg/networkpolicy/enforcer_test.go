@@ -0,0 +1,329 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/util"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+)
+
+const testHostname = "node-1"
+
+func newTestEnforcer(ipt utiliptables.Interface) *Enforcer {
+	return NewEnforcer(framework.NewFakeControllerSource(), framework.NewFakeControllerSource(), framework.NewFakeControllerSource(), testHostname, ipt, time.Minute)
+}
+
+func newPod(namespace, name, node, ip string, labels map[string]string) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       api.PodSpec{NodeName: node},
+		Status:     api.PodStatus{Phase: api.PodRunning, PodIP: ip},
+	}
+}
+
+func newNamespace(name string, labels map[string]string) *api.Namespace {
+	return &api.Namespace{ObjectMeta: api.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func tcpPort(port util.IntOrString) expapi.NetworkPolicyPort {
+	tcp := api.ProtocolTCP
+	return expapi.NetworkPolicyPort{Protocol: &tcp, Port: &port}
+}
+
+func addAll(t *testing.T, e *Enforcer, objs ...interface{}) {
+	for _, obj := range objs {
+		var err error
+		switch obj.(type) {
+		case *api.Pod:
+			err = e.pods.Add(obj)
+		case *api.Namespace:
+			err = e.namespaces.Add(obj)
+		case *expapi.NetworkPolicy:
+			err = e.policies.Add(obj)
+		}
+		if err != nil {
+			t.Fatalf("unexpected error adding %#v: %v", obj, err)
+		}
+	}
+}
+
+func TestBuildRules(t *testing.T) {
+	e := newTestEnforcer(utiliptables.NewFake())
+
+	db := newPod("default", "db-0", testHostname, "10.0.1.2", map[string]string{"name": "db"})
+	db.Spec.Containers = []api.Container{{
+		Name:  "db",
+		Ports: []api.ContainerPort{{Name: "metrics", ContainerPort: 9100, Protocol: api.ProtocolTCP}},
+	}}
+	hostNetwork := newPod("default", "hostnet", testHostname, "192.168.0.1", map[string]string{"name": "frontend"})
+	hostNetwork.Spec.HostNetwork = true
+	terminated := newPod("web", "done", "node-2", "10.0.2.5", nil)
+	terminated.Status.Phase = api.PodSucceeded
+
+	addAll(t, e,
+		newNamespace("default", nil),
+		newNamespace("web", map[string]string{"project": "web"}),
+		db,
+		// Not scheduled to this node.
+		newPod("default", "db-1", "node-2", "10.0.2.2", map[string]string{"name": "db"}),
+		newPod("default", "frontend", "node-2", "10.0.2.3", map[string]string{"name": "frontend"}),
+		// Not selected by any policy.
+		newPod("default", "other", testHostname, "10.0.1.3", nil),
+		hostNetwork,
+		newPod("web", "site", "node-2", "10.0.2.4", nil),
+		terminated,
+		&expapi.NetworkPolicy{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "db"},
+			Spec: expapi.NetworkPolicySpec{
+				PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "db"}},
+				Ingress: []expapi.NetworkPolicyIngressRule{
+					{
+						Ports: []expapi.NetworkPolicyPort{tcpPort(util.NewIntOrStringFromInt(5432))},
+						From: []expapi.NetworkPolicyPeer{
+							{PodSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"name": "frontend"}}},
+						},
+					},
+					{
+						Ports: []expapi.NetworkPolicyPort{
+							tcpPort(util.NewIntOrStringFromString("metrics")),
+							tcpPort(util.NewIntOrStringFromString("missing")),
+						},
+						From: []expapi.NetworkPolicyPeer{
+							{NamespaceSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"project": "web"}}},
+						},
+					},
+				},
+			},
+		},
+		// Selects pods of another namespace only.
+		&expapi.NetworkPolicy{
+			ObjectMeta: api.ObjectMeta{Namespace: "web", Name: "deny-all"},
+		},
+	)
+
+	chain := string(podChainName(db))
+	expected := strings.Join([]string{
+		"*filter",
+		":KUBE-NETWORK-POLICY - [0:0]",
+		":" + chain + " - [0:0]",
+		`-A KUBE-NETWORK-POLICY -m comment --comment "default/db-0" -d 10.0.1.2/32 -j ` + chain,
+		"-A " + chain + ` -m comment --comment "default/db-0" -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT`,
+		"-A " + chain + ` -m comment --comment "default/db" -s 10.0.2.3/32 -p tcp -m tcp --dport 5432 -j ACCEPT`,
+		"-A " + chain + ` -m comment --comment "default/db" -s 10.0.2.4/32 -p tcp -m tcp --dport 9100 -j ACCEPT`,
+		"-A " + chain + ` -m comment --comment "default/db-0" -j DROP`,
+		"COMMIT",
+		"",
+	}, "\n")
+	if got := string(e.buildRules(map[utiliptables.Chain]string{})); got != expected {
+		t.Errorf("expected rules:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestBuildRulesIPv6(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	ipt.Ipv6 = true
+	e := newTestEnforcer(ipt)
+
+	db := newPod("default", "db-0", testHostname, "fd00:1::2", map[string]string{"name": "db"})
+	addAll(t, e,
+		newNamespace("default", nil),
+		db,
+		// IPv4 pods belong to the rules of the IPv4 table.
+		newPod("default", "db-1", testHostname, "10.0.1.2", map[string]string{"name": "db"}),
+		newPod("default", "frontend-0", "node-2", "10.0.2.3", map[string]string{"name": "frontend"}),
+		newPod("default", "frontend-1", "node-2", "fd00:2::3", map[string]string{"name": "frontend"}),
+		&expapi.NetworkPolicy{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "db"},
+			Spec: expapi.NetworkPolicySpec{
+				PodSelector: expapi.LabelSelector{MatchLabels: map[string]string{"name": "db"}},
+				Ingress: []expapi.NetworkPolicyIngressRule{{
+					From: []expapi.NetworkPolicyPeer{
+						{PodSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"name": "frontend"}}},
+					},
+				}},
+			},
+		},
+	)
+
+	chain := string(podChainName(db))
+	expected := strings.Join([]string{
+		"*filter",
+		":KUBE-NETWORK-POLICY - [0:0]",
+		":" + chain + " - [0:0]",
+		`-A KUBE-NETWORK-POLICY -m comment --comment "default/db-0" -d fd00:1::2/128 -j ` + chain,
+		"-A " + chain + ` -m comment --comment "default/db-0" -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT`,
+		"-A " + chain + ` -m comment --comment "default/db" -s fd00:2::3/128 -j ACCEPT`,
+		"-A " + chain + ` -m comment --comment "default/db-0" -j DROP`,
+		"COMMIT",
+		"",
+	}, "\n")
+	if got := string(e.buildRules(map[utiliptables.Chain]string{})); got != expected {
+		t.Errorf("expected rules:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestBuildRulesKeepsCountersAndDeletesStaleChains(t *testing.T) {
+	e := newTestEnforcer(utiliptables.NewFake())
+	udp := api.ProtocolUDP
+	pod := newPod("default", "dns", testHostname, "10.0.1.2", nil)
+	addAll(t, e,
+		newNamespace("default", nil),
+		pod,
+		// Allows all traffic.
+		&expapi.NetworkPolicy{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "a-allow-all"},
+			Spec: expapi.NetworkPolicySpec{
+				Ingress: []expapi.NetworkPolicyIngressRule{{}},
+			},
+		},
+		// Allows UDP from pods which do not exist.
+		&expapi.NetworkPolicy{
+			ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "b-udp"},
+			Spec: expapi.NetworkPolicySpec{
+				Ingress: []expapi.NetworkPolicyIngressRule{
+					{
+						Ports: []expapi.NetworkPolicyPort{{Protocol: &udp}},
+					},
+					{
+						Ports: []expapi.NetworkPolicyPort{{Protocol: &udp}},
+						From: []expapi.NetworkPolicyPeer{
+							{PodSelector: &expapi.LabelSelector{MatchLabels: map[string]string{"name": "nobody"}}},
+						},
+					},
+				},
+			},
+		},
+	)
+
+	chain := podChainName(pod)
+	existingChains := map[utiliptables.Chain]string{
+		networkPolicyChain:        ":KUBE-NETWORK-POLICY - [10:200]",
+		chain:                     ":" + string(chain) + " - [5:100]",
+		"KUBE-NP-STALE":           ":KUBE-NP-STALE - [1:2]",
+		"DOCKER":                  ":DOCKER - [0:0]",
+		utiliptables.ChainForward: ":FORWARD ACCEPT [0:0]",
+	}
+	expected := strings.Join([]string{
+		"*filter",
+		":KUBE-NETWORK-POLICY - [10:200]",
+		":" + string(chain) + " - [5:100]",
+		":KUBE-NP-STALE - [1:2]",
+		`-A KUBE-NETWORK-POLICY -m comment --comment "default/dns" -d 10.0.1.2/32 -j ` + string(chain),
+		"-A " + string(chain) + ` -m comment --comment "default/dns" -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT`,
+		"-A " + string(chain) + ` -m comment --comment "default/a-allow-all" -j ACCEPT`,
+		"-A " + string(chain) + ` -m comment --comment "default/b-udp" -p udp -j ACCEPT`,
+		"-A " + string(chain) + ` -m comment --comment "default/dns" -j DROP`,
+		"-X KUBE-NP-STALE",
+		"COMMIT",
+		"",
+	}, "\n")
+	if got := string(e.buildRules(existingChains)); got != expected {
+		t.Errorf("expected rules:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestSyncRules(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	ipt.SaveData[utiliptables.TableFilter] = []byte("*filter\n:FORWARD ACCEPT [0:0]\n:KUBE-NP-STALE - [0:0]\nCOMMIT\n")
+	e := newTestEnforcer(ipt)
+	e.syncRules()
+
+	if !ipt.Chains[utiliptables.TableFilter][networkPolicyChain] {
+		t.Errorf("expected chain %s to be created", networkPolicyChain)
+	}
+	forward := ipt.Rules[utiliptables.TableFilter][utiliptables.ChainForward]
+	if expected := strings.Join(jumpArgs(), " "); len(forward) != 1 || forward[0] != expected {
+		t.Errorf("expected FORWARD rules [%s], got %v", expected, forward)
+	}
+	expected := "*filter\n:KUBE-NETWORK-POLICY - [0:0]\n:KUBE-NP-STALE - [0:0]\n-X KUBE-NP-STALE\nCOMMIT\n"
+	if got := string(ipt.Restored[utiliptables.TableFilter]); got != expected {
+		t.Errorf("expected restored rules:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCleanupLeftovers(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	ipt.EnsureRule(utiliptables.Prepend, utiliptables.TableFilter, utiliptables.ChainForward, jumpArgs()...)
+	ipt.SaveData[utiliptables.TableFilter] = []byte("*filter\n:FORWARD ACCEPT [0:0]\n:DOCKER - [0:0]\n:KUBE-NETWORK-POLICY - [0:0]\nCOMMIT\n")
+
+	if CleanupLeftovers(ipt) {
+		t.Errorf("unexpected error cleaning up")
+	}
+	if forward := ipt.Rules[utiliptables.TableFilter][utiliptables.ChainForward]; len(forward) != 0 {
+		t.Errorf("expected FORWARD rules to be removed, got %v", forward)
+	}
+	expected := "*filter\n:KUBE-NETWORK-POLICY - [0:0]\n-X KUBE-NETWORK-POLICY\nCOMMIT\n"
+	if got := string(ipt.Restored[utiliptables.TableFilter]); got != expected {
+		t.Errorf("expected restored rules:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// notifyingIPTables sends the data of each restore to a channel.
+type notifyingIPTables struct {
+	*utiliptables.FakeIPTables
+	restored chan string
+}
+
+func (n *notifyingIPTables) Restore(table utiliptables.Table, data []byte, flush utiliptables.FlushFlag, counters utiliptables.RestoreCountersFlag) error {
+	select {
+	case n.restored <- string(data):
+	default:
+	}
+	return n.FakeIPTables.Restore(table, data, flush, counters)
+}
+
+func TestRun(t *testing.T) {
+	policies := framework.NewFakeControllerSource()
+	pods := framework.NewFakeControllerSource()
+	namespaces := framework.NewFakeControllerSource()
+	ipt := &notifyingIPTables{utiliptables.NewFake(), make(chan string, 1)}
+	e := NewEnforcer(policies, pods, namespaces, testHostname, ipt, time.Minute)
+
+	pod := newPod("default", "db-0", testHostname, "10.0.1.2", nil)
+	pods.Add(pod)
+	namespaces.Add(newNamespace("default", nil))
+	policies.Add(&expapi.NetworkPolicy{ObjectMeta: api.ObjectMeta{Namespace: "default", Name: "deny-all"}})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go e.Run(stopCh)
+
+	drop := fmt.Sprintf(`-A %s -m comment --comment "default/db-0" -j DROP`, podChainName(pod))
+	timeout := time.After(30 * time.Second)
+	for {
+		// Events received before the caches synced do not sync the rules,
+		// so keep asking until the rules of the pod show up.
+		e.queueSync()
+		select {
+		case rules := <-ipt.restored:
+			if strings.Contains(rules, drop) {
+				return
+			}
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("timed out waiting for the rules of the isolated pod to be synced")
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/types"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+)
+
+// Server serves one health check port per service.  It is safe for
+// concurrent use.
+type Server struct {
+	// listen opens the listener of a health check port; tests replace it.
+	listen func(port int) (net.Listener, error)
+
+	mu             sync.Mutex // protects the following fields
+	services       map[types.NamespacedName]*hcInstance
+	localEndpoints map[types.NamespacedName]int
+}
+
+type hcInstance struct {
+	port     int
+	listener net.Listener
+}
+
+// NewServer returns a Server which listens on all addresses of the node.
+func NewServer() *Server {
+	return newServer(func(port int) (net.Listener, error) {
+		return net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	})
+}
+
+func newServer(listen func(port int) (net.Listener, error)) *Server {
+	return &Server{
+		listen:         listen,
+		services:       map[types.NamespacedName]*hcInstance{},
+		localEndpoints: map[types.NamespacedName]int{},
+	}
+}
+
+// SyncServices makes the server listen on exactly the given health check
+// ports, keyed by service.  Ports which can not be opened are retried on the
+// next call; the errors are aggregated in the returned error.
+func (s *Server) SyncServices(newServices map[types.NamespacedName]int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Close the ports of services that went away or changed port.
+	for name, hci := range s.services {
+		if port, found := newServices[name]; found && port == hci.port {
+			continue
+		}
+		glog.V(2).Infof("Closing health check port %d for service %q", hci.port, name)
+		if err := hci.listener.Close(); err != nil {
+			glog.Errorf("Failed to close health check port %d for service %q: %v", hci.port, name, err)
+		}
+		delete(s.services, name)
+	}
+
+	errs := []error{}
+	for name, port := range newServices {
+		if _, found := s.services[name]; found {
+			continue
+		}
+		glog.V(2).Infof("Opening health check port %d for service %q", port, name)
+		listener, err := s.listen(port)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open health check port %d for service %q: %v", port, name, err))
+			continue
+		}
+		s.services[name] = &hcInstance{port: port, listener: listener}
+		go func(name types.NamespacedName, listener net.Listener) {
+			// Serve returns once the listener is closed by a later sync.
+			err := http.Serve(listener, hcHandler{name: name, server: s})
+			glog.V(4).Infof("Stopped serving health checks for service %q: %v", name, err)
+		}(name, listener)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// SyncEndpoints replaces the number of endpoints on this node for each
+// service.  Services which are absent have no local endpoints.
+func (s *Server) SyncEndpoints(newEndpoints map[types.NamespacedName]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.localEndpoints = make(map[types.NamespacedName]int, len(newEndpoints))
+	for name, count := range newEndpoints {
+		s.localEndpoints[name] = count
+	}
+}
+
+func (s *Server) localEndpointsFor(name types.NamespacedName) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.localEndpoints[name]
+}
+
+type hcHandler struct {
+	name   types.NamespacedName
+	server *Server
+}
+
+type hcResponse struct {
+	Service        string `json:"service"`
+	LocalEndpoints int    `json:"localEndpoints"`
+}
+
+func (h hcHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	count := h.server.localEndpointsFor(h.name)
+	w.Header().Set("Content-Type", "application/json")
+	if count == 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(hcResponse{Service: h.name.String(), LocalEndpoints: count})
+}
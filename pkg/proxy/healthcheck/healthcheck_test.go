@@ -0,0 +1,105 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// fakeListener records the ports opened by a Server and hands out real
+// listeners on ephemeral localhost ports.
+type fakeListener struct {
+	opened map[int]net.Listener
+}
+
+func (f *fakeListener) listen(port int) (net.Listener, error) {
+	if port == 0 {
+		return nil, fmt.Errorf("invalid port")
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	f.opened[port] = l
+	return l, nil
+}
+
+func statusOf(t *testing.T, l net.Listener) int {
+	// Don't keep connections alive, so closed ports are noticed.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get("http://" + l.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServer(t *testing.T) {
+	fake := &fakeListener{opened: map[int]net.Listener{}}
+	server := newServer(fake.listen)
+
+	foo := types.NamespacedName{Namespace: "ns", Name: "foo"}
+	bar := types.NamespacedName{Namespace: "ns", Name: "bar"}
+	if err := server.SyncServices(map[types.NamespacedName]int{foo: 30001, bar: 30002}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.opened) != 2 {
+		t.Fatalf("expected 2 open ports, got %v", fake.opened)
+	}
+
+	server.SyncEndpoints(map[types.NamespacedName]int{foo: 2})
+	if status := statusOf(t, fake.opened[30001]); status != http.StatusOK {
+		t.Errorf("expected status %d for %s, got %d", http.StatusOK, foo, status)
+	}
+	if status := statusOf(t, fake.opened[30002]); status != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d for %s, got %d", http.StatusServiceUnavailable, bar, status)
+	}
+
+	server.SyncEndpoints(map[types.NamespacedName]int{bar: 1})
+	if status := statusOf(t, fake.opened[30001]); status != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d for %s, got %d", http.StatusServiceUnavailable, foo, status)
+	}
+	if status := statusOf(t, fake.opened[30002]); status != http.StatusOK {
+		t.Errorf("expected status %d for %s, got %d", http.StatusOK, bar, status)
+	}
+
+	// Dropping a service closes its port; the other keeps its listener.
+	kept := fake.opened[30002]
+	if err := server.SyncServices(map[types.NamespacedName]int{bar: 30002}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := net.Dial("tcp", fake.opened[30001].Addr().String()); err == nil {
+		t.Errorf("expected the port of %s to be closed", foo)
+	}
+	if len(server.services) != 1 || server.services[bar].listener != kept {
+		t.Errorf("expected only %s to be served by its original listener, got %v", bar, server.services)
+	}
+
+	// Ports that fail to open are reported.
+	if err := server.SyncServices(map[types.NamespacedName]int{bar: 30002, foo: 0}); err == nil {
+		t.Errorf("expected an error for an invalid port")
+	}
+	if len(server.services) != 1 {
+		t.Errorf("expected only %s to be served, got %v", bar, server.services)
+	}
+}
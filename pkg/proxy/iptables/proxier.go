@@ -39,6 +39,7 @@ import (
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/proxy"
+	"k8s.io/kubernetes/pkg/proxy/healthcheck"
 	"k8s.io/kubernetes/pkg/types"
 	utilexec "k8s.io/kubernetes/pkg/util/exec"
 	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
@@ -60,9 +61,24 @@ const iptablesServicesChain utiliptables.Chain = "KUBE-SERVICES"
 // the nodeports chain
 const iptablesNodePortsChain utiliptables.Chain = "KUBE-NODEPORTS"
 
+// the prefix of the chains that balance external traffic across the local
+// endpoints of a service
+const iptablesExternalLBChainPrefix = "KUBE-XLB-"
+
 // the mark we apply to traffic needing SNAT
 const iptablesMasqueradeMark = "0x4d415351"
 
+// the nat chain marking packets to be dropped; DROP is not allowed in the nat
+// table, so the packets are dropped by the filter chain below
+const iptablesMarkDropChain utiliptables.Chain = "KUBE-MARK-DROP"
+
+// the filter chain dropping marked packets
+const iptablesFirewallChain utiliptables.Chain = "KUBE-FIREWALL"
+
+// the mark we apply to traffic to be dropped; it does not overlap with
+// iptablesMasqueradeMark
+const iptablesDropMark = "0x8000/0x8000"
+
 // ShouldUseIptablesProxier returns true if we should use the iptables Proxier
 // instead of the "classic" userspace Proxier.  This is determined by checking
 // the iptables version and for the existence of kernel features. It may return
@@ -129,6 +145,12 @@ type serviceInfo struct {
 	sessionAffinityType api.ServiceAffinity
 	stickyMaxAgeSeconds int
 	endpoints           []string
+	// localEndpoints is the subset of endpoints running on this node.
+	localEndpoints []string
+	// onlyNodeLocalEndpoints is set when external traffic must stay on the
+	// node which received it, preserving the client source IP.
+	onlyNodeLocalEndpoints bool
+	healthCheckNodePort    int
 	// Deprecated, but required for back-compat (including e2e)
 	externalIPs []string
 }
@@ -136,8 +158,8 @@ type serviceInfo struct {
 // returns a new serviceInfo struct
 func newServiceInfo(service proxy.ServicePortName) *serviceInfo {
 	return &serviceInfo{
-		sessionAffinityType: api.ServiceAffinityNone, // default
-		stickyMaxAgeSeconds: 180,                     // TODO: paramaterize this in the API.
+		sessionAffinityType: api.ServiceAffinityNone,                  // default
+		stickyMaxAgeSeconds: api.DefaultSessionAffinityTimeoutSeconds, // default
 	}
 }
 
@@ -154,6 +176,15 @@ type Proxier struct {
 	syncPeriod    time.Duration
 	iptables      utiliptables.Interface
 	masqueradeAll bool
+	hostname      string
+	healthChecker healthChecker
+}
+
+// healthChecker serves the health check node ports of services whose
+// external traffic only goes to local endpoints.
+type healthChecker interface {
+	SyncServices(newServices map[types.NamespacedName]int) error
+	SyncEndpoints(newEndpoints map[types.NamespacedName]int)
 }
 
 type localPort struct {
@@ -179,7 +210,8 @@ var _ proxy.ProxyProvider = &Proxier{}
 // An error will be returned if iptables fails to update or acquire the initial lock.
 // Once a proxier is created, it will keep iptables up to date in the background and
 // will not terminate if a particular iptables call fails.
-func NewProxier(ipt utiliptables.Interface, exec utilexec.Interface, syncPeriod time.Duration, masqueradeAll bool) (*Proxier, error) {
+// The hostname identifies the endpoints which run on this node.
+func NewProxier(ipt utiliptables.Interface, exec utilexec.Interface, syncPeriod time.Duration, masqueradeAll bool, hostname string) (*Proxier, error) {
 	// Set the route_localnet sysctl we need for
 	if err := setSysctl(sysctlRouteLocalnet, 1); err != nil {
 		return nil, fmt.Errorf("can't set sysctl %s: %v", sysctlRouteLocalnet, err)
@@ -199,6 +231,8 @@ func NewProxier(ipt utiliptables.Interface, exec utilexec.Interface, syncPeriod
 		syncPeriod:    syncPeriod,
 		iptables:      ipt,
 		masqueradeAll: masqueradeAll,
+		hostname:      hostname,
+		healthChecker: healthcheck.NewServer(),
 	}, nil
 }
 
@@ -215,6 +249,12 @@ func CleanupLeftovers(ipt utiliptables.Interface) (encounteredError bool) {
 		glog.Errorf("Error removing pure-iptables proxy rule: %v", err)
 		encounteredError = true
 	}
+	for _, chain := range []utiliptables.Chain{utiliptables.ChainInput, utiliptables.ChainForward, utiliptables.ChainOutput} {
+		if err := ipt.DeleteRule(utiliptables.TableFilter, chain, "-j", string(iptablesFirewallChain)); err != nil {
+			glog.Errorf("Error removing pure-iptables proxy rule: %v", err)
+			encounteredError = true
+		}
+	}
 	return encounteredError
 }
 
@@ -234,6 +274,12 @@ func (proxier *Proxier) sameConfig(info *serviceInfo, service *api.Service, port
 	if info.sessionAffinityType != service.Spec.SessionAffinity {
 		return false
 	}
+	if timeout := service.Spec.SessionAffinityTimeoutSeconds; timeout != 0 && info.stickyMaxAgeSeconds != timeout {
+		return false
+	}
+	if info.onlyNodeLocalEndpoints != api.ExternalTrafficIsLocal(service) || info.healthCheckNodePort != service.Spec.HealthCheckNodePort {
+		return false
+	}
 	return true
 }
 
@@ -280,6 +326,12 @@ func (proxier *Proxier) OnServiceUpdate(allServices []api.Service) {
 			Name:      service.Name,
 		}
 
+		// services of type ExternalName are only resolved by DNS
+		if service.Spec.Type == api.ServiceTypeExternalName {
+			glog.V(3).Infof("Skipping service %s due to type = %q", svcName, service.Spec.Type)
+			continue
+		}
+
 		// if ClusterIP is "None" or empty, skip proxying
 		if !api.IsServiceIPSet(service) {
 			glog.V(3).Infof("Skipping service %s due to clusterIP = %q", svcName, service.Spec.ClusterIP)
@@ -306,6 +358,10 @@ func (proxier *Proxier) OnServiceUpdate(allServices []api.Service) {
 			}
 
 			serviceIP := net.ParseIP(service.Spec.ClusterIP)
+			if !proxier.isSameFamily(serviceIP) {
+				glog.V(3).Infof("Skipping service %s due to clusterIP %q not matching the proxier's IP family", svcName, service.Spec.ClusterIP)
+				continue
+			}
 			glog.V(1).Infof("Adding new service %q at %s:%d/%s", serviceName, serviceIP, servicePort.Port, servicePort.Protocol)
 			info = newServiceInfo(serviceName)
 			info.clusterIP = serviceIP
@@ -316,6 +372,11 @@ func (proxier *Proxier) OnServiceUpdate(allServices []api.Service) {
 			// Deep-copy in case the service instance changes
 			info.loadBalancerStatus = *api.LoadBalancerStatusDeepCopy(&service.Status.LoadBalancer)
 			info.sessionAffinityType = service.Spec.SessionAffinity
+			if service.Spec.SessionAffinityTimeoutSeconds != 0 {
+				info.stickyMaxAgeSeconds = service.Spec.SessionAffinityTimeoutSeconds
+			}
+			info.onlyNodeLocalEndpoints = api.ExternalTrafficIsLocal(service)
+			info.healthCheckNodePort = service.Spec.HealthCheckNodePort
 			proxier.serviceMap[serviceName] = info
 
 			glog.V(4).Infof("added serviceInfo(%s): %s", serviceName, spew.Sdump(info))
@@ -349,13 +410,20 @@ func (proxier *Proxier) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 		// We need to build a map of portname -> all ip:ports for that
 		// portname.  Explode Endpoints.Subsets[*] into this structure.
 		portsToEndpoints := map[string][]hostPortPair{}
+		portsToLocalEndpoints := map[string][]hostPortPair{}
 		for i := range svcEndpoints.Subsets {
 			ss := &svcEndpoints.Subsets[i]
 			for i := range ss.Ports {
 				port := &ss.Ports[i]
 				for i := range ss.Addresses {
 					addr := &ss.Addresses[i]
+					if !proxier.isSameFamily(net.ParseIP(addr.IP)) {
+						continue
+					}
 					portsToEndpoints[port.Name] = append(portsToEndpoints[port.Name], hostPortPair{addr.IP, port.Port})
+					if addr.NodeName != "" && addr.NodeName == proxier.hostname {
+						portsToLocalEndpoints[port.Name] = append(portsToLocalEndpoints[port.Name], hostPortPair{addr.IP, port.Port})
+					}
 				}
 			}
 		}
@@ -377,6 +445,7 @@ func (proxier *Proxier) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 				glog.V(1).Infof("Setting endpoints for %s to %+v", svcPort, newEndpoints)
 				state.endpoints = newEndpoints
 			}
+			state.localEndpoints = flattenValidEndpoints(portsToLocalEndpoints[portname])
 			registeredEndpoints[svcPort] = true
 		}
 	}
@@ -389,6 +458,7 @@ func (proxier *Proxier) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 			// only remove ServicePorts that have no endpoints and were not in the service update,
 			// that way we only remove ServicePorts that were not in both.
 			proxier.serviceMap[service].endpoints = nil
+			proxier.serviceMap[service].localEndpoints = nil
 		}
 	}
 
@@ -441,6 +511,14 @@ func servicePortChainName(s proxy.ServicePortName, protocol string) utiliptables
 	return utiliptables.Chain("KUBE-SVC-" + encoded[:16])
 }
 
+// This is the same as servicePortChainName but with the prefix "KUBE-XLB-",
+// for the chain which balances external traffic across local endpoints only.
+func serviceLBChainName(s proxy.ServicePortName, protocol string) utiliptables.Chain {
+	hash := sha256.Sum256([]byte(s.String() + protocol))
+	encoded := base32.StdEncoding.EncodeToString(hash[:])
+	return utiliptables.Chain(iptablesExternalLBChainPrefix + encoded[:16])
+}
+
 // This is the same as servicePortChainName but with the endpoint included.
 func servicePortEndpointChainName(s proxy.ServicePortName, protocol string, endpoint string) utiliptables.Chain {
 	hash := sha256.Sum256([]byte(s.String() + protocol + endpoint))
@@ -448,6 +526,32 @@ func servicePortEndpointChainName(s proxy.ServicePortName, protocol string, endp
 	return utiliptables.Chain("KUBE-SEP-" + encoded[:16])
 }
 
+// ensureDropChains installs the nat chain that marks packets to be dropped
+// and the filter chain, hooked into INPUT, FORWARD and OUTPUT, that drops them.
+func (proxier *Proxier) ensureDropChains() error {
+	if _, err := proxier.iptables.EnsureChain(utiliptables.TableNAT, iptablesMarkDropChain); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s exists: %v", iptablesMarkDropChain, err)
+	}
+	if _, err := proxier.iptables.EnsureRule(utiliptables.Append, utiliptables.TableNAT, iptablesMarkDropChain,
+		"-j", "MARK", "--set-xmark", iptablesDropMark); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s marks packets: %v", iptablesMarkDropChain, err)
+	}
+	if _, err := proxier.iptables.EnsureChain(utiliptables.TableFilter, iptablesFirewallChain); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s exists: %v", iptablesFirewallChain, err)
+	}
+	if _, err := proxier.iptables.EnsureRule(utiliptables.Append, utiliptables.TableFilter, iptablesFirewallChain,
+		"-m", "comment", "--comment", "kubernetes firewall for dropping marked packets",
+		"-m", "mark", "--mark", iptablesDropMark, "-j", "DROP"); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s drops marked packets: %v", iptablesFirewallChain, err)
+	}
+	for _, chain := range []utiliptables.Chain{utiliptables.ChainInput, utiliptables.ChainForward, utiliptables.ChainOutput} {
+		if _, err := proxier.iptables.EnsureRule(utiliptables.Prepend, utiliptables.TableFilter, chain, "-j", string(iptablesFirewallChain)); err != nil {
+			return fmt.Errorf("failed to ensure that chain %s jumps to %s: %v", chain, iptablesFirewallChain, err)
+		}
+	}
+	return nil
+}
+
 // This is where all of the iptables-save/restore calls happen.
 // The only other iptables rules are those that are setup in iptablesInit()
 // assumes proxier.mu is held
@@ -483,6 +587,11 @@ func (proxier *Proxier) syncProxyRules() {
 			return
 		}
 	}
+	// Link the drop rules.
+	if err := proxier.ensureDropChains(); err != nil {
+		glog.Errorf("Failed to ensure drop chains: %v", err)
+		return
+	}
 
 	// Get iptables-save output so we can check for existing chains and rules.
 	// This will be a map of chain name to chain with rules as stored in iptables-save/iptables-restore
@@ -491,7 +600,7 @@ func (proxier *Proxier) syncProxyRules() {
 	if err != nil { // if we failed to get any rules
 		glog.Errorf("Failed to execute iptable-save, syncing all rules. %s", err.Error())
 	} else { // otherwise parse the output
-		existingChains = getChainLines(utiliptables.TableNAT, iptablesSaveRaw)
+		existingChains = utiliptables.GetChainLines(utiliptables.TableNAT, iptablesSaveRaw)
 	}
 
 	chainsLines := bytes.NewBuffer(nil)
@@ -505,12 +614,12 @@ func (proxier *Proxier) syncProxyRules() {
 	if chain, ok := existingChains[iptablesServicesChain]; ok {
 		writeLine(chainsLines, chain)
 	} else {
-		writeLine(chainsLines, makeChainLine(iptablesServicesChain))
+		writeLine(chainsLines, utiliptables.MakeChainLine(iptablesServicesChain))
 	}
 	if chain, ok := existingChains[iptablesNodePortsChain]; ok {
 		writeLine(chainsLines, chain)
 	} else {
-		writeLine(chainsLines, makeChainLine(iptablesNodePortsChain))
+		writeLine(chainsLines, utiliptables.MakeChainLine(iptablesNodePortsChain))
 	}
 
 	// Accumulate chains to keep.
@@ -528,16 +637,31 @@ func (proxier *Proxier) syncProxyRules() {
 		if chain, ok := existingChains[svcChain]; ok {
 			writeLine(chainsLines, chain)
 		} else {
-			writeLine(chainsLines, makeChainLine(svcChain))
+			writeLine(chainsLines, utiliptables.MakeChainLine(svcChain))
 		}
 		activeChains[svcChain] = true
 
+		// Traffic from outside the cluster goes to the service chain, or, if
+		// the service keeps it on this node, to a chain balancing across the
+		// local endpoints only.  The latter is not SNATed, so the endpoints
+		// see the client source IP.
+		externalChain := svcChain
+		if svcInfo.onlyNodeLocalEndpoints {
+			externalChain = serviceLBChainName(svcName, protocol)
+			if chain, ok := existingChains[externalChain]; ok {
+				writeLine(chainsLines, chain)
+			} else {
+				writeLine(chainsLines, utiliptables.MakeChainLine(externalChain))
+			}
+			activeChains[externalChain] = true
+		}
+
 		// Capture the clusterIP.
 		args := []string{
 			"-A", string(iptablesServicesChain),
 			"-m", "comment", "--comment", fmt.Sprintf("\"%s cluster IP\"", svcName.String()),
 			"-m", protocol, "-p", protocol,
-			"-d", fmt.Sprintf("%s/32", svcInfo.clusterIP.String()),
+			"-d", hostCIDR(svcInfo.clusterIP),
 			"--dport", fmt.Sprintf("%d", svcInfo.port),
 		}
 		if proxier.masqueradeAll {
@@ -549,6 +673,9 @@ func (proxier *Proxier) syncProxyRules() {
 
 		// Capture externalIPs.
 		for _, externalIP := range svcInfo.externalIPs {
+			if !proxier.isSameFamily(net.ParseIP(externalIP)) {
+				continue
+			}
 			// If the "external" IP happens to be an IP that is local to this
 			// machine, hold the local port open so no other process can open it
 			// (because the socket might open but it would never work).
@@ -576,12 +703,14 @@ func (proxier *Proxier) syncProxyRules() {
 				"-A", string(iptablesServicesChain),
 				"-m", "comment", "--comment", fmt.Sprintf("\"%s external IP\"", svcName.String()),
 				"-m", protocol, "-p", protocol,
-				"-d", fmt.Sprintf("%s/32", externalIP),
+				"-d", hostCIDR(net.ParseIP(externalIP)),
 				"--dport", fmt.Sprintf("%d", svcInfo.port),
 			}
-			// We have to SNAT packets to external IPs.
-			writeLine(rulesLines, append(args,
-				"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+			// We have to SNAT packets to external IPs, unless they stay on this node.
+			if !svcInfo.onlyNodeLocalEndpoints {
+				writeLine(rulesLines, append(args,
+					"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+			}
 
 			// Allow traffic for external IPs that does not come from a bridge (i.e. not from a container)
 			// nor from a local process to be forwarded to the service.
@@ -591,29 +720,31 @@ func (proxier *Proxier) syncProxyRules() {
 				"-m", "physdev", "!", "--physdev-is-in",
 				"-m", "addrtype", "!", "--src-type", "LOCAL")
 			writeLine(rulesLines, append(externalTrafficOnlyArgs,
-				"-j", string(svcChain))...)
+				"-j", string(externalChain))...)
 			dstLocalOnlyArgs := append(args, "-m", "addrtype", "--dst-type", "LOCAL")
 			// Allow traffic bound for external IPs that happen to be recognized as local IPs to stay local.
 			// This covers cases like GCE load-balancers which get added to the local routing table.
 			writeLine(rulesLines, append(dstLocalOnlyArgs,
-				"-j", string(svcChain))...)
+				"-j", string(externalChain))...)
 		}
 
 		// Capture load-balancer ingress.
 		for _, ingress := range svcInfo.loadBalancerStatus.Ingress {
-			if ingress.IP != "" {
+			if ingress.IP != "" && proxier.isSameFamily(net.ParseIP(ingress.IP)) {
 				args := []string{
 					"-A", string(iptablesServicesChain),
 					"-m", "comment", "--comment", fmt.Sprintf("\"%s loadbalancer IP\"", svcName.String()),
 					"-m", protocol, "-p", protocol,
-					"-d", fmt.Sprintf("%s/32", ingress.IP),
+					"-d", hostCIDR(net.ParseIP(ingress.IP)),
 					"--dport", fmt.Sprintf("%d", svcInfo.port),
 				}
-				// We have to SNAT packets from external IPs.
-				writeLine(rulesLines, append(args,
-					"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+				// We have to SNAT packets from external IPs, unless they stay on this node.
+				if !svcInfo.onlyNodeLocalEndpoints {
+					writeLine(rulesLines, append(args,
+						"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+				}
 				writeLine(rulesLines, append(args,
-					"-j", string(svcChain))...)
+					"-j", string(externalChain))...)
 			}
 		}
 
@@ -639,20 +770,26 @@ func (proxier *Proxier) syncProxyRules() {
 				}
 				newLocalPorts[lp] = socket
 			} // We're holding the port, so it's OK to install iptables rules.
-			// Nodeports need SNAT.
-			writeLine(rulesLines,
+			args := []string{
 				"-A", string(iptablesNodePortsChain),
 				"-m", "comment", "--comment", svcName.String(),
 				"-m", protocol, "-p", protocol,
 				"--dport", fmt.Sprintf("%d", svcInfo.nodePort),
-				"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))
+			}
+			if !svcInfo.onlyNodeLocalEndpoints {
+				// Nodeports need SNAT.
+				writeLine(rulesLines, append(args,
+					"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+			} else {
+				// Nodeports reached from this node's loopback still need SNAT,
+				// or the endpoint could not answer.
+				writeLine(rulesLines, append(args,
+					"-s", proxier.loopbackCIDR(),
+					"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
+			}
 			// Jump to the service chain.
-			writeLine(rulesLines,
-				"-A", string(iptablesNodePortsChain),
-				"-m", "comment", "--comment", svcName.String(),
-				"-m", protocol, "-p", protocol,
-				"--dport", fmt.Sprintf("%d", svcInfo.nodePort),
-				"-j", string(svcChain))
+			writeLine(rulesLines, append(args,
+				"-j", string(externalChain))...)
 		}
 
 		// Generate the per-endpoint chains.  We do this in multiple passes so we
@@ -668,7 +805,7 @@ func (proxier *Proxier) syncProxyRules() {
 			if chain, ok := existingChains[utiliptables.Chain(endpointChain)]; ok {
 				writeLine(chainsLines, chain)
 			} else {
-				writeLine(chainsLines, makeChainLine(endpointChain))
+				writeLine(chainsLines, utiliptables.MakeChainLine(endpointChain))
 			}
 			activeChains[endpointChain] = true
 		}
@@ -715,7 +852,7 @@ func (proxier *Proxier) syncProxyRules() {
 			// all endpoints.
 			// TODO: if we grow logic to get this node's pod CIDR, we can use it.
 			writeLine(rulesLines, append(args,
-				"-s", fmt.Sprintf("%s/32", strings.Split(endpoints[i], ":")[0]),
+				"-s", hostCIDR(net.ParseIP(endpointIP(endpoints[i]))),
 				"-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...)
 
 			// Update client-affinity lists.
@@ -728,13 +865,52 @@ func (proxier *Proxier) syncProxyRules() {
 				"-j", "DNAT", "--to-destination", endpoints[i])
 			writeLine(rulesLines, args...)
 		}
+
+		// Write the rules balancing external traffic across local endpoints.
+		if svcInfo.onlyNodeLocalEndpoints {
+			localEndpointChains := make([]utiliptables.Chain, 0)
+			for _, ep := range svcInfo.localEndpoints {
+				localEndpointChains = append(localEndpointChains, servicePortEndpointChainName(svcName, protocol, ep))
+			}
+			n := len(localEndpointChains)
+			if n == 0 {
+				writeLine(rulesLines,
+					"-A", string(externalChain),
+					"-m", "comment", "--comment", fmt.Sprintf("\"%s has no local endpoints\"", svcName.String()),
+					"-j", string(iptablesMarkDropChain))
+			}
+			if svcInfo.sessionAffinityType == api.ServiceAffinityClientIP {
+				for _, endpointChain := range localEndpointChains {
+					writeLine(rulesLines,
+						"-A", string(externalChain),
+						"-m", "comment", "--comment", svcName.String(),
+						"-m", "recent", "--name", string(endpointChain),
+						"--rcheck", "--seconds", fmt.Sprintf("%d", svcInfo.stickyMaxAgeSeconds), "--reap",
+						"-j", string(endpointChain))
+				}
+			}
+			for i, endpointChain := range localEndpointChains {
+				args := []string{
+					"-A", string(externalChain),
+					"-m", "comment", "--comment", svcName.String(),
+				}
+				if i < (n - 1) {
+					args = append(args,
+						"-m", "statistic",
+						"--mode", "random",
+						"--probability", fmt.Sprintf("%0.5f", 1.0/float64(n-i)))
+				}
+				args = append(args, "-j", string(endpointChain))
+				writeLine(rulesLines, args...)
+			}
+		}
 	}
 
 	// Delete chains no longer in use.
 	for chain := range existingChains {
 		if !activeChains[chain] {
 			chainString := string(chain)
-			if !strings.HasPrefix(chainString, "KUBE-SVC-") && !strings.HasPrefix(chainString, "KUBE-SEP-") {
+			if !strings.HasPrefix(chainString, "KUBE-SVC-") && !strings.HasPrefix(chainString, "KUBE-SEP-") && !strings.HasPrefix(chainString, iptablesExternalLBChainPrefix) {
 				// Ignore chains that aren't ours.
 				continue
 			}
@@ -778,46 +954,78 @@ func (proxier *Proxier) syncProxyRules() {
 		}
 		proxier.portsMap = newLocalPorts
 	}
-}
 
-// Join all words with spaces, terminate with newline and write to buf.
-func writeLine(buf *bytes.Buffer, words ...string) {
-	buf.WriteString(strings.Join(words, " ") + "\n")
+	proxier.syncHealthChecks()
 }
 
-// return an iptables-save/restore formatted chain line given a Chain
-func makeChainLine(chain utiliptables.Chain) string {
-	return fmt.Sprintf(":%s - [0:0]", chain)
-}
-
-// getChainLines parses a table's iptables-save data to find chains in the table.
-// It returns a map of iptables.Chain to string where the string is the chain line from the save (with counters etc).
-func getChainLines(table utiliptables.Table, save []byte) map[utiliptables.Chain]string {
-	// get lines
-	lines := strings.Split(string(save), "\n")
-	chainsMap := make(map[utiliptables.Chain]string)
-	tablePrefix := "*" + string(table)
-	lineNum := 0
-	// find beginning of table
-	for ; lineNum < len(lines); lineNum++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[lineNum]), tablePrefix) {
-			lineNum++
-			break
-		}
-	}
-	// parse table lines
-	for ; lineNum < len(lines); lineNum++ {
-		line := strings.TrimSpace(lines[lineNum])
-		if strings.HasPrefix(line, "COMMIT") || strings.HasPrefix(line, "*") {
-			break
-		} else if len(line) == 0 || strings.HasPrefix(line, "#") {
+// syncHealthChecks updates the health check node ports of services which
+// keep external traffic on this node, and the number of their local
+// endpoints.
+// assumes proxier.mu is held
+func (proxier *Proxier) syncHealthChecks() {
+	hcServices := map[types.NamespacedName]int{}
+	localIPs := map[types.NamespacedName]map[string]bool{}
+	for svcName, svcInfo := range proxier.serviceMap {
+		if !svcInfo.onlyNodeLocalEndpoints || svcInfo.healthCheckNodePort == 0 {
 			continue
-		} else if strings.HasPrefix(line, ":") && len(line) > 1 {
-			chain := utiliptables.Chain(strings.SplitN(line[1:], " ", 2)[0])
-			chainsMap[chain] = lines[lineNum]
 		}
+		hcServices[svcName.NamespacedName] = svcInfo.healthCheckNodePort
+		if localIPs[svcName.NamespacedName] == nil {
+			localIPs[svcName.NamespacedName] = map[string]bool{}
+		}
+		for _, ep := range svcInfo.localEndpoints {
+			localIPs[svcName.NamespacedName][endpointIP(ep)] = true
+		}
+	}
+	hcEndpoints := map[types.NamespacedName]int{}
+	for name, ips := range localIPs {
+		hcEndpoints[name] = len(ips)
+	}
+	proxier.healthChecker.SyncEndpoints(hcEndpoints)
+	if err := proxier.healthChecker.SyncServices(hcServices); err != nil {
+		glog.Errorf("Failed to sync health check node ports: %v", err)
+	}
+}
+
+// isSameFamily returns true if ip is of the IP family the proxier manages,
+// IPv6 for ip6tables and IPv4 otherwise.
+func (proxier *Proxier) isSameFamily(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	return (ip.To4() == nil) == proxier.iptables.IsIpv6()
+}
+
+// loopbackCIDR returns the loopback range of the proxier's IP family.
+func (proxier *Proxier) loopbackCIDR() string {
+	if proxier.iptables.IsIpv6() {
+		return "::1/128"
+	}
+	return "127.0.0.0/8"
+}
+
+// hostCIDR returns the single host CIDR of ip, e.g. 10.0.0.1/32 or
+// fd00::1/128.
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s/32", ip.String())
 	}
-	return chainsMap
+	return fmt.Sprintf("%s/128", ip.String())
+}
+
+// endpointIP returns the IP of an "ip:port" endpoint, where IPv6 addresses
+// are bracketed.
+func endpointIP(endpoint string) string {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return host
+}
+
+// Join all words with spaces, terminate with newline and write to buf.
+func writeLine(buf *bytes.Buffer, words ...string) {
+	buf.WriteString(strings.Join(words, " ") + "\n")
 }
 
 func isLocalIP(ip string) (bool, error) {
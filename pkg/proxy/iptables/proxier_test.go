@@ -17,12 +17,19 @@ limitations under the License.
 package iptables
 
 import (
-	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/proxy"
+	"k8s.io/kubernetes/pkg/types"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
 )
 
 func checkAllLines(t *testing.T, table utiliptables.Table, save []byte, expectedLines map[utiliptables.Chain]string) {
-	chainLines := getChainLines(table, save)
+	chainLines := utiliptables.GetChainLines(table, save)
 	for chain, line := range chainLines {
 		if expected, exists := expectedLines[chain]; exists {
 			if expected != line {
@@ -122,3 +129,244 @@ func TestgetChainLinesMultipleTables(t *testing.T) {
 	}
 	checkAllLines(t, utiliptables.TableNAT, []byte(iptables_save), expected)
 }
+
+type fakeHealthChecker struct {
+	services  map[types.NamespacedName]int
+	endpoints map[types.NamespacedName]int
+}
+
+func (f *fakeHealthChecker) SyncServices(newServices map[types.NamespacedName]int) error {
+	f.services = newServices
+	return nil
+}
+
+func (f *fakeHealthChecker) SyncEndpoints(newEndpoints map[types.NamespacedName]int) {
+	f.endpoints = newEndpoints
+}
+
+func newFakeProxier(ipt utiliptables.Interface, hc healthChecker) *Proxier {
+	return &Proxier{
+		serviceMap:    make(map[proxy.ServicePortName]*serviceInfo),
+		portsMap:      make(map[localPort]closeable),
+		iptables:      ipt,
+		hostname:      "node1",
+		healthChecker: hc,
+	}
+}
+
+func hasLine(lines []string, words ...string) bool {
+	want := strings.Join(words, " ")
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOnlyLocalLoadBalancing(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	hc := &fakeHealthChecker{}
+	proxier := newFakeProxier(ipt, hc)
+
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc1"}
+	svcPort := proxy.ServicePortName{NamespacedName: svcName, Port: "p80"}
+	proxier.OnServiceUpdate([]api.Service{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Spec: api.ServiceSpec{
+			Type:                  api.ServiceTypeLoadBalancer,
+			ClusterIP:             "10.20.30.41",
+			Ports:                 []api.ServicePort{{Name: "p80", Port: 80, Protocol: api.ProtocolTCP}},
+			ExternalTrafficPolicy: api.ServiceExternalTrafficPolicyTypeLocal,
+			HealthCheckNodePort:   30000,
+		},
+		Status: api.ServiceStatus{
+			LoadBalancer: api.LoadBalancerStatus{Ingress: []api.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+		},
+	}})
+	proxier.OnEndpointsUpdate([]api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "10.180.0.1", NodeName: "node1"}, {IP: "10.180.2.1", NodeName: "node2"}},
+			Ports:     []api.EndpointPort{{Name: "p80", Port: 80}},
+		}},
+	}})
+
+	lines := strings.Split(string(ipt.Restored[utiliptables.TableNAT]), "\n")
+	svcChain := string(servicePortChainName(svcPort, "tcp"))
+	lbChain := string(serviceLBChainName(svcPort, "tcp"))
+	localEpChain := string(servicePortEndpointChainName(svcPort, "tcp", "10.180.0.1:80"))
+	remoteEpChain := string(servicePortEndpointChainName(svcPort, "tcp", "10.180.2.1:80"))
+
+	lbArgs := []string{"-A", string(iptablesServicesChain),
+		"-m", "comment", "--comment", fmt.Sprintf("\"%s loadbalancer IP\"", svcPort.String()),
+		"-m", "tcp", "-p", "tcp", "-d", "1.2.3.4/32", "--dport", "80"}
+	if !hasLine(lines, append(lbArgs, "-j", lbChain)...) {
+		t.Errorf("expected load balancer traffic to jump to %s, got:\n%s", lbChain, strings.Join(lines, "\n"))
+	}
+	if hasLine(lines, append(lbArgs, "-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark))...) {
+		t.Errorf("expected load balancer traffic not to be SNATed")
+	}
+	if !hasLine(lines, "-A", lbChain, "-m", "comment", "--comment", svcPort.String(), "-j", localEpChain) {
+		t.Errorf("expected %s to balance to the local endpoint chain %s", lbChain, localEpChain)
+	}
+	if hasLine(lines, "-A", lbChain, "-m", "comment", "--comment", svcPort.String(), "-j", remoteEpChain) {
+		t.Errorf("expected %s not to balance to the remote endpoint chain %s", lbChain, remoteEpChain)
+	}
+	// In-cluster traffic still reaches all endpoints.
+	if !hasLine(lines, "-A", svcChain, "-m", "comment", "--comment", svcPort.String(), "-j", remoteEpChain) {
+		t.Errorf("expected %s to balance to the remote endpoint chain %s", svcChain, remoteEpChain)
+	}
+
+	if hc.services[svcName] != 30000 {
+		t.Errorf("expected health check node port 30000 for %s, got %v", svcName, hc.services)
+	}
+	if hc.endpoints[svcName] != 1 {
+		t.Errorf("expected 1 local endpoint for %s, got %v", svcName, hc.endpoints)
+	}
+
+	// Without local endpoints external traffic is dropped.
+	proxier.OnEndpointsUpdate([]api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "10.180.2.1", NodeName: "node2"}},
+			Ports:     []api.EndpointPort{{Name: "p80", Port: 80}},
+		}},
+	}})
+	lines = strings.Split(string(ipt.Restored[utiliptables.TableNAT]), "\n")
+	// DROP is not allowed in the nat table: the packets are marked there and
+	// dropped in the filter table.
+	if !hasLine(lines, "-A", lbChain, "-m", "comment", "--comment", fmt.Sprintf("\"%s has no local endpoints\"", svcPort.String()), "-j", string(iptablesMarkDropChain)) {
+		t.Errorf("expected %s to mark traffic for dropping, got:\n%s", lbChain, strings.Join(lines, "\n"))
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "-j DROP") {
+			t.Errorf("expected no DROP rules in the nat table, got %q", line)
+		}
+	}
+	if !ipt.Chains[utiliptables.TableNAT][iptablesMarkDropChain] {
+		t.Errorf("expected chain %s in the nat table", iptablesMarkDropChain)
+	}
+	if rules := ipt.Rules[utiliptables.TableNAT][iptablesMarkDropChain]; !reflect.DeepEqual(rules, []string{"-j MARK --set-xmark " + iptablesDropMark}) {
+		t.Errorf("expected %s to mark packets, got %v", iptablesMarkDropChain, rules)
+	}
+	if rules := ipt.Rules[utiliptables.TableFilter][iptablesFirewallChain]; len(rules) != 1 || !strings.HasSuffix(rules[0], "-m mark --mark "+iptablesDropMark+" -j DROP") {
+		t.Errorf("expected %s to drop marked packets, got %v", iptablesFirewallChain, rules)
+	}
+	for _, chain := range []utiliptables.Chain{utiliptables.ChainInput, utiliptables.ChainForward, utiliptables.ChainOutput} {
+		if rules := ipt.Rules[utiliptables.TableFilter][chain]; len(rules) == 0 || rules[0] != "-j "+string(iptablesFirewallChain) {
+			t.Errorf("expected %s to jump to %s first, got %v", chain, iptablesFirewallChain, rules)
+		}
+	}
+	if hc.endpoints[svcName] != 0 {
+		t.Errorf("expected no local endpoints for %s, got %v", svcName, hc.endpoints)
+	}
+}
+
+func TestIPv6Rules(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	ipt.Ipv6 = true
+	hc := &fakeHealthChecker{}
+	proxier := newFakeProxier(ipt, hc)
+
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc1"}
+	svcPort := proxy.ServicePortName{NamespacedName: svcName, Port: "p80"}
+	v4SvcName := types.NamespacedName{Namespace: "ns1", Name: "svc2"}
+	v4SvcPort := proxy.ServicePortName{NamespacedName: v4SvcName, Port: "p80"}
+	proxier.OnServiceUpdate([]api.Service{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Spec: api.ServiceSpec{
+			Type:        api.ServiceTypeLoadBalancer,
+			ClusterIP:   "fd00:10::41",
+			ExternalIPs: []string{"1.2.3.5", "2001:db8::5"},
+			Ports:       []api.ServicePort{{Name: "p80", Port: 80, Protocol: api.ProtocolTCP}},
+		},
+		Status: api.ServiceStatus{
+			LoadBalancer: api.LoadBalancerStatus{Ingress: []api.LoadBalancerIngress{{IP: "1.2.3.4"}, {IP: "2001:db8::4"}}},
+		},
+	}, {
+		ObjectMeta: api.ObjectMeta{Name: v4SvcName.Name, Namespace: v4SvcName.Namespace},
+		Spec: api.ServiceSpec{
+			ClusterIP: "10.20.30.42",
+			Ports:     []api.ServicePort{{Name: "p80", Port: 80, Protocol: api.ProtocolTCP}},
+		},
+	}})
+	proxier.OnEndpointsUpdate([]api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "fd00:180::1"}, {IP: "10.180.0.1"}},
+			Ports:     []api.EndpointPort{{Name: "p80", Port: 80}},
+		}},
+	}})
+
+	restored := string(ipt.Restored[utiliptables.TableNAT])
+	lines := strings.Split(restored, "\n")
+	svcChain := string(servicePortChainName(svcPort, "tcp"))
+	epChain := string(servicePortEndpointChainName(svcPort, "tcp", "[fd00:180::1]:80"))
+
+	if !hasLine(lines, "-A", string(iptablesServicesChain),
+		"-m", "comment", "--comment", fmt.Sprintf("\"%s cluster IP\"", svcPort.String()),
+		"-m", "tcp", "-p", "tcp", "-d", "fd00:10::41/128", "--dport", "80", "-j", svcChain) {
+		t.Errorf("expected cluster IP rule for %s, got:\n%s", svcPort, restored)
+	}
+	if !hasLine(lines, "-A", string(iptablesServicesChain),
+		"-m", "comment", "--comment", fmt.Sprintf("\"%s loadbalancer IP\"", svcPort.String()),
+		"-m", "tcp", "-p", "tcp", "-d", "2001:db8::4/128", "--dport", "80", "-j", svcChain) {
+		t.Errorf("expected load balancer rule for %s, got:\n%s", svcPort, restored)
+	}
+	if !hasLine(lines, "-A", epChain, "-m", "comment", "--comment", svcPort.String(),
+		"-s", "fd00:180::1/128", "-j", "MARK", "--set-xmark", fmt.Sprintf("%s/0xffffffff", iptablesMasqueradeMark)) {
+		t.Errorf("expected hairpin rule in %s, got:\n%s", epChain, restored)
+	}
+	if !hasLine(lines, "-A", epChain, "-m", "comment", "--comment", svcPort.String(),
+		"-m", "tcp", "-p", "tcp", "-j", "DNAT", "--to-destination", "[fd00:180::1]:80") {
+		t.Errorf("expected DNAT rule in %s, got:\n%s", epChain, restored)
+	}
+	// Addresses of the other family are left to the IPv4 proxier.
+	for _, ip := range []string{"1.2.3.4", "1.2.3.5", "10.180.0.1", "10.20.30.42"} {
+		if strings.Contains(restored, ip) {
+			t.Errorf("expected no rules for IPv4 address %s, got:\n%s", ip, restored)
+		}
+	}
+	if _, found := proxier.serviceMap[v4SvcPort]; found {
+		t.Errorf("expected IPv4 service %s to be skipped", v4SvcPort)
+	}
+}
+
+func TestEndpointIP(t *testing.T) {
+	testCases := map[string]string{
+		"10.180.0.1:80":    "10.180.0.1",
+		"[fd00::1]:80":     "fd00::1",
+		"[fd00::1:2:3]:53": "fd00::1:2:3",
+	}
+	for endpoint, expected := range testCases {
+		if ip := endpointIP(endpoint); ip != expected {
+			t.Errorf("expected IP %q of endpoint %q, got %q", expected, endpoint, ip)
+		}
+	}
+}
+
+func TestExternalNameServicesAreSkipped(t *testing.T) {
+	ipt := utiliptables.NewFake()
+	proxier := newFakeProxier(ipt, &fakeHealthChecker{})
+
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc1"}
+	svcPort := proxy.ServicePortName{NamespacedName: svcName, Port: "p80"}
+	proxier.OnServiceUpdate([]api.Service{{
+		ObjectMeta: api.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace},
+		Spec: api.ServiceSpec{
+			Type:         api.ServiceTypeExternalName,
+			ClusterIP:    "10.20.30.41",
+			ExternalName: "db.example.com",
+			Ports:        []api.ServicePort{{Name: "p80", Port: 80, Protocol: api.ProtocolTCP}},
+		},
+	}})
+	proxier.OnEndpointsUpdate([]api.Endpoints{})
+
+	if _, found := proxier.serviceMap[svcPort]; found {
+		t.Errorf("expected ExternalName service %s to be skipped", svcPort)
+	}
+	if restored := string(ipt.Restored[utiliptables.TableNAT]); strings.Contains(restored, "10.20.30.41") {
+		t.Errorf("expected no rules for ExternalName service %s, got:\n%s", svcPort, restored)
+	}
+}
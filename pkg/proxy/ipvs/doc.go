@@ -0,0 +1,23 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ipvs implements a kube-proxy mode that balances services with the
+// IP Virtual Server of the Linux kernel.  Every service port gets a virtual
+// server for its cluster IP, its external IPs, its load balancer ingress IPs
+// and, for NodePort services, for each address of the node.  Updates only
+// touch the virtual and real servers that changed, so the cost of a sync
+// does not grow with the number of services.
+package ipvs
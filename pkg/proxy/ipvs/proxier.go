@@ -0,0 +1,680 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+//
+// NOTE: this needs to be tested in e2e since it programs the kernel.
+//
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/proxy"
+	"k8s.io/kubernetes/pkg/types"
+	utilexec "k8s.io/kubernetes/pkg/util/exec"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	utilipvs "k8s.io/kubernetes/pkg/util/ipvs"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+// DummyDevice is the device that service addresses are bound to, so that the
+// kernel accepts traffic for them.
+const DummyDevice = "kube-ipvs0"
+
+const sysctlBase = "/proc/sys"
+
+// The service addresses bound to the dummy device must not be announced or
+// answered for over ARP on the other interfaces of the node.
+const sysctlArpIgnore = "net/ipv4/conf/all/arp_ignore"
+const sysctlArpAnnounce = "net/ipv4/conf/all/arp_announce"
+
+// Connections forwarded by IPVS must be tracked for the masquerade rules
+// below to apply to them.
+const sysctlVSConntrack = "net/ipv4/vs/conntrack"
+
+// the nat chain masquerading traffic to IPVS virtual servers
+const iptablesPostroutingChain utiliptables.Chain = "KUBE-IPVS-POSTROUTING"
+
+func setSysctl(sysctl string, newVal int) error {
+	return ioutil.WriteFile(path.Join(sysctlBase, sysctl), []byte(strconv.Itoa(newVal)), 0640)
+}
+
+// internal struct for string service information
+type serviceInfo struct {
+	clusterIP           net.IP
+	port                int
+	protocol            api.Protocol
+	nodePort            int
+	loadBalancerStatus  api.LoadBalancerStatus
+	sessionAffinityType api.ServiceAffinity
+	endpoints           []string
+	externalIPs         []string
+}
+
+// returns a new serviceInfo struct
+func newServiceInfo(service proxy.ServicePortName) *serviceInfo {
+	return &serviceInfo{
+		sessionAffinityType: api.ServiceAffinityNone, // default
+	}
+}
+
+// virtualServer is a virtual server and its real servers, keyed by their
+// String().
+type virtualServer struct {
+	server      *utilipvs.VirtualServer
+	realServers map[string]*utilipvs.RealServer
+	// bound is true if the address of the virtual server is bound to the
+	// dummy device, as opposed to being an address of the node.
+	bound bool
+}
+
+// Proxier is an IPVS based proxy for connections to service addresses.
+type Proxier struct {
+	mu                          sync.Mutex // protects the following fields
+	serviceMap                  map[proxy.ServicePortName]*serviceInfo
+	haveReceivedServiceUpdate   bool // true once we've seen an OnServiceUpdate event
+	haveReceivedEndpointsUpdate bool // true once we've seen an OnEndpointsUpdate event
+	// virtualServers and boundAddresses are the state last written to the
+	// kernel.  Syncs only write the difference with the desired state.
+	virtualServers map[string]*virtualServer
+	boundAddresses map[string]bool
+	// needFullSync is set when the state above has to be read back from the
+	// kernel before the next sync.
+	needFullSync bool
+
+	// These are effectively const and do not need the mutex to be held.
+	syncPeriod  time.Duration
+	ipvs        utilipvs.Interface
+	iptables    utiliptables.Interface
+	scheduler   utilipvs.Scheduler
+	nodeIPs     []net.IP
+	clusterCIDR string
+}
+
+// Proxier implements ProxyProvider
+var _ proxy.ProxyProvider = &Proxier{}
+
+// NewProxier returns a new Proxier given an IPVS Interface instance.  Services
+// without session affinity are balanced with the given scheduler; services
+// with ClientIP affinity always use source hashing.  NodePort services get a
+// virtual server on each of nodeIPs.  Real servers are reached through IPVS
+// masquerading, so replies have to come back through this node: traffic from
+// outside clusterCIDR, the CIDR of the pods in the cluster, is SNATed.  All
+// traffic is SNATed if clusterCIDR is empty.
+// It is assumed that there is only a single Proxier active on a machine.
+func NewProxier(ipvs utilipvs.Interface, ipt utiliptables.Interface, exec utilexec.Interface, syncPeriod time.Duration, scheduler utilipvs.Scheduler, nodeIPs []net.IP, clusterCIDR string) (*Proxier, error) {
+	if !utilipvs.IsValidScheduler(scheduler) {
+		return nil, fmt.Errorf("unsupported IPVS scheduler %q", scheduler)
+	}
+	for _, sysctl := range []struct {
+		name  string
+		value int
+	}{{sysctlArpIgnore, 1}, {sysctlArpAnnounce, 2}, {sysctlVSConntrack, 1}} {
+		if err := setSysctl(sysctl.name, sysctl.value); err != nil {
+			return nil, fmt.Errorf("can't set sysctl %s: %v", sysctl.name, err)
+		}
+	}
+
+	// Load the scheduler modules.  It's OK if this fails (e.g. they are built
+	// into the kernel) because adding a virtual server will fail loudly if a
+	// scheduler is really missing.
+	for _, s := range []utilipvs.Scheduler{scheduler, utilipvs.SchedulerSourceHash} {
+		exec.Command("modprobe", "ip_vs_"+string(s)).CombinedOutput()
+	}
+
+	if clusterCIDR != "" {
+		if _, _, err := net.ParseCIDR(clusterCIDR); err != nil {
+			return nil, fmt.Errorf("invalid cluster CIDR %q: %v", clusterCIDR, err)
+		}
+	}
+	// Drop the masquerade rules of a previous run, which may have been for
+	// another cluster CIDR.
+	if exists, err := ipt.EnsureChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+		return nil, fmt.Errorf("failed to ensure that chain %s exists: %v", iptablesPostroutingChain, err)
+	} else if exists {
+		if err := ipt.FlushChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+			return nil, fmt.Errorf("failed to flush chain %s: %v", iptablesPostroutingChain, err)
+		}
+	}
+
+	return newProxier(ipvs, ipt, syncPeriod, scheduler, nodeIPs, clusterCIDR), nil
+}
+
+func newProxier(ipvs utilipvs.Interface, ipt utiliptables.Interface, syncPeriod time.Duration, scheduler utilipvs.Scheduler, nodeIPs []net.IP, clusterCIDR string) *Proxier {
+	return &Proxier{
+		serviceMap:     make(map[proxy.ServicePortName]*serviceInfo),
+		virtualServers: make(map[string]*virtualServer),
+		boundAddresses: make(map[string]bool),
+		needFullSync:   true,
+		syncPeriod:     syncPeriod,
+		ipvs:           ipvs,
+		iptables:       ipt,
+		scheduler:      scheduler,
+		nodeIPs:        nodeIPs,
+		clusterCIDR:    clusterCIDR,
+	}
+}
+
+// CleanupLeftovers removes the masquerade rules, the virtual servers of all
+// addresses bound to the dummy device, and the device itself.  Virtual
+// servers on node addresses cannot be told apart from others and are left
+// alone.
+// It returns true if an error was encountered. Errors are logged.
+func CleanupLeftovers(ipvs utilipvs.Interface, ipt utiliptables.Interface) (encounteredError bool) {
+	if err := ipt.DeleteRule(utiliptables.TableNAT, utiliptables.ChainPostrouting, postroutingJumpArgs()...); err != nil {
+		glog.Errorf("Error removing IPVS proxy rule: %v", err)
+		encounteredError = true
+	}
+	if _, err := ipt.EnsureChain(utiliptables.TableNAT, iptablesPostroutingChain); err == nil {
+		if err := ipt.FlushChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+			glog.Errorf("Error flushing chain %s: %v", iptablesPostroutingChain, err)
+			encounteredError = true
+		} else if err := ipt.DeleteChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+			glog.Errorf("Error removing chain %s: %v", iptablesPostroutingChain, err)
+			encounteredError = true
+		}
+	}
+
+	bound, err := ipvs.GetBoundAddresses()
+	if err != nil {
+		glog.Errorf("Error listing addresses of %s: %v", DummyDevice, err)
+		return true
+	}
+	if len(bound) == 0 {
+		return false
+	}
+	servers, err := ipvs.GetVirtualServers()
+	if err != nil {
+		glog.Errorf("Error listing IPVS virtual servers: %v", err)
+		return true
+	}
+	for _, vs := range servers {
+		if !containsIP(bound, vs.Address) {
+			continue
+		}
+		if err := ipvs.DeleteVirtualServer(vs); err != nil {
+			glog.Errorf("Error removing IPVS virtual server %s: %v", vs, err)
+			encounteredError = true
+		}
+	}
+	if err := ipvs.DeleteDevice(); err != nil {
+		glog.Errorf("Error removing %s: %v", DummyDevice, err)
+		encounteredError = true
+	}
+	return encounteredError
+}
+
+// postroutingJumpArgs returns the arguments of the rule linking POSTROUTING to
+// iptablesPostroutingChain.
+func postroutingJumpArgs() []string {
+	return []string{"-m", "comment", "--comment", "kubernetes ipvs service traffic requiring SNAT", "-j", string(iptablesPostroutingChain)}
+}
+
+// masqueradeArgs returns the arguments of the rule masquerading connections
+// that IPVS forwards with masquerading from outside the cluster CIDR.
+func (proxier *Proxier) masqueradeArgs() []string {
+	args := []string{"-m", "ipvs", "--ipvs", "--vdir", "ORIGINAL", "--vmethod", "MASQ"}
+	if proxier.clusterCIDR != "" {
+		args = append(args, "!", "-s", proxier.clusterCIDR)
+	}
+	return append(args, "-j", "MASQUERADE")
+}
+
+// ensureMasqueradeRules installs the rules SNATing traffic to virtual servers,
+// so that replies from real servers on other nodes come back through this
+// node and are reverse-NATed by IPVS.
+func (proxier *Proxier) ensureMasqueradeRules() error {
+	if _, err := proxier.iptables.EnsureChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s exists: %v", iptablesPostroutingChain, err)
+	}
+	if _, err := proxier.iptables.EnsureRule(utiliptables.Append, utiliptables.TableNAT, iptablesPostroutingChain, proxier.masqueradeArgs()...); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s masquerades IPVS traffic: %v", iptablesPostroutingChain, err)
+	}
+	if _, err := proxier.iptables.EnsureRule(utiliptables.Append, utiliptables.TableNAT, utiliptables.ChainPostrouting, postroutingJumpArgs()...); err != nil {
+		return fmt.Errorf("failed to ensure that chain %s jumps to %s: %v", utiliptables.ChainPostrouting, iptablesPostroutingChain, err)
+	}
+	return nil
+}
+
+// NodeIPs returns the unicast addresses of the node, except those of the
+// loopback and dummy devices.
+func NodeIPs() ([]net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	ips := []net.IP{}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || iface.Name == DummyDevice {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.IsGlobalUnicast() {
+				ips = append(ips, ipnet.IP)
+			}
+		}
+	}
+	return ips, nil
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for i := range ips {
+		if ips[i].Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (proxier *Proxier) sameConfig(info *serviceInfo, service *api.Service, port *api.ServicePort) bool {
+	if info.protocol != port.Protocol || info.port != port.Port || info.nodePort != port.NodePort {
+		return false
+	}
+	if !info.clusterIP.Equal(net.ParseIP(service.Spec.ClusterIP)) {
+		return false
+	}
+	if !reflect.DeepEqual(info.externalIPs, service.Spec.ExternalIPs) {
+		return false
+	}
+	if !api.LoadBalancerStatusEqual(&info.loadBalancerStatus, &service.Status.LoadBalancer) {
+		return false
+	}
+	if info.sessionAffinityType != service.Spec.SessionAffinity {
+		return false
+	}
+	return true
+}
+
+// SyncLoop runs periodic work.  This is expected to run as a goroutine or as the main loop of the app.  It does not return.
+func (proxier *Proxier) SyncLoop() {
+	t := time.NewTicker(proxier.syncPeriod)
+	defer t.Stop()
+	for {
+		<-t.C
+		glog.V(6).Infof("Periodic sync")
+		func() {
+			proxier.mu.Lock()
+			defer proxier.mu.Unlock()
+			// Read the kernel state back, to repair changes made behind our back.
+			proxier.needFullSync = true
+			proxier.syncProxyRules()
+		}()
+	}
+}
+
+// OnServiceUpdate tracks the active set of service proxies.
+// They will be synchronized using syncProxyRules()
+func (proxier *Proxier) OnServiceUpdate(allServices []api.Service) {
+	proxier.mu.Lock()
+	defer proxier.mu.Unlock()
+	proxier.haveReceivedServiceUpdate = true
+
+	activeServices := make(map[proxy.ServicePortName]bool) // use a map as a set
+
+	for i := range allServices {
+		service := &allServices[i]
+		svcName := types.NamespacedName{
+			Namespace: service.Namespace,
+			Name:      service.Name,
+		}
+
+		// services of type ExternalName are only resolved by DNS
+		if service.Spec.Type == api.ServiceTypeExternalName {
+			glog.V(3).Infof("Skipping service %s due to type = %q", svcName, service.Spec.Type)
+			continue
+		}
+
+		// if ClusterIP is "None" or empty, skip proxying
+		if !api.IsServiceIPSet(service) {
+			glog.V(3).Infof("Skipping service %s due to clusterIP = %q", svcName, service.Spec.ClusterIP)
+			continue
+		}
+
+		for i := range service.Spec.Ports {
+			servicePort := &service.Spec.Ports[i]
+
+			serviceName := proxy.ServicePortName{
+				NamespacedName: svcName,
+				Port:           servicePort.Name,
+			}
+			activeServices[serviceName] = true
+			info, exists := proxier.serviceMap[serviceName]
+			if exists && proxier.sameConfig(info, service, servicePort) {
+				// Nothing changed.
+				continue
+			}
+			if !exists {
+				info = newServiceInfo(serviceName)
+				proxier.serviceMap[serviceName] = info
+			}
+
+			// Endpoints are kept across changes of the service, they are
+			// only updated by OnEndpointsUpdate.
+			serviceIP := net.ParseIP(service.Spec.ClusterIP)
+			glog.V(1).Infof("Setting service %q at %s:%d/%s", serviceName, serviceIP, servicePort.Port, servicePort.Protocol)
+			info.clusterIP = serviceIP
+			info.port = servicePort.Port
+			info.protocol = servicePort.Protocol
+			info.nodePort = servicePort.NodePort
+			info.externalIPs = service.Spec.ExternalIPs
+			// Deep-copy in case the service instance changes
+			info.loadBalancerStatus = *api.LoadBalancerStatusDeepCopy(&service.Status.LoadBalancer)
+			info.sessionAffinityType = service.Spec.SessionAffinity
+		}
+	}
+
+	for name, info := range proxier.serviceMap {
+		if !activeServices[name] {
+			if info.endpoints == nil {
+				glog.V(1).Infof("Removing service %q", name)
+				delete(proxier.serviceMap, name)
+			} else {
+				// Keep the endpoints until they are removed too, but stop
+				// balancing the service.
+				info.clusterIP = nil
+			}
+		}
+	}
+
+	proxier.syncProxyRules()
+}
+
+// OnEndpointsUpdate takes in a slice of updated endpoints.
+func (proxier *Proxier) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
+	proxier.mu.Lock()
+	defer proxier.mu.Unlock()
+	proxier.haveReceivedEndpointsUpdate = true
+
+	registeredEndpoints := make(map[proxy.ServicePortName]bool) // use a map as a set
+
+	// Update endpoints for services.
+	for i := range allEndpoints {
+		svcEndpoints := &allEndpoints[i]
+
+		// We need to build a map of portname -> all ip:ports for that
+		// portname.  Explode Endpoints.Subsets[*] into this structure.
+		portsToEndpoints := map[string][]string{}
+		for i := range svcEndpoints.Subsets {
+			ss := &svcEndpoints.Subsets[i]
+			for i := range ss.Ports {
+				port := &ss.Ports[i]
+				for i := range ss.Addresses {
+					addr := &ss.Addresses[i]
+					if addr.IP == "" || port.Port <= 0 {
+						glog.Warningf("got invalid endpoint: %s:%d", addr.IP, port.Port)
+						continue
+					}
+					portsToEndpoints[port.Name] = append(portsToEndpoints[port.Name], net.JoinHostPort(addr.IP, strconv.Itoa(port.Port)))
+				}
+			}
+		}
+
+		for portname, newEndpoints := range portsToEndpoints {
+			svcPort := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: svcEndpoints.Namespace, Name: svcEndpoints.Name}, Port: portname}
+			state, exists := proxier.serviceMap[svcPort]
+			if !exists || state == nil {
+				state = newServiceInfo(svcPort)
+				proxier.serviceMap[svcPort] = state
+			}
+			newEndpoints = slice.SortStrings(newEndpoints)
+			if !reflect.DeepEqual(state.endpoints, newEndpoints) {
+				glog.V(1).Infof("Setting endpoints for %s to %+v", svcPort, newEndpoints)
+				state.endpoints = newEndpoints
+			}
+			registeredEndpoints[svcPort] = true
+		}
+	}
+	// Remove endpoints missing from the update.
+	for service, info := range proxier.serviceMap {
+		if registeredEndpoints[service] {
+			continue
+		}
+		if info.clusterIP == nil {
+			// Neither the service nor its endpoints exist anymore.
+			delete(proxier.serviceMap, service)
+		} else if info.endpoints != nil {
+			glog.V(2).Infof("Removing endpoints for %s", service)
+			info.endpoints = nil
+		}
+	}
+
+	proxier.syncProxyRules()
+}
+
+// buildVirtualServers returns the virtual servers wanted for the current
+// services and endpoints, keyed by their String().
+// assumes proxier.mu is held
+func (proxier *Proxier) buildVirtualServers() map[string]*virtualServer {
+	result := map[string]*virtualServer{}
+	for svcName, info := range proxier.serviceMap {
+		if info.clusterIP == nil {
+			continue
+		}
+		scheduler := proxier.scheduler
+		if info.sessionAffinityType == api.ServiceAffinityClientIP {
+			scheduler = utilipvs.SchedulerSourceHash
+		}
+		ipv4 := info.clusterIP.To4() != nil
+
+		realServers := map[string]*utilipvs.RealServer{}
+		for _, ep := range info.endpoints {
+			host, port, err := net.SplitHostPort(ep)
+			if err != nil {
+				glog.Errorf("Invalid endpoint %q of %s: %v", ep, svcName, err)
+				continue
+			}
+			portNum, err := strconv.Atoi(port)
+			ip := net.ParseIP(host)
+			if err != nil || ip == nil || (ip.To4() != nil) != ipv4 {
+				glog.Errorf("Invalid endpoint %q of %s", ep, svcName)
+				continue
+			}
+			rs := &utilipvs.RealServer{Address: ip, Port: uint16(portNum), Weight: 1}
+			realServers[rs.String()] = rs
+		}
+
+		add := func(ip net.IP, port int, bound bool) {
+			vs := &utilipvs.VirtualServer{
+				Address:   ip,
+				Protocol:  utilipvs.Protocol(info.protocol),
+				Port:      uint16(port),
+				Scheduler: scheduler,
+			}
+			result[vs.String()] = &virtualServer{server: vs, realServers: realServers, bound: bound}
+		}
+		add(info.clusterIP, info.port, true)
+		for _, externalIP := range info.externalIPs {
+			if ip := net.ParseIP(externalIP); ip != nil && (ip.To4() != nil) == ipv4 {
+				add(ip, info.port, true)
+			}
+		}
+		for _, ingress := range info.loadBalancerStatus.Ingress {
+			if ip := net.ParseIP(ingress.IP); ip != nil && (ip.To4() != nil) == ipv4 {
+				add(ip, info.port, true)
+			}
+		}
+		if info.nodePort != 0 {
+			for _, ip := range proxier.nodeIPs {
+				if (ip.To4() != nil) == ipv4 {
+					add(ip, info.nodePort, false)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// readKernelState replaces the last written state with the one of the
+// kernel.  Virtual servers on addresses bound to the dummy device are ours,
+// as are the ones on node addresses that we wrote or want.
+// assumes proxier.mu is held
+func (proxier *Proxier) readKernelState(wanted map[string]*virtualServer) error {
+	bound, err := proxier.ipvs.GetBoundAddresses()
+	if err != nil {
+		return err
+	}
+	servers, err := proxier.ipvs.GetVirtualServers()
+	if err != nil {
+		return err
+	}
+	boundAddresses := map[string]bool{}
+	for _, ip := range bound {
+		boundAddresses[ip.String()] = true
+	}
+	virtualServers := map[string]*virtualServer{}
+	for _, vs := range servers {
+		key := vs.String()
+		isBound := boundAddresses[vs.Address.String()]
+		_, wrote := proxier.virtualServers[key]
+		_, want := wanted[key]
+		if !isBound && !wrote && !want {
+			continue
+		}
+		reals, err := proxier.ipvs.GetRealServers(vs)
+		if err != nil {
+			return err
+		}
+		realServers := map[string]*utilipvs.RealServer{}
+		for _, rs := range reals {
+			realServers[rs.String()] = rs
+		}
+		virtualServers[key] = &virtualServer{server: vs, realServers: realServers, bound: isBound}
+	}
+	proxier.boundAddresses = boundAddresses
+	proxier.virtualServers = virtualServers
+	return nil
+}
+
+// syncProxyRules writes the difference between the wanted virtual servers
+// and the ones last written to the kernel.  Whatever fails to be written is
+// retried on the next sync.
+// assumes proxier.mu is held
+func (proxier *Proxier) syncProxyRules() {
+	// don't sync rules till we've received services and endpoints
+	if !proxier.haveReceivedEndpointsUpdate || !proxier.haveReceivedServiceUpdate {
+		glog.V(2).Info("Not syncing IPVS until Services and Endpoints have been received from master")
+		return
+	}
+	if err := proxier.ensureMasqueradeRules(); err != nil {
+		glog.Errorf("Failed to ensure masquerade rules: %v", err)
+		return
+	}
+	wanted := proxier.buildVirtualServers()
+
+	if proxier.needFullSync {
+		glog.V(3).Infof("Reading IPVS state")
+		if err := proxier.readKernelState(wanted); err != nil {
+			glog.Errorf("Failed to read IPVS state: %v", err)
+			return
+		}
+		proxier.needFullSync = false
+	}
+	glog.V(3).Infof("Syncing IPVS virtual servers")
+
+	// Bind the addresses before creating the virtual servers, so that their
+	// traffic is accepted as soon as they exist.
+	wantedAddresses := map[string]bool{}
+	for _, vs := range wanted {
+		if !vs.bound {
+			continue
+		}
+		addr := vs.server.Address.String()
+		wantedAddresses[addr] = true
+		if proxier.boundAddresses[addr] {
+			continue
+		}
+		if err := proxier.ipvs.EnsureAddressBound(vs.server.Address); err != nil {
+			glog.Errorf("Failed to bind %s to %s: %v", addr, DummyDevice, err)
+			continue
+		}
+		proxier.boundAddresses[addr] = true
+	}
+
+	for key, vs := range wanted {
+		current, exists := proxier.virtualServers[key]
+		if !exists {
+			if err := proxier.ipvs.AddVirtualServer(vs.server); err != nil {
+				glog.Errorf("Failed to add IPVS virtual server %s: %v", key, err)
+				continue
+			}
+			current = &virtualServer{server: vs.server, realServers: map[string]*utilipvs.RealServer{}, bound: vs.bound}
+			proxier.virtualServers[key] = current
+		} else if !current.server.Equal(vs.server) {
+			if err := proxier.ipvs.UpdateVirtualServer(vs.server); err != nil {
+				glog.Errorf("Failed to update IPVS virtual server %s: %v", key, err)
+			} else {
+				current.server = vs.server
+			}
+		}
+		current.bound = vs.bound
+		for rsKey, rs := range current.realServers {
+			if wantedRS, found := vs.realServers[rsKey]; found && wantedRS.Equal(rs) {
+				continue
+			}
+			if err := proxier.ipvs.DeleteRealServer(current.server, rs); err != nil {
+				glog.Errorf("Failed to remove real server %s of %s: %v", rsKey, key, err)
+				continue
+			}
+			delete(current.realServers, rsKey)
+		}
+		for rsKey, rs := range vs.realServers {
+			if _, found := current.realServers[rsKey]; found {
+				continue
+			}
+			if err := proxier.ipvs.AddRealServer(current.server, rs); err != nil {
+				glog.Errorf("Failed to add real server %s to %s: %v", rsKey, key, err)
+				continue
+			}
+			current.realServers[rsKey] = rs
+		}
+	}
+
+	for key, current := range proxier.virtualServers {
+		if _, found := wanted[key]; found {
+			continue
+		}
+		if err := proxier.ipvs.DeleteVirtualServer(current.server); err != nil {
+			glog.Errorf("Failed to remove IPVS virtual server %s: %v", key, err)
+			continue
+		}
+		delete(proxier.virtualServers, key)
+	}
+
+	for addr := range proxier.boundAddresses {
+		if wantedAddresses[addr] {
+			continue
+		}
+		if err := proxier.ipvs.UnbindAddress(net.ParseIP(addr)); err != nil {
+			glog.Errorf("Failed to unbind %s from %s: %v", addr, DummyDevice, err)
+			continue
+		}
+		delete(proxier.boundAddresses, addr)
+	}
+}
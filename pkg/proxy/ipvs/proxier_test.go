@@ -0,0 +1,302 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	utilipvs "k8s.io/kubernetes/pkg/util/ipvs"
+)
+
+func makeService(name, clusterIP string, port, nodePort int, affinity api.ServiceAffinity, externalIPs ...string) api.Service {
+	return api.Service{
+		ObjectMeta: api.ObjectMeta{Namespace: api.NamespaceDefault, Name: name},
+		Spec: api.ServiceSpec{
+			ClusterIP:       clusterIP,
+			ExternalIPs:     externalIPs,
+			SessionAffinity: affinity,
+			Ports:           []api.ServicePort{{Name: "p", Port: port, NodePort: nodePort, Protocol: api.ProtocolTCP}},
+		},
+	}
+}
+
+func makeEndpoints(name string, port int, ips ...string) api.Endpoints {
+	addresses := []api.EndpointAddress{}
+	for _, ip := range ips {
+		addresses = append(addresses, api.EndpointAddress{IP: ip})
+	}
+	return api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Namespace: api.NamespaceDefault, Name: name},
+		Subsets: []api.EndpointSubset{{
+			Addresses: addresses,
+			Ports:     []api.EndpointPort{{Name: "p", Port: port}},
+		}},
+	}
+}
+
+func newFakeProxier(fake *utilipvs.FakeIPVS, nodeIPs ...string) *Proxier {
+	ips := []net.IP{}
+	for _, ip := range nodeIPs {
+		ips = append(ips, net.ParseIP(ip))
+	}
+	return newProxier(fake, utiliptables.NewFake(), time.Minute, utilipvs.SchedulerRoundRobin, ips, "10.244.0.0/16")
+}
+
+func realServers(fake *utilipvs.FakeIPVS, vs string) []string {
+	result := []string{}
+	for _, rs := range fake.RealServers[vs] {
+		result = append(result, rs.String())
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedLog(fake *utilipvs.FakeIPVS) []string {
+	log := append([]string{}, fake.Log...)
+	sort.Strings(log)
+	return log
+}
+
+func TestNoSyncBeforeServicesAndEndpoints(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake)
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 0, api.ServiceAffinityNone)})
+	if len(fake.Log) != 0 {
+		t.Errorf("expected no changes before endpoints are received, got %v", fake.Log)
+	}
+}
+
+func TestSyncClusterIP(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake)
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("a", "10.0.0.1", 80, 0, api.ServiceAffinityNone),
+		makeService("b", "10.0.0.2", 80, 0, api.ServiceAffinityClientIP),
+		makeService("headless", "None", 80, 0, api.ServiceAffinityNone),
+	})
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("a", 8080, "10.244.1.1", "10.244.2.1"),
+		makeEndpoints("b", 9090, "10.244.1.2"),
+	})
+
+	if len(fake.VirtualServers) != 2 {
+		t.Fatalf("expected 2 virtual servers, got %v", fake.VirtualServers)
+	}
+	if vs := fake.VirtualServers["10.0.0.1:80/TCP"]; vs == nil || vs.Scheduler != utilipvs.SchedulerRoundRobin {
+		t.Errorf("expected a round robin virtual server for a, got %+v", vs)
+	}
+	if vs := fake.VirtualServers["10.0.0.2:80/TCP"]; vs == nil || vs.Scheduler != utilipvs.SchedulerSourceHash {
+		t.Errorf("expected a source hash virtual server for b, got %+v", vs)
+	}
+	if got, expected := realServers(fake, "10.0.0.1:80/TCP"), []string{"10.244.1.1:8080", "10.244.2.1:8080"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected real servers %v, got %v", expected, got)
+	}
+	if got, expected := realServers(fake, "10.0.0.2:80/TCP"), []string{"10.244.1.2:9090"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected real servers %v, got %v", expected, got)
+	}
+	if expected := map[string]bool{"10.0.0.1": true, "10.0.0.2": true}; !reflect.DeepEqual(fake.Addresses, expected) {
+		t.Errorf("expected bound addresses %v, got %v", expected, fake.Addresses)
+	}
+}
+
+func TestSyncNodePortAndExternalIPs(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake, "192.168.0.10", "fd00::10")
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 30080, api.ServiceAffinityNone, "1.2.3.4")})
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+
+	for _, vs := range []string{"10.0.0.1:80/TCP", "1.2.3.4:80/TCP", "192.168.0.10:30080/TCP"} {
+		if got := realServers(fake, vs); !reflect.DeepEqual(got, []string{"10.244.1.1:8080"}) {
+			t.Errorf("expected virtual server %s with one real server, got %v", vs, got)
+		}
+	}
+	if len(fake.VirtualServers) != 3 {
+		t.Errorf("expected no virtual server on the IPv6 node address, got %v", fake.VirtualServers)
+	}
+	if expected := map[string]bool{"10.0.0.1": true, "1.2.3.4": true}; !reflect.DeepEqual(fake.Addresses, expected) {
+		t.Errorf("expected node addresses not to be bound, got %v", fake.Addresses)
+	}
+}
+
+func TestIncrementalUpdates(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake)
+	services := []api.Service{
+		makeService("a", "10.0.0.1", 80, 0, api.ServiceAffinityNone),
+		makeService("b", "10.0.0.2", 80, 0, api.ServiceAffinityNone),
+	}
+	proxier.OnServiceUpdate(services)
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("a", 8080, "10.244.1.1", "10.244.2.1"),
+		makeEndpoints("b", 8080, "10.244.1.2"),
+	})
+
+	// Nothing changed.
+	fake.ResetLog()
+	proxier.OnServiceUpdate(services)
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("a", 8080, "10.244.2.1", "10.244.1.1"),
+		makeEndpoints("b", 8080, "10.244.1.2"),
+	})
+	if len(fake.Log) != 0 {
+		t.Errorf("expected no changes, got %v", fake.Log)
+	}
+
+	// One endpoint of a replaced.
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("a", 8080, "10.244.2.1", "10.244.3.1"),
+		makeEndpoints("b", 8080, "10.244.1.2"),
+	})
+	expected := []string{"add-rs 10.0.0.1:80/TCP 10.244.3.1:8080", "delete-rs 10.0.0.1:80/TCP 10.244.1.1:8080"}
+	if got := sortedLog(fake); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+
+	// Session affinity of b turned on.
+	fake.ResetLog()
+	services[1].Spec.SessionAffinity = api.ServiceAffinityClientIP
+	proxier.OnServiceUpdate(services)
+	expected = []string{"update-vs 10.0.0.2:80/TCP sh"}
+	if !reflect.DeepEqual(fake.Log, expected) {
+		t.Errorf("expected changes %v, got %v", expected, fake.Log)
+	}
+
+	// b removed.
+	fake.ResetLog()
+	proxier.OnServiceUpdate(services[:1])
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.2.1", "10.244.3.1")})
+	expected = []string{"delete-vs 10.0.0.2:80/TCP", "unbind 10.0.0.2"}
+	if !reflect.DeepEqual(fake.Log, expected) {
+		t.Errorf("expected changes %v, got %v", expected, fake.Log)
+	}
+	if len(proxier.serviceMap) != 1 {
+		t.Errorf("expected b to be forgotten, got %v", proxier.serviceMap)
+	}
+}
+
+func TestFullSyncRepairsKernelState(t *testing.T) {
+	fake := utilipvs.NewFake()
+	foreign := &utilipvs.VirtualServer{Address: net.ParseIP("192.168.0.99"), Protocol: utilipvs.ProtocolTCP, Port: 80, Scheduler: utilipvs.SchedulerRoundRobin}
+	fake.AddVirtualServer(foreign)
+	// Left behind by a previous run.
+	stale := &utilipvs.VirtualServer{Address: net.ParseIP("10.0.0.9"), Protocol: utilipvs.ProtocolTCP, Port: 80, Scheduler: utilipvs.SchedulerRoundRobin}
+	fake.AddVirtualServer(stale)
+	fake.EnsureAddressBound(stale.Address)
+	// Already written by a previous run.
+	existing := &utilipvs.VirtualServer{Address: net.ParseIP("10.0.0.1"), Protocol: utilipvs.ProtocolTCP, Port: 80, Scheduler: utilipvs.SchedulerRoundRobin}
+	fake.AddVirtualServer(existing)
+	fake.AddRealServer(existing, &utilipvs.RealServer{Address: net.ParseIP("10.244.1.1"), Port: 8080, Weight: 1})
+	fake.EnsureAddressBound(existing.Address)
+	fake.ResetLog()
+
+	proxier := newFakeProxier(fake)
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 0, api.ServiceAffinityNone)})
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+	expected := []string{"delete-vs 10.0.0.9:80/TCP", "unbind 10.0.0.9"}
+	if !reflect.DeepEqual(fake.Log, expected) {
+		t.Errorf("expected changes %v, got %v", expected, fake.Log)
+	}
+	if _, found := fake.VirtualServers[foreign.String()]; !found {
+		t.Errorf("expected the foreign virtual server to be left alone")
+	}
+
+	// Somebody removes the real server behind our back.
+	fake.DeleteRealServer(existing, &utilipvs.RealServer{Address: net.ParseIP("10.244.1.1"), Port: 8080})
+	fake.ResetLog()
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+	if len(fake.Log) != 0 {
+		t.Errorf("expected no changes without a full sync, got %v", fake.Log)
+	}
+	proxier.needFullSync = true
+	proxier.syncProxyRules()
+	expected = []string{"add-rs 10.0.0.1:80/TCP 10.244.1.1:8080"}
+	if !reflect.DeepEqual(fake.Log, expected) {
+		t.Errorf("expected changes %v, got %v", expected, fake.Log)
+	}
+}
+
+func TestCleanupLeftovers(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake, "192.168.0.10")
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 30080, api.ServiceAffinityNone)})
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+
+	ipt := proxier.iptables.(*utiliptables.FakeIPTables)
+	if CleanupLeftovers(fake, ipt) {
+		t.Errorf("unexpected error cleaning up")
+	}
+	if ipt.Chains[utiliptables.TableNAT][iptablesPostroutingChain] {
+		t.Errorf("expected chain %s to be removed", iptablesPostroutingChain)
+	}
+	if rules := ipt.Rules[utiliptables.TableNAT][utiliptables.ChainPostrouting]; len(rules) != 0 {
+		t.Errorf("expected the jump to %s to be removed, got %v", iptablesPostroutingChain, rules)
+	}
+	if _, found := fake.VirtualServers["10.0.0.1:80/TCP"]; found {
+		t.Errorf("expected the cluster IP virtual server to be removed")
+	}
+	if _, found := fake.VirtualServers["192.168.0.10:30080/TCP"]; !found {
+		t.Errorf("expected the node port virtual server to be left alone")
+	}
+	if len(fake.Addresses) != 0 {
+		t.Errorf("expected the dummy device to be removed, got %v", fake.Addresses)
+	}
+}
+
+func TestMasqueradeRules(t *testing.T) {
+	fake := utilipvs.NewFake()
+	proxier := newFakeProxier(fake, "192.168.0.10")
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 30080, api.ServiceAffinityNone)})
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+
+	ipt := proxier.iptables.(*utiliptables.FakeIPTables)
+	if !ipt.Chains[utiliptables.TableNAT][iptablesPostroutingChain] {
+		t.Fatalf("expected chain %s to exist", iptablesPostroutingChain)
+	}
+	expected := []string{"-m ipvs --ipvs --vdir ORIGINAL --vmethod MASQ ! -s 10.244.0.0/16 -j MASQUERADE"}
+	if rules := ipt.Rules[utiliptables.TableNAT][iptablesPostroutingChain]; !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected rules %v in %s, got %v", expected, iptablesPostroutingChain, rules)
+	}
+	expected = []string{"-m comment --comment kubernetes ipvs service traffic requiring SNAT -j " + string(iptablesPostroutingChain)}
+	if rules := ipt.Rules[utiliptables.TableNAT][utiliptables.ChainPostrouting]; !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected rules %v in %s, got %v", expected, utiliptables.ChainPostrouting, rules)
+	}
+
+	// Syncing again does not duplicate the rules.
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1", "10.244.2.1")})
+	if rules := ipt.Rules[utiliptables.TableNAT][iptablesPostroutingChain]; len(rules) != 1 {
+		t.Errorf("expected one rule in %s, got %v", iptablesPostroutingChain, rules)
+	}
+}
+
+func TestMasqueradeRulesWithoutClusterCIDR(t *testing.T) {
+	fake := utilipvs.NewFake()
+	ipt := utiliptables.NewFake()
+	proxier := newProxier(fake, ipt, time.Minute, utilipvs.SchedulerRoundRobin, nil, "")
+	proxier.OnServiceUpdate([]api.Service{makeService("a", "10.0.0.1", 80, 0, api.ServiceAffinityNone)})
+	proxier.OnEndpointsUpdate([]api.Endpoints{makeEndpoints("a", 8080, "10.244.1.1")})
+
+	expected := []string{"-m ipvs --ipvs --vdir ORIGINAL --vmethod MASQ -j MASQUERADE"}
+	if rules := ipt.Rules[utiliptables.TableNAT][iptablesPostroutingChain]; !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected rules %v in %s, got %v", expected, iptablesPostroutingChain, rules)
+	}
+}
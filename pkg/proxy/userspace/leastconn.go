@@ -0,0 +1,63 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userspace
+
+import (
+	"k8s.io/kubernetes/pkg/proxy"
+)
+
+// LoadBalancerLeastConn is a load balancer which hands each new session to
+// the endpoint with the fewest active connections for its weight.  Endpoints
+// with equal load take turns.  It suits services with long-lived
+// connections, which round-robin piles onto the same endpoints.
+type LoadBalancerLeastConn struct {
+	*LoadBalancerRR
+}
+
+// Ensure this implements LoadBalancer.
+var _ LoadBalancer = &LoadBalancerLeastConn{}
+
+// NewLoadBalancerLeastConn returns a new LoadBalancerLeastConn.
+func NewLoadBalancerLeastConn() *LoadBalancerLeastConn {
+	return &LoadBalancerLeastConn{
+		LoadBalancerRR: &LoadBalancerRR{
+			services: map[proxy.ServicePortName]*balancerState{},
+			pick:     pickLeastConnections,
+		},
+	}
+}
+
+// pickLeastConnections takes the least loaded endpoint, searching from the
+// round-robin index so that ties are broken in turn.
+func pickLeastConnections(state *balancerState) string {
+	n := len(state.endpoints)
+	best := state.index
+	for i := 1; i < n; i++ {
+		j := (state.index + i) % n
+		if lessLoaded(state, state.endpoints[j], state.endpoints[best]) {
+			best = j
+		}
+	}
+	state.index = (best + 1) % n
+	return state.endpoints[best]
+}
+
+// lessLoaded compares the connections per weight of two endpoints, without
+// dividing.
+func lessLoaded(state *balancerState, a, b string) bool {
+	return state.connections[a]*state.weight(b) < state.connections[b]*state.weight(a)
+}
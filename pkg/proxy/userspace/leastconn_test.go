@@ -0,0 +1,119 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userspace
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/proxy"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+func TestLeastConnPicksLeastLoadedEndpoint(t *testing.T) {
+	loadBalancer := NewLoadBalancerLeastConn()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	endpoints := []api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "endpoint1"}, {IP: "endpoint2"}, {IP: "endpoint3"}},
+			Ports:     []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+
+	// Idle endpoints take turns.
+	picked := []string{}
+	for i := 0; i < 3; i++ {
+		endpoint, err := loadBalancer.NextEndpoint(service, nil)
+		if err != nil {
+			t.Fatalf("Didn't find a service for %s: %v", service, err)
+		}
+		picked = append(picked, endpoint)
+		loadBalancer.ConnectionOpened(service, endpoint)
+	}
+	if !stringsInSlice(picked, "endpoint1:1", "endpoint2:1", "endpoint3:1") {
+		t.Fatalf("Expected every endpoint to be picked once, got %v", picked)
+	}
+
+	// The endpoint whose connection closed is the least loaded.
+	loadBalancer.ConnectionClosed(service, picked[1])
+	expectEndpoint(t, loadBalancer.LoadBalancerRR, service, picked[1], nil)
+	expectEndpoint(t, loadBalancer.LoadBalancerRR, service, picked[1], nil)
+	loadBalancer.ConnectionOpened(service, picked[1])
+	loadBalancer.ConnectionOpened(service, picked[0])
+	loadBalancer.ConnectionOpened(service, picked[0])
+	expectEndpoint(t, loadBalancer.LoadBalancerRR, service, picked[2], nil)
+}
+
+func TestLeastConnUsesWeights(t *testing.T) {
+	loadBalancer := NewLoadBalancerLeastConn()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	endpoints := []api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: map[string]string{EndpointWeightsAnnotation: `{"endpoint1": 3}`},
+		},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "endpoint1"}, {IP: "endpoint2"}},
+			Ports:     []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+
+	// endpoint1 takes three connections for each of endpoint2.
+	loadBalancer.ConnectionOpened(service, "endpoint2:1")
+	for i := 0; i < 2; i++ {
+		expectEndpoint(t, loadBalancer.LoadBalancerRR, service, "endpoint1:1", nil)
+		loadBalancer.ConnectionOpened(service, "endpoint1:1")
+	}
+	loadBalancer.ConnectionOpened(service, "endpoint1:1")
+	loadBalancer.ConnectionClosed(service, "endpoint2:1")
+	expectEndpoint(t, loadBalancer.LoadBalancerRR, service, "endpoint2:1", nil)
+}
+
+func TestConnectionsOfRemovedEndpointsAreForgotten(t *testing.T) {
+	loadBalancer := NewLoadBalancerLeastConn()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	endpoints := []api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "endpoint1"}, {IP: "endpoint2"}},
+			Ports:     []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+	loadBalancer.ConnectionOpened(service, "endpoint1:1")
+	loadBalancer.ConnectionOpened(service, "endpoint2:1")
+
+	endpoints[0].Subsets[0].Addresses = endpoints[0].Subsets[0].Addresses[1:]
+	loadBalancer.OnEndpointsUpdate(endpoints)
+	state := loadBalancer.services[service]
+	if _, found := state.connections["endpoint1:1"]; found {
+		t.Errorf("Expected the connections of endpoint1:1 to be forgotten, got %v", state.connections)
+	}
+	if state.connections["endpoint2:1"] != 1 {
+		t.Errorf("Expected endpoint2:1 to keep its connection, got %v", state.connections)
+	}
+
+	// A connection closing after its endpoint went away is ignored.
+	loadBalancer.ConnectionClosed(service, "endpoint1:1")
+	if count := state.connections["endpoint1:1"]; count != 0 {
+		t.Errorf("Expected no connections of endpoint1:1, got %d", count)
+	}
+}
@@ -28,6 +28,12 @@ type LoadBalancer interface {
 	// NextEndpoint returns the endpoint to handle a request for the given
 	// service-port and source address.
 	NextEndpoint(service proxy.ServicePortName, srcAddr net.Addr) (string, error)
-	NewService(service proxy.ServicePortName, sessionAffinityType api.ServiceAffinity, stickyMaxAgeMinutes int) error
+	NewService(service proxy.ServicePortName, sessionAffinityType api.ServiceAffinity, stickyMaxAgeSeconds int) error
 	CleanupStaleStickySessions(service proxy.ServicePortName)
+	// ConnectionOpened records a connection proxied to an endpoint returned
+	// by NextEndpoint.
+	ConnectionOpened(service proxy.ServicePortName, endpoint string)
+	// ConnectionClosed records the end of a connection recorded with
+	// ConnectionOpened.
+	ConnectionClosed(service proxy.ServicePortName, endpoint string)
 }
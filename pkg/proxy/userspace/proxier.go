@@ -50,7 +50,7 @@ type serviceInfo struct {
 	nodePort            int
 	loadBalancerStatus  api.LoadBalancerStatus
 	sessionAffinityType api.ServiceAffinity
-	stickyMaxAgeMinutes int
+	stickyMaxAgeSeconds int
 	// Deprecated, but required for back-compat (including e2e)
 	externalIPs []string
 }
@@ -299,8 +299,8 @@ func (proxier *Proxier) addServiceOnPort(service proxy.ServicePortName, protocol
 		socket:              sock,
 		timeout:             timeout,
 		activeClients:       newClientCache(),
-		sessionAffinityType: api.ServiceAffinityNone, // default
-		stickyMaxAgeMinutes: 180,                     // TODO: parameterize this in the API.
+		sessionAffinityType: api.ServiceAffinityNone,                  // default
+		stickyMaxAgeSeconds: api.DefaultSessionAffinityTimeoutSeconds, // default
 	}
 	proxier.setServiceInfo(service, si)
 
@@ -327,6 +327,12 @@ func (proxier *Proxier) OnServiceUpdate(services []api.Service) {
 	for i := range services {
 		service := &services[i]
 
+		// services of type ExternalName are only resolved by DNS
+		if service.Spec.Type == api.ServiceTypeExternalName {
+			glog.V(3).Infof("Skipping service %s due to type = %q", types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, service.Spec.Type)
+			continue
+		}
+
 		// if ClusterIP is "None" or empty, skip proxying
 		if !api.IsServiceIPSet(service) {
 			glog.V(3).Infof("Skipping service %s due to clusterIP = %q", types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, service.Spec.ClusterIP)
@@ -375,13 +381,16 @@ func (proxier *Proxier) OnServiceUpdate(services []api.Service) {
 			info.loadBalancerStatus = *api.LoadBalancerStatusDeepCopy(&service.Status.LoadBalancer)
 			info.nodePort = servicePort.NodePort
 			info.sessionAffinityType = service.Spec.SessionAffinity
+			if service.Spec.SessionAffinityTimeoutSeconds != 0 {
+				info.stickyMaxAgeSeconds = service.Spec.SessionAffinityTimeoutSeconds
+			}
 			glog.V(4).Infof("info: %+v", info)
 
 			err = proxier.openPortal(serviceName, info)
 			if err != nil {
 				glog.Errorf("Failed to open portal for %q: %v", serviceName, err)
 			}
-			proxier.loadBalancer.NewService(serviceName, info.sessionAffinityType, info.stickyMaxAgeMinutes)
+			proxier.loadBalancer.NewService(serviceName, info.sessionAffinityType, info.stickyMaxAgeSeconds)
 		}
 	}
 	proxier.mu.Lock()
@@ -417,6 +426,9 @@ func sameConfig(info *serviceInfo, service *api.Service, port *api.ServicePort)
 	if info.sessionAffinityType != service.Spec.SessionAffinity {
 		return false
 	}
+	if timeout := service.Spec.SessionAffinityTimeoutSeconds; timeout != 0 && info.stickyMaxAgeSeconds != timeout {
+		return false
+	}
 	return true
 }
 
@@ -86,12 +86,15 @@ func (tcp *tcpProxySocket) ListenPort() int {
 	return tcp.port
 }
 
-func tryConnect(service proxy.ServicePortName, srcAddr net.Addr, protocol string, proxier *Proxier) (out net.Conn, err error) {
+// tryConnect connects to an endpoint of the service, and returns the
+// connection and the endpoint.  The connection is recorded with the load
+// balancer; the caller must call ConnectionClosed once it is closed.
+func tryConnect(service proxy.ServicePortName, srcAddr net.Addr, protocol string, proxier *Proxier) (out net.Conn, endpoint string, err error) {
 	for _, retryTimeout := range endpointDialTimeout {
 		endpoint, err := proxier.loadBalancer.NextEndpoint(service, srcAddr)
 		if err != nil {
 			glog.Errorf("Couldn't find an endpoint for %s: %v", service, err)
-			return nil, err
+			return nil, "", err
 		}
 		glog.V(3).Infof("Mapped service %q to endpoint %s", service, endpoint)
 		// TODO: This could spin up a new goroutine to make the outbound connection,
@@ -104,9 +107,10 @@ func tryConnect(service proxy.ServicePortName, srcAddr net.Addr, protocol string
 			glog.Errorf("Dial failed: %v", err)
 			continue
 		}
-		return outConn, nil
+		proxier.loadBalancer.ConnectionOpened(service, endpoint)
+		return outConn, endpoint, nil
 	}
-	return nil, fmt.Errorf("failed to connect to an endpoint.")
+	return nil, "", fmt.Errorf("failed to connect to an endpoint.")
 }
 
 func (tcp *tcpProxySocket) ProxyLoop(service proxy.ServicePortName, myInfo *serviceInfo, proxier *Proxier) {
@@ -133,14 +137,17 @@ func (tcp *tcpProxySocket) ProxyLoop(service proxy.ServicePortName, myInfo *serv
 			continue
 		}
 		glog.V(2).Infof("Accepted TCP connection from %v to %v", inConn.RemoteAddr(), inConn.LocalAddr())
-		outConn, err := tryConnect(service, inConn.(*net.TCPConn).RemoteAddr(), "tcp", proxier)
+		outConn, endpoint, err := tryConnect(service, inConn.(*net.TCPConn).RemoteAddr(), "tcp", proxier)
 		if err != nil {
 			glog.Errorf("Failed to connect to balancer: %v", err)
 			inConn.Close()
 			continue
 		}
 		// Spin up an async copy loop.
-		go proxyTCP(inConn.(*net.TCPConn), outConn.(*net.TCPConn))
+		go func(in, out *net.TCPConn, endpoint string) {
+			proxyTCP(in, out)
+			proxier.loadBalancer.ConnectionClosed(service, endpoint)
+		}(inConn.(*net.TCPConn), outConn.(*net.TCPConn), endpoint)
 	}
 }
 
@@ -249,18 +256,23 @@ func (udp *udpProxySocket) getBackendConn(activeClients *clientCache, cliAddr ne
 		// and keep accepting inbound traffic.
 		glog.V(2).Infof("New UDP connection from %s", cliAddr)
 		var err error
-		svrConn, err = tryConnect(service, cliAddr, "udp", proxier)
+		var endpoint string
+		svrConn, endpoint, err = tryConnect(service, cliAddr, "udp", proxier)
 		if err != nil {
 			return nil, err
 		}
 		if err = svrConn.SetDeadline(time.Now().Add(timeout)); err != nil {
 			glog.Errorf("SetDeadline failed: %v", err)
+			svrConn.Close()
+			proxier.loadBalancer.ConnectionClosed(service, endpoint)
 			return nil, err
 		}
 		activeClients.clients[cliAddr.String()] = svrConn
 		go func(cliAddr net.Addr, svrConn net.Conn, activeClients *clientCache, timeout time.Duration) {
 			defer util.HandleCrash()
 			udp.proxyClient(cliAddr, svrConn, activeClients, timeout)
+			// A UDP client counts as a connection until it times out.
+			proxier.loadBalancer.ConnectionClosed(service, endpoint)
 		}(cliAddr, svrConn, activeClients, timeout)
 	}
 	return svrConn, nil
@@ -17,6 +17,7 @@ limitations under the License.
 package userspace
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/proxy"
 	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/slice"
 )
 
@@ -37,6 +39,12 @@ var (
 	ErrMissingEndpoints    = errors.New("missing endpoints")
 )
 
+// EndpointWeightsAnnotation is the annotation of an Endpoints object holding
+// the relative weights of its addresses, as a JSON object from IP to a
+// positive integer.  Addresses without a weight have weight 1.  Only the
+// weighted and least-connections load balancers use weights.
+const EndpointWeightsAnnotation = "proxy.alpha.kubernetes.io/endpoint-weights"
+
 type affinityState struct {
 	clientIP string
 	//clientProtocol  api.Protocol //not yet used
@@ -48,13 +56,17 @@ type affinityState struct {
 type affinityPolicy struct {
 	affinityType api.ServiceAffinity
 	affinityMap  map[string]*affinityState // map client IP -> affinity info
-	ttlMinutes   int
+	ttlSeconds   int
 }
 
 // LoadBalancerRR is a round-robin load balancer.
 type LoadBalancerRR struct {
 	lock     sync.RWMutex
 	services map[proxy.ServicePortName]*balancerState
+	// pick chooses the endpoint of a new session.  It assumes lock is held
+	// and the state has endpoints.  Other load balancers reuse LoadBalancerRR
+	// with a different pick.
+	pick func(state *balancerState) string
 }
 
 // Ensure this implements LoadBalancer.
@@ -64,13 +76,27 @@ type balancerState struct {
 	endpoints []string // a list of "ip:port" style strings
 	index     int      // current index into endpoints
 	affinity  affinityPolicy
+	// connections counts the active connections of each endpoint.
+	connections map[string]int
+	// weights holds the weights of endpoints whose weight is not 1.
+	weights map[string]int
+	// currentWeights is the state of the weighted round-robin.
+	currentWeights map[string]int
 }
 
-func newAffinityPolicy(affinityType api.ServiceAffinity, ttlMinutes int) *affinityPolicy {
+// weight returns the weight of an endpoint.
+func (state *balancerState) weight(endpoint string) int {
+	if w, found := state.weights[endpoint]; found {
+		return w
+	}
+	return 1
+}
+
+func newAffinityPolicy(affinityType api.ServiceAffinity, ttlSeconds int) *affinityPolicy {
 	return &affinityPolicy{
 		affinityType: affinityType,
 		affinityMap:  make(map[string]*affinityState),
-		ttlMinutes:   ttlMinutes,
+		ttlSeconds:   ttlSeconds,
 	}
 }
 
@@ -78,27 +104,36 @@ func newAffinityPolicy(affinityType api.ServiceAffinity, ttlMinutes int) *affini
 func NewLoadBalancerRR() *LoadBalancerRR {
 	return &LoadBalancerRR{
 		services: map[proxy.ServicePortName]*balancerState{},
+		pick:     pickRoundRobin,
 	}
 }
 
-func (lb *LoadBalancerRR) NewService(svcPort proxy.ServicePortName, affinityType api.ServiceAffinity, ttlMinutes int) error {
+// pickRoundRobin takes the next endpoint in turn.
+func pickRoundRobin(state *balancerState) string {
+	endpoint := state.endpoints[state.index]
+	state.index = (state.index + 1) % len(state.endpoints)
+	return endpoint
+}
+
+func (lb *LoadBalancerRR) NewService(svcPort proxy.ServicePortName, affinityType api.ServiceAffinity, ttlSeconds int) error {
 	lb.lock.Lock()
 	defer lb.lock.Unlock()
-	lb.newServiceInternal(svcPort, affinityType, ttlMinutes)
+	lb.newServiceInternal(svcPort, affinityType, ttlSeconds)
 	return nil
 }
 
 // This assumes that lb.lock is already held.
-func (lb *LoadBalancerRR) newServiceInternal(svcPort proxy.ServicePortName, affinityType api.ServiceAffinity, ttlMinutes int) *balancerState {
-	if ttlMinutes == 0 {
-		ttlMinutes = 180 //default to 3 hours if not specified.  Should 0 be unlimeted instead????
+func (lb *LoadBalancerRR) newServiceInternal(svcPort proxy.ServicePortName, affinityType api.ServiceAffinity, ttlSeconds int) *balancerState {
+	if ttlSeconds == 0 {
+		ttlSeconds = api.DefaultSessionAffinityTimeoutSeconds
 	}
 
 	if _, exists := lb.services[svcPort]; !exists {
-		lb.services[svcPort] = &balancerState{affinity: *newAffinityPolicy(affinityType, ttlMinutes)}
+		lb.services[svcPort] = &balancerState{affinity: *newAffinityPolicy(affinityType, ttlSeconds), connections: map[string]int{}}
 		glog.V(4).Infof("LoadBalancerRR service %q did not exist, created", svcPort)
 	} else if affinityType != "" {
 		lb.services[svcPort].affinity.affinityType = affinityType
+		lb.services[svcPort].affinity.ttlSeconds = ttlSeconds
 	}
 	return lb.services[svcPort]
 }
@@ -113,7 +148,8 @@ func isSessionAffinity(affinity *affinityPolicy) bool {
 }
 
 // NextEndpoint returns a service endpoint.
-// The service endpoint is chosen using the round-robin algorithm.
+// The service endpoint is chosen using the round-robin algorithm, unless the
+// client has a session with an endpoint.
 func (lb *LoadBalancerRR) NextEndpoint(svcPort proxy.ServicePortName, srcAddr net.Addr) (string, error) {
 	// Coarse locking is simple.  We can get more fine-grained if/when we
 	// can prove it matters.
@@ -140,7 +176,7 @@ func (lb *LoadBalancerRR) NextEndpoint(svcPort proxy.ServicePortName, srcAddr ne
 			return "", fmt.Errorf("malformed source address %q: %v", srcAddr.String(), err)
 		}
 		sessionAffinity, exists := state.affinity.affinityMap[ipaddr]
-		if exists && int(time.Now().Sub(sessionAffinity.lastUsed).Minutes()) < state.affinity.ttlMinutes {
+		if exists && int(time.Now().Sub(sessionAffinity.lastUsed).Seconds()) < state.affinity.ttlSeconds {
 			// Affinity wins.
 			endpoint := sessionAffinity.endpoint
 			sessionAffinity.lastUsed = time.Now()
@@ -149,8 +185,7 @@ func (lb *LoadBalancerRR) NextEndpoint(svcPort proxy.ServicePortName, srcAddr ne
 		}
 	}
 	// Take the next endpoint.
-	endpoint := state.endpoints[state.index]
-	state.index = (state.index + 1) % len(state.endpoints)
+	endpoint := lb.pick(state)
 
 	if sessionAffinityEnabled {
 		var affinity *affinityState
@@ -234,6 +269,7 @@ func (lb *LoadBalancerRR) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 	// Update endpoints for services.
 	for i := range allEndpoints {
 		svcEndpoints := &allEndpoints[i]
+		ipWeights := endpointWeights(svcEndpoints)
 
 		// We need to build a map of portname -> all ip:ports for that
 		// portname.  Explode Endpoints.Subsets[*] into this structure.
@@ -271,6 +307,24 @@ func (lb *LoadBalancerRR) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 
 				// Reset the round-robin index.
 				state.index = 0
+				state.currentWeights = nil
+				// Forget the connections of endpoints which went away.
+				current := util.NewStringSet(state.endpoints...)
+				for endpoint := range state.connections {
+					if !current.Has(endpoint) {
+						delete(state.connections, endpoint)
+					}
+				}
+			}
+			state.weights = map[string]int{}
+			for _, endpoint := range state.endpoints {
+				host, _, err := net.SplitHostPort(endpoint)
+				if err != nil {
+					continue
+				}
+				if w, found := ipWeights[host]; found {
+					state.weights[endpoint] = w
+				}
 			}
 			registeredEndpoints[svcPort] = true
 		}
@@ -284,6 +338,27 @@ func (lb *LoadBalancerRR) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
 	}
 }
 
+// endpointWeights returns the weights of the addresses of endpoints, from its
+// EndpointWeightsAnnotation.  Invalid weights are ignored.
+func endpointWeights(endpoints *api.Endpoints) map[string]int {
+	value, found := endpoints.Annotations[EndpointWeightsAnnotation]
+	if !found {
+		return nil
+	}
+	weights := map[string]int{}
+	if err := json.Unmarshal([]byte(value), &weights); err != nil {
+		glog.Errorf("Ignoring the endpoint weights of %s/%s: %v", endpoints.Namespace, endpoints.Name, err)
+		return nil
+	}
+	for ip, w := range weights {
+		if w <= 0 {
+			glog.Errorf("Ignoring the weight %d of %s in %s/%s: weights must be positive", w, ip, endpoints.Namespace, endpoints.Name)
+			delete(weights, ip)
+		}
+	}
+	return weights
+}
+
 // Tests whether two slices are equivalent.  This sorts both slices in-place.
 func slicesEquiv(lhs, rhs []string) bool {
 	if len(lhs) != len(rhs) {
@@ -304,9 +379,35 @@ func (lb *LoadBalancerRR) CleanupStaleStickySessions(svcPort proxy.ServicePortNa
 		return
 	}
 	for ip, affinity := range state.affinity.affinityMap {
-		if int(time.Now().Sub(affinity.lastUsed).Minutes()) >= state.affinity.ttlMinutes {
+		if int(time.Now().Sub(affinity.lastUsed).Seconds()) >= state.affinity.ttlSeconds {
 			glog.V(4).Infof("Removing client %s from affinityMap for service %q", affinity.clientIP, svcPort)
 			delete(state.affinity.affinityMap, ip)
 		}
 	}
 }
+
+func (lb *LoadBalancerRR) ConnectionOpened(svcPort proxy.ServicePortName, endpoint string) {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+
+	state, exists := lb.services[svcPort]
+	if !exists {
+		return
+	}
+	state.connections[endpoint]++
+}
+
+func (lb *LoadBalancerRR) ConnectionClosed(svcPort proxy.ServicePortName, endpoint string) {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+
+	state, exists := lb.services[svcPort]
+	if !exists {
+		return
+	}
+	// The count is gone if the endpoint was removed while the connection
+	// was open.
+	if state.connections[endpoint] > 0 {
+		state.connections[endpoint]--
+	}
+}
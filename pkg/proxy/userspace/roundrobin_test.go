@@ -153,6 +153,28 @@ func TestLoadBalanceWorksWithMultipleEndpoints(t *testing.T) {
 	expectEndpoint(t, loadBalancer, service, shuffledEndpoints[0], nil)
 }
 
+func TestLoadBalanceSkipsNotReadyAddresses(t *testing.T) {
+	loadBalancer := NewLoadBalancerRR()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	endpoints := make([]api.Endpoints, 1)
+	endpoints[0] = api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+		Subsets: []api.EndpointSubset{{
+			Addresses:         []api.EndpointAddress{{IP: "endpoint1"}},
+			NotReadyAddresses: []api.EndpointAddress{{IP: "endpoint2"}},
+			Ports:             []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+
+	shuffledEndpoints := loadBalancer.services[service].endpoints
+	if len(shuffledEndpoints) != 1 || shuffledEndpoints[0] != "endpoint1:1" {
+		t.Errorf("expected only the ready endpoint, got %v", shuffledEndpoints)
+	}
+	expectEndpoint(t, loadBalancer, service, "endpoint1:1", nil)
+	expectEndpoint(t, loadBalancer, service, "endpoint1:1", nil)
+}
+
 func TestLoadBalanceWorksWithMultipleEndpointsMultiplePorts(t *testing.T) {
 	loadBalancer := NewLoadBalancerRR()
 	serviceP := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
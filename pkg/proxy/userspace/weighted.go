@@ -0,0 +1,63 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userspace
+
+import (
+	"k8s.io/kubernetes/pkg/proxy"
+)
+
+// LoadBalancerWeighted is a weighted round-robin load balancer: each endpoint
+// gets new sessions in proportion to its weight, see
+// EndpointWeightsAnnotation.  Sessions of heavier endpoints are interleaved
+// with the others rather than handed out in bursts.
+type LoadBalancerWeighted struct {
+	*LoadBalancerRR
+}
+
+// Ensure this implements LoadBalancer.
+var _ LoadBalancer = &LoadBalancerWeighted{}
+
+// NewLoadBalancerWeighted returns a new LoadBalancerWeighted.
+func NewLoadBalancerWeighted() *LoadBalancerWeighted {
+	return &LoadBalancerWeighted{
+		LoadBalancerRR: &LoadBalancerRR{
+			services: map[proxy.ServicePortName]*balancerState{},
+			pick:     pickWeightedRoundRobin,
+		},
+	}
+}
+
+// pickWeightedRoundRobin is the smooth weighted round-robin: every endpoint
+// earns its weight on each pick, and the richest endpoint is picked and pays
+// the total weight.
+func pickWeightedRoundRobin(state *balancerState) string {
+	if state.currentWeights == nil {
+		state.currentWeights = map[string]int{}
+	}
+	total := 0
+	best := ""
+	for _, endpoint := range state.endpoints {
+		w := state.weight(endpoint)
+		total += w
+		state.currentWeights[endpoint] += w
+		if best == "" || state.currentWeights[endpoint] > state.currentWeights[best] {
+			best = endpoint
+		}
+	}
+	state.currentWeights[best] -= total
+	return best
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userspace
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/proxy"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+func TestEndpointWeights(t *testing.T) {
+	testCases := []struct {
+		annotations map[string]string
+		expected    map[string]int
+	}{
+		{nil, nil},
+		{map[string]string{EndpointWeightsAnnotation: `{"1.2.3.4": 2, "1.2.3.5": 1}`}, map[string]int{"1.2.3.4": 2, "1.2.3.5": 1}},
+		{map[string]string{EndpointWeightsAnnotation: `{"1.2.3.4": 2, "1.2.3.5": 0, "1.2.3.6": -1}`}, map[string]int{"1.2.3.4": 2}},
+		{map[string]string{EndpointWeightsAnnotation: `not json`}, nil},
+		{map[string]string{EndpointWeightsAnnotation: `{"1.2.3.4": "2"}`}, nil},
+	}
+	for i, tc := range testCases {
+		endpoints := &api.Endpoints{ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: "bar", Annotations: tc.annotations}}
+		if weights := endpointWeights(endpoints); !reflect.DeepEqual(weights, tc.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, tc.expected, weights)
+		}
+	}
+}
+
+func TestWeightedLoadBalancing(t *testing.T) {
+	loadBalancer := NewLoadBalancerWeighted()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	endpoints := []api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: map[string]string{EndpointWeightsAnnotation: `{"endpoint1": 3}`},
+		},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "endpoint1"}, {IP: "endpoint2"}},
+			Ports:     []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		endpoint, err := loadBalancer.NextEndpoint(service, nil)
+		if err != nil {
+			t.Fatalf("Didn't find a service for %s: %v", service, err)
+		}
+		counts[endpoint]++
+		// endpoint2 comes once in every round of four.
+		if i%4 == 3 && counts["endpoint2:1"] != i/4+1 {
+			t.Errorf("Expected endpoint2:1 to be picked %d times after %d picks, got %v", i/4+1, i+1, counts)
+		}
+	}
+	if expected := map[string]int{"endpoint1:1": 6, "endpoint2:1": 2}; !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+
+	// Without weights every endpoint takes its turn.
+	endpoints[0].Annotations = nil
+	loadBalancer.OnEndpointsUpdate(endpoints)
+	counts = map[string]int{}
+	for i := 0; i < 4; i++ {
+		endpoint, err := loadBalancer.NextEndpoint(service, nil)
+		if err != nil {
+			t.Fatalf("Didn't find a service for %s: %v", service, err)
+		}
+		counts[endpoint]++
+	}
+	if expected := map[string]int{"endpoint1:1": 2, "endpoint2:1": 2}; !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %v, got %v", expected, counts)
+	}
+}
+
+func TestWeightedLoadBalancingKeepsSessionAffinity(t *testing.T) {
+	loadBalancer := NewLoadBalancerWeighted()
+	service := proxy.ServicePortName{NamespacedName: types.NamespacedName{Namespace: "testnamespace", Name: "foo"}, Port: "p"}
+	loadBalancer.NewService(service, api.ServiceAffinityClientIP, 60)
+	endpoints := []api.Endpoints{{
+		ObjectMeta: api.ObjectMeta{
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: map[string]string{EndpointWeightsAnnotation: `{"endpoint1": 3}`},
+		},
+		Subsets: []api.EndpointSubset{{
+			Addresses: []api.EndpointAddress{{IP: "endpoint1"}, {IP: "endpoint2"}},
+			Ports:     []api.EndpointPort{{Name: "p", Port: 1}},
+		}},
+	}}
+	loadBalancer.OnEndpointsUpdate(endpoints)
+	client := &net.TCPAddr{IP: net.IPv4(3, 3, 3, 3), Port: 0}
+
+	first, err := loadBalancer.NextEndpoint(service, client)
+	if err != nil {
+		t.Fatalf("Didn't find a service for %s: %v", service, err)
+	}
+	for i := 0; i < 4; i++ {
+		expectEndpoint(t, loadBalancer.LoadBalancerRR, service, first, client)
+	}
+}
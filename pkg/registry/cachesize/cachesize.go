@@ -0,0 +1,86 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cachesize holds the capacities of the watch caches of all
+// resources served by the master.
+package cachesize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resource identifies a resource whose watch cache capacity can be set.
+type Resource string
+
+const (
+	Controllers              Resource = "controllers"
+	Daemons                  Resource = "daemons"
+	Endpoints                Resource = "endpoints"
+	Events                   Resource = "events"
+	HorizontalPodAutoscalers Resource = "horizontalpodautoscalers"
+	LimitRanges              Resource = "limitranges"
+	Namespaces               Resource = "namespaces"
+	NetworkPolicies          Resource = "networkpolicies"
+	Nodes                    Resource = "nodes"
+	PersistentVolumes        Resource = "persistentvolumes"
+	PersistentVolumeClaims   Resource = "persistentvolumeclaims"
+	Pods                     Resource = "pods"
+	PodTemplates             Resource = "podtemplates"
+	ResourceQuotas           Resource = "resourcequotas"
+	Secrets                  Resource = "secrets"
+	ServiceAccounts          Resource = "serviceaccounts"
+	Services                 Resource = "services"
+	ThirdPartyResources      Resource = "thirdpartyresources"
+)
+
+// defaultWatchCacheSize is the capacity of resources not listed in
+// watchCacheSizes.
+const defaultWatchCacheSize = 100
+
+// watchCacheSizes holds the capacities of frequently changing resources.
+var watchCacheSizes = map[Resource]int{
+	Endpoints: 1000,
+	Events:    1000,
+	Nodes:     1000,
+	Pods:      1000,
+}
+
+// SetWatchCacheSizes overrides the default capacities with the given list of
+// resource#size entries, e.g. "pods#5000".
+func SetWatchCacheSizes(cacheSizes []string) error {
+	for _, c := range cacheSizes {
+		tokens := strings.Split(c, "#")
+		if len(tokens) != 2 {
+			return fmt.Errorf("invalid watch cache size %q, expected resource#size", c)
+		}
+		size, err := strconv.Atoi(tokens[1])
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid watch cache size %q, size must be a positive integer", c)
+		}
+		watchCacheSizes[Resource(strings.ToLower(tokens[0]))] = size
+	}
+	return nil
+}
+
+// GetWatchCacheSizeByResource returns the watch cache capacity of the given resource.
+func GetWatchCacheSizeByResource(resource Resource) int {
+	if size, found := watchCacheSizes[resource]; found {
+		return size
+	}
+	return defaultWatchCacheSize
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachesize
+
+import (
+	"testing"
+)
+
+func TestSetWatchCacheSizes(t *testing.T) {
+	defer func(saved map[Resource]int) { watchCacheSizes = saved }(watchCacheSizes)
+	watchCacheSizes = map[Resource]int{Pods: 1000}
+
+	if err := SetWatchCacheSizes([]string{"Pods#5000", "secrets#20"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	table := map[Resource]int{
+		Pods:     5000,
+		Secrets:  20,
+		Services: defaultWatchCacheSize,
+	}
+	for resource, expected := range table {
+		if actual := GetWatchCacheSizeByResource(resource); actual != expected {
+			t.Errorf("%s: expected %d, got %d", resource, expected, actual)
+		}
+	}
+
+	for _, invalid := range []string{"pods", "pods#", "pods#-1", "pods#1#2"} {
+		if err := SetWatchCacheSizes([]string{invalid}); err == nil {
+			t.Errorf("%s: expected error", invalid)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
 	"k8s.io/kubernetes/pkg/registry/registrytest"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -41,7 +42,7 @@ const (
 
 func newStorage(t *testing.T) (*REST, *tools.FakeEtcdClient) {
 	etcdStorage, fakeClient := registrytest.NewEtcdStorage(t)
-	return NewREST(etcdStorage), fakeClient
+	return NewREST(etcdStorage, generic.UndecoratedStorage), fakeClient
 }
 
 // createController is a helper function that returns a controller with the updated resource version.
@@ -327,7 +328,6 @@ func TestEtcdWatchControllersFields(t *testing.T) {
 		FAIL: {
 			{"status.replicas": "10"},
 			{"metadata.name": "bar"},
-			{"name": "foo"},
 			{"status.replicas": "10", "metadata.name": "foo"},
 			{"status.replicas": "0", "metadata.name": "bar"},
 		},
@@ -19,10 +19,10 @@ package controller
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -101,12 +101,13 @@ func (rcStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// ControllerToSelectableFields returns a label set that represents the object.
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.ReplicationController{}, "metadata.name", "status.replicas")
+
+var SelectableFields = fieldPaths.Names()
+
+// ControllerToSelectableFields returns a field set that represents the object.
 func ControllerToSelectableFields(controller *api.ReplicationController) fields.Set {
-	return fields.Set{
-		"metadata.name":   controller.Name,
-		"status.replicas": strconv.Itoa(controller.Status.Replicas),
-	}
+	return fieldPaths.Extract(controller)
 }
 
 // MatchController is the filter used by the generic etcd backend to route
@@ -117,11 +118,11 @@ func MatchController(label labels.Selector, field fields.Selector) generic.Match
 		Label: label,
 		Field: field,
 		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
-			rc, ok := obj.(*api.ReplicationController)
+			controller, ok := obj.(*api.ReplicationController)
 			if !ok {
-				return nil, nil, fmt.Errorf("Given object is not a replication controller.")
+				return nil, nil, fmt.Errorf("not a replication controller")
 			}
-			return labels.Set(rc.ObjectMeta.Labels), ControllerToSelectableFields(rc), nil
+			return labels.Set(controller.Labels), ControllerToSelectableFields(controller), nil
 		},
 	}
 }
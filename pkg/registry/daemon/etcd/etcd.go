@@ -21,6 +21,7 @@ import (
 	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/cachesize"
 	"k8s.io/kubernetes/pkg/registry/daemon"
 	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
@@ -38,12 +39,16 @@ type REST struct {
 var daemonPrefix = "/daemons"
 
 // NewREST returns a RESTStorage object that will work against daemons.
-func NewREST(s storage.Interface) *REST {
+func NewREST(s storage.Interface, storageDecorator generic.StorageDecorator) *REST {
+	newListFunc := func() runtime.Object { return &expapi.DaemonList{} }
+	storageInterface := storageDecorator(
+		s, cachesize.GetWatchCacheSizeByResource(cachesize.Daemons), &expapi.Daemon{}, daemonPrefix, true, newListFunc)
+
 	store := &etcdgeneric.Etcd{
 		NewFunc: func() runtime.Object { return &expapi.Daemon{} },
 
 		// NewListFunc returns an object capable of storing results of an etcd list.
-		NewListFunc: func() runtime.Object { return &expapi.DaemonList{} },
+		NewListFunc: newListFunc,
 		// Produces a path that etcd understands, to the root of the resource
 		// by combining the namespace in the context with the given prefix
 		KeyRootFunc: func(ctx api.Context) string {
@@ -62,7 +67,8 @@ func NewREST(s storage.Interface) *REST {
 		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
 			return daemon.MatchDaemon(label, field)
 		},
-		EndpointName: "daemons",
+		SelectableFields: daemon.SelectableFields,
+		EndpointName:     "daemons",
 
 		// Used to validate daemon creation
 		CreateStrategy: daemon.Strategy,
@@ -70,7 +76,7 @@ func NewREST(s storage.Interface) *REST {
 		// Used to validate daemon updates
 		UpdateStrategy: daemon.Strategy,
 
-		Storage: s,
+		Storage: storageInterface,
 	}
 
 	return &REST{store}
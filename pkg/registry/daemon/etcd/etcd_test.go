@@ -27,6 +27,7 @@ import (
 	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
 	"k8s.io/kubernetes/pkg/registry/registrytest"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -42,7 +43,7 @@ const (
 
 func newStorage(t *testing.T) (*REST, *tools.FakeEtcdClient) {
 	etcdStorage, fakeClient := registrytest.NewEtcdStorage(t)
-	return NewREST(etcdStorage), fakeClient
+	return NewREST(etcdStorage, generic.UndecoratedStorage), fakeClient
 }
 
 // createController is a helper function that returns a controller with the updated resource version.
@@ -63,7 +64,7 @@ func validNewDaemon() *expapi.Daemon {
 			Namespace: api.NamespaceDefault,
 		},
 		Spec: expapi.DaemonSpec{
-			Selector: map[string]string{"a": "b"},
+			Selector: &expapi.LabelSelector{MatchLabels: map[string]string{"a": "b"}},
 			Template: &api.PodTemplateSpec{
 				ObjectMeta: api.ObjectMeta{
 					Labels: map[string]string{"a": "b"},
@@ -103,7 +104,7 @@ func TestCreate(t *testing.T) {
 		// invalid (invalid selector)
 		&expapi.Daemon{
 			Spec: expapi.DaemonSpec{
-				Selector: map[string]string{},
+				Selector: &expapi.LabelSelector{},
 				Template: validDaemon.Spec.Template,
 			},
 		},
@@ -149,7 +150,7 @@ func TestUpdate(t *testing.T) {
 		},
 		func(obj runtime.Object) runtime.Object {
 			object := obj.(*expapi.Daemon)
-			object.Spec.Selector = map[string]string{}
+			object.Spec.Selector = &expapi.LabelSelector{}
 			return object
 		},
 	)
@@ -233,7 +234,7 @@ func TestEtcdWatchControllersMatch(t *testing.T) {
 	fakeClient.ExpectNotFoundGet(etcdgeneric.NamespaceKeyRootFunc(ctx, "/registry/pods"))
 
 	watching, err := storage.Watch(ctx,
-		labels.SelectorFromSet(validDaemon.Spec.Selector),
+		labels.SelectorFromSet(validDaemon.Spec.Selector.MatchLabels),
 		fields.Everything(),
 		"1",
 	)
@@ -248,7 +249,7 @@ func TestEtcdWatchControllersMatch(t *testing.T) {
 	controller := &expapi.Daemon{
 		ObjectMeta: api.ObjectMeta{
 			Name:      "foo",
-			Labels:    validDaemon.Spec.Selector,
+			Labels:    validDaemon.Spec.Selector.MatchLabels,
 			Namespace: "default",
 		},
 	}
@@ -282,7 +283,6 @@ func TestEtcdWatchControllersFields(t *testing.T) {
 		},
 		FAIL: {
 			{"metadata.name": "bar"},
-			{"name": "foo"},
 		},
 	}
 	testEtcdActions := []string{
@@ -294,7 +294,7 @@ func TestEtcdWatchControllersFields(t *testing.T) {
 	controller := &expapi.Daemon{
 		ObjectMeta: api.ObjectMeta{
 			Name:      "foo",
-			Labels:    validDaemon.Spec.Selector,
+			Labels:    validDaemon.Spec.Selector.MatchLabels,
 			Namespace: "default",
 		},
 		Status: expapi.DaemonStatus{
@@ -23,6 +23,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/expapi/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -97,11 +98,13 @@ func (daemonStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
+var fieldPaths = fieldpath.MustNewFieldPaths(&expapi.Daemon{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
 // DaemonToSelectableFields returns a field set that represents the object.
 func DaemonToSelectableFields(daemon *expapi.Daemon) fields.Set {
-	return fields.Set{
-		"metadata.name": daemon.Name,
-	}
+	return fieldPaths.Extract(daemon)
 }
 
 // MatchDaemon is the filter used by the generic etcd backend to route
@@ -114,9 +117,9 @@ func MatchDaemon(label labels.Selector, field fields.Selector) generic.Matcher {
 		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
 			daemon, ok := obj.(*expapi.Daemon)
 			if !ok {
-				return nil, nil, fmt.Errorf("given object is not a daemon.")
+				return nil, nil, fmt.Errorf("not a daemon")
 			}
-			return labels.Set(daemon.ObjectMeta.Labels), DaemonToSelectableFields(daemon), nil
+			return labels.Set(daemon.Labels), DaemonToSelectableFields(daemon), nil
 		},
 	}
 }
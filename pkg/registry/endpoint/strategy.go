@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	endptspkg "k8s.io/kubernetes/pkg/api/endpoints"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -77,19 +78,26 @@ func (endpointsStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// MatchEndpoints returns a generic matcher for a given label and field selector.
-func MatchEndpoints(label labels.Selector, field fields.Selector) generic.Matcher {
-	return &generic.SelectionPredicate{Label: label, Field: field, GetAttrs: EndpointsAttributes}
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Endpoints{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// EndpointsToSelectableFields returns a field set that represents the object.
+func EndpointsToSelectableFields(endpoints *api.Endpoints) fields.Set {
+	return fieldPaths.Extract(endpoints)
 }
 
-// EndpointsAttributes returns the attributes of an endpoint such that a
-// generic.SelectionPredicate can match appropriately.
-func EndpointsAttributes(obj runtime.Object) (objLabels labels.Set, objFields fields.Set, err error) {
-	endpoints, ok := obj.(*api.Endpoints)
-	if !ok {
-		return nil, nil, fmt.Errorf("invalid object type %#v", obj)
+// MatchEndpoints returns a generic matcher for a given label and field selector.
+func MatchEndpoints(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			endpoints, ok := obj.(*api.Endpoints)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a endpoints")
+			}
+			return labels.Set(endpoints.Labels), EndpointsToSelectableFields(endpoints), nil
+		},
 	}
-	return endpoints.Labels, fields.Set{
-		"metadata.name": endpoints.Name,
-	}, nil
 }
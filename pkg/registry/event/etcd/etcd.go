@@ -20,6 +20,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/cachesize"
 	"k8s.io/kubernetes/pkg/registry/event"
 	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
@@ -32,11 +33,16 @@ type REST struct {
 }
 
 // NewREST returns a RESTStorage object that will work against events.
-func NewREST(s storage.Interface, ttl uint64) *REST {
+func NewREST(s storage.Interface, storageDecorator generic.StorageDecorator, ttl uint64) *REST {
 	prefix := "/events"
+
+	newListFunc := func() runtime.Object { return &api.EventList{} }
+	storageInterface := storageDecorator(
+		s, cachesize.GetWatchCacheSizeByResource(cachesize.Events), &api.Event{}, prefix, true, newListFunc)
+
 	store := &etcdgeneric.Etcd{
 		NewFunc:     func() runtime.Object { return &api.Event{} },
-		NewListFunc: func() runtime.Object { return &api.EventList{} },
+		NewListFunc: newListFunc,
 		KeyRootFunc: func(ctx api.Context) string {
 			return etcdgeneric.NamespaceKeyRootFunc(ctx, prefix)
 		},
@@ -49,6 +55,7 @@ func NewREST(s storage.Interface, ttl uint64) *REST {
 		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
 			return event.MatchEvent(label, field)
 		},
+		SelectableFields: event.SelectableFields,
 		TTLFunc: func(runtime.Object, uint64, bool) (uint64, error) {
 			return ttl, nil
 		},
@@ -57,7 +64,7 @@ func NewREST(s storage.Interface, ttl uint64) *REST {
 		CreateStrategy: event.Strategy,
 		UpdateStrategy: event.Strategy,
 
-		Storage: s,
+		Storage: storageInterface,
 	}
 	return &REST{store}
 }
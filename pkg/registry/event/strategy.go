@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -66,6 +67,26 @@ func (eventStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Event{},
+	"metadata.name",
+	"involvedObject.kind",
+	"involvedObject.namespace",
+	"involvedObject.name",
+	"involvedObject.uid",
+	"involvedObject.apiVersion",
+	"involvedObject.resourceVersion",
+	"involvedObject.fieldPath",
+	"reason",
+	"source.component",
+)
+
+var SelectableFields = fieldPaths.Names()
+
+// EventToSelectableFields returns a field set that represents the event.
+func EventToSelectableFields(event *api.Event) fields.Set {
+	return fieldPaths.Extract(event)
+}
+
 func MatchEvent(label labels.Selector, field fields.Selector) generic.Matcher {
 	return &generic.SelectionPredicate{Label: label, Field: field, GetAttrs: getAttrs}
 }
@@ -79,16 +100,5 @@ func getAttrs(obj runtime.Object) (objLabels labels.Set, objFields fields.Set, e
 	if l == nil {
 		l = labels.Set{}
 	}
-	return l, fields.Set{
-		"metadata.name":                  event.Name,
-		"involvedObject.kind":            event.InvolvedObject.Kind,
-		"involvedObject.namespace":       event.InvolvedObject.Namespace,
-		"involvedObject.name":            event.InvolvedObject.Name,
-		"involvedObject.uid":             string(event.InvolvedObject.UID),
-		"involvedObject.apiVersion":      event.InvolvedObject.APIVersion,
-		"involvedObject.resourceVersion": fmt.Sprintf("%s", event.InvolvedObject.ResourceVersion),
-		"involvedObject.fieldPath":       event.InvolvedObject.FieldPath,
-		"reason":                         event.Reason,
-		"source":                         event.Source.Component,
-	}, nil
+	return l, EventToSelectableFields(event), nil
 }
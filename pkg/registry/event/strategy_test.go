@@ -72,7 +72,7 @@ func TestGetAttrs(t *testing.T) {
 		"involvedObject.resourceVersion": "0",
 		"involvedObject.fieldPath":       "",
 		"reason":                         "ForTesting",
-		"source":                         "test",
+		"source.component":               "test",
 	}
 	if e, a := expect, field; !reflect.DeepEqual(e, a) {
 		t.Errorf("diff: %s", util.ObjectDiff(e, a))
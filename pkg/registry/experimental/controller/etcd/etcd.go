@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/registry/generic"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/storage"
 
@@ -38,7 +39,7 @@ type ContainerStorage struct {
 }
 
 func NewStorage(s storage.Interface) ContainerStorage {
-	rcRegistry := controller.NewRegistry(etcd.NewREST(s))
+	rcRegistry := controller.NewRegistry(etcd.NewREST(s, generic.UndecoratedStorage))
 
 	return ContainerStorage{
 		ReplicationController: &RcREST{},
@@ -80,6 +80,11 @@ type Etcd struct {
 	// Returns a matcher corresponding to the provided labels and fields.
 	PredicateFunc func(label labels.Selector, field fields.Selector) generic.Matcher
 
+	// The field paths that List and Watch accept in field selectors. Selectors
+	// on any other field are rejected with a BadRequest error. If empty, field
+	// selectors are passed to PredicateFunc unchecked.
+	SelectableFields []string
+
 	// Called on all objects returned from the underlying store, after
 	// the exit hooks are invoked. Decorators are intended for integrations
 	// that are above etcd and should only be used for specific cases where
@@ -143,6 +148,9 @@ func (e *Etcd) NewList() runtime.Object {
 
 // List returns a list of items matching labels and field
 func (e *Etcd) List(ctx api.Context, label labels.Selector, field fields.Selector) (runtime.Object, error) {
+	if err := e.validateFieldSelector(field); err != nil {
+		return nil, err
+	}
 	return e.ListPredicate(ctx, e.PredicateFunc(label, field))
 }
 
@@ -440,6 +448,9 @@ func (e *Etcd) finalizeDelete(obj runtime.Object, runHooks bool) (runtime.Object
 // matcher that matches by key. generic.SelectionPredicate does this for you
 // automatically.
 func (e *Etcd) Watch(ctx api.Context, label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if err := e.validateFieldSelector(field); err != nil {
+		return nil, err
+	}
 	return e.WatchPredicate(ctx, e.PredicateFunc(label, field), resourceVersion)
 }
 
@@ -476,6 +487,14 @@ func (e *Etcd) WatchPredicate(ctx api.Context, m generic.Matcher, resourceVersio
 	return e.Storage.WatchList(e.KeyRootFunc(ctx), version, filterFunc)
 }
 
+// validateFieldSelector checks that field only selects on SelectableFields.
+func (e *Etcd) validateFieldSelector(field fields.Selector) error {
+	if len(e.SelectableFields) == 0 {
+		return nil
+	}
+	return generic.ValidateFieldSelector(e.EndpointName, field, e.SelectableFields)
+}
+
 // calculateTTL is a helper for retrieving the updated TTL for an object or returning an error
 // if the TTL cannot be calculated. The defaultTTL is changed to 1 if less than zero. Zero means
 // no TTL, not expire immediately.
@@ -24,6 +24,8 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/testapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
 	"k8s.io/kubernetes/pkg/runtime"
 	etcdstorage "k8s.io/kubernetes/pkg/storage/etcd"
@@ -606,3 +608,27 @@ func TestEtcdWatch(t *testing.T) {
 		}
 	}
 }
+
+func TestEtcdSelectableFields(t *testing.T) {
+	_, registry := NewTestGenericEtcdRegistry(t)
+	registry.SelectableFields = []string{"metadata.name", "spec.nodeName"}
+	registry.PredicateFunc = func(label labels.Selector, field fields.Selector) generic.Matcher {
+		return everythingMatcher{}
+	}
+	ctx := api.NewContext()
+
+	unsupported := fields.OneTermEqualSelector("status.hostIP", "10.0.0.1")
+	if _, err := registry.List(ctx, labels.Everything(), unsupported); !errors.IsBadRequest(err) {
+		t.Errorf("expected a bad request error from List, got %v", err)
+	}
+	if _, err := registry.Watch(ctx, labels.Everything(), unsupported, "1"); !errors.IsBadRequest(err) {
+		t.Errorf("expected a bad request error from Watch, got %v", err)
+	}
+
+	supported := fields.OneTermEqualSelector("spec.nodeName", "machine")
+	w, err := registry.Watch(ctx, labels.Everything(), supported, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.Stop()
+}
@@ -17,14 +17,38 @@ limitations under the License.
 package generic
 
 import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
 )
 
 // AttrFunc returns label and field sets for List or Watch to compare against, or an error.
 type AttrFunc func(obj runtime.Object) (label labels.Set, field fields.Set, err error)
 
+// ValidateFieldSelector returns a BadRequest error if the selector refers to
+// a field of the named resource that is not one of selectableFields.
+func ValidateFieldSelector(resource string, field fields.Selector, selectableFields []string) error {
+	if field == nil {
+		return nil
+	}
+	supported := util.NewStringSet(selectableFields...)
+	_, err := field.Transform(func(label, value string) (string, string, error) {
+		if !supported.Has(label) {
+			return "", "", fmt.Errorf("field label %q is not supported for %s; supported fields are: %s", label, resource, strings.Join(supported.List(), ", "))
+		}
+		return label, value, nil
+	})
+	if err != nil {
+		return errors.NewBadRequest(err.Error())
+	}
+	return nil
+}
+
 // SelectionPredicate implements a generic predicate that can be passed to
 // GenericRegistry's List or Watch methods. Implements the Matcher interface.
 type SelectionPredicate struct {
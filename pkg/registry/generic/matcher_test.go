@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	apierrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -166,3 +167,28 @@ func TestSingleMatch(t *testing.T) {
 		t.Errorf("Expected %#v, got %#v", e, a)
 	}
 }
+
+func TestValidateFieldSelector(t *testing.T) {
+	selectable := []string{"metadata.name", "spec.type"}
+	for _, selector := range []string{"", "metadata.name=foo", "spec.type!=ClusterIP,metadata.name=foo"} {
+		parsed, err := fields.ParseSelector(selector)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if err := ValidateFieldSelector("services", parsed, selectable); err != nil {
+			t.Errorf("%q: unexpected error %v", selector, err)
+		}
+	}
+
+	parsed, err := fields.ParseSelector("metadata.name=foo,spec.clusterIP=10.0.0.1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	err = ValidateFieldSelector("services", parsed, selectable)
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("Expected a bad request error, got %v", err)
+	}
+	if e, a := `field label "spec.clusterIP" is not supported for services; supported fields are: metadata.name, spec.type`, err.Error(); e != a {
+		t.Errorf("Expected %q, got %q", e, a)
+	}
+}
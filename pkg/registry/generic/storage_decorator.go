@@ -0,0 +1,80 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+)
+
+// watchProgressInterval is how often watchers served from the watch cache
+// receive a bookmark event.
+const watchProgressInterval = 30 * time.Second
+
+// StorageDecorator is a function signature for producing
+// a storage.Interface from given parameters.
+type StorageDecorator func(
+	storageInterface storage.Interface,
+	capacity int,
+	objectType runtime.Object,
+	resourcePrefix string,
+	namespaceScoped bool,
+	newListFunc func() runtime.Object) storage.Interface
+
+// UndecoratedStorage returns the given storageInterface without any decoration.
+func UndecoratedStorage(
+	storageInterface storage.Interface,
+	capacity int,
+	objectType runtime.Object,
+	resourcePrefix string,
+	namespaceScoped bool,
+	newListFunc func() runtime.Object) storage.Interface {
+	return storageInterface
+}
+
+// StorageWithCacher returns a Cacher on top of the given storageInterface that
+// keeps the last capacity changes of the resource in memory and serves watches
+// from there.
+func StorageWithCacher(
+	storageInterface storage.Interface,
+	capacity int,
+	objectType runtime.Object,
+	resourcePrefix string,
+	namespaceScoped bool,
+	newListFunc func() runtime.Object) storage.Interface {
+	config := storage.CacherConfig{
+		CacheCapacity:    capacity,
+		Storage:          storageInterface,
+		Versioner:        storageInterface.Versioner(),
+		Type:             objectType,
+		ResourcePrefix:   resourcePrefix,
+		NewListFunc:      newListFunc,
+		ProgressInterval: watchProgressInterval,
+	}
+	if namespaceScoped {
+		config.KeyFunc = func(obj runtime.Object) (string, error) {
+			return storage.NamespaceKeyFunc(resourcePrefix, obj)
+		}
+	} else {
+		config.KeyFunc = func(obj runtime.Object) (string, error) {
+			return storage.NoNamespaceKeyFunc(resourcePrefix, obj)
+		}
+	}
+	return storage.NewCacher(config)
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/expapi/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -74,13 +75,26 @@ func (autoscalerStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
+var fieldPaths = fieldpath.MustNewFieldPaths(&expapi.HorizontalPodAutoscaler{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// HorizontalPodAutoscalerToSelectableFields returns a field set that represents the object.
+func HorizontalPodAutoscalerToSelectableFields(autoscaler *expapi.HorizontalPodAutoscaler) fields.Set {
+	return fieldPaths.Extract(autoscaler)
+}
+
 // MatchAutoscaler returns a generic matcher for a given label and field selector.
 func MatchAutoscaler(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		autoscaler, ok := obj.(*expapi.HorizontalPodAutoscaler)
-		if !ok {
-			return false, fmt.Errorf("not a horizontal pod autoscaler")
-		}
-		return label.Matches(labels.Set(autoscaler.Labels)), nil
-	})
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			autoscaler, ok := obj.(*expapi.HorizontalPodAutoscaler)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a horizontal pod autoscaler")
+			}
+			return labels.Set(autoscaler.Labels), HorizontalPodAutoscalerToSelectableFields(autoscaler), nil
+		},
+	}
 }
@@ -17,8 +17,11 @@ limitations under the License.
 package limitrange
 
 import (
+	"fmt"
+
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -68,10 +71,25 @@ func (limitrangeStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-func MatchLimitRange(label labels.Selector, field fields.Selector) generic.Matcher {
-	return &generic.SelectionPredicate{Label: label, Field: field, GetAttrs: getAttrs}
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.LimitRange{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// LimitRangeToSelectableFields returns a field set that represents the object.
+func LimitRangeToSelectableFields(limitRange *api.LimitRange) fields.Set {
+	return fieldPaths.Extract(limitRange)
 }
 
-func getAttrs(obj runtime.Object) (objLabels labels.Set, objFields fields.Set, err error) {
-	return labels.Set{}, fields.Set{}, nil
+func MatchLimitRange(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			limitRange, ok := obj.(*api.LimitRange)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a limit range")
+			}
+			return labels.Set(limitRange.Labels), LimitRangeToSelectableFields(limitRange), nil
+		},
+	}
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/validation"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/master/ports"
@@ -112,12 +113,13 @@ type ResourceGetter interface {
 	Get(api.Context, string) (runtime.Object, error)
 }
 
-// NodeToSelectableFields returns a label set that represents the object.
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Node{}, "metadata.name", "spec.unschedulable")
+
+var SelectableFields = fieldPaths.Names()
+
+// NodeToSelectableFields returns a field set that represents the object.
 func NodeToSelectableFields(node *api.Node) fields.Set {
-	return fields.Set{
-		"metadata.name":      node.Name,
-		"spec.unschedulable": fmt.Sprint(node.Spec.Unschedulable),
-	}
+	return fieldPaths.Extract(node)
 }
 
 // MatchNode returns a generic matcher for a given label and field selector.
@@ -126,11 +128,11 @@ func MatchNode(label labels.Selector, field fields.Selector) generic.Matcher {
 		Label: label,
 		Field: field,
 		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
-			nodeObj, ok := obj.(*api.Node)
+			node, ok := obj.(*api.Node)
 			if !ok {
 				return nil, nil, fmt.Errorf("not a node")
 			}
-			return labels.Set(nodeObj.ObjectMeta.Labels), NodeToSelectableFields(nodeObj), nil
+			return labels.Set(node.Labels), NodeToSelectableFields(node), nil
 		},
 	}
 }
@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -130,23 +131,26 @@ func (namespaceFinalizeStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newNamespace.Status = oldNamespace.Status
 }
 
-// MatchNamespace returns a generic matcher for a given label and field selector.
-func MatchNamespace(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		namespaceObj, ok := obj.(*api.Namespace)
-		if !ok {
-			return false, fmt.Errorf("not a namespace")
-		}
-		fields := NamespaceToSelectableFields(namespaceObj)
-		return label.Matches(labels.Set(namespaceObj.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Namespace{}, "metadata.name", "status.phase")
+
+var SelectableFields = fieldPaths.Names()
+
+// NamespaceToSelectableFields returns a field set that represents the object
+func NamespaceToSelectableFields(namespace *api.Namespace) fields.Set {
+	return fieldPaths.Extract(namespace)
 }
 
-// NamespaceToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func NamespaceToSelectableFields(namespace *api.Namespace) labels.Set {
-	return labels.Set{
-		"name":         namespace.Name,
-		"status.phase": string(namespace.Status.Phase),
+// MatchNamespace returns a generic matcher for a given label and field selector.
+func MatchNamespace(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			namespace, ok := obj.(*api.Namespace)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a namespace")
+			}
+			return labels.Set(namespace.Labels), NamespaceToSelectableFields(namespace), nil
+		},
 	}
 }
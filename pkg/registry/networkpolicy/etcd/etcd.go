@@ -0,0 +1,83 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/cachesize"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
+	"k8s.io/kubernetes/pkg/registry/networkpolicy"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+)
+
+// rest implements a RESTStorage for network policies against etcd
+type REST struct {
+	*etcdgeneric.Etcd
+}
+
+// networkPolicyPrefix is the location for network policies in etcd, only exposed
+// for testing
+var networkPolicyPrefix = "/networkpolicies"
+
+// NewREST returns a RESTStorage object that will work against network policies.
+func NewREST(s storage.Interface, storageDecorator generic.StorageDecorator) *REST {
+	newListFunc := func() runtime.Object { return &expapi.NetworkPolicyList{} }
+	storageInterface := storageDecorator(
+		s, cachesize.GetWatchCacheSizeByResource(cachesize.NetworkPolicies), &expapi.NetworkPolicy{}, networkPolicyPrefix, true, newListFunc)
+
+	store := &etcdgeneric.Etcd{
+		NewFunc: func() runtime.Object { return &expapi.NetworkPolicy{} },
+
+		// NewListFunc returns an object capable of storing results of an etcd list.
+		NewListFunc: newListFunc,
+		// Produces a path that etcd understands, to the root of the resource
+		// by combining the namespace in the context with the given prefix
+		KeyRootFunc: func(ctx api.Context) string {
+			return etcdgeneric.NamespaceKeyRootFunc(ctx, networkPolicyPrefix)
+		},
+		// Produces a path that etcd understands, to the resource by combining
+		// the namespace in the context with the given prefix
+		KeyFunc: func(ctx api.Context, name string) (string, error) {
+			return etcdgeneric.NamespaceKeyFunc(ctx, networkPolicyPrefix, name)
+		},
+		// Retrieve the name field of a network policy
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*expapi.NetworkPolicy).Name, nil
+		},
+		// Used to match objects based on labels/fields for list and watch
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return networkpolicy.MatchNetworkPolicy(label, field)
+		},
+		SelectableFields: networkpolicy.SelectableFields,
+		EndpointName:     "networkpolicies",
+
+		// Used to validate network policy creation
+		CreateStrategy: networkpolicy.Strategy,
+
+		// Used to validate network policy updates
+		UpdateStrategy: networkpolicy.Strategy,
+
+		Storage: storageInterface,
+	}
+
+	return &REST{store}
+}
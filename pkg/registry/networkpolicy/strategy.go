@@ -0,0 +1,102 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
+	"k8s.io/kubernetes/pkg/expapi/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/fielderrors"
+)
+
+// networkPolicyStrategy implements verification logic for network policies.
+type networkPolicyStrategy struct {
+	runtime.ObjectTyper
+	api.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating and updating NetworkPolicy objects.
+var Strategy = networkPolicyStrategy{api.Scheme, api.SimpleNameGenerator}
+
+// NamespaceScoped returns true because all network policies need to be within a namespace.
+func (networkPolicyStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (networkPolicyStrategy) PrepareForCreate(obj runtime.Object) {
+	_ = obj.(*expapi.NetworkPolicy)
+}
+
+// PrepareForUpdate clears fields that are not allowed to be set by end users on update.
+func (networkPolicyStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	_ = obj.(*expapi.NetworkPolicy)
+}
+
+// Validate validates a new network policy.
+func (networkPolicyStrategy) Validate(ctx api.Context, obj runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateNetworkPolicy(obj.(*expapi.NetworkPolicy))
+}
+
+// AllowCreateOnUpdate is false for network policies; this means a POST is
+// needed to create one.
+func (networkPolicyStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (networkPolicyStrategy) ValidateUpdate(ctx api.Context, obj, old runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateNetworkPolicyUpdate(old.(*expapi.NetworkPolicy), obj.(*expapi.NetworkPolicy))
+}
+
+// AllowUnconditionalUpdate is the default update policy for network policy objects.
+func (networkPolicyStrategy) AllowUnconditionalUpdate() bool {
+	return true
+}
+
+var fieldPaths = fieldpath.MustNewFieldPaths(&expapi.NetworkPolicy{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// NetworkPolicyToSelectableFields returns a field set that represents the object.
+func NetworkPolicyToSelectableFields(policy *expapi.NetworkPolicy) fields.Set {
+	return fieldPaths.Extract(policy)
+}
+
+// MatchNetworkPolicy is the filter used by the generic etcd backend to route
+// watch events from etcd to clients of the apiserver only interested in specific
+// labels/fields.
+func MatchNetworkPolicy(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			policy, ok := obj.(*expapi.NetworkPolicy)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a network policy")
+			}
+			return labels.Set(policy.Labels), NetworkPolicyToSelectableFields(policy), nil
+		},
+	}
+}
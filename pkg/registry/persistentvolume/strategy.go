@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -90,22 +91,26 @@ func (persistentvolumeStatusStrategy) ValidateUpdate(ctx api.Context, obj, old r
 	return validation.ValidatePersistentVolumeStatusUpdate(obj.(*api.PersistentVolume), old.(*api.PersistentVolume))
 }
 
-// MatchPersistentVolume returns a generic matcher for a given label and field selector.
-func MatchPersistentVolumes(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		persistentvolumeObj, ok := obj.(*api.PersistentVolume)
-		if !ok {
-			return false, fmt.Errorf("not a persistentvolume")
-		}
-		fields := PersistentVolumeToSelectableFields(persistentvolumeObj)
-		return label.Matches(labels.Set(persistentvolumeObj.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.PersistentVolume{}, "metadata.name", "status.phase")
+
+var SelectableFields = fieldPaths.Names()
+
+// PersistentVolumeToSelectableFields returns a field set that represents the object
+func PersistentVolumeToSelectableFields(volume *api.PersistentVolume) fields.Set {
+	return fieldPaths.Extract(volume)
 }
 
-// PersistentVolumeToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func PersistentVolumeToSelectableFields(persistentvolume *api.PersistentVolume) labels.Set {
-	return labels.Set{
-		"name": persistentvolume.Name,
+// MatchPersistentVolume returns a generic matcher for a given label and field selector.
+func MatchPersistentVolumes(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			volume, ok := obj.(*api.PersistentVolume)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a persistent volume")
+			}
+			return labels.Set(volume.Labels), PersistentVolumeToSelectableFields(volume), nil
+		},
 	}
 }
@@ -30,6 +30,8 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/registry/pod"
 	"k8s.io/kubernetes/pkg/registry/registrytest"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/securitycontext"
@@ -42,7 +44,7 @@ import (
 
 func newStorage(t *testing.T) (*REST, *BindingREST, *StatusREST, *tools.FakeEtcdClient) {
 	etcdStorage, fakeClient := registrytest.NewEtcdStorage(t)
-	storage := NewStorage(etcdStorage, false, nil)
+	storage := NewStorage(etcdStorage, generic.UndecoratedStorage, nil)
 	return storage.Pod, storage.Binding, storage.Status, fakeClient
 }
 
@@ -81,6 +83,17 @@ func validChangedPod() *api.Pod {
 	return pod
 }
 
+func TestSelectableFieldsConvert(t *testing.T) {
+	for _, field := range pod.SelectableFields {
+		label, _, err := api.Scheme.ConvertFieldLabel(testapi.Version(), "Pod", field, "")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", field, err)
+		} else if label != field {
+			t.Errorf("%s: expected the same label, got %s", field, label)
+		}
+	}
+}
+
 func TestCreate(t *testing.T) {
 	storage, _, _, fakeClient := newStorage(t)
 	test := resttest.New(t, storage, fakeClient.SetError)
@@ -21,11 +21,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/validation"
 	client "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -108,6 +111,21 @@ func (podStatusStrategy) ValidateUpdate(ctx api.Context, obj, old runtime.Object
 	return validation.ValidatePodStatusUpdate(obj.(*api.Pod), old.(*api.Pod))
 }
 
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Pod{},
+	"metadata.name",
+	"metadata.namespace",
+	"spec.nodeName",
+	"status.phase",
+	"status.podIP",
+)
+
+var SelectableFields = fieldPaths.Names()
+
+// PodToSelectableFields returns a field set that represents the object
+func PodToSelectableFields(pod *api.Pod) fields.Set {
+	return fieldPaths.Extract(pod)
+}
+
 // MatchPod returns a generic matcher for a given label and field selector.
 func MatchPod(label labels.Selector, field fields.Selector) generic.Matcher {
 	return &generic.SelectionPredicate{
@@ -118,21 +136,11 @@ func MatchPod(label labels.Selector, field fields.Selector) generic.Matcher {
 			if !ok {
 				return nil, nil, fmt.Errorf("not a pod")
 			}
-			return labels.Set(pod.ObjectMeta.Labels), PodToSelectableFields(pod), nil
+			return labels.Set(pod.Labels), PodToSelectableFields(pod), nil
 		},
 	}
 }
 
-// PodToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func PodToSelectableFields(pod *api.Pod) fields.Set {
-	return fields.Set{
-		"metadata.name": pod.Name,
-		"spec.nodeName": pod.Spec.NodeName,
-		"status.phase":  string(pod.Status.Phase),
-	}
-}
-
 // ResourceGetter is an interface for retrieving resources by ResourceLocation.
 type ResourceGetter interface {
 	Get(api.Context, string) (runtime.Object, error)
@@ -219,6 +227,21 @@ func LogLocation(getter ResourceGetter, connInfo client.ConnectionInfoGetter, ct
 	if opts.Previous {
 		params.Add("previous", "true")
 	}
+	if opts.Timestamps {
+		params.Add("timestamps", "true")
+	}
+	if opts.SinceSeconds != nil {
+		params.Add("sinceSeconds", strconv.FormatInt(*opts.SinceSeconds, 10))
+	}
+	if opts.SinceTime != nil {
+		params.Add("sinceTime", opts.SinceTime.Format(time.RFC3339))
+	}
+	if opts.TailLines != nil {
+		params.Add("tailLines", strconv.FormatInt(*opts.TailLines, 10))
+	}
+	if opts.LimitBytes != nil {
+		params.Add("limitBytes", strconv.FormatInt(*opts.LimitBytes, 10))
+	}
 	loc := &url.URL{
 		Scheme:   nodeScheme,
 		Host:     fmt.Sprintf("%s:%d", nodeHost, nodePort),
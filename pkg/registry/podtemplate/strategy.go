@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -73,13 +74,26 @@ func (podTemplateStrategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.PodTemplate{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// PodTemplateToSelectableFields returns a field set that represents the object.
+func PodTemplateToSelectableFields(template *api.PodTemplate) fields.Set {
+	return fieldPaths.Extract(template)
+}
+
 // MatchPodTemplate returns a generic matcher for a given label and field selector.
 func MatchPodTemplate(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		podObj, ok := obj.(*api.PodTemplate)
-		if !ok {
-			return false, fmt.Errorf("not a pod template")
-		}
-		return label.Matches(labels.Set(podObj.Labels)), nil
-	})
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			template, ok := obj.(*api.PodTemplate)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a pod template")
+			}
+			return labels.Set(template.Labels), PodTemplateToSelectableFields(template), nil
+		},
+	}
 }
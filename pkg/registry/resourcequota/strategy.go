@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -93,22 +94,26 @@ func (resourcequotaStatusStrategy) ValidateUpdate(ctx api.Context, obj, old runt
 	return validation.ValidateResourceQuotaStatusUpdate(obj.(*api.ResourceQuota), old.(*api.ResourceQuota))
 }
 
-// MatchResourceQuota returns a generic matcher for a given label and field selector.
-func MatchResourceQuota(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		resourcequotaObj, ok := obj.(*api.ResourceQuota)
-		if !ok {
-			return false, fmt.Errorf("not a resourcequota")
-		}
-		fields := ResourceQuotaToSelectableFields(resourcequotaObj)
-		return label.Matches(labels.Set(resourcequotaObj.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.ResourceQuota{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// ResourceQuotaToSelectableFields returns a field set that represents the object
+func ResourceQuotaToSelectableFields(quota *api.ResourceQuota) fields.Set {
+	return fieldPaths.Extract(quota)
 }
 
-// ResourceQuotaToSelectableFields returns a label set that represents the object
-// TODO: fields are not labels, and the validation rules for them do not apply.
-func ResourceQuotaToSelectableFields(resourcequota *api.ResourceQuota) labels.Set {
-	return labels.Set{
-		"name": resourcequota.Name,
+// MatchResourceQuota returns a generic matcher for a given label and field selector.
+func MatchResourceQuota(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			quota, ok := obj.(*api.ResourceQuota)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a resource quota")
+			}
+			return labels.Set(quota.Labels), ResourceQuotaToSelectableFields(quota), nil
+		},
 	}
 }
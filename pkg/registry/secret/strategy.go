@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -69,21 +70,26 @@ func (strategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// Matcher returns a generic matcher for a given label and field selector.
-func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		sa, ok := obj.(*api.Secret)
-		if !ok {
-			return false, fmt.Errorf("not a secret")
-		}
-		fields := SelectableFields(sa)
-		return label.Matches(labels.Set(sa.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Secret{}, "metadata.name", "type")
+
+var SelectableFields = fieldPaths.Names()
+
+// SecretToSelectableFields returns a field set that can be used for filter selection
+func SecretToSelectableFields(secret *api.Secret) fields.Set {
+	return fieldPaths.Extract(secret)
 }
 
-// SelectableFields returns a label set that can be used for filter selection
-func SelectableFields(obj *api.Secret) labels.Set {
-	return labels.Set{
-		"type": string(obj.Type),
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			secret, ok := obj.(*api.Secret)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a secret")
+			}
+			return labels.Set(secret.Labels), SecretToSelectableFields(secret), nil
+		},
 	}
 }
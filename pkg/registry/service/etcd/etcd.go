@@ -21,8 +21,10 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/cachesize"
 	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -34,11 +36,16 @@ type REST struct {
 }
 
 // NewREST returns a RESTStorage object that will work against services.
-func NewREST(s storage.Interface) *REST {
+func NewREST(s storage.Interface, storageDecorator generic.StorageDecorator) *REST {
 	prefix := "/services/specs"
+
+	newListFunc := func() runtime.Object { return &api.ServiceList{} }
+	storageInterface := storageDecorator(
+		s, cachesize.GetWatchCacheSizeByResource(cachesize.Services), &api.Service{}, prefix, true, newListFunc)
+
 	store := etcdgeneric.Etcd{
 		NewFunc:     func() runtime.Object { return &api.Service{} },
-		NewListFunc: func() runtime.Object { return &api.ServiceList{} },
+		NewListFunc: newListFunc,
 		KeyRootFunc: func(ctx api.Context) string {
 			return etcdgeneric.NamespaceKeyRootFunc(ctx, prefix)
 		},
@@ -51,27 +58,37 @@ func NewREST(s storage.Interface) *REST {
 		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
 			return MatchServices(label, field)
 		},
-		EndpointName: "services",
+		SelectableFields: SelectableFields,
+		EndpointName:     "services",
 
 		CreateStrategy: rest.Services,
 		UpdateStrategy: rest.Services,
 
-		Storage: s,
+		Storage: storageInterface,
 	}
 	return &REST{store}
 }
 
-// FIXME: Move it.
-func MatchServices(label labels.Selector, field fields.Selector) generic.Matcher {
-	return &generic.SelectionPredicate{Label: label, Field: field, GetAttrs: ServiceAttributes}
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.Service{}, "metadata.name", "spec.type")
+
+var SelectableFields = fieldPaths.Names()
+
+// ServiceToSelectableFields returns a field set that represents the service.
+func ServiceToSelectableFields(service *api.Service) fields.Set {
+	return fieldPaths.Extract(service)
 }
 
-func ServiceAttributes(obj runtime.Object) (objLabels labels.Set, objFields fields.Set, err error) {
-	service, ok := obj.(*api.Service)
-	if !ok {
-		return nil, nil, fmt.Errorf("invalid object type %#v", obj)
+// FIXME: Move it.
+func MatchServices(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			service, ok := obj.(*api.Service)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a service")
+			}
+			return labels.Set(service.Labels), ServiceToSelectableFields(service), nil
+		},
 	}
-	return service.Labels, fields.Set{
-		"metadata.name": service.Name,
-	}, nil
 }
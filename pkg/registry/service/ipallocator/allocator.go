@@ -33,6 +33,15 @@ type Interface interface {
 	Release(net.IP) error
 }
 
+// maxIPv6RangeBits is the number of host bits of the largest IPv6 range
+// which is allocated from.  Only the first addresses of larger IPv6 ranges,
+// such as a /64, are used, so that the allocation bitmap stays small.
+const maxIPv6RangeBits = 16
+
+// MaxIPv6RangeSize is the largest number of addresses allocated from an
+// IPv6 range.
+const MaxIPv6RangeSize = int64(1) << maxIPv6RangeBits
+
 var (
 	ErrFull              = errors.New("range is full")
 	ErrNotInRange        = errors.New("provided IP is not in the valid range")
@@ -130,7 +139,7 @@ func (r *Range) AllocateNext() (net.IP, error) {
 	if !ok {
 		return nil, ErrFull
 	}
-	return addIPOffset(r.base, offset), nil
+	return addIPOffset(r.base, offset, len(ipBytes(r.net.IP))), nil
 }
 
 // Release releases the IP back to the pool. Releasing an
@@ -196,19 +205,31 @@ func (r *Range) contains(ip net.IP) (bool, int) {
 	return true, offset
 }
 
-// bigForIP creates a big.Int based on the provided net.IP
-func bigForIP(ip net.IP) *big.Int {
+// ipBytes returns the 4 byte form of an IPv4 address and the 16 byte form
+// of an IPv6 address.
+func ipBytes(ip net.IP) []byte {
 	b := ip.To4()
 	if b == nil {
 		b = ip.To16()
 	}
-	return big.NewInt(0).SetBytes(b)
+	return b
+}
+
+// bigForIP creates a big.Int based on the provided net.IP
+func bigForIP(ip net.IP) *big.Int {
+	return big.NewInt(0).SetBytes(ipBytes(ip))
 }
 
 // addIPOffset adds the provided integer offset to a base big.Int representing a
-// net.IP
-func addIPOffset(base *big.Int, offset int) net.IP {
-	return net.IP(big.NewInt(0).Add(base, big.NewInt(int64(offset))).Bytes())
+// net.IP of length bytes
+func addIPOffset(base *big.Int, offset int, length int) net.IP {
+	b := big.NewInt(0).Add(base, big.NewInt(int64(offset))).Bytes()
+	if len(b) > length {
+		return nil
+	}
+	ip := make(net.IP, length)
+	copy(ip[length-len(b):], b)
+	return ip
 }
 
 // calculateIPOffset calculates the integer offset of ip from base such that
@@ -217,9 +238,13 @@ func calculateIPOffset(base *big.Int, ip net.IP) int {
 	return int(big.NewInt(0).Sub(bigForIP(ip), base).Int64())
 }
 
-// RangeSize returns the size of a range in valid addresses.
+// RangeSize returns the size of a range in valid addresses. IPv6 ranges are
+// capped at MaxIPv6RangeSize addresses.
 func RangeSize(subnet *net.IPNet) int64 {
 	ones, bits := subnet.Mask.Size()
+	if bits == 8*net.IPv6len && (bits-ones) >= maxIPv6RangeBits {
+		return MaxIPv6RangeSize
+	}
 	if (bits - ones) >= 31 {
 		panic("masks greater than 31 bits are not supported")
 	}
@@ -229,8 +254,8 @@ func RangeSize(subnet *net.IPNet) int64 {
 
 // GetIndexedIP returns a net.IP that is subnet.IP + index in the contiguous IP space.
 func GetIndexedIP(subnet *net.IPNet, index int) (net.IP, error) {
-	ip := addIPOffset(bigForIP(subnet.IP), index)
-	if !subnet.Contains(ip) {
+	ip := addIPOffset(bigForIP(subnet.IP), index, len(ipBytes(subnet.IP)))
+	if ip == nil || !subnet.Contains(ip) {
 		return nil, fmt.Errorf("can't generate IP with index %d from subnet. subnet too small. subnet: %q", index, subnet)
 	}
 	return ip, nil
@@ -150,11 +150,86 @@ func TestAllocateSmall(t *testing.T) {
 	t.Logf("allocated: %v", found)
 }
 
+func TestAllocateIPv6(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("fd00:10::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewCIDRRange(cidr)
+	if f := r.Free(); f != int(MaxIPv6RangeSize)-2 {
+		t.Errorf("free: %d", f)
+	}
+	ip, err := r.AllocateNext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cidr.Contains(ip) || len(ip) != net.IPv6len {
+		t.Errorf("allocated IP %s not in %s", ip, cidr)
+	}
+	if err := r.Allocate(ip); err != ErrAllocated {
+		t.Fatal(err)
+	}
+	if err := r.Allocate(net.ParseIP("fd00:10::fffe")); err != nil {
+		t.Fatal(err)
+	}
+	// Addresses beyond the first MaxIPv6RangeSize are not allocated from.
+	if err := r.Allocate(net.ParseIP("fd00:10::1:1")); err != ErrNotInRange {
+		t.Fatal(err)
+	}
+	if err := r.Allocate(net.ParseIP("fd00:11::1")); err != ErrNotInRange {
+		t.Fatal(err)
+	}
+	if err := r.Allocate(net.ParseIP("192.168.1.1")); err != ErrNotInRange {
+		t.Fatal(err)
+	}
+	if err := r.Release(ip); err != nil {
+		t.Fatal(err)
+	}
+	if r.Has(ip) {
+		t.Errorf("expected %s to be released", ip)
+	}
+}
+
+func TestGetIndexedIP(t *testing.T) {
+	testCases := []struct {
+		cidr     string
+		index    int
+		expected string
+	}{
+		{"10.0.0.0/24", 1, "10.0.0.1"},
+		{"10.0.0.0/24", 255, "10.0.0.255"},
+		{"fd00::/64", 1, "fd00::1"},
+		{"::/96", 10, "::a"},
+		{"fd00::/120", 255, "fd00::ff"},
+	}
+	for _, tc := range testCases {
+		_, cidr, err := net.ParseCIDR(tc.cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ip, err := GetIndexedIP(cidr, tc.index)
+		if err != nil {
+			t.Errorf("unexpected error for index %d of %s: %v", tc.index, tc.cidr, err)
+			continue
+		}
+		if ip.String() != tc.expected {
+			t.Errorf("expected index %d of %s to be %s, got %s", tc.index, tc.cidr, tc.expected, ip)
+		}
+	}
+	_, cidr, _ := net.ParseCIDR("fd00::/120")
+	if _, err := GetIndexedIP(cidr, 256); err == nil {
+		t.Errorf("expected an error for an index outside of %s", cidr)
+	}
+}
+
 func TestRangeSize(t *testing.T) {
 	testCases := map[string]int64{
 		"192.168.1.0/24": 256,
 		"192.168.1.0/32": 1,
 		"192.168.1.0/31": 2,
+		"fd00::/120":     256,
+		"fd00::/112":     65536,
+		"fd00::/64":      65536,
 	}
 	for k, v := range testCases {
 		_, cidr, err := net.ParseCIDR(k)
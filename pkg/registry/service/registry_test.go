@@ -25,6 +25,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/testapi"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
 	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
 	etcdservice "k8s.io/kubernetes/pkg/registry/service/etcd"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -38,7 +39,7 @@ import (
 
 func NewTestEtcdRegistry(client tools.EtcdClient) (Registry, *etcdservice.REST) {
 	storage := etcdstorage.NewEtcdStorage(client, testapi.Codec(), etcdtest.PathPrefix())
-	rest := etcdservice.NewREST(storage)
+	rest := etcdservice.NewREST(storage, generic.UndecoratedStorage)
 	registry := NewRegistry(rest)
 	return registry, rest
 }
@@ -297,7 +298,7 @@ func TestEtcdWatchServices(t *testing.T) {
 	registry, _ := NewTestEtcdRegistry(fakeClient)
 	watching, err := registry.WatchServices(ctx,
 		labels.Everything(),
-		fields.SelectorFromSet(fields.Set{"name": "foo"}),
+		fields.SelectorFromSet(fields.Set{"metadata.name": "foo"}),
 		"1",
 	)
 	if err != nil {
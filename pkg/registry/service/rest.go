@@ -78,22 +78,9 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 	nodePortOp := portallocator.StartOperation(rs.serviceNodePorts)
 	defer nodePortOp.Finish()
 
-	if api.IsServiceIPRequested(service) {
-		// Allocate next available.
-		ip, err := rs.serviceIPs.AllocateNext()
-		if err != nil {
-			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, err.Error())}
-			return nil, errors.NewInvalid("Service", service.Name, el)
-		}
-		service.Spec.ClusterIP = ip.String()
-		releaseServiceIP = true
-	} else if api.IsServiceIPSet(service) {
-		// Try to respect the requested IP.
-		if err := rs.serviceIPs.Allocate(net.ParseIP(service.Spec.ClusterIP)); err != nil {
-			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, err.Error())}
-			return nil, errors.NewInvalid("Service", service.Name, el)
-		}
-		releaseServiceIP = true
+	var err error
+	if releaseServiceIP, err = rs.allocateServiceIP(service); err != nil {
+		return nil, err
 	}
 
 	assignNodePorts := shouldAssignNodePorts(service)
@@ -115,6 +102,12 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 		}
 	}
 
+	if api.NeedsHealthCheck(service) {
+		if err := allocateHealthCheckNodePort(nodePortOp, service, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	out, err := rs.registry.CreateService(ctx, service)
 	if err != nil {
 		err = rest.CheckGeneratedNameError(rest.Services, err, service)
@@ -133,6 +126,30 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 	return out, err
 }
 
+// allocateServiceIP allocates the next available cluster IP to service if
+// it requests one, or else the cluster IP it specifies.  It returns whether
+// an IP was allocated.
+func (rs *REST) allocateServiceIP(service *api.Service) (bool, error) {
+	if api.IsServiceIPRequested(service) {
+		// Allocate next available.
+		ip, err := rs.serviceIPs.AllocateNext()
+		if err != nil {
+			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, err.Error())}
+			return false, errors.NewInvalid("Service", service.Name, el)
+		}
+		service.Spec.ClusterIP = ip.String()
+		return true, nil
+	} else if api.IsServiceIPSet(service) {
+		// Try to respect the requested IP.
+		if err := rs.serviceIPs.Allocate(net.ParseIP(service.Spec.ClusterIP)); err != nil {
+			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.clusterIP", service.Spec.ClusterIP, err.Error())}
+			return false, errors.NewInvalid("Service", service.Name, el)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (rs *REST) Delete(ctx api.Context, id string) (runtime.Object, error) {
 	service, err := rs.registry.GetService(ctx, id)
 	if err != nil {
@@ -205,6 +222,22 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (runtime.Object, boo
 		return nil, false, errors.NewInvalid("service", service.Name, errs)
 	}
 
+	// Services changed from type ExternalName get a cluster IP, and services
+	// changed to it give theirs up.
+	releaseServiceIP := false
+	defer func() {
+		if releaseServiceIP {
+			if api.IsServiceIPSet(service) {
+				rs.serviceIPs.Release(net.ParseIP(service.Spec.ClusterIP))
+			}
+		}
+	}()
+	if oldService.Spec.Type == api.ServiceTypeExternalName && service.Spec.Type != api.ServiceTypeExternalName {
+		if releaseServiceIP, err = rs.allocateServiceIP(service); err != nil {
+			return nil, false, err
+		}
+	}
+
 	nodePortOp := portallocator.StartOperation(rs.serviceNodePorts)
 	defer nodePortOp.Finish()
 
@@ -243,10 +276,21 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (runtime.Object, boo
 		// Validate should have validated that nodePort == 0
 	}
 
+	if api.NeedsHealthCheck(service) {
+		// Keep the port of the old service unless another one is requested.
+		if service.Spec.HealthCheckNodePort == 0 {
+			service.Spec.HealthCheckNodePort = oldService.Spec.HealthCheckNodePort
+		}
+		if err := allocateHealthCheckNodePort(nodePortOp, service, oldNodePorts); err != nil {
+			return nil, false, err
+		}
+		newNodePorts = append(newNodePorts, service.Spec.HealthCheckNodePort)
+	}
+
 	// The comparison loops are O(N^2), but we don't expect N to be huge
 	// (there's a hard-limit at 2^16, because they're ports; and even 4 ports would be a lot)
 	for _, oldNodePort := range oldNodePorts {
-		if !contains(newNodePorts, oldNodePort) {
+		if contains(newNodePorts, oldNodePort) {
 			continue
 		}
 		nodePortOp.ReleaseDeferred(oldNodePort)
@@ -258,6 +302,11 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (runtime.Object, boo
 		service.Status.LoadBalancer = api.LoadBalancerStatus{}
 	}
 
+	var oldServiceIP net.IP
+	if service.Spec.Type == api.ServiceTypeExternalName && api.IsServiceIPSet(oldService) {
+		oldServiceIP = net.ParseIP(oldService.Spec.ClusterIP)
+	}
+
 	out, err := rs.registry.UpdateService(ctx, service)
 
 	if err == nil {
@@ -266,6 +315,11 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (runtime.Object, boo
 			// problems should be fixed by an eventual reconciliation / restart
 			glog.Errorf("error(s) committing NodePorts changes: %v", el)
 		}
+
+		releaseServiceIP = false
+		if oldServiceIP != nil {
+			rs.serviceIPs.Release(oldServiceIP)
+		}
 	}
 
 	return out, false, err
@@ -294,6 +348,10 @@ func (rs *REST) ResourceLocation(ctx api.Context, id string) (*url.URL, http.Rou
 	// Find a Subset that has the port.
 	for ssi := 0; ssi < len(eps.Subsets); ssi++ {
 		ss := &eps.Subsets[(ssSeed+ssi)%len(eps.Subsets)]
+		if len(ss.Addresses) == 0 {
+			// Only ready addresses are proxied to.
+			continue
+		}
 		for i := range ss.Ports {
 			if ss.Ports[i].Name == portStr {
 				// Pick a random address.
@@ -310,6 +368,34 @@ func (rs *REST) ResourceLocation(ctx api.Context, id string) (*url.URL, http.Rou
 	return nil, nil, errors.NewServiceUnavailable(fmt.Sprintf("no endpoints available for service %q", id))
 }
 
+// nodePortAllocator allocates single node ports within a port allocation
+// operation.
+type nodePortAllocator interface {
+	Allocate(port int) error
+	AllocateNext() (int, error)
+}
+
+// allocateHealthCheckNodePort allocates the requested health check node port
+// of a service, or the next free one if none is requested.  Ports in
+// allocated already belong to the service.
+func allocateHealthCheckNodePort(nodePortOp nodePortAllocator, service *api.Service, allocated []int) error {
+	port := service.Spec.HealthCheckNodePort
+	if port == 0 {
+		next, err := nodePortOp.AllocateNext()
+		if err != nil {
+			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.healthCheckNodePort", port, err.Error())}
+			return errors.NewInvalid("Service", service.Name, el)
+		}
+		service.Spec.HealthCheckNodePort = next
+	} else if !contains(allocated, port) {
+		if err := nodePortOp.Allocate(port); err != nil {
+			el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid("spec.healthCheckNodePort", port, err.Error())}
+			return errors.NewInvalid("Service", service.Name, el)
+		}
+	}
+	return nil
+}
+
 // This is O(N), but we expect haystack to be small;
 // so small that we expect a linear search to be faster
 func contains(haystack []int, needle int) bool {
@@ -329,6 +415,9 @@ func CollectServiceNodePorts(service *api.Service) []int {
 			servicePorts = append(servicePorts, servicePort.NodePort)
 		}
 	}
+	if service.Spec.HealthCheckNodePort != 0 {
+		servicePorts = append(servicePorts, service.Spec.HealthCheckNodePort)
+	}
 	return servicePorts
 }
 
@@ -340,6 +429,8 @@ func shouldAssignNodePorts(service *api.Service) bool {
 		return true
 	case api.ServiceTypeClusterIP:
 		return false
+	case api.ServiceTypeExternalName:
+		return false
 	default:
 		glog.Errorf("Unknown service type: %v", service.Spec.Type)
 		return false
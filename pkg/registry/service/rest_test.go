@@ -375,6 +375,127 @@ func TestServiceRegistryUpdateExternalService(t *testing.T) {
 	}
 }
 
+func TestServiceRegistryExternalName(t *testing.T) {
+	ctx := api.NewDefaultContext()
+	storage, _ := NewTestREST(t, nil)
+	ipRange := storage.serviceIPs.(*ipallocator.Range)
+	free := ipRange.Free()
+
+	svc1 := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+		Spec: api.ServiceSpec{
+			SessionAffinity: api.ServiceAffinityNone,
+			Type:            api.ServiceTypeExternalName,
+			ExternalName:    "db.example.com",
+			Ports: []api.ServicePort{{
+				Port:       6502,
+				Protocol:   api.ProtocolTCP,
+				TargetPort: util.NewIntOrStringFromInt(6502),
+			}},
+		},
+	}
+	created, err := storage.Create(ctx, svc1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	created_service := created.(*api.Service)
+	if created_service.Spec.ClusterIP != "" {
+		t.Errorf("Expected no ClusterIP, got %s", created_service.Spec.ClusterIP)
+	}
+	if created_service.Spec.Ports[0].NodePort != 0 {
+		t.Errorf("Expected no NodePort, got %d", created_service.Spec.Ports[0].NodePort)
+	}
+	if ipRange.Free() != free {
+		t.Errorf("Expected no IP to be allocated")
+	}
+
+	// Changed to type ClusterIP, the service gets a cluster IP.
+	svc2 := deepCloneService(created_service)
+	svc2.Spec.Type = api.ServiceTypeClusterIP
+	svc2.Spec.ExternalName = ""
+	updated, _, err := storage.Update(ctx, svc2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated_service := updated.(*api.Service)
+	if !makeIPNet(t).Contains(net.ParseIP(updated_service.Spec.ClusterIP)) {
+		t.Errorf("Unexpected ClusterIP: %s", updated_service.Spec.ClusterIP)
+	}
+	if !ipRange.Has(net.ParseIP(updated_service.Spec.ClusterIP)) {
+		t.Errorf("Expected ClusterIP %s to be allocated", updated_service.Spec.ClusterIP)
+	}
+
+	// Changed back to type ExternalName, it gives it up.
+	svc3 := deepCloneService(updated_service)
+	svc3.Spec.Type = api.ServiceTypeExternalName
+	svc3.Spec.ExternalName = "db.example.com"
+	svc3.Spec.ClusterIP = ""
+	if _, _, err := storage.Update(ctx, svc3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ipRange.Has(net.ParseIP(updated_service.Spec.ClusterIP)) {
+		t.Errorf("Expected ClusterIP %s to be released", updated_service.Spec.ClusterIP)
+	}
+}
+
+func TestServiceRegistryHealthCheckNodePort(t *testing.T) {
+	ctx := api.NewDefaultContext()
+	storage, _ := NewTestREST(t, nil)
+
+	svc1 := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+		Spec: api.ServiceSpec{
+			Selector:              map[string]string{"bar": "baz"},
+			SessionAffinity:       api.ServiceAffinityNone,
+			Type:                  api.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: api.ServiceExternalTrafficPolicyTypeLocal,
+			Ports: []api.ServicePort{{
+				Port:       6502,
+				Protocol:   api.ProtocolTCP,
+				TargetPort: util.NewIntOrStringFromInt(6502),
+			}},
+		},
+	}
+	obj, err := storage.Create(ctx, svc1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	created := obj.(*api.Service)
+	port := created.Spec.HealthCheckNodePort
+	if port == 0 || port == created.Spec.Ports[0].NodePort {
+		t.Fatalf("Expected a health check node port of its own, got %d", port)
+	}
+	if err := storage.serviceNodePorts.Allocate(port); err == nil {
+		t.Errorf("Expected health check node port %d to be allocated", port)
+	}
+
+	// Updates without a health check node port keep the allocated one.
+	svc2 := deepCloneService(created)
+	svc2.Spec.HealthCheckNodePort = 0
+	obj, _, err = storage.Update(ctx, svc2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated := obj.(*api.Service)
+	if updated.Spec.HealthCheckNodePort != port {
+		t.Errorf("Expected health check node port %d to be kept, got %d", port, updated.Spec.HealthCheckNodePort)
+	}
+	if err := storage.serviceNodePorts.Allocate(updated.Spec.Ports[0].NodePort); err == nil {
+		t.Errorf("Expected node port %d to stay allocated", updated.Spec.Ports[0].NodePort)
+	}
+
+	// Balancing across the cluster needs no health checks.
+	svc3 := deepCloneService(updated)
+	svc3.Spec.ExternalTrafficPolicy = api.ServiceExternalTrafficPolicyTypeCluster
+	svc3.Spec.HealthCheckNodePort = 0
+	if _, _, err := storage.Update(ctx, svc3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := storage.serviceNodePorts.Allocate(port); err != nil {
+		t.Errorf("Expected health check node port %d to be released: %v", port, err)
+	}
+}
+
 func TestServiceRegistryUpdateMultiPortExternalService(t *testing.T) {
 	ctx := api.NewDefaultContext()
 	storage, _ := NewTestREST(t, nil)
@@ -411,6 +532,51 @@ func TestServiceRegistryUpdateMultiPortExternalService(t *testing.T) {
 	}
 }
 
+func TestServiceRegistryUpdateReleasesUnusedNodePorts(t *testing.T) {
+	ctx := api.NewDefaultContext()
+	storage, _ := NewTestREST(t, nil)
+
+	svc1 := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+		Spec: api.ServiceSpec{
+			Selector:        map[string]string{"bar": "baz"},
+			SessionAffinity: api.ServiceAffinityNone,
+			Type:            api.ServiceTypeNodePort,
+			Ports: []api.ServicePort{{
+				Name:       "p",
+				Port:       6502,
+				NodePort:   30001,
+				Protocol:   api.ProtocolTCP,
+				TargetPort: util.NewIntOrStringFromInt(6502),
+			}, {
+				Name:       "q",
+				Port:       8086,
+				NodePort:   30002,
+				Protocol:   api.ProtocolTCP,
+				TargetPort: util.NewIntOrStringFromInt(8086),
+			}},
+		},
+	}
+	if _, err := storage.Create(ctx, svc1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Move the second port to another node port.
+	svc2 := deepCloneService(svc1)
+	svc2.Spec.Ports[1].NodePort = 30003
+	if _, _, err := storage.Update(ctx, svc2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, port := range []int{30001, 30003} {
+		if err := storage.serviceNodePorts.Allocate(port); err == nil {
+			t.Errorf("Expected node port %d to stay allocated", port)
+		}
+	}
+	if err := storage.serviceNodePorts.Allocate(30002); err != nil {
+		t.Errorf("Expected node port 30002 to be released: %v", err)
+	}
+}
+
 func TestServiceRegistryGet(t *testing.T) {
 	ctx := api.NewDefaultContext()
 	storage, registry := NewTestREST(t, nil)
@@ -438,6 +604,9 @@ func TestServiceRegistryResourceLocation(t *testing.T) {
 				Subsets: []api.EndpointSubset{{
 					Addresses: []api.EndpointAddress{{IP: "1.2.3.4"}},
 					Ports:     []api.EndpointPort{{Name: "", Port: 80}, {Name: "p", Port: 93}},
+				}, {
+					NotReadyAddresses: []api.EndpointAddress{{IP: "1.2.3.5"}},
+					Ports:             []api.EndpointPort{{Name: "p", Port: 93}, {Name: "q", Port: 94}},
 				}},
 			},
 		},
@@ -475,12 +644,18 @@ func TestServiceRegistryResourceLocation(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", e, a)
 	}
 
-	// Test a non-existent name + port.
+	// Test a name + port of not-ready addresses only.
 	location, _, err = redirector.ResourceLocation(ctx, "foo:q")
 	if err == nil {
 		t.Errorf("Unexpected nil error")
 	}
 
+	// Test a non-existent name + port.
+	location, _, err = redirector.ResourceLocation(ctx, "foo:r")
+	if err == nil {
+		t.Errorf("Unexpected nil error")
+	}
+
 	// Test error path
 	if _, _, err = redirector.ResourceLocation(ctx, "bar"); err == nil {
 		t.Errorf("unexpected nil error")
@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -72,21 +73,26 @@ func (strategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// Matcher returns a generic matcher for a given label and field selector.
-func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		sa, ok := obj.(*api.ServiceAccount)
-		if !ok {
-			return false, fmt.Errorf("not a serviceaccount")
-		}
-		fields := SelectableFields(sa)
-		return label.Matches(labels.Set(sa.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&api.ServiceAccount{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// ServiceAccountToSelectableFields returns a field set that represents the object
+func ServiceAccountToSelectableFields(serviceAccount *api.ServiceAccount) fields.Set {
+	return fieldPaths.Extract(serviceAccount)
 }
 
-// SelectableFields returns a label set that represents the object
-func SelectableFields(obj *api.ServiceAccount) labels.Set {
-	return labels.Set{
-		"metadata.name": obj.Name,
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			serviceAccount, ok := obj.(*api.ServiceAccount)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a service account")
+			}
+			return labels.Set(serviceAccount.Labels), ServiceAccountToSelectableFields(serviceAccount), nil
+		},
 	}
 }
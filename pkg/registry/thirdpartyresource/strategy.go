@@ -23,6 +23,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/rest"
 	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/expapi/validation"
+	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/registry/generic"
@@ -70,19 +71,26 @@ func (strategy) AllowUnconditionalUpdate() bool {
 	return true
 }
 
-// Matcher returns a generic matcher for a given label and field selector.
-func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
-	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
-		sa, ok := obj.(*expapi.ThirdPartyResource)
-		if !ok {
-			return false, fmt.Errorf("not a ThirdPartyResource")
-		}
-		fields := SelectableFields(sa)
-		return label.Matches(labels.Set(sa.Labels)) && field.Matches(fields), nil
-	})
+var fieldPaths = fieldpath.MustNewFieldPaths(&expapi.ThirdPartyResource{}, "metadata.name")
+
+var SelectableFields = fieldPaths.Names()
+
+// ThirdPartyResourceToSelectableFields returns a field set that can be used for filter selection
+func ThirdPartyResourceToSelectableFields(resource *expapi.ThirdPartyResource) fields.Set {
+	return fieldPaths.Extract(resource)
 }
 
-// SelectableFields returns a label set that can be used for filter selection
-func SelectableFields(obj *expapi.ThirdPartyResource) labels.Set {
-	return labels.Set{}
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return &generic.SelectionPredicate{
+		Label: label,
+		Field: field,
+		GetAttrs: func(obj runtime.Object) (labels.Set, fields.Set, error) {
+			resource, ok := obj.(*expapi.ThirdPartyResource)
+			if !ok {
+				return nil, nil, fmt.Errorf("not a third party resource")
+			}
+			return labels.Set(resource.Labels), ThirdPartyResourceToSelectableFields(resource), nil
+		},
+	}
 }
@@ -44,6 +44,7 @@ var DefaultStringConversions = []interface{}{
 	convertStringSliceToInt,
 	convertStringSliceToBool,
 	convertStringSliceToInt64,
+	convertStringSliceToInt64Pointer,
 }
 
 func convertStringSliceToString(input *[]string, out *string, s conversion.Scope) error {
@@ -96,3 +97,17 @@ func convertStringSliceToInt64(input *[]string, out *int64, s conversion.Scope)
 	*out = i
 	return nil
 }
+
+func convertStringSliceToInt64Pointer(input *[]string, out **int64, s conversion.Scope) error {
+	if len(*input) == 0 {
+		*out = nil
+		return nil
+	}
+	str := (*input)[0]
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*out = &i
+	return nil
+}
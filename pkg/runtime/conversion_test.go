@@ -29,6 +29,7 @@ type InternalComplex struct {
 	Integer   int
 	Integer64 int64
 	Int64     int64
+	Pointer   *int64
 	Bool      bool
 }
 
@@ -38,7 +39,8 @@ type ExternalComplex struct {
 	Integer   int    `json:"int"`
 	Integer64 int64  `json:",omitempty"`
 	Int64     int64
-	Bool      bool `json:"bool"`
+	Pointer   *int64 `json:"pointer"`
+	Bool      bool   `json:"bool"`
 }
 
 func (*InternalComplex) IsAnAPIObject() {}
@@ -49,6 +51,7 @@ func TestStringMapConversion(t *testing.T) {
 	scheme.Log(t)
 	scheme.AddKnownTypeWithName("", "Complex", &InternalComplex{})
 	scheme.AddKnownTypeWithName("external", "Complex", &ExternalComplex{})
+	five := int64(5)
 
 	testCases := map[string]struct {
 		input    map[string][]string
@@ -84,6 +87,19 @@ func TestStringMapConversion(t *testing.T) {
 			errFn:    func(err error) bool { return err != nil },
 			expected: &ExternalComplex{},
 		},
+		"parses int64 pointer": {
+			input: map[string][]string{
+				"pointer": {"5"},
+			},
+			expected: &ExternalComplex{Pointer: &five},
+		},
+		"returns error on bad int64 pointer": {
+			input: map[string][]string{
+				"pointer": {"a"},
+			},
+			errFn:    func(err error) bool { return err != nil },
+			expected: &ExternalComplex{},
+		},
 		"parses boolean true": {
 			input: map[string][]string{
 				"bool": {"true"},
@@ -0,0 +1,192 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+const (
+	// ContentType is the media type of objects encoded by Codec.
+	ContentType = "application/vnd.kubernetes.protobuf"
+	// StreamContentType is the media type of a stream of length prefixed
+	// protobuf messages, as used for watch.
+	StreamContentType = "application/vnd.kubernetes.protobuf;stream=watch"
+)
+
+// magic prefixes every object encoded by Codec. It can never begin a JSON or
+// YAML document, which lets Decode fall back to JSON for other input.
+var magic = []byte{0x6b, 0x38, 0x73, 0x00}
+
+// IsProtobuf returns true if the provided media type, as found in an Accept or
+// Content-Type header, names the protobuf serialization.
+func IsProtobuf(mediaType string) bool {
+	t, _, err := mime.ParseMediaType(mediaType)
+	return err == nil && t == ContentType
+}
+
+// MediaTypeFor returns the media type of the data produced by codec. Every
+// codec other than a protobuf Codec produces JSON.
+func MediaTypeFor(codec runtime.Codec) string {
+	if _, ok := codec.(*Codec); ok {
+		return ContentType
+	}
+	return "application/json"
+}
+
+// Codec encodes and decodes objects of a single API version in the protobuf
+// wire format. Types without generated protobuf methods cannot be encoded.
+type Codec struct {
+	scheme  *runtime.Scheme
+	version string
+}
+
+// Codec implements runtime.Codec
+var _ runtime.Codec = &Codec{}
+
+// NewCodec returns a Codec that converts objects to the provided version in
+// scheme before encoding them.
+func NewCodec(scheme *runtime.Scheme, version string) *Codec {
+	return &Codec{scheme: scheme, version: version}
+}
+
+// Encode implements runtime.Encoder.
+func (c *Codec) Encode(obj runtime.Object) ([]byte, error) {
+	out, err := c.scheme.ConvertToVersion(obj, c.version)
+	if err != nil {
+		return nil, err
+	}
+	_, kind, err := c.scheme.ObjectVersionAndKind(out)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := out.(Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%s %q has no protobuf serialization", c.version, kind)
+	}
+	payload := Buffer{}
+	m.ProtoMarshal(&payload)
+
+	envelope := Buffer{buf: append(make([]byte, 0, len(magic)+payload.Len()+len(kind)+len(c.version)+16), magic...)}
+	envelope.WriteString(1, c.version)
+	envelope.WriteString(2, kind)
+	envelope.WriteBytes(3, payload.Bytes())
+	return envelope.Bytes(), nil
+}
+
+// Decode implements runtime.Decoder. The object is converted to the internal
+// version of the scheme.
+func (c *Codec) Decode(data []byte) (runtime.Object, error) {
+	return c.DecodeToVersion(data, "")
+}
+
+// DecodeToVersion implements runtime.Decoder. Input that was not produced by
+// a Codec is decoded as JSON.
+func (c *Codec) DecodeToVersion(data []byte, version string) (runtime.Object, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return c.scheme.DecodeToVersion(data, version)
+	}
+	dataVersion, kind, obj, err := c.decodeVersioned(data)
+	if err != nil {
+		return nil, err
+	}
+	if version == dataVersion {
+		return obj, nil
+	}
+	out, err := c.scheme.New(version, kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.scheme.Convert(obj, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecodeInto implements runtime.Decoder.
+func (c *Codec) DecodeInto(data []byte, obj runtime.Object) error {
+	return c.DecodeIntoWithSpecifiedVersionKind(data, obj, "", "")
+}
+
+// DecodeIntoWithSpecifiedVersionKind implements runtime.Decoder. Unlike JSON,
+// protobuf data always carries its version and kind, so they must match the
+// specified values if any are provided.
+func (c *Codec) DecodeIntoWithSpecifiedVersionKind(data []byte, obj runtime.Object, version, kind string) error {
+	if !bytes.HasPrefix(data, magic) {
+		return c.scheme.DecodeIntoWithSpecifiedVersionKind(data, obj, version, kind)
+	}
+	dataVersion, dataKind, external, err := c.decodeVersioned(data)
+	if err != nil {
+		return err
+	}
+	if len(version) > 0 && dataVersion != version {
+		return fmt.Errorf("The apiVersion in the data (%s) does not match the specified apiVersion(%s)", dataVersion, version)
+	}
+	if len(kind) > 0 && dataKind != kind {
+		return fmt.Errorf("The kind in the data (%s) does not match the specified kind(%s)", dataKind, kind)
+	}
+	if err := c.scheme.Convert(external, obj); err != nil {
+		return err
+	}
+	// Version and Kind should be blank in memory.
+	return c.scheme.Raw().SetVersionAndKind("", "", obj)
+}
+
+// decodeVersioned unwraps the envelope in data and returns the versioned
+// object it holds, with its Version and Kind cleared.
+func (c *Codec) decodeVersioned(data []byte) (version, kind string, obj runtime.Object, err error) {
+	var payload []byte
+	d := NewDecoder(data[len(magic):])
+	for d.Next() {
+		switch d.Field() {
+		case 1:
+			version = d.String()
+		case 2:
+			kind = d.String()
+		case 3:
+			payload = d.Bytes()
+		default:
+			d.Skip()
+		}
+	}
+	if err := d.Err(); err != nil {
+		return "", "", nil, err
+	}
+	if len(kind) == 0 {
+		return "", "", nil, errors.New("kind not set in protobuf envelope")
+	}
+	obj, err = c.scheme.New(version, kind)
+	if err != nil {
+		return "", "", nil, err
+	}
+	u, ok := obj.(Unmarshaler)
+	if !ok {
+		return "", "", nil, fmt.Errorf("%s %q has no protobuf serialization", version, kind)
+	}
+	if err := u.ProtoUnmarshal(payload); err != nil {
+		return "", "", nil, err
+	}
+	if err := c.scheme.Raw().SetVersionAndKind("", "", obj); err != nil {
+		return "", "", nil, err
+	}
+	return version, kind, obj, nil
+}
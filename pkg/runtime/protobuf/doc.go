@@ -0,0 +1,30 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protobuf implements a compact binary serialization of versioned API
+// objects using the protocol buffer wire format.
+//
+// Versioned types opt in by implementing Marshaler and Unmarshaler; those
+// methods are generated by cmd/genprotobuf and should not be written by hand.
+// Field numbers are assigned in struct declaration order, starting at 1, so
+// fields of a versioned type must only ever be appended.
+//
+// An encoded object is a fixed magic prefix followed by an envelope message
+// carrying the apiVersion and kind of the object, which lets a decoder
+// allocate the right type without inspecting the payload. JSON remains the
+// default serialization; this package is used only when a client asks for
+// the protobuf media type.
+package protobuf
@@ -0,0 +1,123 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+// The types below are shared by every API version and have no generated
+// methods, so the generator emits calls to these helpers instead.
+
+// WriteTime writes t as a message of seconds and nanoseconds since the epoch.
+func (b *Buffer) WriteTime(field int, t util.Time) {
+	child := Buffer{}
+	if sec := t.Unix(); sec != 0 {
+		child.WriteInt64(1, sec)
+	}
+	if nsec := t.Nanosecond(); nsec != 0 {
+		child.WriteInt64(2, int64(nsec))
+	}
+	b.WriteBytes(field, child.buf)
+}
+
+// Time reads the current field as a util.Time.
+func (d *Decoder) Time() util.Time {
+	var sec, nsec int64
+	e := d.Embedded()
+	for e.Next() {
+		switch e.Field() {
+		case 1:
+			sec = e.Int64()
+		case 2:
+			nsec = e.Int64()
+		default:
+			e.Skip()
+		}
+	}
+	if err := e.Err(); err != nil {
+		d.fail(err)
+		return util.Time{}
+	}
+	return util.Unix(sec, nsec)
+}
+
+// WriteQuantity writes q in its canonical string form.
+func (b *Buffer) WriteQuantity(field int, q resource.Quantity) {
+	b.WriteString(field, q.String())
+}
+
+// Quantity reads the current field as a resource.Quantity.
+func (d *Decoder) Quantity() resource.Quantity {
+	s := d.String()
+	if d.err != nil {
+		return resource.Quantity{}
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		d.fail(err)
+		return resource.Quantity{}
+	}
+	return *q
+}
+
+// WriteIntOrString writes v as a message of its kind and value.
+func (b *Buffer) WriteIntOrString(field int, v util.IntOrString) {
+	child := Buffer{}
+	if v.Kind != 0 {
+		child.WriteInt64(1, int64(v.Kind))
+	}
+	if v.IntVal != 0 {
+		child.WriteInt64(2, int64(v.IntVal))
+	}
+	if len(v.StrVal) != 0 {
+		child.WriteString(3, v.StrVal)
+	}
+	b.WriteBytes(field, child.buf)
+}
+
+// IntOrString reads the current field as a util.IntOrString.
+func (d *Decoder) IntOrString() util.IntOrString {
+	v := util.IntOrString{}
+	e := d.Embedded()
+	for e.Next() {
+		switch e.Field() {
+		case 1:
+			v.Kind = util.IntstrKind(e.Int64())
+		case 2:
+			v.IntVal = int(e.Int64())
+		case 3:
+			v.StrVal = e.String()
+		default:
+			e.Skip()
+		}
+	}
+	d.fail(e.Err())
+	return v
+}
+
+// WriteRawExtension writes the serialized object held by v unchanged.
+func (b *Buffer) WriteRawExtension(field int, v runtime.RawExtension) {
+	b.WriteBytes(field, v.RawJSON)
+}
+
+// RawExtension reads the current field as a runtime.RawExtension.
+func (d *Decoder) RawExtension() runtime.RawExtension {
+	return runtime.RawExtension{RawJSON: d.Bytes()}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Wire types used by this package. Fixed width encodings are skipped when
+// decoding but never produced.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("protobuf: unexpected end of data")
+
+// Marshaler is implemented by types that can write themselves in the protobuf
+// wire format.
+type Marshaler interface {
+	ProtoMarshal(b *Buffer)
+}
+
+// Unmarshaler is implemented by types that can read themselves from the
+// protobuf wire format.
+type Unmarshaler interface {
+	ProtoUnmarshal(data []byte) error
+}
+
+// Buffer accumulates the wire encoding of a single message. The Write methods
+// always emit their field; callers are responsible for omitting zero values.
+type Buffer struct {
+	buf []byte
+}
+
+// Bytes returns the encoded message.
+func (b *Buffer) Bytes() []byte {
+	return b.buf
+}
+
+// Len returns the number of bytes written so far.
+func (b *Buffer) Len() int {
+	return len(b.buf)
+}
+
+func (b *Buffer) writeKey(field, wireType int) {
+	b.writeRawVarint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (b *Buffer) writeRawVarint(v uint64) {
+	for v >= 0x80 {
+		b.buf = append(b.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	b.buf = append(b.buf, byte(v))
+}
+
+// WriteVarint writes an unsigned integer field.
+func (b *Buffer) WriteVarint(field int, v uint64) {
+	b.writeKey(field, wireVarint)
+	b.writeRawVarint(v)
+}
+
+// WriteInt64 writes a signed integer field. Negative values use the ten byte
+// two's complement encoding of the protobuf int64 type.
+func (b *Buffer) WriteInt64(field int, v int64) {
+	b.WriteVarint(field, uint64(v))
+}
+
+// WriteBool writes a boolean field.
+func (b *Buffer) WriteBool(field int, v bool) {
+	if v {
+		b.WriteVarint(field, 1)
+	} else {
+		b.WriteVarint(field, 0)
+	}
+}
+
+// WriteString writes a string field.
+func (b *Buffer) WriteString(field int, v string) {
+	b.writeKey(field, wireBytes)
+	b.writeRawVarint(uint64(len(v)))
+	b.buf = append(b.buf, v...)
+}
+
+// WriteBytes writes a length delimited field with the provided contents.
+func (b *Buffer) WriteBytes(field int, v []byte) {
+	b.writeKey(field, wireBytes)
+	b.writeRawVarint(uint64(len(v)))
+	b.buf = append(b.buf, v...)
+}
+
+// WriteMessage writes m as an embedded message field.
+func (b *Buffer) WriteMessage(field int, m Marshaler) {
+	child := Buffer{}
+	m.ProtoMarshal(&child)
+	b.WriteBytes(field, child.buf)
+}
+
+// Decoder reads the fields of a single message in order. Errors are sticky:
+// once a read fails, Next returns false and Err reports the failure, so
+// callers may check for errors once after the loop.
+type Decoder struct {
+	data     []byte
+	pos      int
+	field    int
+	wireType int
+	err      error
+}
+
+// NewDecoder returns a Decoder reading the fields of the message in data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Next advances to the next field and reports whether there is one.
+func (d *Decoder) Next() bool {
+	if d.err != nil || d.pos >= len(d.data) {
+		return false
+	}
+	key := d.readRawVarint()
+	if d.err != nil {
+		return false
+	}
+	d.field = int(key >> 3)
+	d.wireType = int(key & 0x7)
+	if d.field <= 0 {
+		d.err = fmt.Errorf("protobuf: illegal field number %d", d.field)
+		return false
+	}
+	return true
+}
+
+// Field returns the number of the current field.
+func (d *Decoder) Field() int {
+	return d.field
+}
+
+// Err returns the first error encountered while decoding.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// fail records err unless an earlier error has been recorded.
+func (d *Decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *Decoder) expect(wireType int) bool {
+	if d.err != nil {
+		return false
+	}
+	if d.wireType != wireType {
+		d.err = fmt.Errorf("protobuf: field %d has wire type %d, expected %d", d.field, d.wireType, wireType)
+		return false
+	}
+	return true
+}
+
+func (d *Decoder) readRawVarint() uint64 {
+	var v uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		if d.pos >= len(d.data) {
+			d.fail(errTruncated)
+			return 0
+		}
+		c := d.data[d.pos]
+		d.pos++
+		v |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			return v
+		}
+	}
+	d.fail(errors.New("protobuf: varint overflows 64 bits"))
+	return 0
+}
+
+func (d *Decoder) readRawBytes() []byte {
+	n := d.readRawVarint()
+	if d.err != nil {
+		return nil
+	}
+	if n > uint64(len(d.data)-d.pos) {
+		d.fail(errTruncated)
+		return nil
+	}
+	v := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return v
+}
+
+// Varint reads the current field as an unsigned integer.
+func (d *Decoder) Varint() uint64 {
+	if !d.expect(wireVarint) {
+		return 0
+	}
+	return d.readRawVarint()
+}
+
+// Int64 reads the current field as a signed integer.
+func (d *Decoder) Int64() int64 {
+	return int64(d.Varint())
+}
+
+// Bool reads the current field as a boolean.
+func (d *Decoder) Bool() bool {
+	return d.Varint() != 0
+}
+
+// String reads the current field as a string.
+func (d *Decoder) String() string {
+	if !d.expect(wireBytes) {
+		return ""
+	}
+	return string(d.readRawBytes())
+}
+
+// Bytes reads the current field as a byte slice. The returned slice does not
+// alias the input.
+func (d *Decoder) Bytes() []byte {
+	if !d.expect(wireBytes) {
+		return nil
+	}
+	raw := d.readRawBytes()
+	if raw == nil {
+		return nil
+	}
+	v := make([]byte, len(raw))
+	copy(v, raw)
+	return v
+}
+
+// Message decodes the current field into m.
+func (d *Decoder) Message(m Unmarshaler) {
+	if !d.expect(wireBytes) {
+		return
+	}
+	raw := d.readRawBytes()
+	if d.err != nil {
+		return
+	}
+	if err := m.ProtoUnmarshal(raw); err != nil {
+		d.fail(err)
+	}
+}
+
+// Embedded returns a Decoder over the embedded message in the current field.
+// Errors from the returned Decoder are not propagated to d.
+func (d *Decoder) Embedded() *Decoder {
+	if !d.expect(wireBytes) {
+		return &Decoder{err: d.err}
+	}
+	raw := d.readRawBytes()
+	return &Decoder{data: raw, err: d.err}
+}
+
+// Skip discards the current field. Unknown fields are skipped so that older
+// clients can read objects written by newer servers.
+func (d *Decoder) Skip() {
+	switch d.wireType {
+	case wireVarint:
+		d.readRawVarint()
+	case wireBytes:
+		d.readRawBytes()
+	case wireFixed64:
+		d.skipRaw(8)
+	case wireFixed32:
+		d.skipRaw(4)
+	default:
+		d.fail(fmt.Errorf("protobuf: field %d has unsupported wire type %d", d.field, d.wireType))
+	}
+}
+
+func (d *Decoder) skipRaw(n int) {
+	if len(d.data)-d.pos < n {
+		d.fail(errTruncated)
+		return
+	}
+	d.pos += n
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/util"
+)
+
+type testMessage struct {
+	Name  string
+	Count int64
+	Ready bool
+	Data  []byte
+}
+
+func (m *testMessage) ProtoMarshal(b *Buffer) {
+	b.WriteString(1, m.Name)
+	b.WriteInt64(2, m.Count)
+	b.WriteBool(3, m.Ready)
+	b.WriteBytes(4, m.Data)
+}
+
+func (m *testMessage) ProtoUnmarshal(data []byte) error {
+	d := NewDecoder(data)
+	for d.Next() {
+		switch d.Field() {
+		case 1:
+			m.Name = d.String()
+		case 2:
+			m.Count = d.Int64()
+		case 3:
+			m.Ready = d.Bool()
+		case 4:
+			m.Data = d.Bytes()
+		default:
+			d.Skip()
+		}
+	}
+	return d.Err()
+}
+
+func TestRoundTrip(t *testing.T) {
+	testCases := []testMessage{
+		{Name: "foo", Count: 1, Ready: true, Data: []byte("bar")},
+		{Name: "", Count: -1, Data: []byte{}},
+		{Name: "very long name that spans more than one byte of length prefix", Count: 1 << 62, Data: []byte{0, 1, 2}},
+	}
+	for i, expected := range testCases {
+		b := Buffer{}
+		b.WriteMessage(5, &expected)
+
+		actual := testMessage{}
+		d := NewDecoder(b.Bytes())
+		if !d.Next() || d.Field() != 5 {
+			t.Fatalf("%d: expected field 5, got %d: %v", i, d.Field(), d.Err())
+		}
+		d.Message(&actual)
+		if d.Next() || d.Err() != nil {
+			t.Fatalf("%d: unexpected trailing data or error: %v", i, d.Err())
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%d: expected %#v, got %#v", i, expected, actual)
+		}
+	}
+}
+
+func TestSkipUnknownFields(t *testing.T) {
+	b := Buffer{}
+	b.WriteString(1, "foo")
+	b.WriteInt64(10, 42)
+	b.WriteString(11, "ignored")
+	b.buf = append(b.buf, 12<<3|wireFixed64, 1, 2, 3, 4, 5, 6, 7, 8)
+	b.buf = append(b.buf, 13<<3|wireFixed32, 1, 2, 3, 4)
+	b.WriteBool(3, true)
+
+	actual := testMessage{}
+	if err := actual.ProtoUnmarshal(b.Bytes()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual.Name != "foo" || !actual.Ready {
+		t.Errorf("unexpected message: %#v", actual)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	valid := Buffer{}
+	valid.WriteString(1, "foo")
+	wrongType := Buffer{}
+	wrongType.WriteInt64(1, 1)
+
+	testCases := map[string][]byte{
+		"truncated string": valid.Bytes()[:len(valid.Bytes())-1],
+		"truncated varint": {1<<3 | wireVarint, 0x80},
+		"wrong wire type":  wrongType.Bytes(),
+		"zero field":       {0, 0},
+		"bad wire type":    {1<<3 | 7},
+	}
+	for name, data := range testCases {
+		if err := (&testMessage{}).ProtoUnmarshal(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSpecialTypes(t *testing.T) {
+	now := util.NewTime(time.Unix(1440000000, 12345))
+	quantity := resource.MustParse("1500m")
+	intOrString := util.NewIntOrStringFromString("http")
+
+	b := Buffer{}
+	b.WriteTime(1, now)
+	b.WriteQuantity(2, quantity)
+	b.WriteIntOrString(3, intOrString)
+	b.WriteTime(4, util.Time{})
+
+	d := NewDecoder(b.Bytes())
+	for d.Next() {
+		switch d.Field() {
+		case 1:
+			if actual := d.Time(); !actual.Equal(now) {
+				t.Errorf("expected %v, got %v", now, actual)
+			}
+		case 2:
+			if actual := d.Quantity(); actual.String() != quantity.String() {
+				t.Errorf("expected %v, got %v", quantity.String(), actual.String())
+			}
+		case 3:
+			if actual := d.IntOrString(); actual != intOrString {
+				t.Errorf("expected %#v, got %#v", intOrString, actual)
+			}
+		case 4:
+			if actual := d.Time(); !actual.IsZero() {
+				t.Errorf("expected zero time, got %v", actual)
+			}
+		}
+	}
+	if err := d.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
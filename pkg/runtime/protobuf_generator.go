@@ -0,0 +1,432 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+)
+
+const protobufPkgPath = "k8s.io/kubernetes/pkg/runtime/protobuf"
+
+// ProtobufGenerator writes ProtoMarshal and ProtoUnmarshal methods, as
+// defined by pkg/runtime/protobuf, for the structs of a versioned API package.
+// Field numbers are the 1-based declaration index of each struct field.
+type ProtobufGenerator interface {
+	// AddType adds a struct type, and every struct type of the target package
+	// reachable from it, to the generator. It returns an error if a field has
+	// a type with no protobuf representation.
+	AddType(inType reflect.Type) error
+
+	// WriteProtobufFunctions writes the methods for all added types.
+	WriteProtobufFunctions(w io.Writer) error
+
+	// WriteImports writes the imports needed by the generated methods. It
+	// must be called after WriteProtobufFunctions.
+	WriteImports(w io.Writer) error
+}
+
+// protobufSpecialTypes are the shared types outside of versioned packages that
+// have hand written helpers on protobuf.Buffer and protobuf.Decoder. The value
+// is the suffix of the helper names.
+var protobufSpecialTypes = map[pkgPathNamePair]string{
+	{"k8s.io/kubernetes/pkg/util", "Time"}:             "Time",
+	{"k8s.io/kubernetes/pkg/util", "IntOrString"}:      "IntOrString",
+	{"k8s.io/kubernetes/pkg/api/resource", "Quantity"}: "Quantity",
+	{"k8s.io/kubernetes/pkg/runtime", "RawExtension"}:  "RawExtension",
+}
+
+// protobufZeroChecks are the conditions under which an optional special type
+// is written. Types not listed are always written.
+var protobufZeroChecks = map[string]string{
+	"Time":         "!%s.IsZero()",
+	"Quantity":     "%s.Amount != nil",
+	"RawExtension": "len(%s.RawJSON) != 0",
+}
+
+func NewProtobufGenerator(targetPkg string) ProtobufGenerator {
+	g := &protobufGenerator{
+		targetPkg: targetPkg,
+		types:     make(map[reflect.Type]bool),
+		imports:   make(map[string]string),
+	}
+	g.addImport(protobufPkgPath)
+	return g
+}
+
+type protobufGenerator struct {
+	targetPkg string
+	types     map[reflect.Type]bool
+	// map of package paths to short names
+	imports map[string]string
+}
+
+func (g *protobufGenerator) addImport(pkg string) string {
+	if pkg == g.targetPkg {
+		return ""
+	}
+	if name, ok := g.imports[pkg]; ok {
+		return name
+	}
+	name := path.Base(pkg)
+	for _, existing := range g.imports {
+		if existing == name {
+			name = path.Base(path.Dir(pkg)) + name
+			break
+		}
+	}
+	g.imports[pkg] = name
+	return name
+}
+
+func (g *protobufGenerator) special(inType reflect.Type) (string, bool) {
+	name, ok := protobufSpecialTypes[pkgPathNamePair{inType.PkgPath(), inType.Name()}]
+	return name, ok
+}
+
+func (g *protobufGenerator) AddType(inType reflect.Type) error {
+	if inType.Kind() != reflect.Struct {
+		return fmt.Errorf("non-struct types are not supported: %v", inType)
+	}
+	return g.addAllRecursiveTypes(inType)
+}
+
+func (g *protobufGenerator) addAllRecursiveTypes(inType reflect.Type) error {
+	if _, found := g.types[inType]; found {
+		return nil
+	}
+	if _, ok := g.special(inType); ok {
+		return nil
+	}
+	switch inType.Kind() {
+	case reflect.Map:
+		if inType.Key().Kind() != reflect.String {
+			return fmt.Errorf("map keys must be strings: %v", inType)
+		}
+		return g.addAllRecursiveTypes(inType.Elem())
+	case reflect.Ptr:
+		return g.addAllRecursiveTypes(inType.Elem())
+	case reflect.Slice:
+		elem := inType.Elem()
+		if elem.Kind() == reflect.Uint8 {
+			return nil
+		}
+		if elem.Kind() == reflect.Slice && elem.Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("nested slices are not supported: %v", inType)
+		}
+		return g.addAllRecursiveTypes(elem)
+	case reflect.Struct:
+		if inType.PkgPath() != g.targetPkg {
+			return fmt.Errorf("struct %v is outside of %s and has no protobuf helper", inType, g.targetPkg)
+		}
+		g.types[inType] = true
+		for i := 0; i < inType.NumField(); i++ {
+			if err := g.addAllRecursiveTypes(inType.Field(i).Type); err != nil {
+				return fmt.Errorf("%s.%s: %v", inType.Name(), inType.Field(i).Name, err)
+			}
+		}
+		return nil
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	default:
+		return fmt.Errorf("type %v of kind %v has no protobuf representation", inType, inType.Kind())
+	}
+}
+
+func (g *protobufGenerator) WriteImports(w io.Writer) error {
+	var packages []string
+	for key := range g.imports {
+		packages = append(packages, key)
+	}
+	sort.Strings(packages)
+
+	buffer := newBuffer()
+	indent := 0
+	buffer.addLine("import (\n", indent)
+	for _, importPkg := range packages {
+		name := g.imports[importPkg]
+		if name == path.Base(importPkg) {
+			buffer.addLine(fmt.Sprintf("\"%s\"\n", importPkg), indent+1)
+		} else {
+			buffer.addLine(fmt.Sprintf("%s \"%s\"\n", name, importPkg), indent+1)
+		}
+	}
+	buffer.addLine(")\n", indent)
+	buffer.addLine("\n", indent)
+	return buffer.flushLines(w)
+}
+
+func (g *protobufGenerator) typeName(inType reflect.Type) string {
+	switch {
+	case len(inType.Name()) != 0:
+		if len(inType.PkgPath()) == 0 {
+			return inType.Name()
+		}
+		if short := g.addImport(inType.PkgPath()); len(short) != 0 {
+			return short + "." + inType.Name()
+		}
+		return inType.Name()
+	case inType.Kind() == reflect.Slice:
+		return "[]" + g.typeName(inType.Elem())
+	case inType.Kind() == reflect.Ptr:
+		return "*" + g.typeName(inType.Elem())
+	case inType.Kind() == reflect.Map:
+		return fmt.Sprintf("map[%s]%s", g.typeName(inType.Key()), g.typeName(inType.Elem()))
+	}
+	panic(fmt.Sprintf("unnamed type %v is not supported", inType))
+}
+
+// isBasic returns true if the type needs no conversion to be passed to or
+// returned from the protobuf helpers of the given Go type.
+func isBasic(inType reflect.Type, basic string) bool {
+	return len(inType.PkgPath()) == 0 && inType.Name() == basic
+}
+
+func (g *protobufGenerator) WriteProtobufFunctions(w io.Writer) error {
+	var keys []reflect.Type
+	for key := range g.types {
+		keys = append(keys, key)
+	}
+	sort.Sort(byPkgAndName(keys))
+
+	buffer := newBuffer()
+	for _, inType := range keys {
+		if err := g.writeMarshal(buffer, inType); err != nil {
+			return err
+		}
+		buffer.addLine("\n", 0)
+		if err := g.writeUnmarshal(buffer, inType); err != nil {
+			return err
+		}
+		buffer.addLine("\n", 0)
+	}
+	return buffer.flushLines(w)
+}
+
+func (g *protobufGenerator) writeMarshal(b *buffer, inType reflect.Type) error {
+	b.addLine("// ProtoMarshal implements protobuf.Marshaler.\n", 0)
+	b.addLine(fmt.Sprintf("func (obj *%s) ProtoMarshal(b *protobuf.Buffer) {\n", inType.Name()), 0)
+	for i := 0; i < inType.NumField(); i++ {
+		field := inType.Field(i)
+		if err := g.writeEncode(b, 1, 1, "b", i+1, "obj."+field.Name, field.Type, true); err != nil {
+			return err
+		}
+	}
+	b.addLine("}\n", 0)
+	return nil
+}
+
+// writeEncode writes statements that encode expr of type inType as field
+// number into the buffer named buf. Zero values are skipped if optional is
+// true. depth is used to name temporary variables.
+func (g *protobufGenerator) writeEncode(b *buffer, indent, depth int, buf string, field int, expr string, inType reflect.Type, optional bool) error {
+	if name, ok := g.special(inType); ok {
+		check, hasCheck := protobufZeroChecks[name]
+		if optional && hasCheck {
+			b.addLine(fmt.Sprintf("if "+check+" {\n", expr), indent)
+			b.addLine(fmt.Sprintf("%s.Write%s(%d, %s)\n", buf, name, field, expr), indent+1)
+			b.addLine("}\n", indent)
+		} else {
+			b.addLine(fmt.Sprintf("%s.Write%s(%d, %s)\n", buf, name, field, expr), indent)
+		}
+		return nil
+	}
+
+	write := func(cond, stmt string) {
+		if optional {
+			b.addLine(fmt.Sprintf("if %s {\n", cond), indent)
+			b.addLine(stmt, indent+1)
+			b.addLine("}\n", indent)
+		} else {
+			b.addLine(stmt, indent)
+		}
+	}
+	switch inType.Kind() {
+	case reflect.String:
+		value := expr
+		if !isBasic(inType, "string") {
+			value = fmt.Sprintf("string(%s)", expr)
+		}
+		write(fmt.Sprintf("len(%s) != 0", expr), fmt.Sprintf("%s.WriteString(%d, %s)\n", buf, field, value))
+	case reflect.Bool:
+		value := expr
+		if !isBasic(inType, "bool") {
+			value = fmt.Sprintf("bool(%s)", expr)
+		}
+		write(value, fmt.Sprintf("%s.WriteBool(%d, %s)\n", buf, field, value))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		write(fmt.Sprintf("%s != 0", expr), fmt.Sprintf("%s.WriteInt64(%d, int64(%s))\n", buf, field, expr))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		write(fmt.Sprintf("%s != 0", expr), fmt.Sprintf("%s.WriteVarint(%d, uint64(%s))\n", buf, field, expr))
+	case reflect.Struct:
+		b.addLine(fmt.Sprintf("%s.WriteMessage(%d, &%s)\n", buf, field, expr), indent)
+	case reflect.Ptr:
+		b.addLine(fmt.Sprintf("if %s != nil {\n", expr), indent)
+		elem := inType.Elem()
+		if _, ok := g.special(elem); !ok && elem.Kind() == reflect.Struct {
+			b.addLine(fmt.Sprintf("%s.WriteMessage(%d, %s)\n", buf, field, expr), indent+1)
+		} else if err := g.writeEncode(b, indent+1, depth, buf, field, "*"+expr, elem, false); err != nil {
+			return err
+		}
+		b.addLine("}\n", indent)
+	case reflect.Slice:
+		if inType.Elem().Kind() == reflect.Uint8 {
+			write(fmt.Sprintf("len(%s) != 0", expr), fmt.Sprintf("%s.WriteBytes(%d, %s)\n", buf, field, expr))
+			return nil
+		}
+		i := fmt.Sprintf("i%d", depth)
+		b.addLine(fmt.Sprintf("for %s := range %s {\n", i, expr), indent)
+		if err := g.writeEncode(b, indent+1, depth+1, buf, field, fmt.Sprintf("%s[%s]", expr, i), inType.Elem(), false); err != nil {
+			return err
+		}
+		b.addLine("}\n", indent)
+	case reflect.Map:
+		k, v, entry := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth), fmt.Sprintf("entry%d", depth)
+		b.addLine(fmt.Sprintf("for %s, %s := range %s {\n", k, v, expr), indent)
+		b.addLine(fmt.Sprintf("%s := protobuf.Buffer{}\n", entry), indent+1)
+		if err := g.writeEncode(b, indent+1, depth+1, entry, 1, k, inType.Key(), false); err != nil {
+			return err
+		}
+		if err := g.writeEncode(b, indent+1, depth+1, entry, 2, v, inType.Elem(), false); err != nil {
+			return err
+		}
+		b.addLine(fmt.Sprintf("%s.WriteBytes(%d, %s.Bytes())\n", buf, field, entry), indent+1)
+		b.addLine("}\n", indent)
+	default:
+		return fmt.Errorf("type %v of kind %v has no protobuf representation", inType, inType.Kind())
+	}
+	return nil
+}
+
+func (g *protobufGenerator) writeUnmarshal(b *buffer, inType reflect.Type) error {
+	b.addLine("// ProtoUnmarshal implements protobuf.Unmarshaler.\n", 0)
+	b.addLine(fmt.Sprintf("func (obj *%s) ProtoUnmarshal(data []byte) error {\n", inType.Name()), 0)
+	b.addLine("d := protobuf.NewDecoder(data)\n", 1)
+	if err := g.writeFieldLoop(b, 1, "d", func(b *buffer, indent int) error {
+		for i := 0; i < inType.NumField(); i++ {
+			field := inType.Field(i)
+			b.addLine(fmt.Sprintf("case %d:\n", i+1), indent)
+			if err := g.writeDecode(b, indent+1, 1, "d", "obj."+field.Name, field.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	b.addLine("return d.Err()\n", 1)
+	b.addLine("}\n", 0)
+	return nil
+}
+
+// writeFieldLoop writes a loop over the fields of the decoder named dec. The
+// cases callback writes one case clause per known field.
+func (g *protobufGenerator) writeFieldLoop(b *buffer, indent int, dec string, cases func(*buffer, int) error) error {
+	b.addLine(fmt.Sprintf("for %s.Next() {\n", dec), indent)
+	b.addLine(fmt.Sprintf("switch %s.Field() {\n", dec), indent+1)
+	if err := cases(b, indent+1); err != nil {
+		return err
+	}
+	b.addLine("default:\n", indent+1)
+	b.addLine(fmt.Sprintf("%s.Skip()\n", dec), indent+2)
+	b.addLine("}\n", indent+1)
+	b.addLine("}\n", indent)
+	return nil
+}
+
+// writeDecode writes statements that decode the current field of the decoder
+// named dec into lhs, which has type inType.
+func (g *protobufGenerator) writeDecode(b *buffer, indent, depth int, dec, lhs string, inType reflect.Type) error {
+	if name, ok := g.special(inType); ok {
+		b.addLine(fmt.Sprintf("%s = %s.%s()\n", lhs, dec, name), indent)
+		return nil
+	}
+
+	convert := func(value, basic string) string {
+		if isBasic(inType, basic) {
+			return value
+		}
+		return fmt.Sprintf("%s(%s)", g.typeName(inType), value)
+	}
+	switch inType.Kind() {
+	case reflect.String:
+		b.addLine(fmt.Sprintf("%s = %s\n", lhs, convert(dec+".String()", "string")), indent)
+	case reflect.Bool:
+		b.addLine(fmt.Sprintf("%s = %s\n", lhs, convert(dec+".Bool()", "bool")), indent)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.addLine(fmt.Sprintf("%s = %s\n", lhs, convert(dec+".Int64()", "int64")), indent)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.addLine(fmt.Sprintf("%s = %s\n", lhs, convert(dec+".Varint()", "uint64")), indent)
+	case reflect.Struct:
+		b.addLine(fmt.Sprintf("%s.Message(&%s)\n", dec, lhs), indent)
+	case reflect.Ptr:
+		elem := inType.Elem()
+		if _, ok := g.special(elem); !ok && elem.Kind() == reflect.Struct {
+			b.addLine(fmt.Sprintf("%s = &%s{}\n", lhs, g.typeName(elem)), indent)
+			b.addLine(fmt.Sprintf("%s.Message(%s)\n", dec, lhs), indent)
+			return nil
+		}
+		v := fmt.Sprintf("v%d", depth)
+		b.addLine(fmt.Sprintf("var %s %s\n", v, g.typeName(elem)), indent)
+		if err := g.writeDecode(b, indent, depth+1, dec, v, elem); err != nil {
+			return err
+		}
+		b.addLine(fmt.Sprintf("%s = &%s\n", lhs, v), indent)
+	case reflect.Slice:
+		elem := inType.Elem()
+		if elem.Kind() == reflect.Uint8 {
+			b.addLine(fmt.Sprintf("%s = %s.Bytes()\n", lhs, dec), indent)
+			return nil
+		}
+		v := fmt.Sprintf("v%d", depth)
+		b.addLine(fmt.Sprintf("var %s %s\n", v, g.typeName(elem)), indent)
+		if err := g.writeDecode(b, indent, depth+1, dec, v, elem); err != nil {
+			return err
+		}
+		b.addLine(fmt.Sprintf("%s = append(%s, %s)\n", lhs, lhs, v), indent)
+	case reflect.Map:
+		k, v, entry := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth), fmt.Sprintf("entry%d", depth)
+		b.addLine(fmt.Sprintf("var %s %s\n", k, g.typeName(inType.Key())), indent)
+		b.addLine(fmt.Sprintf("var %s %s\n", v, g.typeName(inType.Elem())), indent)
+		b.addLine(fmt.Sprintf("%s := %s.Embedded()\n", entry, dec), indent)
+		if err := g.writeFieldLoop(b, indent, entry, func(b *buffer, indent int) error {
+			b.addLine("case 1:\n", indent)
+			if err := g.writeDecode(b, indent+1, depth+1, entry, k, inType.Key()); err != nil {
+				return err
+			}
+			b.addLine("case 2:\n", indent)
+			return g.writeDecode(b, indent+1, depth+1, entry, v, inType.Elem())
+		}); err != nil {
+			return err
+		}
+		b.addLine(fmt.Sprintf("if err := %s.Err(); err != nil {\n", entry), indent)
+		b.addLine("return err\n", indent+1)
+		b.addLine("}\n", indent)
+		b.addLine(fmt.Sprintf("if %s == nil {\n", lhs), indent)
+		b.addLine(fmt.Sprintf("%s = make(%s)\n", lhs, g.typeName(inType)), indent+1)
+		b.addLine("}\n", indent)
+		b.addLine(fmt.Sprintf("%s[%s] = %s\n", lhs, k, v), indent)
+	default:
+		return fmt.Errorf("type %v of kind %v has no protobuf representation", inType, inType.Kind())
+	}
+	return nil
+}
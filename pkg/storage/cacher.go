@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/unversioned/cache"
@@ -56,6 +57,10 @@ type CacherConfig struct {
 	// objects of type Type.
 	NewListFunc func() runtime.Object
 
+	// ProgressInterval is how often watchers get a bookmark event with the
+	// resource version the cache is synced to. Zero disables bookmarks.
+	ProgressInterval time.Duration
+
 	// Cacher will be stopped when the StopChannel will be closed.
 	StopChannel <-chan struct{}
 }
@@ -98,6 +103,9 @@ type Cacher struct {
 
 	// keyFunc is used to get a key in the underyling storage for a given object.
 	keyFunc func(runtime.Object) (string, error)
+
+	// objectType is the type of cached objects, used to create bookmarks.
+	objectType reflect.Type
 }
 
 // Create a new Cacher responsible from service WATCH and LIST requests from its
@@ -116,6 +124,7 @@ func NewCacher(config CacherConfig) *Cacher {
 		watchers:   make(map[int]*cacheWatcher),
 		versioner:  config.Versioner,
 		keyFunc:    config.KeyFunc,
+		objectType: reflect.TypeOf(config.Type),
 	}
 	cacher.usable.Lock()
 	// See startCaching method for why explanation on it.
@@ -124,6 +133,9 @@ func NewCacher(config CacherConfig) *Cacher {
 
 	stopCh := config.StopChannel
 	go util.Until(func() { cacher.startCaching(stopCh) }, 0, stopCh)
+	if config.ProgressInterval > 0 {
+		go util.Until(cacher.sendProgress, config.ProgressInterval, stopCh)
+	}
 	return cacher
 }
 
@@ -280,6 +292,39 @@ func (c *Cacher) processEvent(event cache.WatchCacheEvent) {
 	}
 }
 
+// sendProgress sends a bookmark with the current resource version of the cache
+// to all watchers. This lets clients whose filters did not match any recent
+// changes resume their watches from a recent point instead of relisting.
+func (c *Cacher) sendProgress() {
+	c.usable.RLock()
+	defer c.usable.RUnlock()
+
+	// No event can be processed while we hold the watchCache lock, so every
+	// event up to resourceVersion has already been queued for the watchers.
+	c.watchCache.RLock()
+	defer c.watchCache.RUnlock()
+	resourceVersion := c.watchCache.GetResourceVersionThreadUnsafe()
+	if resourceVersion == 0 {
+		return
+	}
+	object, ok := reflect.New(c.objectType.Elem()).Interface().(runtime.Object)
+	if !ok {
+		glog.Errorf("cannot create bookmark object of type %v", c.objectType)
+		return
+	}
+	if err := c.storage.Versioner().UpdateObject(object, nil, resourceVersion); err != nil {
+		glog.Errorf("unexpected bookmark error: %v", err)
+		return
+	}
+	event := cache.WatchCacheEvent{Type: watch.Bookmark, Object: object}
+
+	c.Lock()
+	defer c.Unlock()
+	for _, watcher := range c.watchers {
+		watcher.addProgress(event)
+	}
+}
+
 func (c *Cacher) terminateAllWatchers() {
 	c.Lock()
 	defer c.Unlock()
@@ -404,7 +449,21 @@ func (c *cacheWatcher) add(event cache.WatchCacheEvent) {
 	c.input <- event
 }
 
+// addProgress is like add, but drops the event if the watcher is lagging
+// behind. Bookmarks are only an optimization and are not worth blocking for.
+func (c *cacheWatcher) addProgress(event cache.WatchCacheEvent) {
+	select {
+	case c.input <- event:
+	default:
+	}
+}
+
 func (c *cacheWatcher) sendWatchCacheEvent(event cache.WatchCacheEvent) {
+	if event.Type == watch.Bookmark {
+		// Bookmarks are not subject to filtering.
+		c.result <- watch.Event{Type: watch.Bookmark, Object: event.Object}
+		return
+	}
 	curObjPasses := event.Type != watch.Deleted && c.filter(event.Object)
 	oldObjPasses := false
 	if event.PrevObject != nil {
@@ -39,16 +39,21 @@ import (
 )
 
 func newTestCacher(client tools.EtcdClient) *storage.Cacher {
+	return newTestCacherWithProgress(client, 0)
+}
+
+func newTestCacherWithProgress(client tools.EtcdClient, progressInterval time.Duration) *storage.Cacher {
 	prefix := "pods"
 	config := storage.CacherConfig{
-		CacheCapacity:  10,
-		Versioner:      etcdstorage.APIObjectVersioner{},
-		Storage:        etcdstorage.NewEtcdStorage(client, testapi.Codec(), etcdtest.PathPrefix()),
-		Type:           &api.Pod{},
-		ResourcePrefix: prefix,
-		KeyFunc:        func(obj runtime.Object) (string, error) { return storage.NamespaceKeyFunc(prefix, obj) },
-		NewListFunc:    func() runtime.Object { return &api.PodList{} },
-		StopChannel:    util.NeverStop,
+		CacheCapacity:    10,
+		Versioner:        etcdstorage.APIObjectVersioner{},
+		Storage:          etcdstorage.NewEtcdStorage(client, testapi.Codec(), etcdtest.PathPrefix()),
+		Type:             &api.Pod{},
+		ResourcePrefix:   prefix,
+		KeyFunc:          func(obj runtime.Object) (string, error) { return storage.NamespaceKeyFunc(prefix, obj) },
+		NewListFunc:      func() runtime.Object { return &api.PodList{} },
+		ProgressInterval: progressInterval,
+		StopChannel:      util.NeverStop,
 	}
 	return storage.NewCacher(config)
 }
@@ -479,3 +484,48 @@ func TestStorageError(t *testing.T) {
 		t.Errorf("unexpected event")
 	}
 }
+
+func TestProgressEvents(t *testing.T) {
+	fakeClient := tools.NewFakeEtcdClient(t)
+	prefixedKey := etcdtest.AddPrefix("pods")
+	fakeClient.ExpectNotFoundGet(prefixedKey)
+	cacher := newTestCacherWithProgress(fakeClient, 10*time.Millisecond)
+	fakeClient.WaitForWatchCompletion()
+
+	// Watch a pod that is never modified, so that the only events
+	// the watcher can get are bookmarks.
+	watcher, err := cacher.Watch("pods/ns/bar", 1, storage.Everything)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fakeClient.WatchResponse <- &etcd.Response{
+		Action: "create",
+		Node: &etcd.Node{
+			Value:         string(runtime.EncodeOrDie(testapi.Codec(), makeTestPod("foo"))),
+			CreatedIndex:  5,
+			ModifiedIndex: 5,
+		},
+	}
+	if err := waitForUpToDateCache(cacher, 5); err != nil {
+		t.Fatalf("watch cache didn't propagated correctly: %v", err)
+	}
+
+	// Bookmarks sent before the create was processed may still be queued.
+	for {
+		event := <-watcher.ResultChan()
+		if e, a := watch.Bookmark, event.Type; e != a {
+			t.Fatalf("expected %v, got %v", e, a)
+		}
+		obj := event.Object.(*api.Pod)
+		if obj.Name != "" {
+			t.Errorf("unexpected object in bookmark: %#v", obj)
+		}
+		if obj.ResourceVersion == "5" {
+			break
+		}
+	}
+
+	watcher.Stop()
+	close(fakeClient.WatchResponse)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bandwidth
+
+import (
+	"k8s.io/kubernetes/pkg/api/resource"
+)
+
+// FakeShaper is a BandwidthShaper for testing, which records the CIDRs it
+// limits and resets.
+type FakeShaper struct {
+	CIDRs      []string
+	ResetCIDRs []string
+	// EgressCIDRs and IngressCIDRs are the CIDRs limited in each direction.
+	EgressCIDRs  []string
+	IngressCIDRs []string
+}
+
+func (f *FakeShaper) Limit(cidr string, egress, ingress *resource.Quantity) error {
+	f.CIDRs = append(f.CIDRs, cidr)
+	if egress != nil {
+		f.EgressCIDRs = append(f.EgressCIDRs, cidr)
+	}
+	if ingress != nil {
+		f.IngressCIDRs = append(f.IngressCIDRs, cidr)
+	}
+	return nil
+}
+
+func (f *FakeShaper) Reset(cidr string) error {
+	f.ResetCIDRs = append(f.ResetCIDRs, cidr)
+	return nil
+}
+
+func (f *FakeShaper) ReconcileInterface() error {
+	return nil
+}
+
+func (f *FakeShaper) ReconcileCIDR(cidr string, egress, ingress *resource.Quantity) error {
+	for _, existing := range f.CIDRs {
+		if existing == cidr {
+			return nil
+		}
+	}
+	return f.Limit(cidr, egress, ingress)
+}
+
+func (f *FakeShaper) GetCIDRs() ([]string, error) {
+	return f.CIDRs, nil
+}
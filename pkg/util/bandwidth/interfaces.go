@@ -22,11 +22,21 @@ type BandwidthShaper interface {
 	// Limit the bandwidth for a particular CIDR on a particular interface
 	//   * ingress and egress are in bits/second
 	//   * cidr is expected to be a valid network CIDR (e.g. '1.2.3.4/32' or '10.20.0.1/16')
-	// 'egress' bandwidth limit applies to all packets on the interface whose source matches 'cidr'
-	// 'ingress' bandwidth limit applies to all packets on the interface whose destination matches 'cidr'
+	// 'egress' bandwidth limit applies to all packets sent on the interface whose source matches 'cidr'
+	// 'ingress' bandwidth limit applies to all packets sent on the interface whose destination matches 'cidr'
+	// Only packets sent on the interface are shaped, so the egress of a pod is limited on the
+	// interface of the pod, and its ingress on the bridge the pod is attached to.
+	// A nil limit leaves that direction unlimited.
 	// Limits are aggregate limits for the CIDR, not per IP address.  CIDRs must be unique, but can be overlapping, traffic
 	// that matches multiple CIDRs counts against all limits.
-	Limit(cidr string, egress, ingress resource.Quantity) error
+	Limit(cidr string, egress, ingress *resource.Quantity) error
 	// Remove a bandwidth limit for a particular CIDR on a particular network interface
 	Reset(cidr string) error
+	// Reconcile the interface managed by this shaper with the state on the ground.
+	ReconcileInterface() error
+	// Reconcile a CIDR managed by this shaper with the state on the ground: the CIDR is limited
+	// if it isn't yet, and its limits are replaced if they differ from 'egress' and 'ingress'.
+	ReconcileCIDR(cidr string, egress, ingress *resource.Quantity) error
+	// GetCIDRs returns the set of CIDRs that are being managed by this shaper
+	GetCIDRs() ([]string, error)
 }
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"k8s.io/kubernetes/pkg/api/resource"
@@ -40,33 +41,59 @@ import (
 type tcShaper struct {
 	e     exec.Interface
 	iface string
+	// netns is the path of the network namespace of the interface, or empty
+	// for the namespace of the caller.
+	netns string
 }
 
+// NewTCShaper returns a shaper of the interface iface.  The interface is
+// set up by ReconcileInterface.
 func NewTCShaper(iface string) BandwidthShaper {
 	shaper := &tcShaper{
 		e:     exec.New(),
 		iface: iface,
 	}
-	shaper.initializeInterface()
 	return shaper
 }
 
-func (t *tcShaper) execAndLog(cmdStr string, args ...string) error {
-	glog.V(6).Infof("Running: %s %s", cmdStr, strings.Join(args, " "))
-	cmd := t.e.Command(cmdStr, args...)
+// NewTCShaperInNetNs returns a shaper of the interface iface in the network
+// namespace netns, e.g. the interface of a pod, which tc reaches through
+// nsenter.  The interface is set up by ReconcileInterface.
+func NewTCShaperInNetNs(netns, iface string) BandwidthShaper {
+	return &tcShaper{
+		e:     exec.New(),
+		iface: iface,
+		netns: netns,
+	}
+}
+
+// tc returns the command running tc with args in the network namespace of
+// the interface.
+func (t *tcShaper) tc(args ...string) exec.Cmd {
+	if len(t.netns) == 0 {
+		return t.e.Command("tc", args...)
+	}
+	return t.e.Command("nsenter", append([]string{"--net=" + t.netns, "-F", "--", "tc"}, args...)...)
+}
+
+func (t *tcShaper) execAndLog(args ...string) error {
+	glog.V(6).Infof("Running: tc %s (network namespace %q)", strings.Join(args, " "), t.netns)
+	cmd := t.tc(args...)
 	out, err := cmd.CombinedOutput()
 	glog.V(6).Infof("Output from tc: %s", string(out))
 	return err
 }
 
-func (t *tcShaper) nextClassID() (int, error) {
-	data, err := t.e.Command("tc", "class", "show", "dev", t.iface).CombinedOutput()
+// classRates returns the rates of the classes on the interface in
+// bits/second, by class ID.
+func (t *tcShaper) classRates() (map[string]int64, error) {
+	data, err := t.tc("class", "show", "dev", t.iface).CombinedOutput()
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	scanner := bufio.NewScanner(bytes.NewBuffer(data))
-	classes := util.StringSet{}
+	rates := map[string]int64{}
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		// skip empty lines
@@ -77,14 +104,26 @@ func (t *tcShaper) nextClassID() (int, error) {
 		// expected tc line:
 		// class htb 1:1 root prio 0 rate 1000Kbit ceil 1000Kbit burst 1600b cburst 1600b
 		if len(parts) != 14 {
-			return -1, fmt.Errorf("unexpected output from tc: %s (%v)", scanner.Text(), parts)
+			return nil, fmt.Errorf("unexpected output from tc: %s (%v)", scanner.Text(), parts)
 		}
-		classes.Insert(parts[2])
+		rate, err := parseRate(parts[7])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected output from tc: %s (%v)", scanner.Text(), err)
+		}
+		rates[parts[2]] = rate
+	}
+	return rates, nil
+}
+
+func (t *tcShaper) nextClassID() (int, error) {
+	rates, err := t.classRates()
+	if err != nil {
+		return -1, err
 	}
 
 	// Make sure it doesn't go forever
 	for nextClass := 1; nextClass < 10000; nextClass++ {
-		if !classes.Has(fmt.Sprintf("1:%d", nextClass)) {
+		if _, found := rates[fmt.Sprintf("1:%d", nextClass)]; !found {
 			return nextClass, nil
 		}
 	}
@@ -92,33 +131,94 @@ func (t *tcShaper) nextClassID() (int, error) {
 	return -1, fmt.Errorf("exhausted class space, please try again")
 }
 
+// rateUnits are the units of rates printed by tc, largest first.
+var rateUnits = []struct {
+	suffix string
+	bits   int64
+}{
+	{"Tbit", 1000 * 1000 * 1000 * 1000},
+	{"Gbit", 1000 * 1000 * 1000},
+	{"Mbit", 1000 * 1000},
+	{"Kbit", 1000},
+	{"bit", 1},
+}
+
+// Convert a rate printed by tc (e.g. '10Mbit') to bits/second
+func parseRate(rate string) (int64, error) {
+	for _, unit := range rateUnits {
+		if strings.HasSuffix(rate, unit.suffix) {
+			value, err := strconv.ParseInt(strings.TrimSuffix(rate, unit.suffix), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return value * unit.bits, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rate: %s", rate)
+}
+
 // Convert a CIDR from text to a hex representation
 // Strips any masked parts of the IP, so 1.2.3.4/16 becomes hex(1.2.0.0)/ffffffff
+// Only IPv4 CIDRs are supported, as the filters match the IPv4 header.
 func hexCIDR(cidr string) (string, error) {
 	ip, ipnet, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return "", err
 	}
+	if ip.To4() == nil {
+		return "", fmt.Errorf("only IPv4 CIDRs can be shaped: %s", cidr)
+	}
 	ip = ip.Mask(ipnet.Mask)
 	hexIP := hex.EncodeToString([]byte(ip.To4()))
 	hexMask := ipnet.Mask.String()
 	return hexIP + "/" + hexMask, nil
 }
 
-func (t *tcShaper) findCIDRClass(cidr string) (class, handle string, err error) {
-	data, err := t.e.Command("tc", "filter", "show", "dev", t.iface).CombinedOutput()
+// Convert a CIDR from hex representation to text, opposite of the above.
+func asciiCIDR(cidr string) (string, error) {
+	parts := strings.Split(cidr, "/")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("unexpected CIDR format: %s", cidr)
+	}
+	ipData, err := hex.DecodeString(parts[0])
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
+	maskData, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+	size, _ := net.IPMask(maskData).Size()
+	return fmt.Sprintf("%s/%d", net.IP(ipData).String(), size), nil
+}
 
-	hex, err := hexCIDR(cidr)
+// The offsets in the IP header of the addresses matched by filters, as shown
+// by tc.
+const (
+	srcOffset = "12"
+	dstOffset = "16"
+)
+
+// tcFilter is a filter sending the packets from or to a CIDR to a class.
+type tcFilter struct {
+	class  string
+	handle string
+	// cidr is the matched CIDR, in hex.
+	cidr string
+	// dst is whether the filter matches the destination of packets (ingress)
+	// rather than their source (egress).
+	dst bool
+}
+
+func (t *tcShaper) filters() ([]tcFilter, error) {
+	data, err := t.tc("filter", "show", "dev", t.iface).CombinedOutput()
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	spec := fmt.Sprintf("match %s", hex)
 
 	scanner := bufio.NewScanner(bytes.NewBuffer(data))
 	filter := ""
+	filters := []tcFilter{}
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if len(line) == 0 {
@@ -128,17 +228,40 @@ func (t *tcShaper) findCIDRClass(cidr string) (class, handle string, err error)
 			filter = line
 			continue
 		}
-		if strings.Contains(line, spec) {
-			parts := strings.Split(filter, " ")
-			// expected tc line:
-			// filter parent 1: protocol ip pref 1 u32 fh 800::800 order 2048 key ht 800 bkt 0 flowid 1:1
-			if len(parts) != 19 {
-				return "", "", fmt.Errorf("unexpected output from tc: %s %d (%v)", filter, len(parts), parts)
-			}
-			return parts[18], parts[9], nil
+		// expected tc line:
+		//   match ac110002/ffffffff at 16
+		match := strings.Split(line, " ")
+		if len(match) != 4 || match[0] != "match" {
+			continue
+		}
+		parts := strings.Split(filter, " ")
+		// expected tc line:
+		// filter parent 1: protocol ip pref 1 u32 fh 800::800 order 2048 key ht 800 bkt 0 flowid 1:1
+		if len(parts) != 19 {
+			return nil, fmt.Errorf("unexpected output from tc: %s %d (%v)", filter, len(parts), parts)
+		}
+		filters = append(filters, tcFilter{class: parts[18], handle: parts[9], cidr: match[1], dst: match[3] == dstOffset})
+	}
+	return filters, nil
+}
+
+// findCIDRFilters returns the filters of the cidr, if any.
+func (t *tcShaper) findCIDRFilters(cidr string) ([]tcFilter, error) {
+	filters, err := t.filters()
+	if err != nil {
+		return nil, err
+	}
+	hex, err := hexCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	cidrFilters := []tcFilter{}
+	for _, filter := range filters {
+		if filter.cidr == hex {
+			cidrFilters = append(cidrFilters, filter)
 		}
 	}
-	return "", "", fmt.Errorf("Failed to find cidr: %s on interface: %s", cidr, t.iface)
+	return cidrFilters, nil
 }
 
 func makeKBitString(rsrc resource.Quantity) string {
@@ -150,41 +273,144 @@ func (t *tcShaper) makeNewClass(rate string) (int, error) {
 	if err != nil {
 		return -1, err
 	}
-	if err := t.execAndLog("tc", "class", "add", "dev", t.iface, "parent", "1:", "classid", fmt.Sprintf("1:%d", class), "htb", "rate", rate); err != nil {
+	if err := t.execAndLog("class", "add", "dev", t.iface, "parent", "1:", "classid", fmt.Sprintf("1:%d", class), "htb", "rate", rate); err != nil {
 		return -1, err
 	}
 	return class, nil
 }
 
-func (t *tcShaper) Limit(cidr string, upload, download resource.Quantity) (err error) {
-	var downloadClass, uploadClass int
-	if downloadClass, err = t.makeNewClass(makeKBitString(download)); err != nil {
+func (t *tcShaper) Limit(cidr string, egress, ingress *resource.Quantity) (err error) {
+	var ingressClass, egressClass int
+	if ingress != nil {
+		if ingressClass, err = t.makeNewClass(makeKBitString(*ingress)); err != nil {
+			return err
+		}
+	}
+	if egress != nil {
+		if egressClass, err = t.makeNewClass(makeKBitString(*egress)); err != nil {
+			return err
+		}
+	}
+
+	if ingress != nil {
+		if err := t.execAndLog("filter", "add", "dev", t.iface, "protocol", "ip", "parent", "1:0", "prio", "1", "u32", "match", "ip", "dst", cidr, "flowid", fmt.Sprintf("1:%d", ingressClass)); err != nil {
+			return err
+		}
+	}
+	if egress != nil {
+		if err := t.execAndLog("filter", "add", "dev", t.iface, "protocol", "ip", "parent", "1:0", "prio", "1", "u32", "match", "ip", "src", cidr, "flowid", fmt.Sprintf("1:%d", egressClass)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interfaceExists returns whether the root queueing discipline of the shaper
+// is set up on the interface.
+func (t *tcShaper) interfaceExists() (bool, error) {
+	data, err := t.tc("qdisc", "show", "dev", t.iface).CombinedOutput()
+	if err != nil {
+		return false, err
+	}
+	scanner := bufio.NewScanner(bytes.NewBuffer(data))
+	for scanner.Scan() {
+		// expected tc line:
+		// qdisc htb 1: root refcnt 2 r2q 10 default 30 direct_packets_stat 0
+		parts := strings.Split(strings.TrimSpace(scanner.Text()), " ")
+		if len(parts) >= 4 && parts[0] == "qdisc" && parts[1] == "htb" && parts[2] == "1:" && parts[3] == "root" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *tcShaper) initializeInterface() error {
+	return t.execAndLog("qdisc", "add", "dev", t.iface, "root", "handle", "1:", "htb", "default", "30")
+}
+
+func (t *tcShaper) ReconcileInterface() error {
+	exists, err := t.interfaceExists()
+	if err != nil {
 		return err
 	}
-	if uploadClass, err = t.makeNewClass(makeKBitString(upload)); err != nil {
+	if !exists {
+		glog.V(4).Infof("Setting up bandwidth shaping on %s", t.iface)
+		return t.initializeInterface()
+	}
+	return nil
+}
+
+func (t *tcShaper) ReconcileCIDR(cidr string, egress, ingress *resource.Quantity) error {
+	filters, err := t.findCIDRFilters(cidr)
+	if err != nil {
 		return err
 	}
+	if len(filters) == 0 {
+		return t.Limit(cidr, egress, ingress)
+	}
 
-	if err := t.execAndLog("tc", "filter", "add", "dev", t.iface, "protocol", "ip", "parent", "1:0", "prio", "1", "u32", "match", "ip", "dst", cidr, "flowid", fmt.Sprintf("1:%d", downloadClass)); err != nil {
+	rates, err := t.classRates()
+	if err != nil {
 		return err
 	}
-	if err := t.execAndLog("tc", "filter", "add", "dev", t.iface, "protocol", "ip", "parent", "1:0", "prio", "1", "u32", "match", "ip", "src", cidr, "flowid", fmt.Sprintf("1:%d", uploadClass)); err != nil {
+	limits := 0
+	for _, limit := range []*resource.Quantity{egress, ingress} {
+		if limit != nil {
+			limits++
+		}
+	}
+	upToDate := len(filters) == limits
+	for _, filter := range filters {
+		limit := egress
+		if filter.dst {
+			limit = ingress
+		}
+		// The rates of classes are in kbit, see makeKBitString.
+		if limit == nil || rates[filter.class] != limit.Value()/1000*1000 {
+			upToDate = false
+		}
+	}
+	if upToDate {
+		return nil
+	}
+	glog.V(4).Infof("Replacing the bandwidth limits of %s on %s", cidr, t.iface)
+	if err := t.Reset(cidr); err != nil {
 		return err
 	}
-	return nil
+	return t.Limit(cidr, egress, ingress)
 }
 
-func (t *tcShaper) initializeInterface() error {
-	return t.execAndLog("tc", "qdisc", "add", "dev", t.iface, "root", "handle", "1:", "htb", "default", "30")
+func (t *tcShaper) GetCIDRs() ([]string, error) {
+	filters, err := t.filters()
+	if err != nil {
+		return nil, err
+	}
+	cidrs := util.StringSet{}
+	for _, filter := range filters {
+		cidr, err := asciiCIDR(filter.cidr)
+		if err != nil {
+			return nil, err
+		}
+		cidrs.Insert(cidr)
+	}
+	return cidrs.List(), nil
 }
 
 func (t *tcShaper) Reset(cidr string) error {
-	class, handle, err := t.findCIDRClass(cidr)
+	filters, err := t.findCIDRFilters(cidr)
 	if err != nil {
 		return err
 	}
-	if err := t.execAndLog("tc", "filter", "del", "dev", t.iface, "parent", "1:", "proto", "ip", "prio", "1", "handle", handle, "u32"); err != nil {
-		return err
+	if len(filters) == 0 {
+		return fmt.Errorf("Failed to find cidr: %s on interface: %s", cidr, t.iface)
+	}
+	for _, filter := range filters {
+		if err := t.execAndLog("filter", "del", "dev", t.iface, "parent", "1:", "proto", "ip", "prio", "1", "handle", filter.handle, "u32"); err != nil {
+			return err
+		}
+		if err := t.execAndLog("class", "del", "dev", t.iface, "parent", "1:", "classid", filter.class); err != nil {
+			return err
+		}
 	}
-	return t.execAndLog("tc", "class", "del", "dev", t.iface, "parent", "1:", "classid", class)
+	return nil
 }
@@ -18,6 +18,8 @@ package bandwidth
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"k8s.io/kubernetes/pkg/api/resource"
@@ -106,6 +108,10 @@ func TestHexCIDR(t *testing.T) {
 			input:     "foo",
 			expectErr: true,
 		},
+		{
+			input:     "fd00::4/128",
+			expectErr: true,
+		},
 	}
 	for _, test := range tests {
 		output, err := hexCIDR(test.input)
@@ -132,26 +138,44 @@ filter parent 1: protocol ip pref 1 u32 fh 800::801 order 2049 key ht 800 bkt 0
   match 01020000/ffff0000 at 16
 `
 
-func TestFindCIDRClass(t *testing.T) {
+var tcFilterOutputBothWays = `filter parent 1: protocol ip pref 1 u32 
+filter parent 1: protocol ip pref 1 u32 fh 800: ht divisor 1 
+filter parent 1: protocol ip pref 1 u32 fh 800::800 order 2048 key ht 800 bkt 0 flowid 1:1 
+  match ac110002/ffffffff at 16
+filter parent 1: protocol ip pref 1 u32 fh 800::801 order 2049 key ht 800 bkt 0 flowid 1:2 
+  match ac110002/ffffffff at 12
+`
+
+func TestFindCIDRFilters(t *testing.T) {
 	tests := []struct {
-		cidr           string
-		output         string
-		expectErr      bool
-		expectedClass  string
-		expectedHandle string
-		err            error
+		cidr      string
+		output    string
+		expectErr bool
+		expected  []tcFilter
+		err       error
 	}{
 		{
-			cidr:           "172.17.0.2/32",
-			output:         tcFilterOutput,
-			expectedClass:  "1:1",
-			expectedHandle: "800::800",
+			cidr:     "172.17.0.2/32",
+			output:   tcFilterOutput,
+			expected: []tcFilter{{class: "1:1", handle: "800::800", cidr: "ac110002/ffffffff", dst: true}},
 		},
 		{
-			cidr:           "1.2.3.4/16",
-			output:         tcFilterOutput,
-			expectedClass:  "1:2",
-			expectedHandle: "800::801",
+			cidr:     "1.2.3.4/16",
+			output:   tcFilterOutput,
+			expected: []tcFilter{{class: "1:2", handle: "800::801", cidr: "01020000/ffff0000", dst: true}},
+		},
+		{
+			cidr:   "172.17.0.2/32",
+			output: tcFilterOutputBothWays,
+			expected: []tcFilter{
+				{class: "1:1", handle: "800::800", cidr: "ac110002/ffffffff", dst: true},
+				{class: "1:2", handle: "800::801", cidr: "ac110002/ffffffff", dst: false},
+			},
+		},
+		{
+			cidr:     "10.0.0.1/32",
+			output:   tcFilterOutput,
+			expected: []tcFilter{},
 		},
 		{
 			err:       errors.New("test error"),
@@ -172,7 +196,7 @@ func TestFindCIDRClass(t *testing.T) {
 			},
 		}
 		shaper := &tcShaper{e: &fexec}
-		class, handle, err := shaper.findCIDRClass(test.cidr)
+		filters, err := shaper.findCIDRFilters(test.cidr)
 		if test.expectErr {
 			if err == nil {
 				t.Errorf("unexpected non-error")
@@ -181,11 +205,8 @@ func TestFindCIDRClass(t *testing.T) {
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
-			if class != test.expectedClass {
-				t.Errorf("expected: %s, found %s", test.expectedClass, class)
-			}
-			if handle != test.expectedHandle {
-				t.Errorf("expected: %s, found %s", test.expectedHandle, handle)
+			if !reflect.DeepEqual(filters, test.expected) {
+				t.Errorf("expected: %+v, found %+v", test.expected, filters)
 			}
 		}
 	}
@@ -201,8 +222,8 @@ func TestLimit(t *testing.T) {
 	}{
 		{
 			cidr:    "1.2.3.4/32",
-			ingress: resource.NewQuantity(10, resource.DecimalSI),
-			egress:  resource.NewQuantity(20, resource.DecimalSI),
+			ingress: resource.NewQuantity(10000, resource.DecimalSI),
+			egress:  resource.NewQuantity(20000, resource.DecimalSI),
 		},
 		{
 			err:       errors.New("test error"),
@@ -236,7 +257,7 @@ func TestLimit(t *testing.T) {
 		}
 		iface := "cbr0"
 		shaper := &tcShaper{e: &fexec, iface: iface}
-		if err := shaper.Limit(test.cidr, *test.ingress, *test.egress); err != nil && !test.expectErr {
+		if err := shaper.Limit(test.cidr, test.egress, test.ingress); err != nil && !test.expectErr {
 			t.Errorf("unexpected error: %v", err)
 			return
 		} else if err == nil && test.expectErr {
@@ -280,6 +301,9 @@ func TestLimit(t *testing.T) {
 				}
 			}
 			if ix == 4 {
+				if output[14] != "dst" {
+					t.Errorf("unexpected direction: %s, expected: dst", output[14])
+				}
 				if output[15] != test.cidr {
 					t.Errorf("unexpected cidr: %s, expected: %s", output[15], test.cidr)
 				}
@@ -288,6 +312,9 @@ func TestLimit(t *testing.T) {
 				}
 			}
 			if ix == 5 {
+				if output[14] != "src" {
+					t.Errorf("unexpected direction: %s, expected: src", output[14])
+				}
 				if output[15] != test.cidr {
 					t.Errorf("unexpected cidr: %s, expected: %s", output[15], test.cidr)
 				}
@@ -378,3 +405,296 @@ func TestReset(t *testing.T) {
 		}
 	}
 }
+
+func TestResetBothWays(t *testing.T) {
+	fcmd := exec.FakeCmd{
+		CombinedOutputScript: []exec.FakeCombinedOutputAction{
+			func() ([]byte, error) { return []byte(tcFilterOutputBothWays), nil },
+			func() ([]byte, error) { return []byte{}, nil },
+			func() ([]byte, error) { return []byte{}, nil },
+			func() ([]byte, error) { return []byte{}, nil },
+			func() ([]byte, error) { return []byte{}, nil },
+		},
+	}
+	fexec := exec.FakeExec{
+		CommandScript: []exec.FakeCommandAction{
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+	shaper := &tcShaper{e: &fexec, iface: "cbr0"}
+
+	if err := shaper.Reset("172.17.0.2/32"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fcmd.CombinedOutputCalls != 5 {
+		t.Errorf("unexpected number of calls: %d, expected: 5", fcmd.CombinedOutputCalls)
+	}
+	expected := []string{"800::800", "1:1", "800::801", "1:2"}
+	for ix, output := range fcmd.CombinedOutputLog[1:] {
+		if output[len(output)-1] == "u32" {
+			output = output[:len(output)-1]
+		}
+		if output[len(output)-1] != expected[ix] {
+			t.Errorf("unexpected command: %v, expected to delete %s", output, expected[ix])
+		}
+	}
+}
+
+func TestAsciiCIDR(t *testing.T) {
+	tests := []struct {
+		input     string
+		output    string
+		expectErr bool
+	}{
+		{
+			input:  "01020000/ffff0000",
+			output: "1.2.0.0/16",
+		},
+		{
+			input:  "ac110002/ffffffff",
+			output: "172.17.0.2/32",
+		},
+		{
+			input:     "foo",
+			expectErr: true,
+		},
+		{
+			input:     "zz020000/ffff0000",
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		output, err := asciiCIDR(test.input)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("unexpected non-error for %s", test.input)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if output != test.output {
+				t.Errorf("expected: %s, saw: %s", test.output, output)
+			}
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input     string
+		output    int64
+		expectErr bool
+	}{
+		{input: "10000bit", output: 10000},
+		{input: "20Kbit", output: 20000},
+		{input: "10Mbit", output: 10000000},
+		{input: "1Gbit", output: 1000000000},
+		{input: "1kB", expectErr: true},
+		{input: "Mbit", expectErr: true},
+	}
+	for _, test := range tests {
+		output, err := parseRate(test.input)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("unexpected non-error for %s", test.input)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if output != test.output {
+				t.Errorf("expected: %d, saw: %d", test.output, output)
+			}
+		}
+	}
+}
+
+func TestGetCIDRs(t *testing.T) {
+	fcmd := exec.FakeCmd{
+		CombinedOutputScript: []exec.FakeCombinedOutputAction{
+			func() ([]byte, error) { return []byte(tcFilterOutput + "  match ac110002/ffffffff at 12\n"), nil },
+		},
+	}
+	fexec := exec.FakeExec{
+		CommandScript: []exec.FakeCommandAction{
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+	shaper := &tcShaper{e: &fexec, iface: "cbr0"}
+	cidrs, err := shaper.GetCIDRs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"1.2.0.0/16", "172.17.0.2/32"}
+	if !reflect.DeepEqual(cidrs, expected) {
+		t.Errorf("expected: %v, saw: %v", expected, cidrs)
+	}
+}
+
+func TestReconcileInterface(t *testing.T) {
+	tests := []struct {
+		output        string
+		expectedCalls int
+	}{
+		{
+			output:        "qdisc htb 1: root refcnt 2 r2q 10 default 30 direct_packets_stat 0\n",
+			expectedCalls: 1,
+		},
+		{
+			output:        "qdisc pfifo_fast 0: root refcnt 2 bands 3 priomap  1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1\n",
+			expectedCalls: 2,
+		},
+	}
+	for _, test := range tests {
+		fcmd := exec.FakeCmd{
+			CombinedOutputScript: []exec.FakeCombinedOutputAction{
+				func() ([]byte, error) { return []byte(test.output), nil },
+				func() ([]byte, error) { return []byte{}, nil },
+			},
+		}
+		fexec := exec.FakeExec{
+			CommandScript: []exec.FakeCommandAction{
+				func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+				func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			},
+		}
+		shaper := &tcShaper{e: &fexec, iface: "cbr0"}
+		if err := shaper.ReconcileInterface(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if fcmd.CombinedOutputCalls != test.expectedCalls {
+			t.Errorf("unexpected number of calls: %d, expected: %d", fcmd.CombinedOutputCalls, test.expectedCalls)
+		}
+		if test.expectedCalls == 2 {
+			if cmd := strings.Join(fcmd.CombinedOutputLog[1], " "); cmd != "tc qdisc add dev cbr0 root handle 1: htb default 30" {
+				t.Errorf("unexpected command: %s", cmd)
+			}
+		}
+	}
+}
+
+var tcClassOutputReconcile = `class htb 1:1 root prio 0 rate 10Mbit ceil 10Mbit burst 1600b cburst 1600b 
+class htb 1:2 root prio 0 rate 20Mbit ceil 20Mbit burst 1600b cburst 1600b 
+`
+
+func TestReconcileCIDR(t *testing.T) {
+	tests := []struct {
+		name          string
+		filterOutput  string
+		egress        *resource.Quantity
+		ingress       *resource.Quantity
+		expectedCalls int
+	}{
+		{
+			name:          "up to date",
+			filterOutput:  tcFilterOutputBothWays,
+			ingress:       resource.NewQuantity(10*1000*1000, resource.DecimalSI),
+			egress:        resource.NewQuantity(20*1000*1000, resource.DecimalSI),
+			expectedCalls: 2,
+		},
+		{
+			name:          "new",
+			filterOutput:  "",
+			ingress:       resource.NewQuantity(10*1000*1000, resource.DecimalSI),
+			expectedCalls: 4,
+		},
+		{
+			name:         "changed rate",
+			filterOutput: tcFilterOutputBothWays,
+			ingress:      resource.NewQuantity(30*1000*1000, resource.DecimalSI),
+			egress:       resource.NewQuantity(20*1000*1000, resource.DecimalSI),
+			// class show, filter show + 4 deletes, and 2 class shows + 4 adds
+			expectedCalls: 13,
+		},
+		{
+			name:          "removed direction",
+			filterOutput:  tcFilterOutputBothWays,
+			ingress:       resource.NewQuantity(10*1000*1000, resource.DecimalSI),
+			expectedCalls: 10,
+		},
+	}
+	for _, test := range tests {
+		fcmd := exec.FakeCmd{}
+		fexec := exec.FakeExec{}
+		for i := 0; i < 13; i++ {
+			fcmd.CombinedOutputScript = append(fcmd.CombinedOutputScript, func() ([]byte, error) {
+				// The command being run is the last one initialized.
+				switch strings.Join(fcmd.Argv[:3], " ") {
+				case "tc filter show":
+					return []byte(test.filterOutput), nil
+				case "tc class show":
+					return []byte(tcClassOutputReconcile), nil
+				}
+				return []byte{}, nil
+			})
+			fexec.CommandScript = append(fexec.CommandScript, func(cmd string, args ...string) exec.Cmd {
+				return exec.InitFakeCmd(&fcmd, cmd, args...)
+			})
+		}
+		shaper := &tcShaper{e: &fexec, iface: "cbr0"}
+		if err := shaper.ReconcileCIDR("172.17.0.2/32", test.egress, test.ingress); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if fcmd.CombinedOutputCalls != test.expectedCalls {
+			t.Errorf("%s: unexpected number of calls: %d, expected: %d (%v)", test.name, fcmd.CombinedOutputCalls, test.expectedCalls, fcmd.CombinedOutputLog)
+		}
+	}
+}
+
+func TestLimitInNetNs(t *testing.T) {
+	fcmd := exec.FakeCmd{
+		CombinedOutputScript: []exec.FakeCombinedOutputAction{
+			func() ([]byte, error) { return []byte{}, nil },
+			func() ([]byte, error) { return []byte{}, nil },
+			func() ([]byte, error) { return []byte{}, nil },
+		},
+	}
+	fexec := exec.FakeExec{
+		CommandScript: []exec.FakeCommandAction{
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+	shaper := &tcShaper{e: &fexec, iface: "eth0", netns: "/proc/42/ns/net"}
+	if err := shaper.Limit("1.2.3.4/32", resource.NewQuantity(20000, resource.DecimalSI), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The egress of the pod is filtered on its own interface, by source.
+	expected := [][]string{
+		{"nsenter", "--net=/proc/42/ns/net", "-F", "--", "tc", "class", "show", "dev", "eth0"},
+		{"nsenter", "--net=/proc/42/ns/net", "-F", "--", "tc", "class", "add", "dev", "eth0", "parent", "1:", "classid", "1:1", "htb", "rate", "20kbit"},
+		{"nsenter", "--net=/proc/42/ns/net", "-F", "--", "tc", "filter", "add", "dev", "eth0", "protocol", "ip", "parent", "1:0", "prio", "1", "u32", "match", "ip", "src", "1.2.3.4/32", "flowid", "1:1"},
+	}
+	if !reflect.DeepEqual(fcmd.CombinedOutputLog, expected) {
+		t.Errorf("expected:\n%v\nsaw:\n%v", expected, fcmd.CombinedOutputLog)
+	}
+}
+
+func TestReconcileIPv6CIDR(t *testing.T) {
+	fcmd := exec.FakeCmd{
+		CombinedOutputScript: []exec.FakeCombinedOutputAction{
+			func() ([]byte, error) { return []byte(tcFilterOutput), nil },
+		},
+	}
+	fexec := exec.FakeExec{
+		CommandScript: []exec.FakeCommandAction{
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+	shaper := &tcShaper{e: &fexec, iface: "cbr0"}
+	// The filters match the IPv4 header, so IPv6 CIDRs are rejected rather
+	// than limited.
+	if err := shaper.ReconcileCIDR("fd00::4/128", nil, resource.NewQuantity(10*1000*1000, resource.DecimalSI)); err == nil {
+		t.Error("unexpected non-error")
+	}
+	expected := [][]string{{"tc", "filter", "show", "dev", "cbr0"}}
+	if !reflect.DeepEqual(fcmd.CombinedOutputLog, expected) {
+		t.Errorf("expected: %v, saw: %v", expected, fcmd.CombinedOutputLog)
+	}
+}
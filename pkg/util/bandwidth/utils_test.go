@@ -0,0 +1,98 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bandwidth
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/resource"
+)
+
+func TestExtractPodBandwidthResources(t *testing.T) {
+	four, _ := resource.ParseQuantity("4M")
+	ten, _ := resource.ParseQuantity("10M")
+	twenty, _ := resource.ParseQuantity("20M")
+
+	tests := []struct {
+		annotations     map[string]string
+		expectedIngress *resource.Quantity
+		expectedEgress  *resource.Quantity
+		expectError     bool
+	}{
+		{
+			annotations: map[string]string{},
+		},
+		{
+			annotations: map[string]string{
+				IngressBandwidthAnnotation: "4M",
+			},
+			expectedIngress: four,
+		},
+		{
+			annotations: map[string]string{
+				EgressBandwidthAnnotation: "20M",
+			},
+			expectedEgress: twenty,
+		},
+		{
+			annotations: map[string]string{
+				IngressBandwidthAnnotation: "10M",
+				EgressBandwidthAnnotation:  "20M",
+			},
+			expectedIngress: ten,
+			expectedEgress:  twenty,
+		},
+		{
+			annotations: map[string]string{
+				IngressBandwidthAnnotation: "foo",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				EgressBandwidthAnnotation: "10",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				EgressBandwidthAnnotation: "10E",
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		ingress, egress, err := ExtractPodBandwidthResources(test.annotations)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("unexpected non-error for %v", test.annotations)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(ingress, test.expectedIngress) {
+			t.Errorf("expected: %v, saw: %v", test.expectedIngress, ingress)
+		}
+		if !reflect.DeepEqual(egress, test.expectedEgress) {
+			t.Errorf("expected: %v, saw: %v", test.expectedEgress, egress)
+		}
+	}
+}
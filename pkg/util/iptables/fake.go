@@ -0,0 +1,152 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FakeIPTables implements iptables.Interface for tests. It remembers the
+// chains and rules created through it and the data of the last restore of
+// each table, and answers Save with canned data.
+type FakeIPTables struct {
+	mu sync.Mutex
+	// Chains holds the chains of each table created with EnsureChain.
+	Chains map[Table]map[Chain]bool
+	// Rules holds the rules of each chain, as space separated arguments.
+	Rules map[Table]map[Chain][]string
+	// SaveData is returned by Save for each table.
+	SaveData map[Table][]byte
+	// Restored holds the data of the last Restore of each table.
+	Restored map[Table][]byte
+	// Ipv6 is returned by IsIpv6.
+	Ipv6 bool
+}
+
+var _ Interface = &FakeIPTables{}
+
+// NewFake returns a FakeIPTables without any chains or rules.
+func NewFake() *FakeIPTables {
+	return &FakeIPTables{
+		Chains:   map[Table]map[Chain]bool{},
+		Rules:    map[Table]map[Chain][]string{},
+		SaveData: map[Table][]byte{},
+		Restored: map[Table][]byte{},
+	}
+}
+
+func (f *FakeIPTables) EnsureChain(table Table, chain Chain) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Chains[table] == nil {
+		f.Chains[table] = map[Chain]bool{}
+	}
+	exists := f.Chains[table][chain]
+	f.Chains[table][chain] = true
+	return exists, nil
+}
+
+func (f *FakeIPTables) FlushChain(table Table, chain Chain) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Chains[table][chain] {
+		return fmt.Errorf("chain %s does not exist in table %s", chain, table)
+	}
+	delete(f.Rules[table], chain)
+	return nil
+}
+
+func (f *FakeIPTables) DeleteChain(table Table, chain Chain) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Chains[table][chain] {
+		return fmt.Errorf("chain %s does not exist in table %s", chain, table)
+	}
+	delete(f.Chains[table], chain)
+	delete(f.Rules[table], chain)
+	return nil
+}
+
+func (f *FakeIPTables) EnsureRule(position RulePosition, table Table, chain Chain, args ...string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rule := strings.Join(args, " ")
+	if f.Rules[table] == nil {
+		f.Rules[table] = map[Chain][]string{}
+	}
+	for _, r := range f.Rules[table][chain] {
+		if r == rule {
+			return true, nil
+		}
+	}
+	if position == Prepend {
+		f.Rules[table][chain] = append([]string{rule}, f.Rules[table][chain]...)
+	} else {
+		f.Rules[table][chain] = append(f.Rules[table][chain], rule)
+	}
+	return false, nil
+}
+
+func (f *FakeIPTables) DeleteRule(table Table, chain Chain, args ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rule := strings.Join(args, " ")
+	rules := f.Rules[table][chain]
+	for i, r := range rules {
+		if r == rule {
+			f.Rules[table][chain] = append(rules[:i], rules[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *FakeIPTables) IsIpv6() bool {
+	return f.Ipv6
+}
+
+func (f *FakeIPTables) Save(table Table) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.SaveData[table], nil
+}
+
+func (f *FakeIPTables) SaveAll() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data := []byte{}
+	for _, d := range f.SaveData {
+		data = append(data, d...)
+	}
+	return data, nil
+}
+
+func (f *FakeIPTables) Restore(table Table, data []byte, flush FlushFlag, counters RestoreCountersFlag) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Restored[table] = data
+	return nil
+}
+
+func (f *FakeIPTables) RestoreAll(data []byte, flush FlushFlag, counters RestoreCountersFlag) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Restored[""] = data
+	return nil
+}
@@ -76,7 +76,8 @@ const (
 type Table string
 
 const (
-	TableNAT Table = "nat"
+	TableNAT    Table = "nat"
+	TableFilter Table = "filter"
 )
 
 type Chain string
@@ -85,13 +86,17 @@ const (
 	ChainPostrouting Chain = "POSTROUTING"
 	ChainPrerouting  Chain = "PREROUTING"
 	ChainOutput      Chain = "OUTPUT"
+	ChainForward     Chain = "FORWARD"
+	ChainInput       Chain = "INPUT"
 )
 
 const (
-	cmdIptablesSave    string = "iptables-save"
-	cmdIptablesRestore string = "iptables-restore"
-	cmdIptables        string = "iptables"
-	cmdIp6tables       string = "ip6tables"
+	cmdIptablesSave     string = "iptables-save"
+	cmdIptablesRestore  string = "iptables-restore"
+	cmdIptables         string = "iptables"
+	cmdIp6tablesSave    string = "ip6tables-save"
+	cmdIp6tablesRestore string = "ip6tables-restore"
+	cmdIp6tables        string = "ip6tables"
 )
 
 // Option flag for Restore
@@ -223,7 +228,7 @@ func (runner *runner) Save(table Table) ([]byte, error) {
 
 	// run and return
 	args := []string{"-t", string(table)}
-	return runner.exec.Command(cmdIptablesSave, args...).CombinedOutput()
+	return runner.exec.Command(runner.iptablesSaveCommand(), args...).CombinedOutput()
 }
 
 // SaveAll is part of Interface.
@@ -232,7 +237,7 @@ func (runner *runner) SaveAll() ([]byte, error) {
 	defer runner.mu.Unlock()
 
 	// run and return
-	return runner.exec.Command(cmdIptablesSave, []string{}...).CombinedOutput()
+	return runner.exec.Command(runner.iptablesSaveCommand(), []string{}...).CombinedOutput()
 }
 
 // Restore is part of Interface.
@@ -281,7 +286,7 @@ func (runner *runner) restoreInternal(args []string, data []byte, flush FlushFla
 		return err
 	}
 	// run the command and return the output or an error including the output and error
-	b, err := runner.exec.Command(cmdIptablesRestore, args...).CombinedOutput()
+	b, err := runner.exec.Command(runner.iptablesRestoreCommand(), args...).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%v (%s)", err, b)
 	}
@@ -296,6 +301,22 @@ func (runner *runner) iptablesCommand() string {
 	}
 }
 
+func (runner *runner) iptablesSaveCommand() string {
+	if runner.IsIpv6() {
+		return cmdIp6tablesSave
+	} else {
+		return cmdIptablesSave
+	}
+}
+
+func (runner *runner) iptablesRestoreCommand() string {
+	if runner.IsIpv6() {
+		return cmdIp6tablesRestore
+	} else {
+		return cmdIptablesRestore
+	}
+}
+
 func (runner *runner) run(op operation, args []string) ([]byte, error) {
 	iptablesCmd := runner.iptablesCommand()
 
@@ -324,8 +345,9 @@ func (runner *runner) checkRule(table Table, chain Chain, args ...string) (bool,
 // Present for compatibility with <1.4.11 versions of iptables.  This is full
 // of hack and half-measures.  We should nix this ASAP.
 func (runner *runner) checkRuleWithoutCheck(table Table, chain Chain, args ...string) (bool, error) {
-	glog.V(1).Infof("running iptables-save -t %s", string(table))
-	out, err := runner.exec.Command(cmdIptablesSave, "-t", string(table)).CombinedOutput()
+	iptablesSaveCmd := runner.iptablesSaveCommand()
+	glog.V(1).Infof("running %s -t %s", iptablesSaveCmd, string(table))
+	out, err := runner.exec.Command(iptablesSaveCmd, "-t", string(table)).CombinedOutput()
 	if err != nil {
 		return false, fmt.Errorf("error checking rule: %v", err)
 	}
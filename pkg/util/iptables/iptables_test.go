@@ -513,3 +513,44 @@ COMMIT
 		t.Errorf("wrong CombinedOutput() log, got %s", fcmd.CombinedOutputLog[0])
 	}
 }
+
+func testSaveAndRestore(t *testing.T, protocol Protocol, saveCmd, restoreCmd string) {
+	fcmd := exec.FakeCmd{
+		CombinedOutputScript: []exec.FakeCombinedOutputAction{
+			// Save.
+			func() ([]byte, error) { return []byte("*nat\nCOMMIT\n"), nil },
+			// Restore.
+			func() ([]byte, error) { return []byte{}, nil },
+		},
+	}
+	fexec := exec.FakeExec{
+		CommandScript: []exec.FakeCommandAction{
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+			func(cmd string, args ...string) exec.Cmd { return exec.InitFakeCmd(&fcmd, cmd, args...) },
+		},
+	}
+	runner := New(&fexec, protocol)
+	if _, err := runner.Save(TableNAT); err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+	if err := runner.Restore(TableNAT, []byte("*nat\nCOMMIT\n"), NoFlushTables, RestoreCounters); err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+	if fcmd.CombinedOutputCalls != 2 {
+		t.Fatalf("expected 2 CombinedOutput() calls, got %d", fcmd.CombinedOutputCalls)
+	}
+	if !util.NewStringSet(fcmd.CombinedOutputLog[0]...).HasAll(saveCmd, "-t", "nat") {
+		t.Errorf("wrong CombinedOutput() log, got %s", fcmd.CombinedOutputLog[0])
+	}
+	if !util.NewStringSet(fcmd.CombinedOutputLog[1]...).HasAll(restoreCmd, "-T", "nat", "--noflush", "--counters") {
+		t.Errorf("wrong CombinedOutput() log, got %s", fcmd.CombinedOutputLog[1])
+	}
+}
+
+func TestSaveAndRestoreIpv4(t *testing.T) {
+	testSaveAndRestore(t, ProtocolIpv4, "iptables-save", "iptables-restore")
+}
+
+func TestSaveAndRestoreIpv6(t *testing.T) {
+	testSaveAndRestore(t, ProtocolIpv6, "ip6tables-save", "ip6tables-restore")
+}
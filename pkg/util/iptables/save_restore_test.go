@@ -0,0 +1,75 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetChainLines(t *testing.T) {
+	save := `# Generated by iptables-save v1.4.21 on Fri Aug  7 14:47:37 2015
+*nat
+:PREROUTING ACCEPT [2:138]
+:POSTROUTING ACCEPT [0:0]
+:KUBE-SERVICES - [0:0]
+-A PREROUTING -j KUBE-SERVICES
+COMMIT
+*filter
+:INPUT ACCEPT [17514:83115836]
+:FORWARD ACCEPT [0:0]
+:DOCKER - [0:0]
+-A FORWARD -o cbr0 -j DOCKER
+COMMIT
+`
+	testCases := []struct {
+		table    Table
+		expected map[Chain]string
+	}{
+		{
+			table: TableNAT,
+			expected: map[Chain]string{
+				ChainPrerouting:  ":PREROUTING ACCEPT [2:138]",
+				ChainPostrouting: ":POSTROUTING ACCEPT [0:0]",
+				"KUBE-SERVICES":  ":KUBE-SERVICES - [0:0]",
+			},
+		},
+		{
+			table: TableFilter,
+			expected: map[Chain]string{
+				"INPUT":      ":INPUT ACCEPT [17514:83115836]",
+				ChainForward: ":FORWARD ACCEPT [0:0]",
+				"DOCKER":     ":DOCKER - [0:0]",
+			},
+		},
+		{
+			table:    "mangle",
+			expected: map[Chain]string{},
+		},
+	}
+	for _, tc := range testCases {
+		if got := GetChainLines(tc.table, []byte(save)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("table %s: expected %v, got %v", tc.table, tc.expected, got)
+		}
+	}
+}
+
+func TestMakeChainLine(t *testing.T) {
+	if got, expected := MakeChainLine("KUBE-SERVICES"), ":KUBE-SERVICES - [0:0]"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// FakeIPVS implements ipvs.Interface for tests.  It remembers the virtual
+// servers, real servers and bound addresses, and logs every change made
+// through it.
+type FakeIPVS struct {
+	mu sync.Mutex
+	// VirtualServers holds the virtual servers keyed by their String().
+	VirtualServers map[string]*VirtualServer
+	// RealServers holds the real servers of each virtual server.
+	RealServers map[string][]*RealServer
+	// Addresses holds the addresses bound to the dummy device.
+	Addresses map[string]bool
+	// Log holds a line for each change, e.g. "add-vs 10.0.0.1:80/TCP".
+	Log []string
+}
+
+var _ Interface = &FakeIPVS{}
+
+// NewFake returns a FakeIPVS without any virtual servers.
+func NewFake() *FakeIPVS {
+	return &FakeIPVS{
+		VirtualServers: map[string]*VirtualServer{},
+		RealServers:    map[string][]*RealServer{},
+		Addresses:      map[string]bool{},
+	}
+}
+
+// ResetLog clears the log of changes.
+func (f *FakeIPVS) ResetLog() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Log = nil
+}
+
+func (f *FakeIPVS) log(format string, args ...interface{}) {
+	f.Log = append(f.Log, fmt.Sprintf(format, args...))
+}
+
+func (f *FakeIPVS) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.VirtualServers = map[string]*VirtualServer{}
+	f.RealServers = map[string][]*RealServer{}
+	f.log("flush")
+	return nil
+}
+
+func (f *FakeIPVS) AddVirtualServer(vs *VirtualServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := validateProtocol(vs.Protocol); err != nil {
+		return err
+	}
+	key := vs.String()
+	if _, found := f.VirtualServers[key]; found {
+		return fmt.Errorf("virtual server %s already exists", key)
+	}
+	copy := *vs
+	f.VirtualServers[key] = &copy
+	f.log("add-vs %s %s", key, vs.Scheduler)
+	return nil
+}
+
+func (f *FakeIPVS) UpdateVirtualServer(vs *VirtualServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := vs.String()
+	if _, found := f.VirtualServers[key]; !found {
+		return fmt.Errorf("virtual server %s does not exist", key)
+	}
+	copy := *vs
+	f.VirtualServers[key] = &copy
+	f.log("update-vs %s %s", key, vs.Scheduler)
+	return nil
+}
+
+func (f *FakeIPVS) DeleteVirtualServer(vs *VirtualServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := vs.String()
+	if _, found := f.VirtualServers[key]; !found {
+		return fmt.Errorf("virtual server %s does not exist", key)
+	}
+	delete(f.VirtualServers, key)
+	delete(f.RealServers, key)
+	f.log("delete-vs %s", key)
+	return nil
+}
+
+func (f *FakeIPVS) GetVirtualServers() ([]*VirtualServer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	servers := []*VirtualServer{}
+	for _, vs := range f.VirtualServers {
+		copy := *vs
+		servers = append(servers, &copy)
+	}
+	return servers, nil
+}
+
+func (f *FakeIPVS) AddRealServer(vs *VirtualServer, rs *RealServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := vs.String()
+	if _, found := f.VirtualServers[key]; !found {
+		return fmt.Errorf("virtual server %s does not exist", key)
+	}
+	for _, existing := range f.RealServers[key] {
+		if existing.String() == rs.String() {
+			return fmt.Errorf("real server %s of %s already exists", rs, key)
+		}
+	}
+	copy := *rs
+	f.RealServers[key] = append(f.RealServers[key], &copy)
+	f.log("add-rs %s %s", key, rs)
+	return nil
+}
+
+func (f *FakeIPVS) DeleteRealServer(vs *VirtualServer, rs *RealServer) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := vs.String()
+	for i, existing := range f.RealServers[key] {
+		if existing.String() == rs.String() {
+			f.RealServers[key] = append(f.RealServers[key][:i], f.RealServers[key][i+1:]...)
+			f.log("delete-rs %s %s", key, rs)
+			return nil
+		}
+	}
+	return fmt.Errorf("real server %s of %s does not exist", rs, key)
+}
+
+func (f *FakeIPVS) GetRealServers(vs *VirtualServer) ([]*RealServer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := vs.String()
+	if _, found := f.VirtualServers[key]; !found {
+		return nil, fmt.Errorf("virtual server %s does not exist", key)
+	}
+	servers := []*RealServer{}
+	for _, rs := range f.RealServers[key] {
+		copy := *rs
+		servers = append(servers, &copy)
+	}
+	return servers, nil
+}
+
+func (f *FakeIPVS) EnsureAddressBound(ip net.IP) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Addresses[ip.String()] {
+		f.Addresses[ip.String()] = true
+		f.log("bind %s", ip)
+	}
+	return nil
+}
+
+func (f *FakeIPVS) UnbindAddress(ip net.IP) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.Addresses[ip.String()] {
+		return fmt.Errorf("address %s is not bound", ip)
+	}
+	delete(f.Addresses, ip.String())
+	f.log("unbind %s", ip)
+	return nil
+}
+
+func (f *FakeIPVS) GetBoundAddresses() ([]net.IP, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ips := []net.IP{}
+	for addr := range f.Addresses {
+		ips = append(ips, net.ParseIP(addr))
+	}
+	return ips, nil
+}
+
+func (f *FakeIPVS) DeleteDevice() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Addresses = map[string]bool{}
+	f.log("delete-device")
+	return nil
+}
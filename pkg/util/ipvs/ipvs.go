@@ -0,0 +1,141 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// Interface is an injectable interface for programming IPVS virtual servers.
+// The kernel only accepts traffic for a virtual server whose address is
+// local, so the interface also manages a dummy device to bind those
+// addresses to.
+type Interface interface {
+	// Flush deletes all virtual servers, including those not created through
+	// this interface.
+	Flush() error
+	// AddVirtualServer creates a virtual server.
+	AddVirtualServer(vs *VirtualServer) error
+	// UpdateVirtualServer changes the scheduler and timeout of an existing
+	// virtual server.
+	UpdateVirtualServer(vs *VirtualServer) error
+	// DeleteVirtualServer deletes a virtual server and all its real servers.
+	DeleteVirtualServer(vs *VirtualServer) error
+	// GetVirtualServers lists all virtual servers.
+	GetVirtualServers() ([]*VirtualServer, error)
+	// AddRealServer adds a real server to a virtual server.
+	AddRealServer(vs *VirtualServer, rs *RealServer) error
+	// DeleteRealServer removes a real server from a virtual server.
+	DeleteRealServer(vs *VirtualServer, rs *RealServer) error
+	// GetRealServers lists the real servers of a virtual server.
+	GetRealServers(vs *VirtualServer) ([]*RealServer, error)
+	// EnsureAddressBound binds an address to the dummy device, creating the
+	// device if needed.
+	EnsureAddressBound(ip net.IP) error
+	// UnbindAddress removes an address from the dummy device.
+	UnbindAddress(ip net.IP) error
+	// GetBoundAddresses lists the addresses bound to the dummy device.  It
+	// returns no addresses if the device does not exist.
+	GetBoundAddresses() ([]net.IP, error)
+	// DeleteDevice deletes the dummy device, if it exists.
+	DeleteDevice() error
+}
+
+// Protocol is the transport protocol of a virtual server.
+type Protocol string
+
+const (
+	ProtocolTCP Protocol = "TCP"
+	ProtocolUDP Protocol = "UDP"
+)
+
+// Scheduler is the name of an IPVS scheduling algorithm.
+type Scheduler string
+
+const (
+	// SchedulerRoundRobin sends connections to each real server in turn.
+	SchedulerRoundRobin Scheduler = "rr"
+	// SchedulerLeastConnection sends connections to the real server with
+	// the fewest active connections.
+	SchedulerLeastConnection Scheduler = "lc"
+	// SchedulerSourceHash sends all connections from a client address to the
+	// same real server, as long as the set of real servers does not change.
+	SchedulerSourceHash Scheduler = "sh"
+)
+
+// IsValidScheduler returns true if the scheduler is one of the schedulers
+// above.
+func IsValidScheduler(s Scheduler) bool {
+	switch s {
+	case SchedulerRoundRobin, SchedulerLeastConnection, SchedulerSourceHash:
+		return true
+	}
+	return false
+}
+
+// VirtualServer is an address, port and protocol whose connections are
+// balanced across a set of real servers.
+type VirtualServer struct {
+	Address   net.IP
+	Protocol  Protocol
+	Port      uint16
+	Scheduler Scheduler
+	// Timeout is the persistence timeout in seconds.  Zero disables
+	// persistence.
+	Timeout uint32
+}
+
+// String returns the address, port and protocol identifying the virtual
+// server, e.g. "10.0.0.1:80/TCP".
+func (vs *VirtualServer) String() string {
+	return net.JoinHostPort(vs.Address.String(), strconv.Itoa(int(vs.Port))) + "/" + string(vs.Protocol)
+}
+
+// Equal returns true if both virtual servers have the same identity and
+// configuration.
+func (vs *VirtualServer) Equal(other *VirtualServer) bool {
+	return vs.Address.Equal(other.Address) &&
+		vs.Protocol == other.Protocol &&
+		vs.Port == other.Port &&
+		vs.Scheduler == other.Scheduler &&
+		vs.Timeout == other.Timeout
+}
+
+// RealServer is a backend of a virtual server.
+type RealServer struct {
+	Address net.IP
+	Port    uint16
+	Weight  int
+}
+
+// String returns the address and port of the real server.
+func (rs *RealServer) String() string {
+	return net.JoinHostPort(rs.Address.String(), strconv.Itoa(int(rs.Port)))
+}
+
+func (rs *RealServer) Equal(other *RealServer) bool {
+	return rs.Address.Equal(other.Address) && rs.Port == other.Port && rs.Weight == other.Weight
+}
+
+func validateProtocol(p Protocol) error {
+	if p != ProtocolTCP && p != ProtocolUDP {
+		return fmt.Errorf("unsupported protocol %q", p)
+	}
+	return nil
+}
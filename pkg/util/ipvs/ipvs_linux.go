@@ -0,0 +1,404 @@
+// +build linux
+
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/docker/libcontainer/netlink"
+)
+
+// IPVS generic netlink constants, from linux/ip_vs.h.
+const (
+	ipvsGenlName    = "IPVS"
+	ipvsGenlVersion = 1
+
+	ipvsCmdNewService = 1
+	ipvsCmdSetService = 2
+	ipvsCmdDelService = 3
+	ipvsCmdGetService = 4
+	ipvsCmdNewDest    = 5
+	ipvsCmdDelDest    = 7
+	ipvsCmdGetDest    = 8
+	ipvsCmdFlush      = 17
+
+	ipvsCmdAttrService = 1
+	ipvsCmdAttrDest    = 2
+
+	ipvsSvcAttrAF        = 1
+	ipvsSvcAttrProtocol  = 2
+	ipvsSvcAttrAddr      = 3
+	ipvsSvcAttrPort      = 4
+	ipvsSvcAttrSchedName = 6
+	ipvsSvcAttrFlags     = 7
+	ipvsSvcAttrTimeout   = 8
+	ipvsSvcAttrNetmask   = 9
+
+	ipvsDestAttrAddr      = 1
+	ipvsDestAttrPort      = 2
+	ipvsDestAttrFwdMethod = 3
+	ipvsDestAttrWeight    = 4
+	ipvsDestAttrUThresh   = 5
+	ipvsDestAttrLThresh   = 6
+
+	ipvsSvcFlagPersistent = 0x1
+	ipvsConnFlagMasq      = 0x0
+)
+
+// runner implements Interface in terms of the IPVS generic netlink family
+// and a dummy device.
+type runner struct {
+	sock   *genlSocket
+	device string
+}
+
+// New returns a new Interface which binds virtual server addresses to the
+// named dummy device.  It fails if the kernel does not support IPVS.
+func New(device string) (Interface, error) {
+	sock, err := newGenlSocket(ipvsGenlName, ipvsGenlVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &runner{sock: sock, device: device}, nil
+}
+
+func (r *runner) Flush() error {
+	_, err := r.sock.execute(ipvsCmdFlush, false)
+	return err
+}
+
+func (r *runner) AddVirtualServer(vs *VirtualServer) error {
+	return r.setVirtualServer(ipvsCmdNewService, vs)
+}
+
+func (r *runner) UpdateVirtualServer(vs *VirtualServer) error {
+	return r.setVirtualServer(ipvsCmdSetService, vs)
+}
+
+func (r *runner) setVirtualServer(cmd uint8, vs *VirtualServer) error {
+	svc, err := virtualServerAttr(vs, true)
+	if err != nil {
+		return err
+	}
+	_, err = r.sock.execute(cmd, false, svc)
+	return err
+}
+
+func (r *runner) DeleteVirtualServer(vs *VirtualServer) error {
+	svc, err := virtualServerAttr(vs, false)
+	if err != nil {
+		return err
+	}
+	_, err = r.sock.execute(ipvsCmdDelService, false, svc)
+	return err
+}
+
+func (r *runner) GetVirtualServers() ([]*VirtualServer, error) {
+	replies, err := r.sock.execute(ipvsCmdGetService, true)
+	if err != nil {
+		return nil, err
+	}
+	servers := []*VirtualServer{}
+	for _, reply := range replies {
+		attrs, err := parseAttrs(reply)
+		if err != nil {
+			return nil, err
+		}
+		vs, err := parseVirtualServer(attrs[ipvsCmdAttrService])
+		if err != nil {
+			return nil, err
+		}
+		// Firewall mark services have no address; they are not ours.
+		if vs != nil {
+			servers = append(servers, vs)
+		}
+	}
+	return servers, nil
+}
+
+func (r *runner) AddRealServer(vs *VirtualServer, rs *RealServer) error {
+	return r.setRealServer(ipvsCmdNewDest, vs, rs)
+}
+
+func (r *runner) DeleteRealServer(vs *VirtualServer, rs *RealServer) error {
+	return r.setRealServer(ipvsCmdDelDest, vs, rs)
+}
+
+func (r *runner) setRealServer(cmd uint8, vs *VirtualServer, rs *RealServer) error {
+	svc, err := virtualServerAttr(vs, false)
+	if err != nil {
+		return err
+	}
+	dest, err := realServerAttr(rs, cmd == ipvsCmdNewDest)
+	if err != nil {
+		return err
+	}
+	_, err = r.sock.execute(cmd, false, svc, dest)
+	return err
+}
+
+func (r *runner) GetRealServers(vs *VirtualServer) ([]*RealServer, error) {
+	svc, err := virtualServerAttr(vs, false)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := r.sock.execute(ipvsCmdGetDest, true, svc)
+	if err != nil {
+		return nil, err
+	}
+	servers := []*RealServer{}
+	for _, reply := range replies {
+		attrs, err := parseAttrs(reply)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := parseRealServer(attrs[ipvsCmdAttrDest], vs.Address.To4() != nil)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, rs)
+	}
+	return servers, nil
+}
+
+func (r *runner) EnsureAddressBound(ip net.IP) error {
+	iface, err := net.InterfaceByName(r.device)
+	if err != nil {
+		if err := netlink.NetworkLinkAdd(r.device, "dummy"); err != nil {
+			return fmt.Errorf("failed to create dummy device %q: %v", r.device, err)
+		}
+		if iface, err = net.InterfaceByName(r.device); err != nil {
+			return err
+		}
+	}
+	bound, err := interfaceAddresses(iface)
+	if err != nil {
+		return err
+	}
+	for _, addr := range bound {
+		if addr.Equal(ip) {
+			return nil
+		}
+	}
+	return netlink.NetworkLinkAddIp(iface, ip, &net.IPNet{IP: ip, Mask: hostMask(ip)})
+}
+
+func (r *runner) UnbindAddress(ip net.IP) error {
+	iface, err := net.InterfaceByName(r.device)
+	if err != nil {
+		return err
+	}
+	return netlink.NetworkLinkDelIp(iface, ip, &net.IPNet{IP: ip, Mask: hostMask(ip)})
+}
+
+func (r *runner) GetBoundAddresses() ([]net.IP, error) {
+	iface, err := net.InterfaceByName(r.device)
+	if err != nil {
+		// The device is only created when the first address is bound.
+		return nil, nil
+	}
+	return interfaceAddresses(iface)
+}
+
+func (r *runner) DeleteDevice() error {
+	if _, err := net.InterfaceByName(r.device); err != nil {
+		return nil
+	}
+	return netlink.NetworkLinkDel(r.device)
+}
+
+func interfaceAddresses(iface *net.Interface) ([]net.IP, error) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	ips := []net.IP{}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, ipnet.IP)
+		}
+	}
+	return ips, nil
+}
+
+// hostMask returns a mask covering the whole address.
+func hostMask(ip net.IP) net.IPMask {
+	if ip.To4() != nil {
+		return net.CIDRMask(32, 32)
+	}
+	return net.CIDRMask(128, 128)
+}
+
+// encodeAddress returns the address family of an IP and the IP in the
+// 16 byte layout of union nf_inet_addr.
+func encodeAddress(ip net.IP) (uint16, []byte, error) {
+	b := make([]byte, 16)
+	if ip4 := ip.To4(); ip4 != nil {
+		copy(b, ip4)
+		return syscall.AF_INET, b, nil
+	}
+	if ip6 := ip.To16(); ip6 != nil {
+		copy(b, ip6)
+		return syscall.AF_INET6, b, nil
+	}
+	return 0, nil, fmt.Errorf("invalid IP address %q", ip)
+}
+
+func decodeAddress(b []byte, ipv4 bool) (net.IP, error) {
+	if ipv4 && len(b) >= 4 {
+		return net.IPv4(b[0], b[1], b[2], b[3]), nil
+	}
+	if !ipv4 && len(b) >= 16 {
+		return net.IP(append([]byte(nil), b[:16]...)), nil
+	}
+	return nil, fmt.Errorf("invalid IPVS address of %d bytes", len(b))
+}
+
+func encodeProtocol(p Protocol) (uint16, error) {
+	switch p {
+	case ProtocolTCP:
+		return syscall.IPPROTO_TCP, nil
+	case ProtocolUDP:
+		return syscall.IPPROTO_UDP, nil
+	}
+	return 0, validateProtocol(p)
+}
+
+func encodePort(port uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, port)
+	return b
+}
+
+// virtualServerAttr encodes a virtual server.  Only the attributes
+// identifying it are included unless full is set.
+func virtualServerAttr(vs *VirtualServer, full bool) ([]byte, error) {
+	family, addr, err := encodeAddress(vs.Address)
+	if err != nil {
+		return nil, err
+	}
+	protocol, err := encodeProtocol(vs.Protocol)
+	if err != nil {
+		return nil, err
+	}
+	attrs := [][]byte{
+		uint16Attr(ipvsSvcAttrAF, family),
+		uint16Attr(ipvsSvcAttrProtocol, protocol),
+		attr(ipvsSvcAttrAddr, addr),
+		attr(ipvsSvcAttrPort, encodePort(vs.Port)),
+	}
+	if full {
+		// struct ip_vs_flags holds the flags and the mask of flags to set.
+		flags := make([]byte, 8)
+		if vs.Timeout > 0 {
+			nativeEndian.PutUint32(flags[0:4], ipvsSvcFlagPersistent)
+		}
+		nativeEndian.PutUint32(flags[4:8], ipvsSvcFlagPersistent)
+		netmask := uint32(0xffffffff)
+		if family == syscall.AF_INET6 {
+			// IPv6 services take a prefix length instead of a mask.
+			netmask = 128
+		}
+		attrs = append(attrs,
+			stringAttr(ipvsSvcAttrSchedName, string(vs.Scheduler)),
+			attr(ipvsSvcAttrFlags, flags),
+			uint32Attr(ipvsSvcAttrTimeout, vs.Timeout),
+			uint32Attr(ipvsSvcAttrNetmask, netmask),
+		)
+	}
+	return nestedAttr(ipvsCmdAttrService, attrs...), nil
+}
+
+// parseVirtualServer decodes the nested attributes of a virtual server.  It
+// returns nil for virtual servers without an address and port.
+func parseVirtualServer(b []byte) (*VirtualServer, error) {
+	attrs, err := parseAttrs(b)
+	if err != nil {
+		return nil, err
+	}
+	family, protocol, addr, port := attrs[ipvsSvcAttrAF], attrs[ipvsSvcAttrProtocol], attrs[ipvsSvcAttrAddr], attrs[ipvsSvcAttrPort]
+	if len(family) < 2 || len(protocol) < 2 || addr == nil || len(port) < 2 {
+		return nil, nil
+	}
+	vs := &VirtualServer{Port: binary.BigEndian.Uint16(port)}
+	if vs.Address, err = decodeAddress(addr, nativeEndian.Uint16(family) == syscall.AF_INET); err != nil {
+		return nil, err
+	}
+	switch nativeEndian.Uint16(protocol) {
+	case syscall.IPPROTO_TCP:
+		vs.Protocol = ProtocolTCP
+	case syscall.IPPROTO_UDP:
+		vs.Protocol = ProtocolUDP
+	default:
+		vs.Protocol = Protocol(fmt.Sprintf("%d", nativeEndian.Uint16(protocol)))
+	}
+	vs.Scheduler = Scheduler(strings.TrimRight(string(attrs[ipvsSvcAttrSchedName]), "\x00"))
+	if flags := attrs[ipvsSvcAttrFlags]; len(flags) >= 4 && nativeEndian.Uint32(flags)&ipvsSvcFlagPersistent != 0 {
+		if timeout := attrs[ipvsSvcAttrTimeout]; len(timeout) >= 4 {
+			vs.Timeout = nativeEndian.Uint32(timeout)
+		}
+	}
+	return vs, nil
+}
+
+// realServerAttr encodes a real server, with the attributes needed to
+// create it if full is set.  Real servers are always reached by
+// masquerading.
+func realServerAttr(rs *RealServer, full bool) ([]byte, error) {
+	_, addr, err := encodeAddress(rs.Address)
+	if err != nil {
+		return nil, err
+	}
+	attrs := [][]byte{
+		attr(ipvsDestAttrAddr, addr),
+		attr(ipvsDestAttrPort, encodePort(rs.Port)),
+	}
+	if full {
+		attrs = append(attrs,
+			uint32Attr(ipvsDestAttrFwdMethod, ipvsConnFlagMasq),
+			uint32Attr(ipvsDestAttrWeight, uint32(rs.Weight)),
+			uint32Attr(ipvsDestAttrUThresh, 0),
+			uint32Attr(ipvsDestAttrLThresh, 0),
+		)
+	}
+	return nestedAttr(ipvsCmdAttrDest, attrs...), nil
+}
+
+func parseRealServer(b []byte, ipv4 bool) (*RealServer, error) {
+	attrs, err := parseAttrs(b)
+	if err != nil {
+		return nil, err
+	}
+	port := attrs[ipvsDestAttrPort]
+	if len(port) < 2 {
+		return nil, fmt.Errorf("IPVS real server without a port")
+	}
+	rs := &RealServer{Port: binary.BigEndian.Uint16(port)}
+	if rs.Address, err = decodeAddress(attrs[ipvsDestAttrAddr], ipv4); err != nil {
+		return nil, err
+	}
+	if weight := attrs[ipvsDestAttrWeight]; len(weight) >= 4 {
+		rs.Weight = int(nativeEndian.Uint32(weight))
+	}
+	return rs, nil
+}
@@ -0,0 +1,142 @@
+// +build linux
+
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestVirtualServerAttrRoundTrip(t *testing.T) {
+	testCases := []VirtualServer{
+		{Address: net.ParseIP("10.0.0.1"), Protocol: ProtocolTCP, Port: 80, Scheduler: SchedulerRoundRobin},
+		{Address: net.ParseIP("10.0.0.2"), Protocol: ProtocolUDP, Port: 53, Scheduler: SchedulerSourceHash},
+		{Address: net.ParseIP("fd00::1"), Protocol: ProtocolTCP, Port: 443, Scheduler: SchedulerLeastConnection, Timeout: 180},
+	}
+	for _, vs := range testCases {
+		b, err := virtualServerAttr(&vs, true)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", vs.String(), err)
+			continue
+		}
+		attrs, err := parseAttrs(b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", vs.String(), err)
+			continue
+		}
+		got, err := parseVirtualServer(attrs[ipvsCmdAttrService])
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", vs.String(), err)
+			continue
+		}
+		if got == nil || !got.Equal(&vs) {
+			t.Errorf("expected %+v, got %+v", vs, got)
+		}
+	}
+}
+
+func TestVirtualServerAttrIdentity(t *testing.T) {
+	vs := &VirtualServer{Address: net.ParseIP("10.0.0.1"), Protocol: ProtocolTCP, Port: 80, Scheduler: SchedulerRoundRobin}
+	b, err := virtualServerAttr(vs, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outer, err := parseAttrs(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attrs, err := parseAttrs(outer[ipvsCmdAttrService])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, typ := range []uint16{ipvsSvcAttrSchedName, ipvsSvcAttrFlags, ipvsSvcAttrTimeout, ipvsSvcAttrNetmask} {
+		if _, found := attrs[typ]; found {
+			t.Errorf("unexpected attribute %d identifying a virtual server", typ)
+		}
+	}
+	if port := attrs[ipvsSvcAttrPort]; !reflect.DeepEqual(port, []byte{0, 80}) {
+		t.Errorf("expected the port in network byte order, got %v", port)
+	}
+}
+
+func TestVirtualServerAttrInvalid(t *testing.T) {
+	if _, err := virtualServerAttr(&VirtualServer{Address: net.ParseIP("10.0.0.1"), Protocol: "SCTP", Port: 80}, true); err == nil {
+		t.Errorf("expected an error for an unsupported protocol")
+	}
+	if _, err := virtualServerAttr(&VirtualServer{Protocol: ProtocolTCP, Port: 80}, true); err == nil {
+		t.Errorf("expected an error for a missing address")
+	}
+}
+
+func TestRealServerAttrRoundTrip(t *testing.T) {
+	testCases := []struct {
+		rs   RealServer
+		ipv4 bool
+	}{
+		{RealServer{Address: net.ParseIP("10.244.1.5"), Port: 8080, Weight: 1}, true},
+		{RealServer{Address: net.ParseIP("fd00:10:244::5"), Port: 8443, Weight: 3}, false},
+	}
+	for _, tc := range testCases {
+		b, err := realServerAttr(&tc.rs, true)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.rs.String(), err)
+			continue
+		}
+		attrs, err := parseAttrs(b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.rs.String(), err)
+			continue
+		}
+		got, err := parseRealServer(attrs[ipvsCmdAttrDest], tc.ipv4)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.rs.String(), err)
+			continue
+		}
+		if !got.Equal(&tc.rs) {
+			t.Errorf("expected %+v, got %+v", tc.rs, got)
+		}
+	}
+}
+
+func TestParseAttrs(t *testing.T) {
+	b := append(stringAttr(1, "IPVS"), uint16Attr(2, 7)...)
+	b = append(b, nestedAttr(3, uint32Attr(1, 9))...)
+	attrs, err := parseAttrs(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(attrs[1]) != "IPVS\x00" {
+		t.Errorf("unexpected string attribute %q", attrs[1])
+	}
+	if v := nativeEndian.Uint16(attrs[2]); v != 7 {
+		t.Errorf("unexpected uint16 attribute %d", v)
+	}
+	nested, err := parseAttrs(attrs[3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := nativeEndian.Uint32(nested[1]); v != 9 {
+		t.Errorf("unexpected nested attribute %d", v)
+	}
+
+	if _, err := parseAttrs([]byte{2, 0, 1, 0}); err == nil {
+		t.Errorf("expected an error for a truncated attribute")
+	}
+}
@@ -0,0 +1,204 @@
+// +build linux
+
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipvs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Generic netlink constants, from linux/genetlink.h.
+const (
+	genlHdrLen             = 4
+	genlCtrlID             = 0x10
+	genlCtrlVersion        = 1
+	genlCtrlCmdGetFamily   = 3
+	genlCtrlAttrFamilyID   = 1
+	genlCtrlAttrFamilyName = 2
+
+	nlaHdrLen   = 4
+	nlaFNested  = 0x8000
+	nlaTypeMask = 0x3fff
+)
+
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+func nlaAlign(n int) int {
+	return (n + 3) &^ 3
+}
+
+// genlSocket sends requests to a generic netlink family and collects the
+// replies.
+type genlSocket struct {
+	mu      sync.Mutex
+	fd      int
+	family  uint16
+	version uint8
+	seq     uint32
+}
+
+// newGenlSocket opens a generic netlink socket talking to the named family.
+func newGenlSocket(name string, version uint8) (*genlSocket, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_GENERIC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink socket: %v", err)
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to bind netlink socket: %v", err)
+	}
+	s := &genlSocket{fd: fd, family: genlCtrlID, version: genlCtrlVersion}
+	replies, err := s.execute(genlCtrlCmdGetFamily, false, stringAttr(genlCtrlAttrFamilyName, name))
+	if err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to resolve generic netlink family %q: %v", name, err)
+	}
+	for _, reply := range replies {
+		attrs, err := parseAttrs(reply)
+		if err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+		if id, found := attrs[genlCtrlAttrFamilyID]; found && len(id) >= 2 {
+			s.family = nativeEndian.Uint16(id)
+			s.version = version
+			return s, nil
+		}
+	}
+	syscall.Close(fd)
+	return nil, fmt.Errorf("generic netlink family %q has no id", name)
+}
+
+// execute sends a command with the given attributes and returns the
+// attributes of each reply, without their generic netlink header.
+func (s *genlSocket) execute(cmd uint8, dump bool, attrs ...[]byte) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	flags := syscall.NLM_F_REQUEST | syscall.NLM_F_ACK
+	if dump {
+		flags |= syscall.NLM_F_DUMP
+	}
+	payload := bytes.Join(attrs, nil)
+	msg := make([]byte, syscall.NLMSG_HDRLEN+genlHdrLen+len(payload))
+	nativeEndian.PutUint32(msg[0:4], uint32(len(msg)))
+	nativeEndian.PutUint16(msg[4:6], s.family)
+	nativeEndian.PutUint16(msg[6:8], uint16(flags))
+	nativeEndian.PutUint32(msg[8:12], s.seq)
+	msg[syscall.NLMSG_HDRLEN] = cmd
+	msg[syscall.NLMSG_HDRLEN+1] = s.version
+	copy(msg[syscall.NLMSG_HDRLEN+genlHdrLen:], payload)
+	if err := syscall.Sendto(s.fd, msg, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	var replies [][]byte
+	buf := make([]byte, 65536)
+	for {
+		n, _, err := syscall.Recvfrom(s.fd, buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != s.seq {
+				continue
+			}
+			switch m.Header.Type {
+			case syscall.NLMSG_DONE:
+				return replies, nil
+			case syscall.NLMSG_ERROR:
+				if len(m.Data) < 4 {
+					return nil, fmt.Errorf("short netlink error message")
+				}
+				if errno := int32(nativeEndian.Uint32(m.Data[0:4])); errno != 0 {
+					return nil, syscall.Errno(-errno)
+				}
+				// An acknowledgement ends a request that is not a dump.
+				if !dump {
+					return replies, nil
+				}
+			default:
+				if len(m.Data) >= genlHdrLen {
+					replies = append(replies, m.Data[genlHdrLen:])
+				}
+			}
+		}
+	}
+}
+
+// attr encodes a netlink attribute.
+func attr(typ uint16, data []byte) []byte {
+	b := make([]byte, nlaAlign(nlaHdrLen+len(data)))
+	nativeEndian.PutUint16(b[0:2], uint16(nlaHdrLen+len(data)))
+	nativeEndian.PutUint16(b[2:4], typ)
+	copy(b[nlaHdrLen:], data)
+	return b
+}
+
+func nestedAttr(typ uint16, children ...[]byte) []byte {
+	return attr(typ|nlaFNested, bytes.Join(children, nil))
+}
+
+func uint16Attr(typ uint16, v uint16) []byte {
+	b := make([]byte, 2)
+	nativeEndian.PutUint16(b, v)
+	return attr(typ, b)
+}
+
+func uint32Attr(typ uint16, v uint32) []byte {
+	b := make([]byte, 4)
+	nativeEndian.PutUint32(b, v)
+	return attr(typ, b)
+}
+
+func stringAttr(typ uint16, v string) []byte {
+	return attr(typ, append([]byte(v), 0))
+}
+
+// parseAttrs decodes a sequence of netlink attributes, keyed by type.
+func parseAttrs(b []byte) (map[uint16][]byte, error) {
+	attrs := map[uint16][]byte{}
+	for len(b) >= nlaHdrLen {
+		l := int(nativeEndian.Uint16(b[0:2]))
+		if l < nlaHdrLen || l > len(b) {
+			return nil, fmt.Errorf("invalid netlink attribute length %d", l)
+		}
+		attrs[nativeEndian.Uint16(b[2:4])&nlaTypeMask] = b[nlaHdrLen:l]
+		if nlaAlign(l) >= len(b) {
+			break
+		}
+		b = b[nlaAlign(l):]
+	}
+	return attrs, nil
+}
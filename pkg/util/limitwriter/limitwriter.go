@@ -0,0 +1,51 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitwriter
+
+import (
+	"errors"
+	"io"
+)
+
+// New creates a writer that is limited in the number of bytes it can write.
+func New(w io.Writer, n int64) io.Writer {
+	return &limitWriter{w, n}
+}
+
+// ErrMaximumWrite is returned when all bytes have been written.
+var ErrMaximumWrite = errors.New("maximum write")
+
+// limitWriter writes at most n bytes to the underlying writer and then
+// returns ErrMaximumWrite on every subsequent write.
+type limitWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *limitWriter) Write(p []byte) (n int, err error) {
+	if int64(len(p)) > w.n {
+		p = p[:w.n]
+	}
+	if len(p) > 0 {
+		n, err = w.w.Write(p)
+		w.n -= int64(n)
+	}
+	if w.n == 0 {
+		err = ErrMaximumWrite
+	}
+	return
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitwriter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLimitWriter(t *testing.T) {
+	testCases := []struct {
+		limit    int64
+		writes   []string
+		expected string
+		errAt    int
+	}{
+		{limit: 10, writes: []string{"abc", "def"}, expected: "abcdef", errAt: -1},
+		{limit: 6, writes: []string{"abc", "def"}, expected: "abcdef", errAt: 1},
+		{limit: 4, writes: []string{"abc", "def", "ghi"}, expected: "abcd", errAt: 1},
+		{limit: 0, writes: []string{"abc"}, expected: "", errAt: 0},
+	}
+	for i, test := range testCases {
+		buf := &bytes.Buffer{}
+		w := New(buf, test.limit)
+		for j, s := range test.writes {
+			_, err := w.Write([]byte(s))
+			if j < test.errAt || test.errAt == -1 {
+				if err != nil {
+					t.Errorf("%d: unexpected error on write %d: %v", i, j, err)
+				}
+				continue
+			}
+			if err != ErrMaximumWrite {
+				t.Errorf("%d: expected ErrMaximumWrite on write %d, got %v", i, j, err)
+			}
+		}
+		if buf.String() != test.expected {
+			t.Errorf("%d: expected %q, got %q", i, test.expected, buf.String())
+		}
+	}
+}
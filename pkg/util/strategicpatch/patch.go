@@ -44,6 +44,111 @@ func StrategicMergePatchData(original, patch []byte, dataStruct interface{}) ([]
 		return nil, err
 	}
 
+	t, err := getTagStructType(dataStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := mergeMap(o, p, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+// CreateTwoWayMergePatch creates a strategic merge patch that transforms the
+// original document into the modified document. Both documents are passed as
+// JSON, and dataStruct supplies the patchStrategy and patchMergeKey struct
+// tags used to decide how lists are diffed.
+//
+// Note that removals from lists of scalars with the merge strategy cannot be
+// expressed in a strategic merge patch, so only additions are recorded for them.
+func CreateTwoWayMergePatch(original, modified []byte, dataStruct interface{}) ([]byte, error) {
+	t, err := getTagStructType(dataStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	originalMap, err := unmarshalDocument(original)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedMap, err := unmarshalDocument(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	patchMap, err := diffMaps(originalMap, modifiedMap, t, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(patchMap)
+}
+
+// CreateThreeWayMergePatch creates a strategic merge patch that brings the
+// current document in line with the modified document, where original is the
+// configuration that was last applied to produce current. Fields that were
+// added or changed in modified relative to current are included in the patch.
+// Fields are deleted only if they were present in original and are absent from
+// modified, so fields that were set in current by other actors, such as
+// controllers or defaulting, are left untouched.
+func CreateThreeWayMergePatch(original, modified, current []byte, dataStruct interface{}) ([]byte, error) {
+	t, err := getTagStructType(dataStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	originalMap, err := unmarshalDocument(original)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedMap, err := unmarshalDocument(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	currentMap, err := unmarshalDocument(current)
+	if err != nil {
+		return nil, err
+	}
+
+	// Changes and additions come from comparing current against modified,
+	// while deletions come from comparing the last applied configuration
+	// against modified.
+	deltaMap, err := diffMaps(currentMap, modifiedMap, t, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	deletionsMap, err := diffMaps(originalMap, modifiedMap, t, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	patchMap, err := mergeMap(deletionsMap, deltaMap, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(patchMap)
+}
+
+func unmarshalDocument(data []byte) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if len(data) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func getTagStructType(dataStruct interface{}) (reflect.Type, error) {
 	t := reflect.TypeOf(dataStruct)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -51,13 +156,180 @@ func StrategicMergePatchData(original, patch []byte, dataStruct interface{}) ([]
 	if t.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("strategic merge patch needs a struct, %s received instead", t.Kind().String())
 	}
+	return t, nil
+}
 
-	result, err := mergeMap(o, p, t)
+// Returns a patch that describes the differences between the original and the
+// modified maps. If ignoreChangesAndAdditions is true, only deletions are
+// recorded; if ignoreDeletions is true, deleted fields are left out.
+func diffMaps(original, modified map[string]interface{}, t reflect.Type, ignoreChangesAndAdditions, ignoreDeletions bool) (map[string]interface{}, error) {
+	patch := map[string]interface{}{}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for key, modifiedValue := range modified {
+		originalValue, ok := original[key]
+		// A null value in modified means the key should not be set.
+		if modifiedValue == nil {
+			if ok && originalValue != nil && !ignoreDeletions {
+				patch[key] = nil
+			}
+			continue
+		}
+		if !ok {
+			// The key was added, so add it to the patch.
+			if !ignoreChangesAndAdditions {
+				patch[key] = modifiedValue
+			}
+			continue
+		}
+
+		// Values of different types, or nil values, can only be replaced.
+		if originalValue == nil || reflect.TypeOf(originalValue) != reflect.TypeOf(modifiedValue) {
+			if !ignoreChangesAndAdditions && !reflect.DeepEqual(originalValue, modifiedValue) {
+				patch[key] = modifiedValue
+			}
+			continue
+		}
+
+		switch typedOriginal := originalValue.(type) {
+		case map[string]interface{}:
+			typedModified := modifiedValue.(map[string]interface{})
+			fieldType, fieldPatchStrategy, _, err := forkedjson.LookupPatchMetadata(t, key)
+			if err != nil {
+				return nil, err
+			}
+			if fieldPatchStrategy == "replace" {
+				if !ignoreChangesAndAdditions && !reflect.DeepEqual(typedOriginal, typedModified) {
+					patch[key] = modifiedValue
+				}
+				continue
+			}
+			patchValue, err := diffMaps(typedOriginal, typedModified, fieldType, ignoreChangesAndAdditions, ignoreDeletions)
+			if err != nil {
+				return nil, err
+			}
+			if len(patchValue) > 0 {
+				patch[key] = patchValue
+			}
+			continue
+		case []interface{}:
+			typedModified := modifiedValue.([]interface{})
+			fieldType, fieldPatchStrategy, fieldPatchMergeKey, err := forkedjson.LookupPatchMetadata(t, key)
+			if err != nil {
+				return nil, err
+			}
+			if fieldPatchStrategy == "merge" {
+				patchValue, err := diffLists(typedOriginal, typedModified, fieldType.Elem(), fieldPatchMergeKey, ignoreChangesAndAdditions, ignoreDeletions)
+				if err != nil {
+					return nil, err
+				}
+				if len(patchValue) > 0 {
+					patch[key] = patchValue
+				}
+				continue
+			}
+		}
+
+		// Scalars, and lists that are replaced rather than merged.
+		if !ignoreChangesAndAdditions && !reflect.DeepEqual(originalValue, modifiedValue) {
+			patch[key] = modifiedValue
+		}
+	}
+
+	if !ignoreDeletions {
+		// Keys that are missing from modified are deleted by setting them to null.
+		for key := range original {
+			if _, found := modified[key]; !found {
+				patch[key] = nil
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+// Returns a patch for a list with the merge strategy. Elements that are maps
+// are matched on mergeKey; removed elements are recorded with the special
+// "$patch: delete" directive.
+func diffLists(original, modified []interface{}, elemType reflect.Type, mergeKey string, ignoreChangesAndAdditions, ignoreDeletions bool) ([]interface{}, error) {
+	if len(original) == 0 && len(modified) == 0 {
+		return nil, nil
+	}
+	t, err := sliceElementType(original, modified)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("types of list elements need to be the same, type: %s: %v",
+			elemType.Kind().String(), err)
+	}
+	if t.Kind() == reflect.Slice {
+		return nil, fmt.Errorf("not supporting diffing lists of lists yet")
 	}
 
-	return json.Marshal(result)
+	patch := []interface{}{}
+	if t.Kind() != reflect.Map {
+		if ignoreChangesAndAdditions {
+			return patch, nil
+		}
+		for _, v := range modified {
+			if !containsScalar(original, v) {
+				patch = append(patch, v)
+			}
+		}
+		return patch, nil
+	}
+
+	if mergeKey == "" {
+		return nil, fmt.Errorf("cannot diff lists without merge key for type %s", elemType.Kind().String())
+	}
+
+	for _, v := range modified {
+		typedModified := v.(map[string]interface{})
+		mergeValue, ok := typedModified[mergeKey]
+		if !ok {
+			return nil, fmt.Errorf("all list elements need the merge key %s", mergeKey)
+		}
+		originalMap, _, found := findMapInSliceBasedOnKeyValue(original, mergeKey, mergeValue)
+		if !found {
+			if !ignoreChangesAndAdditions {
+				patch = append(patch, typedModified)
+			}
+			continue
+		}
+		patchValue, err := diffMaps(originalMap, typedModified, elemType, ignoreChangesAndAdditions, ignoreDeletions)
+		if err != nil {
+			return nil, err
+		}
+		if len(patchValue) > 0 {
+			// The merge key identifies the element the patch applies to.
+			patchValue[mergeKey] = mergeValue
+			patch = append(patch, patchValue)
+		}
+	}
+
+	if !ignoreDeletions {
+		for _, v := range original {
+			typedOriginal := v.(map[string]interface{})
+			mergeValue, ok := typedOriginal[mergeKey]
+			if !ok {
+				return nil, fmt.Errorf("all list elements need the merge key %s", mergeKey)
+			}
+			if _, _, found := findMapInSliceBasedOnKeyValue(modified, mergeKey, mergeValue); !found {
+				patch = append(patch, map[string]interface{}{mergeKey: mergeValue, specialKey: "delete"})
+			}
+		}
+	}
+
+	return patch, nil
+}
+
+func containsScalar(s []interface{}, v interface{}) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
 }
 
 const specialKey = "$patch"
@@ -272,8 +544,15 @@ func sortMergeListsByName(mapJSON []byte, dataStruct interface{}) ([]byte, error
 }
 
 func sortMergeListsByNameMap(s map[string]interface{}, t reflect.Type) (map[string]interface{}, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
 	newS := map[string]interface{}{}
 	for k, v := range s {
+		if k == specialKey {
+			newS[k] = v
+			continue
+		}
 		fieldType, fieldPatchStrategy, fieldPatchMergeKey, err := forkedjson.LookupPatchMetadata(t, k)
 		if err != nil {
 			return nil, err
@@ -29,6 +29,8 @@ import (
 type TestCases struct {
 	StrategicMergePatchCases []StrategicMergePatchCase
 	SortMergeListTestCases   []SortMergeListCase
+	TwoWayMergePatchCases    []TwoWayMergePatchCase
+	ThreeWayMergePatchCases  []ThreeWayMergePatchCase
 }
 
 type StrategicMergePatchCase struct {
@@ -38,6 +40,22 @@ type StrategicMergePatchCase struct {
 	Result      map[string]interface{}
 }
 
+type TwoWayMergePatchCase struct {
+	Description string
+	Original    map[string]interface{}
+	Modified    map[string]interface{}
+	Patch       map[string]interface{}
+}
+
+type ThreeWayMergePatchCase struct {
+	Description string
+	Original    map[string]interface{}
+	Modified    map[string]interface{}
+	Current     map[string]interface{}
+	Patch       map[string]interface{}
+	Result      map[string]interface{}
+}
+
 type SortMergeListCase struct {
 	Description string
 	Original    map[string]interface{}
@@ -423,6 +441,195 @@ sortMergeListTestCases:
         - name: 3
 `)
 
+var createPatchTestCaseData = []byte(`
+twoWayMergePatchCases:
+  - description: nothing changed
+    original:
+      name: 1
+      value: 1
+    modified:
+      name: 1
+      value: 1
+    patch: {}
+  - description: add, change and delete fields
+    original:
+      name: 1
+      value: 1
+    modified:
+      name: 2
+      mergeItemPtr:
+        name: 3
+    patch:
+      name: 2
+      value: null
+      mergeItemPtr:
+        name: 3
+  - description: replace a non merging list
+    original:
+      nonMergingIntList:
+        - 1
+        - 2
+    modified:
+      nonMergingIntList:
+        - 2
+    patch:
+      nonMergingIntList:
+        - 2
+  - description: add to a merging list of scalars
+    original:
+      mergingIntList:
+        - 1
+    modified:
+      mergingIntList:
+        - 1
+        - 2
+    patch:
+      mergingIntList:
+        - 2
+  - description: change, add and delete items in a merge list
+    original:
+      mergingList:
+        - name: 1
+          value: 1
+        - name: 2
+          value: 2
+        - name: 3
+    modified:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 3
+        - name: 4
+    patch:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 2
+          $patch: delete
+        - name: 4
+  - description: delete field inside a merge list item
+    original:
+      mergingList:
+        - name: 1
+          value: 1
+    modified:
+      mergingList:
+        - name: 1
+    patch:
+      mergingList:
+        - name: 1
+          value: null
+  - description: delete a key from a map
+    original:
+      simpleMap:
+        key1: value1
+        key2: value2
+    modified:
+      simpleMap:
+        key1: value1
+    patch:
+      simpleMap:
+        key2: null
+threeWayMergePatchCases:
+  - description: keep fields set by other actors
+    original:
+      name: 1
+    modified:
+      name: 1
+    current:
+      name: 1
+      value: 1
+    patch: {}
+    result:
+      name: 1
+      value: 1
+  - description: delete fields removed from the configuration
+    original:
+      name: 1
+      value: 1
+    modified:
+      name: 1
+    current:
+      name: 1
+      value: 1
+      mergeItemPtr:
+        name: 2
+    patch:
+      value: null
+    result:
+      name: 1
+      mergeItemPtr:
+        name: 2
+  - description: overwrite fields changed by other actors
+    original:
+      value: 1
+    modified:
+      value: 2
+    current:
+      value: 3
+    patch:
+      value: 2
+    result:
+      value: 2
+  - description: merge lists changed by other actors
+    original:
+      mergingList:
+        - name: 1
+          value: 1
+        - name: 2
+    modified:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 3
+    current:
+      mergingList:
+        - name: 1
+          value: 1
+          mergingIntList:
+            - 1
+        - name: 2
+        - name: 4
+    patch:
+      mergingList:
+        - name: 1
+          value: a
+        - name: 2
+          $patch: delete
+        - name: 3
+    result:
+      mergingList:
+        - name: 1
+          value: a
+          mergingIntList:
+            - 1
+        - name: 3
+        - name: 4
+  - description: delete a nested field removed from the configuration
+    original:
+      mergingList:
+        - name: 1
+          value: 1
+    modified:
+      mergingList:
+        - name: 1
+    current:
+      mergingList:
+        - name: 1
+          value: 1
+          simpleMap:
+            key: value
+    patch:
+      mergingList:
+        - name: 1
+          value: null
+    result:
+      mergingList:
+        - name: 1
+          simpleMap:
+            key: value
+`)
+
 func TestStrategicMergePatch(t *testing.T) {
 	tc := TestCases{}
 	err := yaml.Unmarshal(testCaseData, &tc)
@@ -478,6 +685,76 @@ func TestSortMergeLists(t *testing.T) {
 	}
 }
 
+func TestCreateTwoWayMergePatch(t *testing.T) {
+	tc := TestCases{}
+	err := yaml.Unmarshal(createPatchTestCaseData, &tc)
+	if err != nil {
+		t.Errorf("can't unmarshal test cases: %v", err)
+		return
+	}
+
+	var e MergeItem
+	for _, c := range tc.TwoWayMergePatchCases {
+		patch, err := CreateTwoWayMergePatch(toJSON(c.Original), toJSON(c.Modified), e)
+		if err != nil {
+			t.Errorf("error creating patch: %s: %v", c.Description, err)
+			continue
+		}
+		assertPatchesEqual(t, c.Description, patch, toJSON(c.Patch), e)
+
+		// Applying the patch to the original must yield the modified document.
+		result, err := StrategicMergePatchData(toJSON(c.Original), patch, e)
+		if err != nil {
+			t.Errorf("error applying patch: %s: %v", c.Description, err)
+			continue
+		}
+		assertPatchesEqual(t, c.Description, result, toJSON(c.Modified), e)
+	}
+}
+
+func TestCreateThreeWayMergePatch(t *testing.T) {
+	tc := TestCases{}
+	err := yaml.Unmarshal(createPatchTestCaseData, &tc)
+	if err != nil {
+		t.Errorf("can't unmarshal test cases: %v", err)
+		return
+	}
+
+	var e MergeItem
+	for _, c := range tc.ThreeWayMergePatchCases {
+		patch, err := CreateThreeWayMergePatch(toJSON(c.Original), toJSON(c.Modified), toJSON(c.Current), e)
+		if err != nil {
+			t.Errorf("error creating patch: %s: %v", c.Description, err)
+			continue
+		}
+		assertPatchesEqual(t, c.Description, patch, toJSON(c.Patch), e)
+
+		result, err := StrategicMergePatchData(toJSON(c.Current), patch, e)
+		if err != nil {
+			t.Errorf("error applying patch: %s: %v", c.Description, err)
+			continue
+		}
+		assertPatchesEqual(t, c.Description, result, toJSON(c.Result), e)
+	}
+}
+
+// assertPatchesEqual compares two documents, ignoring the order of merge lists.
+func assertPatchesEqual(t *testing.T, description string, got, expected []byte, dataStruct interface{}) {
+	sortedGot, err := sortMergeListsByName(got, dataStruct)
+	if err != nil {
+		t.Errorf("error sorting result object: %v", err)
+		return
+	}
+	sortedExpected, err := sortMergeListsByName(expected, dataStruct)
+	if err != nil {
+		t.Errorf("error sorting expected object: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(sortedGot, sortedExpected) {
+		t.Errorf("%s\nexpected:\n%s\ngot:\n%s", description, jsonToYAML(sortedExpected), jsonToYAML(sortedGot))
+	}
+}
+
 func toYAML(v interface{}) string {
 	y, err := yaml.Marshal(v)
 	if err != nil {
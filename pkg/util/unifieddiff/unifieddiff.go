@@ -0,0 +1,175 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unifieddiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+// edit is a single step in turning one list of lines into another.
+type edit struct {
+	// kind is ' ' for a line in both lists, '-' for a line only in the
+	// first list and '+' for a line only in the second.
+	kind byte
+	// a and b are the indices of the line in the first and second list. For
+	// an added or removed line, the index into the other list is where the
+	// line would be.
+	a, b int
+}
+
+// Diff returns the unified diff that turns from into to, labelled fromName
+// and toName, showing context unchanged lines around each change. It returns
+// the empty string if the texts are equal. Both texts are expected to end with
+// a newline; a missing final newline is not reported.
+func Diff(fromName, toName, from, to string, context int) string {
+	a, b := splitLines(from), splitLines(to)
+	edits := editScript(a, b)
+
+	buf := &bytes.Buffer{}
+	for start := 0; start < len(edits); {
+		// Find the next change and the extent of the hunk around it. Changes
+		// closer together than twice the context share a hunk.
+		first := nextChange(edits, start)
+		if first == len(edits) {
+			break
+		}
+		last := first
+		for {
+			next := nextChange(edits, last+1)
+			if next == len(edits) || next-last-1 > 2*context {
+				break
+			}
+			last = next
+		}
+		hunkStart, hunkEnd := max(first-context, 0), min(last+context+1, len(edits))
+		if buf.Len() == 0 {
+			fmt.Fprintf(buf, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(buf, a, b, edits[hunkStart:hunkEnd])
+		start = hunkEnd
+	}
+	return buf.String()
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// editScript returns a shortest list of edits turning a into b, computed from
+// their longest common subsequence.
+func editScript(a, b []string) []edit {
+	// common[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', i, j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			edits = append(edits, edit{'-', i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', i, j})
+			j++
+		}
+	}
+	return edits
+}
+
+// nextChange returns the index of the first edit at or after start which is
+// not an unchanged line, or len(edits) if there is none.
+func nextChange(edits []edit, start int) int {
+	for i := start; i < len(edits); i++ {
+		if edits[i].kind != ' ' {
+			return i
+		}
+	}
+	return len(edits)
+}
+
+func writeHunk(buf *bytes.Buffer, a, b []string, edits []edit) {
+	fromCount, toCount := 0, 0
+	for _, e := range edits {
+		if e.kind != '+' {
+			fromCount++
+		}
+		if e.kind != '-' {
+			toCount++
+		}
+	}
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(edits[0].a, fromCount), hunkRange(edits[0].b, toCount))
+	for _, e := range edits {
+		line := ""
+		if e.kind == '+' {
+			line = b[e.b]
+		} else {
+			line = a[e.a]
+		}
+		fmt.Fprintf(buf, "%c%s\n", e.kind, line)
+	}
+}
+
+// hunkRange formats the lines of one side of a hunk, which start at the
+// zero based index start. An empty range names the line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
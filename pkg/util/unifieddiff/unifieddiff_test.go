@@ -0,0 +1,114 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unifieddiff
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		context  int
+		expected string
+	}{
+		{
+			name:     "equal",
+			from:     "a\nb\n",
+			to:       "a\nb\n",
+			context:  DefaultContext,
+			expected: "",
+		},
+		{
+			name:    "changed line",
+			from:    "a\nb\nc\n",
+			to:      "a\nB\nc\n",
+			context: DefaultContext,
+			expected: `--- from
++++ to
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			name:    "added to empty",
+			from:    "",
+			to:      "a\nb\n",
+			context: DefaultContext,
+			expected: `--- from
++++ to
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		},
+		{
+			name:    "removed last line",
+			from:    "a\nb\nc\n",
+			to:      "a\nb\n",
+			context: 1,
+			expected: `--- from
++++ to
+@@ -2,2 +2 @@
+ b
+-c
+`,
+		},
+		{
+			name:    "separate hunks",
+			from:    "1\n2\n3\n4\n5\n6\n7\n8\n",
+			to:      "one\n2\n3\n4\n5\n6\n7\neight\n",
+			context: 1,
+			expected: `--- from
++++ to
+@@ -1,2 +1,2 @@
+-1
++one
+ 2
+@@ -7,2 +7,2 @@
+ 7
+-8
++eight
+`,
+		},
+		{
+			name:    "merged hunks",
+			from:    "1\n2\n3\n4\n",
+			to:      "one\n2\n3\nfour\n",
+			context: 1,
+			expected: `--- from
++++ to
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+-4
++four
+`,
+		},
+	}
+	for _, test := range tests {
+		if actual := Diff("from", "to", test.from, test.to, test.context); actual != test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, test.expected, actual)
+		}
+	}
+}
@@ -168,6 +168,10 @@ func (intstr IntOrString) MarshalJSON() ([]byte, error) {
 }
 
 func (intstr *IntOrString) Fuzz(c fuzz.Continue) {
+	if intstr == nil {
+		// gofuzz calls this on nil *IntOrString fields; leave them nil.
+		return
+	}
 	if c.RandBool() {
 		intstr.Kind = IntstrInt
 		c.Fuzz(&intstr.IntVal)
@@ -482,6 +486,15 @@ func chooseHostInterfaceFromRoute(inFile io.Reader, nw networkInterfacer) (net.I
 	return nil, nil
 }
 
+// HostCIDR returns the CIDR containing only the given IP address, e.g.
+// 10.0.0.1/32 or fd00::1/128.
+func HostCIDR(ip string) string {
+	if addr := net.ParseIP(ip); addr != nil && addr.To4() == nil {
+		return addr.String() + "/128"
+	}
+	return ip + "/32"
+}
+
 func GetClient(req *http.Request) string {
 	if userAgent, ok := req.Header["User-Agent"]; ok {
 		if len(userAgent) > 0 {
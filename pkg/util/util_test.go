@@ -431,6 +431,19 @@ func TestParseIP(t *testing.T) {
 	}
 }
 
+func TestHostCIDR(t *testing.T) {
+	testCases := map[string]string{
+		"10.0.0.1":        "10.0.0.1/32",
+		"fd00::1":         "fd00::1/128",
+		"fd00:0:0:0:0::1": "fd00::1/128",
+	}
+	for ip, expected := range testCases {
+		if cidr := HostCIDR(ip); cidr != expected {
+			t.Errorf("%s: expected %q, got %q", ip, expected, cidr)
+		}
+	}
+}
+
 func TestIsInterfaceUp(t *testing.T) {
 	testCases := []struct {
 		tcase    string
@@ -225,6 +225,9 @@ func (b *glusterfsBuilder) setUpAtInternal(dir string) error {
 	start := rand.Int() % l
 	// Iterate all hosts until mount succeeds.
 	for i := start; i < start+l; i++ {
+		if len(b.hosts.Subsets[i%l].Addresses) == 0 {
+			continue
+		}
 		hostIP := b.hosts.Subsets[i%l].Addresses[0].IP
 		errs = b.mounter.Mount(hostIP+":"+b.path, dir, "glusterfs", options)
 		if errs == nil {
@@ -0,0 +1,92 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// Decoder implements the watch.Decoder interface for io.ReadClosers that
+// have contents which consist of a series of length prefixed watch events
+// written by an Encoder.
+type Decoder struct {
+	r     io.ReadCloser
+	codec runtime.Codec
+}
+
+// NewDecoder creates an Decoder for the given writer and codec.
+func NewDecoder(r io.ReadCloser, codec runtime.Codec) *Decoder {
+	return &Decoder{
+		r:     r,
+		codec: codec,
+	}
+}
+
+// Decode blocks until it can return the next object in the writer. Returns an error
+// if the writer is closed or an object can't be decoded.
+func (d *Decoder) Decode() (watch.EventType, runtime.Object, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(d.r, size[:]); err != nil {
+		return "", nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxFrameSize {
+		return "", nil, fmt.Errorf("watch event of %d bytes exceeds the maximum of %d", n, maxFrameSize)
+	}
+	frame := make([]byte, n)
+	if _, err := io.ReadFull(d.r, frame); err != nil {
+		return "", nil, err
+	}
+
+	var eventType watch.EventType
+	var data []byte
+	fields := protobuf.NewDecoder(frame)
+	for fields.Next() {
+		switch fields.Field() {
+		case 1:
+			eventType = watch.EventType(fields.String())
+		case 2:
+			data = fields.Bytes()
+		default:
+			fields.Skip()
+		}
+	}
+	if err := fields.Err(); err != nil {
+		return "", nil, err
+	}
+	switch eventType {
+	case watch.Added, watch.Modified, watch.Deleted, watch.Error, watch.Bookmark:
+	default:
+		return "", nil, fmt.Errorf("got invalid watch event type: %v", eventType)
+	}
+	obj, err := d.codec.Decode(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to decode watch event: %v", err)
+	}
+	return eventType, obj, nil
+}
+
+// Close closes the underlying r.
+func (d *Decoder) Close() {
+	d.r.Close()
+}
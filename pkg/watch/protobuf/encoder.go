@@ -0,0 +1,70 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+// maxFrameSize bounds the size of a single event so that a corrupt length
+// prefix cannot cause an arbitrarily large allocation.
+const maxFrameSize = 64 * 1024 * 1024
+
+// Encoder writes watch events to an io.Writer. Each event is a four byte big
+// endian length followed by a message holding the event type and the object
+// encoded with the supplied codec.
+type Encoder struct {
+	w     io.Writer
+	codec runtime.Codec
+}
+
+// NewEncoder creates an Encoder for the given writer and codec
+func NewEncoder(w io.Writer, codec runtime.Codec) *Encoder {
+	return &Encoder{
+		w:     w,
+		codec: codec,
+	}
+}
+
+// Encode writes an event to the writer. Returns an error
+// if the writer is closed or an object can't be encoded.
+func (e *Encoder) Encode(event *watch.Event) error {
+	obj, ok := event.Object.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("the event object cannot be safely encoded: %v", reflect.TypeOf(event.Object).Name())
+	}
+	data, err := e.codec.Encode(obj)
+	if err != nil {
+		return err
+	}
+	b := protobuf.Buffer{}
+	b.WriteString(1, string(event.Type))
+	b.WriteBytes(2, data)
+
+	frame := make([]byte, 4, 4+b.Len())
+	binary.BigEndian.PutUint32(frame, uint32(b.Len()))
+	frame = append(frame, b.Bytes()...)
+	_, err = e.w.Write(frame)
+	return err
+}
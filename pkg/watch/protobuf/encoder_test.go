@@ -0,0 +1,90 @@
+/*
+Copyright 2015 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	_ "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/runtime/protobuf"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	codec := protobuf.NewCodec(api.Scheme, "v1")
+	testCases := []struct {
+		Type   watch.EventType
+		Object runtime.Object
+	}{
+		{watch.Added, &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}},
+		{watch.Modified, &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", Labels: map[string]string{"a": "b"}}}},
+		{watch.Deleted, &api.Service{ObjectMeta: api.ObjectMeta{Name: "bar"}}},
+		{watch.Error, &api.Status{Status: api.StatusFailure, Message: "expired", Code: 410}},
+	}
+
+	// All events are written to one stream to verify the framing.
+	buf := &bytes.Buffer{}
+	encoder := NewEncoder(buf, codec)
+	for i, testCase := range testCases {
+		if err := encoder.Encode(&watch.Event{Type: testCase.Type, Object: testCase.Object}); err != nil {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+	}
+
+	decoder := NewDecoder(ioutil.NopCloser(buf), codec)
+	for i, testCase := range testCases {
+		event, obj, err := decoder.Decode()
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+		if !api.Semantic.DeepDerivative(testCase.Object, obj) {
+			t.Errorf("%d: expected %#v, got %#v", i, testCase.Object, obj)
+		}
+		if event != testCase.Type {
+			t.Errorf("%d: unexpected type: %#v", i, event)
+		}
+	}
+	if _, _, err := decoder.Decode(); err != io.EOF {
+		t.Errorf("expected EOF at end of stream, got %v", err)
+	}
+}
+
+func TestDecodeTruncatedFrame(t *testing.T) {
+	codec := protobuf.NewCodec(api.Scheme, "v1")
+	buf := &bytes.Buffer{}
+	if err := NewEncoder(buf, codec).Encode(&watch.Event{Type: watch.Added, Object: &api.Pod{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+	decoder := NewDecoder(ioutil.NopCloser(bytes.NewReader(data[:len(data)-1])), codec)
+	if _, _, err := decoder.Decode(); err == nil {
+		t.Errorf("expected error for truncated frame")
+	}
+}
+
+func TestDecodeOversizedFrame(t *testing.T) {
+	data := []byte{0xff, 0xff, 0xff, 0xff}
+	decoder := NewDecoder(ioutil.NopCloser(bytes.NewReader(data)), protobuf.NewCodec(api.Scheme, "v1"))
+	if _, _, err := decoder.Decode(); err == nil {
+		t.Errorf("expected error for oversized frame")
+	}
+}
@@ -42,6 +42,9 @@ const (
 	Modified EventType = "MODIFIED"
 	Deleted  EventType = "DELETED"
 	Error    EventType = "ERROR"
+	// Bookmark events carry no changes; they only report that the watch has
+	// progressed up to the resource version of their object.
+	Bookmark EventType = "BOOKMARK"
 )
 
 // Event represents a single event to a watched resource.
@@ -53,6 +56,8 @@ type Event struct {
 	//  * If Type is Deleted: the state of the object immediately before deletion.
 	//  * If Type is Error: *api.Status is recommended; other types may make sense
 	//    depending on context.
+	//  * If Type is Bookmark: an empty object of the watched type with only
+	//    its resource version set.
 	Object runtime.Object
 }
 
@@ -19,6 +19,7 @@ package priorities
 import (
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/expapi"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
 )
@@ -47,13 +48,13 @@ func (s *SelectorSpread) CalculateSpreadPriority(pod *api.Pod, podLister algorit
 	services, err := s.serviceLister.GetPodServices(pod)
 	if err == nil {
 		for _, service := range services {
-			selectors = append(selectors, labels.SelectorFromSet(service.Spec.Selector))
+			selectors = appendSelector(selectors, service.Spec.Selector)
 		}
 	}
 	controllers, err := s.controllerLister.GetPodControllers(pod)
 	if err == nil {
 		for _, controller := range controllers {
-			selectors = append(selectors, labels.SelectorFromSet(controller.Spec.Selector))
+			selectors = appendSelector(selectors, controller.Spec.Selector)
 		}
 	}
 
@@ -112,6 +113,17 @@ func (s *SelectorSpread) CalculateSpreadPriority(pod *api.Pod, podLister algorit
 	return result, nil
 }
 
+// appendSelector appends the selector of a set of labels to selectors. The set
+// is converted as a LabelSelector, so a nil set selects nothing.
+func appendSelector(selectors []labels.Selector, set map[string]string) []labels.Selector {
+	selector, err := expapi.LabelSelectorAsSelector(expapi.SetAsLabelSelector(labels.Set(set)))
+	if err != nil {
+		glog.V(4).Infof("Ignoring invalid selector %v: %v", set, err)
+		return selectors
+	}
+	return append(selectors, selector)
+}
+
 type ServiceAntiAffinity struct {
 	serviceLister algorithm.ServiceLister
 	label         string
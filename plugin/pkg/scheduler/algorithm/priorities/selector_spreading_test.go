@@ -213,6 +213,19 @@ func TestSelectorSpreadPriority(t *testing.T) {
 			expectedList: []algorithm.HostPriority{{"machine1", 0}, {"machine2", 5}},
 			test:         "Replication controller with partial pod label matches",
 		},
+		{
+			pod: &api.Pod{ObjectMeta: api.ObjectMeta{Labels: labels1}},
+			pods: []*api.Pod{
+				{Spec: zone1Spec, ObjectMeta: api.ObjectMeta{Labels: labels2}},
+				{Spec: zone1Spec, ObjectMeta: api.ObjectMeta{Labels: labels1}},
+				{Spec: zone2Spec, ObjectMeta: api.ObjectMeta{Labels: labels1}},
+			},
+			nodes: []string{"machine1", "machine2"},
+			// A nil selector selects no pods, as a nil LabelSelector does.
+			services:     []api.Service{{Spec: api.ServiceSpec{Selector: nil}}},
+			expectedList: []algorithm.HostPriority{{"machine1", 10}, {"machine2", 10}},
+			test:         "service with a nil selector",
+		},
 	}
 
 	for _, test := range tests {